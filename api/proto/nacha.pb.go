@@ -9,6 +9,7 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,16 +22,119 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AmountFormat int32
+
+const (
+	AmountFormat_AMOUNT_DEFAULT AmountFormat = 0 // format native to the export format
+	AmountFormat_AMOUNT_CENTS   AmountFormat = 1 // integer cents
+	AmountFormat_AMOUNT_DECIMAL AmountFormat = 2 // decimal currency units
+)
+
+// Enum value maps for AmountFormat.
+var (
+	AmountFormat_name = map[int32]string{
+		0: "AMOUNT_DEFAULT",
+		1: "AMOUNT_CENTS",
+		2: "AMOUNT_DECIMAL",
+	}
+	AmountFormat_value = map[string]int32{
+		"AMOUNT_DEFAULT": 0,
+		"AMOUNT_CENTS":   1,
+		"AMOUNT_DECIMAL": 2,
+	}
+)
+
+func (x AmountFormat) Enum() *AmountFormat {
+	p := new(AmountFormat)
+	*p = x
+	return p
+}
+
+func (x AmountFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AmountFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_nacha_proto_enumTypes[0].Descriptor()
+}
+
+func (AmountFormat) Type() protoreflect.EnumType {
+	return &file_api_proto_nacha_proto_enumTypes[0]
+}
+
+func (x AmountFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AmountFormat.Descriptor instead.
+func (AmountFormat) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{0}
+}
+
+type SqlDialect int32
+
+const (
+	SqlDialect_SQL_DIALECT_POSTGRESQL SqlDialect = 0
+	SqlDialect_SQL_DIALECT_MYSQL      SqlDialect = 1
+	SqlDialect_SQL_DIALECT_SQLITE     SqlDialect = 2
+	SqlDialect_SQL_DIALECT_SQLSERVER  SqlDialect = 3
+)
+
+// Enum value maps for SqlDialect.
+var (
+	SqlDialect_name = map[int32]string{
+		0: "SQL_DIALECT_POSTGRESQL",
+		1: "SQL_DIALECT_MYSQL",
+		2: "SQL_DIALECT_SQLITE",
+		3: "SQL_DIALECT_SQLSERVER",
+	}
+	SqlDialect_value = map[string]int32{
+		"SQL_DIALECT_POSTGRESQL": 0,
+		"SQL_DIALECT_MYSQL":      1,
+		"SQL_DIALECT_SQLITE":     2,
+		"SQL_DIALECT_SQLSERVER":  3,
+	}
+)
+
+func (x SqlDialect) Enum() *SqlDialect {
+	p := new(SqlDialect)
+	*p = x
+	return p
+}
+
+func (x SqlDialect) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SqlDialect) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_nacha_proto_enumTypes[1].Descriptor()
+}
+
+func (SqlDialect) Type() protoreflect.EnumType {
+	return &file_api_proto_nacha_proto_enumTypes[1]
+}
+
+func (x SqlDialect) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SqlDialect.Descriptor instead.
+func (SqlDialect) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{1}
+}
+
 type ExportFormat int32
 
 const (
-	ExportFormat_JSON    ExportFormat = 0
-	ExportFormat_CSV     ExportFormat = 1
-	ExportFormat_SQL     ExportFormat = 2
-	ExportFormat_HTML    ExportFormat = 3
-	ExportFormat_PDF     ExportFormat = 4
-	ExportFormat_TXT     ExportFormat = 5
-	ExportFormat_PARQUET ExportFormat = 6
+	ExportFormat_JSON         ExportFormat = 0
+	ExportFormat_CSV          ExportFormat = 1
+	ExportFormat_SQL          ExportFormat = 2
+	ExportFormat_HTML         ExportFormat = 3
+	ExportFormat_PDF          ExportFormat = 4
+	ExportFormat_TXT          ExportFormat = 5
+	ExportFormat_PARQUET      ExportFormat = 6
+	ExportFormat_SUMMARY_CSV  ExportFormat = 7
+	ExportFormat_SUMMARY_JSON ExportFormat = 8
 )
 
 // Enum value maps for ExportFormat.
@@ -43,15 +147,19 @@ var (
 		4: "PDF",
 		5: "TXT",
 		6: "PARQUET",
+		7: "SUMMARY_CSV",
+		8: "SUMMARY_JSON",
 	}
 	ExportFormat_value = map[string]int32{
-		"JSON":    0,
-		"CSV":     1,
-		"SQL":     2,
-		"HTML":    3,
-		"PDF":     4,
-		"TXT":     5,
-		"PARQUET": 6,
+		"JSON":         0,
+		"CSV":          1,
+		"SQL":          2,
+		"HTML":         3,
+		"PDF":          4,
+		"TXT":          5,
+		"PARQUET":      6,
+		"SUMMARY_CSV":  7,
+		"SUMMARY_JSON": 8,
 	}
 )
 
@@ -66,11 +174,11 @@ func (x ExportFormat) String() string {
 }
 
 func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_nacha_proto_enumTypes[0].Descriptor()
+	return file_api_proto_nacha_proto_enumTypes[2].Descriptor()
 }
 
 func (ExportFormat) Type() protoreflect.EnumType {
-	return &file_api_proto_nacha_proto_enumTypes[0]
+	return &file_api_proto_nacha_proto_enumTypes[2]
 }
 
 func (x ExportFormat) Number() protoreflect.EnumNumber {
@@ -79,15 +187,163 @@ func (x ExportFormat) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ExportFormat.Descriptor instead.
 func (ExportFormat) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_nacha_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{2}
+}
+
+type EntryDirection int32
+
+const (
+	EntryDirection_DIRECTION_ANY    EntryDirection = 0
+	EntryDirection_DIRECTION_DEBIT  EntryDirection = 1
+	EntryDirection_DIRECTION_CREDIT EntryDirection = 2
+)
+
+// Enum value maps for EntryDirection.
+var (
+	EntryDirection_name = map[int32]string{
+		0: "DIRECTION_ANY",
+		1: "DIRECTION_DEBIT",
+		2: "DIRECTION_CREDIT",
+	}
+	EntryDirection_value = map[string]int32{
+		"DIRECTION_ANY":    0,
+		"DIRECTION_DEBIT":  1,
+		"DIRECTION_CREDIT": 2,
+	}
+)
+
+func (x EntryDirection) Enum() *EntryDirection {
+	p := new(EntryDirection)
+	*p = x
+	return p
+}
+
+func (x EntryDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EntryDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_nacha_proto_enumTypes[3].Descriptor()
+}
+
+func (EntryDirection) Type() protoreflect.EnumType {
+	return &file_api_proto_nacha_proto_enumTypes[3]
+}
+
+func (x EntryDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EntryDirection.Descriptor instead.
+func (EntryDirection) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{3}
+}
+
+type CsvAmountFormat int32
+
+const (
+	CsvAmountFormat_CSV_AMOUNT_DECIMAL CsvAmountFormat = 0 // dollars, e.g. 1,234.56
+	CsvAmountFormat_CSV_AMOUNT_CENTS   CsvAmountFormat = 1 // cents, e.g. 123456
+)
+
+// Enum value maps for CsvAmountFormat.
+var (
+	CsvAmountFormat_name = map[int32]string{
+		0: "CSV_AMOUNT_DECIMAL",
+		1: "CSV_AMOUNT_CENTS",
+	}
+	CsvAmountFormat_value = map[string]int32{
+		"CSV_AMOUNT_DECIMAL": 0,
+		"CSV_AMOUNT_CENTS":   1,
+	}
+)
+
+func (x CsvAmountFormat) Enum() *CsvAmountFormat {
+	p := new(CsvAmountFormat)
+	*p = x
+	return p
+}
+
+func (x CsvAmountFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CsvAmountFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_nacha_proto_enumTypes[4].Descriptor()
+}
+
+func (CsvAmountFormat) Type() protoreflect.EnumType {
+	return &file_api_proto_nacha_proto_enumTypes[4]
+}
+
+func (x CsvAmountFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CsvAmountFormat.Descriptor instead.
+func (CsvAmountFormat) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{4}
+}
+
+type RemittanceFormat int32
+
+const (
+	RemittanceFormat_REMITTANCE_PDF  RemittanceFormat = 0
+	RemittanceFormat_REMITTANCE_HTML RemittanceFormat = 1
+)
+
+// Enum value maps for RemittanceFormat.
+var (
+	RemittanceFormat_name = map[int32]string{
+		0: "REMITTANCE_PDF",
+		1: "REMITTANCE_HTML",
+	}
+	RemittanceFormat_value = map[string]int32{
+		"REMITTANCE_PDF":  0,
+		"REMITTANCE_HTML": 1,
+	}
+)
+
+func (x RemittanceFormat) Enum() *RemittanceFormat {
+	p := new(RemittanceFormat)
+	*p = x
+	return p
+}
+
+func (x RemittanceFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RemittanceFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_nacha_proto_enumTypes[5].Descriptor()
+}
+
+func (RemittanceFormat) Type() protoreflect.EnumType {
+	return &file_api_proto_nacha_proto_enumTypes[5]
+}
+
+func (x RemittanceFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RemittanceFormat.Descriptor instead.
+func (RemittanceFormat) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{5}
 }
 
 type FileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
-	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	FileContent []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	FilePath    string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	// Paging and field selection, used by ViewFile
+	BatchPageSize  int32                  `protobuf:"varint,3,opt,name=batch_page_size,json=batchPageSize,proto3" json:"batch_page_size,omitempty"`   // 0 returns every batch
+	BatchPageToken string                 `protobuf:"bytes,4,opt,name=batch_page_token,json=batchPageToken,proto3" json:"batch_page_token,omitempty"` // next_batch_page_token from a previous response
+	EntryPageSize  int32                  `protobuf:"varint,5,opt,name=entry_page_size,json=entryPageSize,proto3" json:"entry_page_size,omitempty"`   // entries per returned batch, 0 returns every entry
+	EntryPageToken string                 `protobuf:"bytes,6,opt,name=entry_page_token,json=entryPageToken,proto3" json:"entry_page_token,omitempty"` // next_entry_page_token of a returned batch, pages that batch only
+	ViewMask       *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=view_mask,json=viewMask,proto3" json:"view_mask,omitempty"`                     // e.g. "file_header", "file_control", "batches.header"
+	FileId         string                 `protobuf:"bytes,8,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`                           // file_id from UploadFile, instead of file_content
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *FileRequest) Reset() {
@@ -134,6 +390,48 @@ func (x *FileRequest) GetFilePath() string {
 	return ""
 }
 
+func (x *FileRequest) GetBatchPageSize() int32 {
+	if x != nil {
+		return x.BatchPageSize
+	}
+	return 0
+}
+
+func (x *FileRequest) GetBatchPageToken() string {
+	if x != nil {
+		return x.BatchPageToken
+	}
+	return ""
+}
+
+func (x *FileRequest) GetEntryPageSize() int32 {
+	if x != nil {
+		return x.EntryPageSize
+	}
+	return 0
+}
+
+func (x *FileRequest) GetEntryPageToken() string {
+	if x != nil {
+		return x.EntryPageToken
+	}
+	return ""
+}
+
+func (x *FileRequest) GetViewMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ViewMask
+	}
+	return nil
+}
+
+func (x *FileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
 type ValidationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	IsValid       bool                   `protobuf:"varint,1,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
@@ -1126,6 +1424,10 @@ type ExportRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
 	Format        ExportFormat           `protobuf:"varint,2,opt,name=format,proto3,enum=nacha.ExportFormat" json:"format,omitempty"`
+	FileId        string                 `protobuf:"bytes,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ChunkSize     int32                  `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`   // bytes per ExportChunk, used by ExportStream
+	FormatName    string                 `protobuf:"bytes,5,opt,name=format_name,json=formatName,proto3" json:"format_name,omitempty"` // registered format name, takes precedence over format
+	Options       *ExportOptions         `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1174,29 +1476,71 @@ func (x *ExportRequest) GetFormat() ExportFormat {
 	return ExportFormat_JSON
 }
 
-type ExportResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ExportedContent []byte                 `protobuf:"bytes,1,opt,name=exported_content,json=exportedContent,proto3" json:"exported_content,omitempty"`
-	FileType        string                 `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
-	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *ExportRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
 }
 
-func (x *ExportResponse) Reset() {
-	*x = ExportResponse{}
+func (x *ExportRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+func (x *ExportRequest) GetFormatName() string {
+	if x != nil {
+		return x.FormatName
+	}
+	return ""
+}
+
+func (x *ExportRequest) GetOptions() *ExportOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// ExportOptions controls the content and formatting of an export
+type ExportOptions struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Fields             []string               `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"` // entry fields to include, in order; empty includes every field
+	MaskAccountNumbers bool                   `protobuf:"varint,2,opt,name=mask_account_numbers,json=maskAccountNumbers,proto3" json:"mask_account_numbers,omitempty"`
+	MaskVisibleDigits  int32                  `protobuf:"varint,3,opt,name=mask_visible_digits,json=maskVisibleDigits,proto3" json:"mask_visible_digits,omitempty"` // characters left visible when masking, default 4
+	BatchNumbers       []string               `protobuf:"bytes,4,rep,name=batch_numbers,json=batchNumbers,proto3" json:"batch_numbers,omitempty"`                   // only export these batches
+	SecCodes           []string               `protobuf:"bytes,5,rep,name=sec_codes,json=secCodes,proto3" json:"sec_codes,omitempty"`                               // only export batches with these standard entry classes
+	AmountFormat       AmountFormat           `protobuf:"varint,6,opt,name=amount_format,json=amountFormat,proto3,enum=nacha.AmountFormat" json:"amount_format,omitempty"`
+	Locale             string                 `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`                                                   // separators of decimal amounts: "en-US" (default) or "pt-BR"
+	DateFormat         string                 `protobuf:"bytes,8,opt,name=date_format,json=dateFormat,proto3" json:"date_format,omitempty"`                         // e.g. "YYYY-MM-DD", "DD/MM/YYYY"
+	Timezone           string                 `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`                                               // IANA name, e.g. "America/Sao_Paulo"
+	SqlDialect         SqlDialect             `protobuf:"varint,10,opt,name=sql_dialect,json=sqlDialect,proto3,enum=nacha.SqlDialect" json:"sql_dialect,omitempty"` // database of SQL exports
+	SkipSqlSchema      bool                   `protobuf:"varint,11,opt,name=skip_sql_schema,json=skipSqlSchema,proto3" json:"skip_sql_schema,omitempty"`            // leave CREATE TABLE statements out of SQL exports
+	Cnab               *CnabOptions           `protobuf:"bytes,12,opt,name=cnab,proto3" json:"cnab,omitempty"`                                                      // company values of CNAB 240 exports
+	Cpa                *CpaOptions            `protobuf:"bytes,13,opt,name=cpa,proto3" json:"cpa,omitempty"`                                                        // originator values of CPA 005 exports
+	Template           *TemplateOptions       `protobuf:"bytes,14,opt,name=template,proto3" json:"template,omitempty"`                                              // template of TEMPLATE exports
+	Gl                 *GlOptions             `protobuf:"bytes,15,opt,name=gl,proto3" json:"gl,omitempty"`                                                          // chart of accounts of GL_CSV and GL_IIF exports
+	Pain               *PainOptions           `protobuf:"bytes,16,opt,name=pain,proto3" json:"pain,omitempty"`                                                      // originator accounts of PAIN001 and PAIN008 exports
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExportOptions) Reset() {
+	*x = ExportOptions{}
 	mi := &file_api_proto_nacha_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ExportResponse) String() string {
+func (x *ExportOptions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExportResponse) ProtoMessage() {}
+func (*ExportOptions) ProtoMessage() {}
 
-func (x *ExportResponse) ProtoReflect() protoreflect.Message {
+func (x *ExportOptions) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_nacha_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1208,124 +1552,3139 @@ func (x *ExportResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExportResponse.ProtoReflect.Descriptor instead.
-func (*ExportResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportOptions.ProtoReflect.Descriptor instead.
+func (*ExportOptions) Descriptor() ([]byte, []int) {
 	return file_api_proto_nacha_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ExportResponse) GetExportedContent() []byte {
+func (x *ExportOptions) GetFields() []string {
 	if x != nil {
-		return x.ExportedContent
+		return x.Fields
 	}
 	return nil
 }
 
-func (x *ExportResponse) GetFileType() string {
+func (x *ExportOptions) GetMaskAccountNumbers() bool {
 	if x != nil {
-		return x.FileType
+		return x.MaskAccountNumbers
 	}
-	return ""
+	return false
 }
 
-func (x *ExportResponse) GetMessage() string {
+func (x *ExportOptions) GetMaskVisibleDigits() int32 {
 	if x != nil {
-		return x.Message
+		return x.MaskVisibleDigits
 	}
-	return ""
+	return 0
 }
 
-type FileDetailsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileHeader    *FileHeader            `protobuf:"bytes,1,opt,name=file_header,json=fileHeader,proto3" json:"file_header,omitempty"`
-	Batches       []*BatchDetails        `protobuf:"bytes,2,rep,name=batches,proto3" json:"batches,omitempty"`
-	FileControl   *FileControl           `protobuf:"bytes,3,opt,name=file_control,json=fileControl,proto3" json:"file_control,omitempty"`
-	Summary       map[string]string      `protobuf:"bytes,4,rep,name=summary,proto3" json:"summary,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ExportOptions) GetBatchNumbers() []string {
+	if x != nil {
+		return x.BatchNumbers
+	}
+	return nil
 }
 
-func (x *FileDetailsResponse) Reset() {
-	*x = FileDetailsResponse{}
-	mi := &file_api_proto_nacha_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ExportOptions) GetSecCodes() []string {
+	if x != nil {
+		return x.SecCodes
+	}
+	return nil
 }
 
-func (x *FileDetailsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ExportOptions) GetAmountFormat() AmountFormat {
+	if x != nil {
+		return x.AmountFormat
+	}
+	return AmountFormat_AMOUNT_DEFAULT
 }
 
-func (*FileDetailsResponse) ProtoMessage() {}
-
-func (x *FileDetailsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_nacha_proto_msgTypes[14]
+func (x *ExportOptions) GetLocale() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Locale
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use FileDetailsResponse.ProtoReflect.Descriptor instead.
-func (*FileDetailsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_nacha_proto_rawDescGZIP(), []int{14}
+func (x *ExportOptions) GetDateFormat() string {
+	if x != nil {
+		return x.DateFormat
+	}
+	return ""
 }
 
-func (x *FileDetailsResponse) GetFileHeader() *FileHeader {
+func (x *ExportOptions) GetTimezone() string {
 	if x != nil {
-		return x.FileHeader
+		return x.Timezone
 	}
-	return nil
+	return ""
 }
 
-func (x *FileDetailsResponse) GetBatches() []*BatchDetails {
+func (x *ExportOptions) GetSqlDialect() SqlDialect {
 	if x != nil {
-		return x.Batches
+		return x.SqlDialect
 	}
-	return nil
+	return SqlDialect_SQL_DIALECT_POSTGRESQL
+}
+
+func (x *ExportOptions) GetSkipSqlSchema() bool {
+	if x != nil {
+		return x.SkipSqlSchema
+	}
+	return false
+}
+
+func (x *ExportOptions) GetCnab() *CnabOptions {
+	if x != nil {
+		return x.Cnab
+	}
+	return nil
+}
+
+func (x *ExportOptions) GetCpa() *CpaOptions {
+	if x != nil {
+		return x.Cpa
+	}
+	return nil
+}
+
+func (x *ExportOptions) GetTemplate() *TemplateOptions {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *ExportOptions) GetGl() *GlOptions {
+	if x != nil {
+		return x.Gl
+	}
+	return nil
+}
+
+func (x *ExportOptions) GetPain() *PainOptions {
+	if x != nil {
+		return x.Pain
+	}
+	return nil
+}
+
+type CnabOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CompanyDocument string                 `protobuf:"bytes,1,opt,name=company_document,json=companyDocument,proto3" json:"company_document,omitempty"` // CNPJ or CPF, by default the company identification
+	Agreement       string                 `protobuf:"bytes,2,opt,name=agreement,proto3" json:"agreement,omitempty"`                                    // convênio of the company with its bank
+	CompanyAccount  string                 `protobuf:"bytes,3,opt,name=company_account,json=companyAccount,proto3" json:"company_account,omitempty"`    // account with the check digit after a dash, e.g. "12345-6"
+	FileSequence    int32                  `protobuf:"varint,4,opt,name=file_sequence,json=fileSequence,proto3" json:"file_sequence,omitempty"`         // file sequence number (NSA), default 1
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CnabOptions) Reset() {
+	*x = CnabOptions{}
+	mi := &file_api_proto_nacha_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabOptions) ProtoMessage() {}
+
+func (x *CnabOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabOptions.ProtoReflect.Descriptor instead.
+func (*CnabOptions) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CnabOptions) GetCompanyDocument() string {
+	if x != nil {
+		return x.CompanyDocument
+	}
+	return ""
+}
+
+func (x *CnabOptions) GetAgreement() string {
+	if x != nil {
+		return x.Agreement
+	}
+	return ""
+}
+
+func (x *CnabOptions) GetCompanyAccount() string {
+	if x != nil {
+		return x.CompanyAccount
+	}
+	return ""
+}
+
+func (x *CnabOptions) GetFileSequence() int32 {
+	if x != nil {
+		return x.FileSequence
+	}
+	return 0
+}
+
+type CpaOptions struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	OriginatorId          string                 `protobuf:"bytes,1,opt,name=originator_id,json=originatorId,proto3" json:"originator_id,omitempty"`                              // by default the immediate origin
+	FileCreationNumber    int32                  `protobuf:"varint,2,opt,name=file_creation_number,json=fileCreationNumber,proto3" json:"file_creation_number,omitempty"`         // 1 to 9999, default 1
+	DestinationDataCentre string                 `protobuf:"bytes,3,opt,name=destination_data_centre,json=destinationDataCentre,proto3" json:"destination_data_centre,omitempty"` // five digits
+	Currency              string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`                                                          // "CAD" (default) or "USD"
+	CreditTransactionType string                 `protobuf:"bytes,5,opt,name=credit_transaction_type,json=creditTransactionType,proto3" json:"credit_transaction_type,omitempty"` // default 200 in PPD batches, otherwise 450
+	DebitTransactionType  string                 `protobuf:"bytes,6,opt,name=debit_transaction_type,json=debitTransactionType,proto3" json:"debit_transaction_type,omitempty"`    // default 700
+	ReturnAccount         string                 `protobuf:"bytes,7,opt,name=return_account,json=returnAccount,proto3" json:"return_account,omitempty"`                           // originator's account for returned items
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CpaOptions) Reset() {
+	*x = CpaOptions{}
+	mi := &file_api_proto_nacha_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CpaOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CpaOptions) ProtoMessage() {}
+
+func (x *CpaOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CpaOptions.ProtoReflect.Descriptor instead.
+func (*CpaOptions) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CpaOptions) GetOriginatorId() string {
+	if x != nil {
+		return x.OriginatorId
+	}
+	return ""
+}
+
+func (x *CpaOptions) GetFileCreationNumber() int32 {
+	if x != nil {
+		return x.FileCreationNumber
+	}
+	return 0
+}
+
+func (x *CpaOptions) GetDestinationDataCentre() string {
+	if x != nil {
+		return x.DestinationDataCentre
+	}
+	return ""
+}
+
+func (x *CpaOptions) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CpaOptions) GetCreditTransactionType() string {
+	if x != nil {
+		return x.CreditTransactionType
+	}
+	return ""
+}
+
+func (x *CpaOptions) GetDebitTransactionType() string {
+	if x != nil {
+		return x.DebitTransactionType
+	}
+	return ""
+}
+
+func (x *CpaOptions) GetReturnAccount() string {
+	if x != nil {
+		return x.ReturnAccount
+	}
+	return ""
+}
+
+type TemplateOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`                              // Go text/template or html/template source
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                  // template of the server template directory, in place of source
+	Html          bool                   `protobuf:"varint,3,opt,name=html,proto3" json:"html,omitempty"`                                 // parse source with html/template
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // default text/html for HTML templates, otherwise text/plain
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TemplateOptions) Reset() {
+	*x = TemplateOptions{}
+	mi := &file_api_proto_nacha_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TemplateOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateOptions) ProtoMessage() {}
+
+func (x *TemplateOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateOptions.ProtoReflect.Descriptor instead.
+func (*TemplateOptions) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TemplateOptions) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TemplateOptions) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemplateOptions) GetHtml() bool {
+	if x != nil {
+		return x.Html
+	}
+	return false
+}
+
+func (x *TemplateOptions) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type GlOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*GlAccountRule       `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`                                      // the most specific matching rule maps an entry
+	DebitAccount  string                 `protobuf:"bytes,2,opt,name=debit_account,json=debitAccount,proto3" json:"debit_account,omitempty"`    // account of debit entries no rule maps, default "ACH Receivable"
+	CreditAccount string                 `protobuf:"bytes,3,opt,name=credit_account,json=creditAccount,proto3" json:"credit_account,omitempty"` // account of credit entries no rule maps, default "ACH Payable"
+	OffsetAccount string                 `protobuf:"bytes,4,opt,name=offset_account,json=offsetAccount,proto3" json:"offset_account,omitempty"` // account of the batch offset lines, default "ACH Clearing"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlOptions) Reset() {
+	*x = GlOptions{}
+	mi := &file_api_proto_nacha_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlOptions) ProtoMessage() {}
+
+func (x *GlOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlOptions.ProtoReflect.Descriptor instead.
+func (*GlOptions) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GlOptions) GetRules() []*GlAccountRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GlOptions) GetDebitAccount() string {
+	if x != nil {
+		return x.DebitAccount
+	}
+	return ""
+}
+
+func (x *GlOptions) GetCreditAccount() string {
+	if x != nil {
+		return x.CreditAccount
+	}
+	return ""
+}
+
+func (x *GlOptions) GetOffsetAccount() string {
+	if x != nil {
+		return x.OffsetAccount
+	}
+	return ""
+}
+
+type GlAccountRule struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CompanyId       string                 `protobuf:"bytes,1,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`                   // company identification; empty matches every company
+	SecCode         string                 `protobuf:"bytes,2,opt,name=sec_code,json=secCode,proto3" json:"sec_code,omitempty"`                         // standard entry class; empty matches every class
+	TransactionCode string                 `protobuf:"bytes,3,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"` // empty matches every transaction code
+	Account         string                 `protobuf:"bytes,4,opt,name=account,proto3" json:"account,omitempty"`                                        // account of the matching entries
+	OffsetAccount   string                 `protobuf:"bytes,5,opt,name=offset_account,json=offsetAccount,proto3" json:"offset_account,omitempty"`       // account of their offset lines, by default the offset account
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GlAccountRule) Reset() {
+	*x = GlAccountRule{}
+	mi := &file_api_proto_nacha_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlAccountRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlAccountRule) ProtoMessage() {}
+
+func (x *GlAccountRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlAccountRule.ProtoReflect.Descriptor instead.
+func (*GlAccountRule) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GlAccountRule) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *GlAccountRule) GetSecCode() string {
+	if x != nil {
+		return x.SecCode
+	}
+	return ""
+}
+
+func (x *GlAccountRule) GetTransactionCode() string {
+	if x != nil {
+		return x.TransactionCode
+	}
+	return ""
+}
+
+func (x *GlAccountRule) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *GlAccountRule) GetOffsetAccount() string {
+	if x != nil {
+		return x.OffsetAccount
+	}
+	return ""
+}
+
+type PainOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CompanyAccount  string                 `protobuf:"bytes,1,opt,name=company_account,json=companyAccount,proto3" json:"company_account,omitempty"`                                                                              // originator's account: debtor account of PAIN001, creditor account of PAIN008
+	CompanyAccounts map[string]string      `protobuf:"bytes,2,rep,name=company_accounts,json=companyAccounts,proto3" json:"company_accounts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // accounts by company identification, in place of company_account
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PainOptions) Reset() {
+	*x = PainOptions{}
+	mi := &file_api_proto_nacha_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PainOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PainOptions) ProtoMessage() {}
+
+func (x *PainOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PainOptions.ProtoReflect.Descriptor instead.
+func (*PainOptions) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PainOptions) GetCompanyAccount() string {
+	if x != nil {
+		return x.CompanyAccount
+	}
+	return ""
+}
+
+func (x *PainOptions) GetCompanyAccounts() map[string]string {
+	if x != nil {
+		return x.CompanyAccounts
+	}
+	return nil
+}
+
+type ExportResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ExportedContent []byte                 `protobuf:"bytes,1,opt,name=exported_content,json=exportedContent,proto3" json:"exported_content,omitempty"`
+	FileType        string                 `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	Message         string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportResponse) Reset() {
+	*x = ExportResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportResponse) ProtoMessage() {}
+
+func (x *ExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportResponse.ProtoReflect.Descriptor instead.
+func (*ExportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ExportResponse) GetExportedContent() []byte {
+	if x != nil {
+		return x.ExportedContent
+	}
+	return nil
+}
+
+func (x *ExportResponse) GetFileType() string {
+	if x != nil {
+		return x.FileType
+	}
+	return ""
+}
+
+func (x *ExportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FileDetailsResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	FileHeader         *FileHeader            `protobuf:"bytes,1,opt,name=file_header,json=fileHeader,proto3" json:"file_header,omitempty"`
+	Batches            []*BatchDetails        `protobuf:"bytes,2,rep,name=batches,proto3" json:"batches,omitempty"`
+	FileControl        *FileControl           `protobuf:"bytes,3,opt,name=file_control,json=fileControl,proto3" json:"file_control,omitempty"`
+	Summary            map[string]string      `protobuf:"bytes,4,rep,name=summary,proto3" json:"summary,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TotalBatches       int32                  `protobuf:"varint,5,opt,name=total_batches,json=totalBatches,proto3" json:"total_batches,omitempty"`
+	NextBatchPageToken string                 `protobuf:"bytes,6,opt,name=next_batch_page_token,json=nextBatchPageToken,proto3" json:"next_batch_page_token,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FileDetailsResponse) Reset() {
+	*x = FileDetailsResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileDetailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileDetailsResponse) ProtoMessage() {}
+
+func (x *FileDetailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileDetailsResponse.ProtoReflect.Descriptor instead.
+func (*FileDetailsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FileDetailsResponse) GetFileHeader() *FileHeader {
+	if x != nil {
+		return x.FileHeader
+	}
+	return nil
+}
+
+func (x *FileDetailsResponse) GetBatches() []*BatchDetails {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
 }
 
 func (x *FileDetailsResponse) GetFileControl() *FileControl {
 	if x != nil {
-		return x.FileControl
+		return x.FileControl
+	}
+	return nil
+}
+
+func (x *FileDetailsResponse) GetSummary() map[string]string {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *FileDetailsResponse) GetTotalBatches() int32 {
+	if x != nil {
+		return x.TotalBatches
+	}
+	return 0
+}
+
+func (x *FileDetailsResponse) GetNextBatchPageToken() string {
+	if x != nil {
+		return x.NextBatchPageToken
+	}
+	return ""
+}
+
+type BatchDetails struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Header             *BatchHeader           `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Entries            []*EntryDetail         `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Control            *BatchControl          `protobuf:"bytes,3,opt,name=control,proto3" json:"control,omitempty"`
+	TotalEntries       int32                  `protobuf:"varint,4,opt,name=total_entries,json=totalEntries,proto3" json:"total_entries,omitempty"`
+	NextEntryPageToken string                 `protobuf:"bytes,5,opt,name=next_entry_page_token,json=nextEntryPageToken,proto3" json:"next_entry_page_token,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BatchDetails) Reset() {
+	*x = BatchDetails{}
+	mi := &file_api_proto_nacha_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDetails) ProtoMessage() {}
+
+func (x *BatchDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDetails.ProtoReflect.Descriptor instead.
+func (*BatchDetails) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BatchDetails) GetHeader() *BatchHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *BatchDetails) GetEntries() []*EntryDetail {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *BatchDetails) GetControl() *BatchControl {
+	if x != nil {
+		return x.Control
+	}
+	return nil
+}
+
+func (x *BatchDetails) GetTotalEntries() int32 {
+	if x != nil {
+		return x.TotalEntries
+	}
+	return 0
+}
+
+func (x *BatchDetails) GetNextEntryPageToken() string {
+	if x != nil {
+		return x.NextEntryPageToken
+	}
+	return ""
+}
+
+type DetailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	DetailType    string                 `protobuf:"bytes,2,opt,name=detail_type,json=detailType,proto3" json:"detail_type,omitempty"` // "batch" or "entry"
+	Identifier    string                 `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`                   // batch number or trace number
+	FileId        string                 `protobuf:"bytes,4,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetailRequest) Reset() {
+	*x = DetailRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailRequest) ProtoMessage() {}
+
+func (x *DetailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailRequest.ProtoReflect.Descriptor instead.
+func (*DetailRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DetailRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *DetailRequest) GetDetailType() string {
+	if x != nil {
+		return x.DetailType
+	}
+	return ""
+}
+
+func (x *DetailRequest) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+func (x *DetailRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DetailResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Detail:
+	//
+	//	*DetailResponse_Batch
+	//	*DetailResponse_Entry
+	Detail        isDetailResponse_Detail `protobuf_oneof:"detail"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetailResponse) Reset() {
+	*x = DetailResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetailResponse) ProtoMessage() {}
+
+func (x *DetailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetailResponse.ProtoReflect.Descriptor instead.
+func (*DetailResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DetailResponse) GetDetail() isDetailResponse_Detail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+func (x *DetailResponse) GetBatch() *BatchDetails {
+	if x != nil {
+		if x, ok := x.Detail.(*DetailResponse_Batch); ok {
+			return x.Batch
+		}
+	}
+	return nil
+}
+
+func (x *DetailResponse) GetEntry() *EntryDetail {
+	if x != nil {
+		if x, ok := x.Detail.(*DetailResponse_Entry); ok {
+			return x.Entry
+		}
+	}
+	return nil
+}
+
+type isDetailResponse_Detail interface {
+	isDetailResponse_Detail()
+}
+
+type DetailResponse_Batch struct {
+	Batch *BatchDetails `protobuf:"bytes,1,opt,name=batch,proto3,oneof"`
+}
+
+type DetailResponse_Entry struct {
+	Entry *EntryDetail `protobuf:"bytes,2,opt,name=entry,proto3,oneof"`
+}
+
+func (*DetailResponse_Batch) isDetailResponse_Detail() {}
+
+func (*DetailResponse_Entry) isDetailResponse_Detail() {}
+
+type EntryDetail struct {
+	state                          protoimpl.MessageState `protogen:"open.v1"`
+	TransactionCode                string                 `protobuf:"bytes,1,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"`
+	ReceivingDfiIdentification     string                 `protobuf:"bytes,2,opt,name=receiving_dfi_identification,json=receivingDfiIdentification,proto3" json:"receiving_dfi_identification,omitempty"`
+	CheckDigit                     string                 `protobuf:"bytes,3,opt,name=check_digit,json=checkDigit,proto3" json:"check_digit,omitempty"`
+	DfiAccountNumber               string                 `protobuf:"bytes,4,opt,name=dfi_account_number,json=dfiAccountNumber,proto3" json:"dfi_account_number,omitempty"`
+	Amount                         int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	IndividualIdentificationNumber string                 `protobuf:"bytes,6,opt,name=individual_identification_number,json=individualIdentificationNumber,proto3" json:"individual_identification_number,omitempty"`
+	IndividualName                 string                 `protobuf:"bytes,7,opt,name=individual_name,json=individualName,proto3" json:"individual_name,omitempty"`
+	DiscretionaryData              string                 `protobuf:"bytes,8,opt,name=discretionary_data,json=discretionaryData,proto3" json:"discretionary_data,omitempty"`
+	AddendaRecordIndicator         string                 `protobuf:"bytes,9,opt,name=addenda_record_indicator,json=addendaRecordIndicator,proto3" json:"addenda_record_indicator,omitempty"`
+	TraceNumber                    string                 `protobuf:"bytes,10,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
+	AddendaRecords                 []*AddendaRecord       `protobuf:"bytes,11,rep,name=addenda_records,json=addendaRecords,proto3" json:"addenda_records,omitempty"`
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
+}
+
+func (x *EntryDetail) Reset() {
+	*x = EntryDetail{}
+	mi := &file_api_proto_nacha_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryDetail) ProtoMessage() {}
+
+func (x *EntryDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryDetail.ProtoReflect.Descriptor instead.
+func (*EntryDetail) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *EntryDetail) GetTransactionCode() string {
+	if x != nil {
+		return x.TransactionCode
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetReceivingDfiIdentification() string {
+	if x != nil {
+		return x.ReceivingDfiIdentification
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetCheckDigit() string {
+	if x != nil {
+		return x.CheckDigit
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetDfiAccountNumber() string {
+	if x != nil {
+		return x.DfiAccountNumber
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *EntryDetail) GetIndividualIdentificationNumber() string {
+	if x != nil {
+		return x.IndividualIdentificationNumber
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetIndividualName() string {
+	if x != nil {
+		return x.IndividualName
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetDiscretionaryData() string {
+	if x != nil {
+		return x.DiscretionaryData
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetAddendaRecordIndicator() string {
+	if x != nil {
+		return x.AddendaRecordIndicator
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetTraceNumber() string {
+	if x != nil {
+		return x.TraceNumber
+	}
+	return ""
+}
+
+func (x *EntryDetail) GetAddendaRecords() []*AddendaRecord {
+	if x != nil {
+		return x.AddendaRecords
+	}
+	return nil
+}
+
+type ImportRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	JsonContent       []byte                 `protobuf:"bytes,1,opt,name=json_content,json=jsonContent,proto3" json:"json_content,omitempty"`                    // document in the NACHA JSON schema or the moov-io/ach layout
+	RecomputeControls bool                   `protobuf:"varint,2,opt,name=recompute_controls,json=recomputeControls,proto3" json:"recompute_controls,omitempty"` // compute the batch and file controls instead of checking them
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ImportRequest) Reset() {
+	*x = ImportRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRequest) ProtoMessage() {}
+
+func (x *ImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRequest.ProtoReflect.Descriptor instead.
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportRequest) GetJsonContent() []byte {
+	if x != nil {
+		return x.JsonContent
+	}
+	return nil
+}
+
+func (x *ImportRequest) GetRecomputeControls() bool {
+	if x != nil {
+		return x.RecomputeControls
+	}
+	return false
+}
+
+type QueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	Filter        *EntryFilter           `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	SortBy        string                 `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"` // "amount", "trace_number", "individual_name", "receiving_dfi", "batch_number"
+	Descending    bool                   `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 0 returns every match
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token from a previous response
+	FileId        string                 `protobuf:"bytes,7,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRequest) Reset() {
+	*x = QueryRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRequest) ProtoMessage() {}
+
+func (x *QueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QueryRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetFilter() *EntryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *QueryRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type EntryFilter struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	MinAmount             int64                  `protobuf:"varint,1,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"` // in cents, inclusive; 0 means no lower bound
+	MaxAmount             int64                  `protobuf:"varint,2,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"` // in cents, inclusive; 0 means no upper bound
+	TransactionCodes      []string               `protobuf:"bytes,3,rep,name=transaction_codes,json=transactionCodes,proto3" json:"transaction_codes,omitempty"`
+	Direction             EntryDirection         `protobuf:"varint,4,opt,name=direction,proto3,enum=nacha.EntryDirection" json:"direction,omitempty"`
+	ReceivingDfi          string                 `protobuf:"bytes,5,opt,name=receiving_dfi,json=receivingDfi,proto3" json:"receiving_dfi,omitempty"` // prefix match on the 8-digit routing number
+	DfiAccountNumber      string                 `protobuf:"bytes,6,opt,name=dfi_account_number,json=dfiAccountNumber,proto3" json:"dfi_account_number,omitempty"`
+	IndividualName        string                 `protobuf:"bytes,7,opt,name=individual_name,json=individualName,proto3" json:"individual_name,omitempty"` // case-insensitive substring match
+	FuzzyName             bool                   `protobuf:"varint,8,opt,name=fuzzy_name,json=fuzzyName,proto3" json:"fuzzy_name,omitempty"`               // also accept names within a small edit distance
+	StandardEntryClasses  []string               `protobuf:"bytes,9,rep,name=standard_entry_classes,json=standardEntryClasses,proto3" json:"standard_entry_classes,omitempty"`
+	CompanyName           string                 `protobuf:"bytes,10,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"` // case-insensitive substring match
+	CompanyIdentification string                 `protobuf:"bytes,11,opt,name=company_identification,json=companyIdentification,proto3" json:"company_identification,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *EntryFilter) Reset() {
+	*x = EntryFilter{}
+	mi := &file_api_proto_nacha_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryFilter) ProtoMessage() {}
+
+func (x *EntryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryFilter.ProtoReflect.Descriptor instead.
+func (*EntryFilter) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *EntryFilter) GetMinAmount() int64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *EntryFilter) GetMaxAmount() int64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *EntryFilter) GetTransactionCodes() []string {
+	if x != nil {
+		return x.TransactionCodes
+	}
+	return nil
+}
+
+func (x *EntryFilter) GetDirection() EntryDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return EntryDirection_DIRECTION_ANY
+}
+
+func (x *EntryFilter) GetReceivingDfi() string {
+	if x != nil {
+		return x.ReceivingDfi
+	}
+	return ""
+}
+
+func (x *EntryFilter) GetDfiAccountNumber() string {
+	if x != nil {
+		return x.DfiAccountNumber
+	}
+	return ""
+}
+
+func (x *EntryFilter) GetIndividualName() string {
+	if x != nil {
+		return x.IndividualName
+	}
+	return ""
+}
+
+func (x *EntryFilter) GetFuzzyName() bool {
+	if x != nil {
+		return x.FuzzyName
+	}
+	return false
+}
+
+func (x *EntryFilter) GetStandardEntryClasses() []string {
+	if x != nil {
+		return x.StandardEntryClasses
+	}
+	return nil
+}
+
+func (x *EntryFilter) GetCompanyName() string {
+	if x != nil {
+		return x.CompanyName
+	}
+	return ""
+}
+
+func (x *EntryFilter) GetCompanyIdentification() string {
+	if x != nil {
+		return x.CompanyIdentification
+	}
+	return ""
+}
+
+type QueryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*EntryMatch          `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	TotalMatches  int32                  `protobuf:"varint,2,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResponse) ProtoMessage() {}
+
+func (x *QueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QueryResponse) GetMatches() []*EntryMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *QueryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type EntryMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entry         *EntryDetail           `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	BatchHeader   *BatchHeader           `protobuf:"bytes,2,opt,name=batch_header,json=batchHeader,proto3" json:"batch_header,omitempty"`
+	BatchIndex    int32                  `protobuf:"varint,3,opt,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"`
+	EntryIndex    int32                  `protobuf:"varint,4,opt,name=entry_index,json=entryIndex,proto3" json:"entry_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryMatch) Reset() {
+	*x = EntryMatch{}
+	mi := &file_api_proto_nacha_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryMatch) ProtoMessage() {}
+
+func (x *EntryMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryMatch.ProtoReflect.Descriptor instead.
+func (*EntryMatch) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *EntryMatch) GetEntry() *EntryDetail {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+func (x *EntryMatch) GetBatchHeader() *BatchHeader {
+	if x != nil {
+		return x.BatchHeader
+	}
+	return nil
+}
+
+func (x *EntryMatch) GetBatchIndex() int32 {
+	if x != nil {
+		return x.BatchIndex
+	}
+	return 0
+}
+
+func (x *EntryMatch) GetEntryIndex() int32 {
+	if x != nil {
+		return x.EntryIndex
+	}
+	return 0
+}
+
+type SummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	TopEntries    int32                  `protobuf:"varint,2,opt,name=top_entries,json=topEntries,proto3" json:"top_entries,omitempty"` // size of the largest entries list, defaults to 10
+	FileId        string                 `protobuf:"bytes,3,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummaryRequest) Reset() {
+	*x = SummaryRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummaryRequest) ProtoMessage() {}
+
+func (x *SummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummaryRequest.ProtoReflect.Descriptor instead.
+func (*SummaryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SummaryRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *SummaryRequest) GetTopEntries() int32 {
+	if x != nil {
+		return x.TopEntries
+	}
+	return 0
+}
+
+func (x *SummaryRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type SummaryResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Totals            *Aggregate             `protobuf:"bytes,1,opt,name=totals,proto3" json:"totals,omitempty"`
+	BySecCode         []*Aggregate           `protobuf:"bytes,2,rep,name=by_sec_code,json=bySecCode,proto3" json:"by_sec_code,omitempty"`
+	ByCompany         []*Aggregate           `protobuf:"bytes,3,rep,name=by_company,json=byCompany,proto3" json:"by_company,omitempty"` // keyed by company identification
+	ByReceivingDfi    []*Aggregate           `protobuf:"bytes,4,rep,name=by_receiving_dfi,json=byReceivingDfi,proto3" json:"by_receiving_dfi,omitempty"`
+	ByTransactionCode []*Aggregate           `protobuf:"bytes,5,rep,name=by_transaction_code,json=byTransactionCode,proto3" json:"by_transaction_code,omitempty"`
+	ByEffectiveDate   []*Aggregate           `protobuf:"bytes,6,rep,name=by_effective_date,json=byEffectiveDate,proto3" json:"by_effective_date,omitempty"`
+	ByDirection       []*Aggregate           `protobuf:"bytes,7,rep,name=by_direction,json=byDirection,proto3" json:"by_direction,omitempty"` // "DEBIT", "CREDIT" or "OTHER"
+	LargestEntries    []*EntryMatch          `protobuf:"bytes,8,rep,name=largest_entries,json=largestEntries,proto3" json:"largest_entries,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SummaryResponse) Reset() {
+	*x = SummaryResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummaryResponse) ProtoMessage() {}
+
+func (x *SummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummaryResponse.ProtoReflect.Descriptor instead.
+func (*SummaryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SummaryResponse) GetTotals() *Aggregate {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetBySecCode() []*Aggregate {
+	if x != nil {
+		return x.BySecCode
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetByCompany() []*Aggregate {
+	if x != nil {
+		return x.ByCompany
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetByReceivingDfi() []*Aggregate {
+	if x != nil {
+		return x.ByReceivingDfi
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetByTransactionCode() []*Aggregate {
+	if x != nil {
+		return x.ByTransactionCode
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetByEffectiveDate() []*Aggregate {
+	if x != nil {
+		return x.ByEffectiveDate
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetByDirection() []*Aggregate {
+	if x != nil {
+		return x.ByDirection
+	}
+	return nil
+}
+
+func (x *SummaryResponse) GetLargestEntries() []*EntryMatch {
+	if x != nil {
+		return x.LargestEntries
+	}
+	return nil
+}
+
+type Aggregate struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Key                string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Label              string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	EntryCount         int32                  `protobuf:"varint,3,opt,name=entry_count,json=entryCount,proto3" json:"entry_count,omitempty"`
+	AddendaCount       int32                  `protobuf:"varint,4,opt,name=addenda_count,json=addendaCount,proto3" json:"addenda_count,omitempty"`
+	EntriesWithAddenda int32                  `protobuf:"varint,5,opt,name=entries_with_addenda,json=entriesWithAddenda,proto3" json:"entries_with_addenda,omitempty"`
+	AddendaUsageRate   float64                `protobuf:"fixed64,6,opt,name=addenda_usage_rate,json=addendaUsageRate,proto3" json:"addenda_usage_rate,omitempty"`
+	DebitCount         int32                  `protobuf:"varint,7,opt,name=debit_count,json=debitCount,proto3" json:"debit_count,omitempty"`
+	DebitAmount        int64                  `protobuf:"varint,8,opt,name=debit_amount,json=debitAmount,proto3" json:"debit_amount,omitempty"`
+	CreditCount        int32                  `protobuf:"varint,9,opt,name=credit_count,json=creditCount,proto3" json:"credit_count,omitempty"`
+	CreditAmount       int64                  `protobuf:"varint,10,opt,name=credit_amount,json=creditAmount,proto3" json:"credit_amount,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Aggregate) Reset() {
+	*x = Aggregate{}
+	mi := &file_api_proto_nacha_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Aggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Aggregate) ProtoMessage() {}
+
+func (x *Aggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Aggregate.ProtoReflect.Descriptor instead.
+func (*Aggregate) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Aggregate) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Aggregate) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Aggregate) GetEntryCount() int32 {
+	if x != nil {
+		return x.EntryCount
+	}
+	return 0
+}
+
+func (x *Aggregate) GetAddendaCount() int32 {
+	if x != nil {
+		return x.AddendaCount
+	}
+	return 0
+}
+
+func (x *Aggregate) GetEntriesWithAddenda() int32 {
+	if x != nil {
+		return x.EntriesWithAddenda
+	}
+	return 0
+}
+
+func (x *Aggregate) GetAddendaUsageRate() float64 {
+	if x != nil {
+		return x.AddendaUsageRate
+	}
+	return 0
+}
+
+func (x *Aggregate) GetDebitCount() int32 {
+	if x != nil {
+		return x.DebitCount
+	}
+	return 0
+}
+
+func (x *Aggregate) GetDebitAmount() int64 {
+	if x != nil {
+		return x.DebitAmount
+	}
+	return 0
+}
+
+func (x *Aggregate) GetCreditCount() int32 {
+	if x != nil {
+		return x.CreditCount
+	}
+	return 0
+}
+
+func (x *Aggregate) GetCreditAmount() int64 {
+	if x != nil {
+		return x.CreditAmount
+	}
+	return 0
+}
+
+type UploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadRequest) Reset() {
+	*x = UploadRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadRequest) ProtoMessage() {}
+
+func (x *UploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadRequest.ProtoReflect.Descriptor instead.
+func (*UploadRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UploadRequest) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+type UploadChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunk) Reset() {
+	*x = UploadChunk{}
+	mi := &file_api_proto_nacha_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunk) ProtoMessage() {}
+
+func (x *UploadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunk.ProtoReflect.Descriptor instead.
+func (*UploadChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UploadChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"` // SHA-256 of the content
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix time in seconds
+	BatchCount    int32                  `protobuf:"varint,4,opt,name=batch_count,json=batchCount,proto3" json:"batch_count,omitempty"`
+	EntryCount    int32                  `protobuf:"varint,5,opt,name=entry_count,json=entryCount,proto3" json:"entry_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadResponse) Reset() {
+	*x = UploadResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadResponse) ProtoMessage() {}
+
+func (x *UploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadResponse.ProtoReflect.Descriptor instead.
+func (*UploadResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UploadResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *UploadResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetBatchCount() int32 {
+	if x != nil {
+		return x.BatchCount
+	}
+	return 0
+}
+
+func (x *UploadResponse) GetEntryCount() int32 {
+	if x != nil {
+		return x.EntryCount
+	}
+	return 0
+}
+
+type ExportChunk struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Data     []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset   int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`     // position of data in the exported output
+	Sequence int32                  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"` // chunk number, starting at 0
+	Last     bool                   `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`         // set on the final chunk
+	// Set on the first chunk
+	FileType     string `protobuf:"bytes,5,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	TotalBatches int32  `protobuf:"varint,6,opt,name=total_batches,json=totalBatches,proto3" json:"total_batches,omitempty"`
+	TotalEntries int32  `protobuf:"varint,7,opt,name=total_entries,json=totalEntries,proto3" json:"total_entries,omitempty"`
+	// Set on the final chunk
+	TotalSize     int64 `protobuf:"varint,8,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	mi := &file_api_proto_nacha_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportChunk) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
+func (x *ExportChunk) GetFileType() string {
+	if x != nil {
+		return x.FileType
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetTotalBatches() int32 {
+	if x != nil {
+		return x.TotalBatches
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetTotalEntries() int32 {
+	if x != nil {
+		return x.TotalEntries
+	}
+	return 0
+}
+
+func (x *ExportChunk) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type ListExportFormatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExportFormatsRequest) Reset() {
+	*x = ListExportFormatsRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExportFormatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExportFormatsRequest) ProtoMessage() {}
+
+func (x *ListExportFormatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExportFormatsRequest.ProtoReflect.Descriptor instead.
+func (*ListExportFormatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{38}
+}
+
+type ListExportFormatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Formats       []*ExportFormatInfo    `protobuf:"bytes,1,rep,name=formats,proto3" json:"formats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListExportFormatsResponse) Reset() {
+	*x = ListExportFormatsResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListExportFormatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListExportFormatsResponse) ProtoMessage() {}
+
+func (x *ListExportFormatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListExportFormatsResponse.ProtoReflect.Descriptor instead.
+func (*ListExportFormatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListExportFormatsResponse) GetFormats() []*ExportFormatInfo {
+	if x != nil {
+		return x.Formats
+	}
+	return nil
+}
+
+type ExportFormatInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // value for ExportRequest.format_name
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Extension     string                 `protobuf:"bytes,3,opt,name=extension,proto3" json:"extension,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Binary        bool                   `protobuf:"varint,5,opt,name=binary,proto3" json:"binary,omitempty"`                         // output is not text
+	Aggregated    bool                   `protobuf:"varint,6,opt,name=aggregated,proto3" json:"aggregated,omitempty"`                 // output holds aggregates instead of records
+	Importable    bool                   `protobuf:"varint,7,opt,name=importable,proto3" json:"importable,omitempty"`                 // output can be imported back
+	HasEnum       bool                   `protobuf:"varint,8,opt,name=has_enum,json=hasEnum,proto3" json:"has_enum,omitempty"`        // format can also be selected with ExportFormat
+	Format        ExportFormat           `protobuf:"varint,9,opt,name=format,proto3,enum=nacha.ExportFormat" json:"format,omitempty"` // ExportFormat value when has_enum is set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFormatInfo) Reset() {
+	*x = ExportFormatInfo{}
+	mi := &file_api_proto_nacha_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFormatInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFormatInfo) ProtoMessage() {}
+
+func (x *ExportFormatInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFormatInfo.ProtoReflect.Descriptor instead.
+func (*ExportFormatInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ExportFormatInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExportFormatInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportFormatInfo) GetExtension() string {
+	if x != nil {
+		return x.Extension
+	}
+	return ""
+}
+
+func (x *ExportFormatInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ExportFormatInfo) GetBinary() bool {
+	if x != nil {
+		return x.Binary
+	}
+	return false
+}
+
+func (x *ExportFormatInfo) GetAggregated() bool {
+	if x != nil {
+		return x.Aggregated
+	}
+	return false
+}
+
+func (x *ExportFormatInfo) GetImportable() bool {
+	if x != nil {
+		return x.Importable
+	}
+	return false
+}
+
+func (x *ExportFormatInfo) GetHasEnum() bool {
+	if x != nil {
+		return x.HasEnum
+	}
+	return false
+}
+
+func (x *ExportFormatInfo) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_JSON
+}
+
+type PainImportRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	XmlContent []byte                 `protobuf:"bytes,1,opt,name=xml_content,json=xmlContent,proto3" json:"xml_content,omitempty"` // pain.001.001.03 or pain.008.001.02 message
+	// File header fields that pain messages do not carry. Empty fields are
+	// derived from the message.
+	ImmediateDestination     string `protobuf:"bytes,2,opt,name=immediate_destination,json=immediateDestination,proto3" json:"immediate_destination,omitempty"`
+	ImmediateDestinationName string `protobuf:"bytes,3,opt,name=immediate_destination_name,json=immediateDestinationName,proto3" json:"immediate_destination_name,omitempty"`
+	ImmediateOrigin          string `protobuf:"bytes,4,opt,name=immediate_origin,json=immediateOrigin,proto3" json:"immediate_origin,omitempty"`
+	FileIdModifier           string `protobuf:"bytes,5,opt,name=file_id_modifier,json=fileIdModifier,proto3" json:"file_id_modifier,omitempty"`
+	CompanyEntryDescription  string `protobuf:"bytes,6,opt,name=company_entry_description,json=companyEntryDescription,proto3" json:"company_entry_description,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *PainImportRequest) Reset() {
+	*x = PainImportRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PainImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PainImportRequest) ProtoMessage() {}
+
+func (x *PainImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PainImportRequest.ProtoReflect.Descriptor instead.
+func (*PainImportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PainImportRequest) GetXmlContent() []byte {
+	if x != nil {
+		return x.XmlContent
+	}
+	return nil
+}
+
+func (x *PainImportRequest) GetImmediateDestination() string {
+	if x != nil {
+		return x.ImmediateDestination
+	}
+	return ""
+}
+
+func (x *PainImportRequest) GetImmediateDestinationName() string {
+	if x != nil {
+		return x.ImmediateDestinationName
+	}
+	return ""
+}
+
+func (x *PainImportRequest) GetImmediateOrigin() string {
+	if x != nil {
+		return x.ImmediateOrigin
+	}
+	return ""
+}
+
+func (x *PainImportRequest) GetFileIdModifier() string {
+	if x != nil {
+		return x.FileIdModifier
+	}
+	return ""
+}
+
+func (x *PainImportRequest) GetCompanyEntryDescription() string {
+	if x != nil {
+		return x.CompanyEntryDescription
+	}
+	return ""
+}
+
+type PainImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"` // empty when errors are reported
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	MessageType   string                 `protobuf:"bytes,3,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"` // "pain.001.001.03" or "pain.008.001.02"
+	Errors        []*ImportError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PainImportResponse) Reset() {
+	*x = PainImportResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PainImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PainImportResponse) ProtoMessage() {}
+
+func (x *PainImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PainImportResponse.ProtoReflect.Descriptor instead.
+func (*PainImportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PainImportResponse) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *PainImportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PainImportResponse) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *PainImportResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// ImportError reports a value of the source that cannot be mapped to NACHA
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ErrorCode     string                 `protobuf:"bytes,1,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // e.g. "UNSUPPORTED_CURRENCY"
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Location      string                 `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"` // path of the value in the source
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_api_proto_nacha_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ImportError) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportError) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ImportError) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CsvImportRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CsvContent []byte                 `protobuf:"bytes,1,opt,name=csv_content,json=csvContent,proto3" json:"csv_content,omitempty"`
+	// Header fields of the file and of its single batch, used for payee
+	// spreadsheets. A CSV written by ExportFile carries its own headers.
+	FileHeader    *FileHeader       `protobuf:"bytes,2,opt,name=file_header,json=fileHeader,proto3" json:"file_header,omitempty"` // file_creation_date as YYMMDD, defaults to today
+	BatchHeader   *BatchHeader      `protobuf:"bytes,3,opt,name=batch_header,json=batchHeader,proto3" json:"batch_header,omitempty"`
+	Columns       *CsvColumnMapping `protobuf:"bytes,4,opt,name=columns,proto3" json:"columns,omitempty"`
+	AmountFormat  CsvAmountFormat   `protobuf:"varint,5,opt,name=amount_format,json=amountFormat,proto3,enum=nacha.CsvAmountFormat" json:"amount_format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CsvImportRequest) Reset() {
+	*x = CsvImportRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CsvImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CsvImportRequest) ProtoMessage() {}
+
+func (x *CsvImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CsvImportRequest.ProtoReflect.Descriptor instead.
+func (*CsvImportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CsvImportRequest) GetCsvContent() []byte {
+	if x != nil {
+		return x.CsvContent
+	}
+	return nil
+}
+
+func (x *CsvImportRequest) GetFileHeader() *FileHeader {
+	if x != nil {
+		return x.FileHeader
+	}
+	return nil
+}
+
+func (x *CsvImportRequest) GetBatchHeader() *BatchHeader {
+	if x != nil {
+		return x.BatchHeader
+	}
+	return nil
+}
+
+func (x *CsvImportRequest) GetColumns() *CsvColumnMapping {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *CsvImportRequest) GetAmountFormat() CsvAmountFormat {
+	if x != nil {
+		return x.AmountFormat
+	}
+	return CsvAmountFormat_CSV_AMOUNT_DECIMAL
+}
+
+// CsvColumnMapping names the columns of a payee spreadsheet, matched against
+// the header row without regard to case. Empty names use the defaults.
+type CsvColumnMapping struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                              // default "name"
+	Routing         string                 `protobuf:"bytes,2,opt,name=routing,proto3" json:"routing,omitempty"`                                        // default "routing", nine digits
+	Account         string                 `protobuf:"bytes,3,opt,name=account,proto3" json:"account,omitempty"`                                        // default "account"
+	AccountType     string                 `protobuf:"bytes,4,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`             // default "account_type", optional: checking or savings
+	Amount          string                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`                                          // default "amount"
+	Addenda         string                 `protobuf:"bytes,6,opt,name=addenda,proto3" json:"addenda,omitempty"`                                        // default "addenda", optional
+	IdNumber        string                 `protobuf:"bytes,7,opt,name=id_number,json=idNumber,proto3" json:"id_number,omitempty"`                      // default "id_number", optional
+	TransactionCode string                 `protobuf:"bytes,8,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"` // default "transaction_code", optional
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CsvColumnMapping) Reset() {
+	*x = CsvColumnMapping{}
+	mi := &file_api_proto_nacha_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CsvColumnMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CsvColumnMapping) ProtoMessage() {}
+
+func (x *CsvColumnMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CsvColumnMapping.ProtoReflect.Descriptor instead.
+func (*CsvColumnMapping) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CsvColumnMapping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetRouting() string {
+	if x != nil {
+		return x.Routing
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetAddenda() string {
+	if x != nil {
+		return x.Addenda
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetIdNumber() string {
+	if x != nil {
+		return x.IdNumber
+	}
+	return ""
+}
+
+func (x *CsvColumnMapping) GetTransactionCode() string {
+	if x != nil {
+		return x.TransactionCode
+	}
+	return ""
+}
+
+type CsvImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"` // empty when errors are reported
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Layout        string                 `protobuf:"bytes,3,opt,name=layout,proto3" json:"layout,omitempty"` // "EXPORT" or "COLUMNS"
+	Errors        []*ImportError         `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CsvImportResponse) Reset() {
+	*x = CsvImportResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CsvImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CsvImportResponse) ProtoMessage() {}
+
+func (x *CsvImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CsvImportResponse.ProtoReflect.Descriptor instead.
+func (*CsvImportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CsvImportResponse) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *CsvImportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CsvImportResponse) GetLayout() string {
+	if x != nil {
+		return x.Layout
+	}
+	return ""
+}
+
+func (x *CsvImportResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type XlsxImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	XlsxContent   []byte                 `protobuf:"bytes,1,opt,name=xlsx_content,json=xlsxContent,proto3" json:"xlsx_content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XlsxImportRequest) Reset() {
+	*x = XlsxImportRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XlsxImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XlsxImportRequest) ProtoMessage() {}
+
+func (x *XlsxImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XlsxImportRequest.ProtoReflect.Descriptor instead.
+func (*XlsxImportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *XlsxImportRequest) GetXlsxContent() []byte {
+	if x != nil {
+		return x.XlsxContent
+	}
+	return nil
+}
+
+type XlsxImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"` // empty when errors are reported
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *XlsxImportResponse) Reset() {
+	*x = XlsxImportResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *XlsxImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*XlsxImportResponse) ProtoMessage() {}
+
+func (x *XlsxImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use XlsxImportResponse.ProtoReflect.Descriptor instead.
+func (*XlsxImportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *XlsxImportResponse) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *XlsxImportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *XlsxImportResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type CnabRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CnabContent   []byte                 `protobuf:"bytes,1,opt,name=cnab_content,json=cnabContent,proto3" json:"cnab_content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CnabRequest) Reset() {
+	*x = CnabRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabRequest) ProtoMessage() {}
+
+func (x *CnabRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabRequest.ProtoReflect.Descriptor instead.
+func (*CnabRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CnabRequest) GetCnabContent() []byte {
+	if x != nil {
+		return x.CnabContent
+	}
+	return nil
+}
+
+type CnabImportRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CnabContent []byte                 `protobuf:"bytes,1,opt,name=cnab_content,json=cnabContent,proto3" json:"cnab_content,omitempty"`
+	// Replaces the company inscription (CNPJ or CPF) as company
+	// identification and immediate origin. Required when the inscription
+	// has more than ten digits.
+	CompanyIdentification string `protobuf:"bytes,2,opt,name=company_identification,json=companyIdentification,proto3" json:"company_identification,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CnabImportRequest) Reset() {
+	*x = CnabImportRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabImportRequest) ProtoMessage() {}
+
+func (x *CnabImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabImportRequest.ProtoReflect.Descriptor instead.
+func (*CnabImportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CnabImportRequest) GetCnabContent() []byte {
+	if x != nil {
+		return x.CnabContent
+	}
+	return nil
+}
+
+func (x *CnabImportRequest) GetCompanyIdentification() string {
+	if x != nil {
+		return x.CompanyIdentification
+	}
+	return ""
+}
+
+type CnabImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"` // empty when errors are reported
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CnabImportResponse) Reset() {
+	*x = CnabImportResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabImportResponse) ProtoMessage() {}
+
+func (x *CnabImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabImportResponse.ProtoReflect.Descriptor instead.
+func (*CnabImportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CnabImportResponse) GetFileContent() []byte {
+	if x != nil {
+		return x.FileContent
+	}
+	return nil
+}
+
+func (x *CnabImportResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CnabImportResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type CnabViewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *CnabRecord            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Lotes         []*CnabLote            `protobuf:"bytes,2,rep,name=lotes,proto3" json:"lotes,omitempty"`
+	Trailer       *CnabRecord            `protobuf:"bytes,3,opt,name=trailer,proto3" json:"trailer,omitempty"` // absent when the file has no trailer
+	IsValid       bool                   `protobuf:"varint,4,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	Errors        []*ValidationError     `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CnabViewResponse) Reset() {
+	*x = CnabViewResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabViewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabViewResponse) ProtoMessage() {}
+
+func (x *CnabViewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabViewResponse.ProtoReflect.Descriptor instead.
+func (*CnabViewResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CnabViewResponse) GetHeader() *CnabRecord {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CnabViewResponse) GetLotes() []*CnabLote {
+	if x != nil {
+		return x.Lotes
+	}
+	return nil
+}
+
+func (x *CnabViewResponse) GetTrailer() *CnabRecord {
+	if x != nil {
+		return x.Trailer
+	}
+	return nil
+}
+
+func (x *CnabViewResponse) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *CnabViewResponse) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type CnabLote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *CnabRecord            `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Details       []*CnabRecord          `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty"`
+	Trailer       *CnabRecord            `protobuf:"bytes,3,opt,name=trailer,proto3" json:"trailer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CnabLote) Reset() {
+	*x = CnabLote{}
+	mi := &file_api_proto_nacha_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabLote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabLote) ProtoMessage() {}
+
+func (x *CnabLote) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabLote.ProtoReflect.Descriptor instead.
+func (*CnabLote) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CnabLote) GetHeader() *CnabRecord {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *CnabLote) GetDetails() []*CnabRecord {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *CnabLote) GetTrailer() *CnabRecord {
+	if x != nil {
+		return x.Trailer
+	}
+	return nil
+}
+
+// CnabRecord is a record of a CNAB 240 file split into the fields of its layout
+type CnabRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	RecordType    string                 `protobuf:"bytes,2,opt,name=record_type,json=recordType,proto3" json:"record_type,omitempty"` // tipo de registro, 0 to 9
+	Segment       string                 `protobuf:"bytes,3,opt,name=segment,proto3" json:"segment,omitempty"`                         // segment of detail records, such as "A"
+	Layout        string                 `protobuf:"bytes,4,opt,name=layout,proto3" json:"layout,omitempty"`                           // e.g. "segmento_a"
+	Fields        []*CnabField           `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CnabRecord) Reset() {
+	*x = CnabRecord{}
+	mi := &file_api_proto_nacha_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CnabRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CnabRecord) ProtoMessage() {}
+
+func (x *CnabRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CnabRecord.ProtoReflect.Descriptor instead.
+func (*CnabRecord) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CnabRecord) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *CnabRecord) GetRecordType() string {
+	if x != nil {
+		return x.RecordType
+	}
+	return ""
+}
+
+func (x *CnabRecord) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *CnabRecord) GetLayout() string {
+	if x != nil {
+		return x.Layout
 	}
-	return nil
+	return ""
 }
 
-func (x *FileDetailsResponse) GetSummary() map[string]string {
+func (x *CnabRecord) GetFields() []*CnabField {
 	if x != nil {
-		return x.Summary
+		return x.Fields
 	}
 	return nil
 }
 
-type BatchDetails struct {
+type CnabField struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        *BatchHeader           `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Entries       []*EntryDetail         `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
-	Control       *BatchControl          `protobuf:"bytes,3,opt,name=control,proto3" json:"control,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Start         int32                  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"` // one-based position of the first character
+	End           int32                  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`     // one-based position of the last character
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`  // trimmed value
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchDetails) Reset() {
-	*x = BatchDetails{}
-	mi := &file_api_proto_nacha_proto_msgTypes[15]
+func (x *CnabField) Reset() {
+	*x = CnabField{}
+	mi := &file_api_proto_nacha_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchDetails) String() string {
+func (x *CnabField) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchDetails) ProtoMessage() {}
+func (*CnabField) ProtoMessage() {}
 
-func (x *BatchDetails) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_nacha_proto_msgTypes[15]
+func (x *CnabField) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,56 +4695,61 @@ func (x *BatchDetails) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchDetails.ProtoReflect.Descriptor instead.
-func (*BatchDetails) Descriptor() ([]byte, []int) {
-	return file_api_proto_nacha_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CnabField.ProtoReflect.Descriptor instead.
+func (*CnabField) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *BatchDetails) GetHeader() *BatchHeader {
+func (x *CnabField) GetName() string {
 	if x != nil {
-		return x.Header
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *BatchDetails) GetEntries() []*EntryDetail {
+func (x *CnabField) GetStart() int32 {
 	if x != nil {
-		return x.Entries
+		return x.Start
 	}
-	return nil
+	return 0
 }
 
-func (x *BatchDetails) GetControl() *BatchControl {
+func (x *CnabField) GetEnd() int32 {
 	if x != nil {
-		return x.Control
+		return x.End
 	}
-	return nil
+	return 0
 }
 
-type DetailRequest struct {
+func (x *CnabField) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type CpaRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
-	DetailType    string                 `protobuf:"bytes,2,opt,name=detail_type,json=detailType,proto3" json:"detail_type,omitempty"` // "batch" or "entry"
-	Identifier    string                 `protobuf:"bytes,3,opt,name=identifier,proto3" json:"identifier,omitempty"`                   // batch number or trace number
+	CpaContent    []byte                 `protobuf:"bytes,1,opt,name=cpa_content,json=cpaContent,proto3" json:"cpa_content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DetailRequest) Reset() {
-	*x = DetailRequest{}
-	mi := &file_api_proto_nacha_proto_msgTypes[16]
+func (x *CpaRequest) Reset() {
+	*x = CpaRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetailRequest) String() string {
+func (x *CpaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetailRequest) ProtoMessage() {}
+func (*CpaRequest) ProtoMessage() {}
 
-func (x *DetailRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_nacha_proto_msgTypes[16]
+func (x *CpaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1396,58 +4760,104 @@ func (x *DetailRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetailRequest.ProtoReflect.Descriptor instead.
-func (*DetailRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_nacha_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CpaRequest.ProtoReflect.Descriptor instead.
+func (*CpaRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *DetailRequest) GetFileContent() []byte {
+func (x *CpaRequest) GetCpaContent() []byte {
 	if x != nil {
-		return x.FileContent
+		return x.CpaContent
 	}
 	return nil
 }
 
-func (x *DetailRequest) GetDetailType() string {
+type CpaImportRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CpaContent []byte                 `protobuf:"bytes,1,opt,name=cpa_content,json=cpaContent,proto3" json:"cpa_content,omitempty"`
+	// File header fields that CPA 005 files do not carry. The immediate
+	// destination defaults to the institution for returns.
+	ImmediateDestination     string `protobuf:"bytes,2,opt,name=immediate_destination,json=immediateDestination,proto3" json:"immediate_destination,omitempty"`
+	ImmediateDestinationName string `protobuf:"bytes,3,opt,name=immediate_destination_name,json=immediateDestinationName,proto3" json:"immediate_destination_name,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *CpaImportRequest) Reset() {
+	*x = CpaImportRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CpaImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CpaImportRequest) ProtoMessage() {}
+
+func (x *CpaImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[57]
 	if x != nil {
-		return x.DetailType
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CpaImportRequest.ProtoReflect.Descriptor instead.
+func (*CpaImportRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CpaImportRequest) GetCpaContent() []byte {
+	if x != nil {
+		return x.CpaContent
+	}
+	return nil
+}
+
+func (x *CpaImportRequest) GetImmediateDestination() string {
+	if x != nil {
+		return x.ImmediateDestination
 	}
 	return ""
 }
 
-func (x *DetailRequest) GetIdentifier() string {
+func (x *CpaImportRequest) GetImmediateDestinationName() string {
 	if x != nil {
-		return x.Identifier
+		return x.ImmediateDestinationName
 	}
 	return ""
 }
 
-type DetailResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Detail:
-	//
-	//	*DetailResponse_Batch
-	//	*DetailResponse_Entry
-	Detail        isDetailResponse_Detail `protobuf_oneof:"detail"`
+type CpaImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"` // empty when errors are reported
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DetailResponse) Reset() {
-	*x = DetailResponse{}
-	mi := &file_api_proto_nacha_proto_msgTypes[17]
+func (x *CpaImportResponse) Reset() {
+	*x = CpaImportResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetailResponse) String() string {
+func (x *CpaImportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetailResponse) ProtoMessage() {}
+func (*CpaImportResponse) ProtoMessage() {}
 
-func (x *DetailResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_nacha_proto_msgTypes[17]
+func (x *CpaImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1458,84 +4868,57 @@ func (x *DetailResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetailResponse.ProtoReflect.Descriptor instead.
-func (*DetailResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_nacha_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use CpaImportResponse.ProtoReflect.Descriptor instead.
+func (*CpaImportResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *DetailResponse) GetDetail() isDetailResponse_Detail {
+func (x *CpaImportResponse) GetFileContent() []byte {
 	if x != nil {
-		return x.Detail
+		return x.FileContent
 	}
 	return nil
 }
 
-func (x *DetailResponse) GetBatch() *BatchDetails {
+func (x *CpaImportResponse) GetMessage() string {
 	if x != nil {
-		if x, ok := x.Detail.(*DetailResponse_Batch); ok {
-			return x.Batch
-		}
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
-func (x *DetailResponse) GetEntry() *EntryDetail {
+func (x *CpaImportResponse) GetErrors() []*ImportError {
 	if x != nil {
-		if x, ok := x.Detail.(*DetailResponse_Entry); ok {
-			return x.Entry
-		}
+		return x.Errors
 	}
 	return nil
 }
 
-type isDetailResponse_Detail interface {
-	isDetailResponse_Detail()
-}
-
-type DetailResponse_Batch struct {
-	Batch *BatchDetails `protobuf:"bytes,1,opt,name=batch,proto3,oneof"`
-}
-
-type DetailResponse_Entry struct {
-	Entry *EntryDetail `protobuf:"bytes,2,opt,name=entry,proto3,oneof"`
-}
-
-func (*DetailResponse_Batch) isDetailResponse_Detail() {}
-
-func (*DetailResponse_Entry) isDetailResponse_Detail() {}
-
-type EntryDetail struct {
-	state                          protoimpl.MessageState `protogen:"open.v1"`
-	TransactionCode                string                 `protobuf:"bytes,1,opt,name=transaction_code,json=transactionCode,proto3" json:"transaction_code,omitempty"`
-	ReceivingDfiIdentification     string                 `protobuf:"bytes,2,opt,name=receiving_dfi_identification,json=receivingDfiIdentification,proto3" json:"receiving_dfi_identification,omitempty"`
-	CheckDigit                     string                 `protobuf:"bytes,3,opt,name=check_digit,json=checkDigit,proto3" json:"check_digit,omitempty"`
-	DfiAccountNumber               string                 `protobuf:"bytes,4,opt,name=dfi_account_number,json=dfiAccountNumber,proto3" json:"dfi_account_number,omitempty"`
-	Amount                         int64                  `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
-	IndividualIdentificationNumber string                 `protobuf:"bytes,6,opt,name=individual_identification_number,json=individualIdentificationNumber,proto3" json:"individual_identification_number,omitempty"`
-	IndividualName                 string                 `protobuf:"bytes,7,opt,name=individual_name,json=individualName,proto3" json:"individual_name,omitempty"`
-	DiscretionaryData              string                 `protobuf:"bytes,8,opt,name=discretionary_data,json=discretionaryData,proto3" json:"discretionary_data,omitempty"`
-	AddendaRecordIndicator         string                 `protobuf:"bytes,9,opt,name=addenda_record_indicator,json=addendaRecordIndicator,proto3" json:"addenda_record_indicator,omitempty"`
-	TraceNumber                    string                 `protobuf:"bytes,10,opt,name=trace_number,json=traceNumber,proto3" json:"trace_number,omitempty"`
-	AddendaRecords                 []*AddendaRecord       `protobuf:"bytes,11,rep,name=addenda_records,json=addendaRecords,proto3" json:"addenda_records,omitempty"`
-	unknownFields                  protoimpl.UnknownFields
-	sizeCache                      protoimpl.SizeCache
+type RemittanceAdviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileContent   []byte                 `protobuf:"bytes,1,opt,name=file_content,json=fileContent,proto3" json:"file_content,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Format        RemittanceFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=nacha.RemittanceFormat" json:"format,omitempty"`
+	Options       *ExportOptions         `protobuf:"bytes,4,opt,name=options,proto3" json:"options,omitempty"` // batch and SEC code selection, amount and date formats, mask_visible_digits
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EntryDetail) Reset() {
-	*x = EntryDetail{}
-	mi := &file_api_proto_nacha_proto_msgTypes[18]
+func (x *RemittanceAdviceRequest) Reset() {
+	*x = RemittanceAdviceRequest{}
+	mi := &file_api_proto_nacha_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EntryDetail) String() string {
+func (x *RemittanceAdviceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EntryDetail) ProtoMessage() {}
+func (*RemittanceAdviceRequest) ProtoMessage() {}
 
-func (x *EntryDetail) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_nacha_proto_msgTypes[18]
+func (x *RemittanceAdviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1546,96 +4929,113 @@ func (x *EntryDetail) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EntryDetail.ProtoReflect.Descriptor instead.
-func (*EntryDetail) Descriptor() ([]byte, []int) {
-	return file_api_proto_nacha_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RemittanceAdviceRequest.ProtoReflect.Descriptor instead.
+func (*RemittanceAdviceRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *EntryDetail) GetTransactionCode() string {
+func (x *RemittanceAdviceRequest) GetFileContent() []byte {
 	if x != nil {
-		return x.TransactionCode
+		return x.FileContent
 	}
-	return ""
+	return nil
 }
 
-func (x *EntryDetail) GetReceivingDfiIdentification() string {
+func (x *RemittanceAdviceRequest) GetFileId() string {
 	if x != nil {
-		return x.ReceivingDfiIdentification
+		return x.FileId
 	}
 	return ""
 }
 
-func (x *EntryDetail) GetCheckDigit() string {
+func (x *RemittanceAdviceRequest) GetFormat() RemittanceFormat {
 	if x != nil {
-		return x.CheckDigit
+		return x.Format
 	}
-	return ""
+	return RemittanceFormat_REMITTANCE_PDF
 }
 
-func (x *EntryDetail) GetDfiAccountNumber() string {
+func (x *RemittanceAdviceRequest) GetOptions() *ExportOptions {
 	if x != nil {
-		return x.DfiAccountNumber
+		return x.Options
 	}
-	return ""
+	return nil
 }
 
-func (x *EntryDetail) GetAmount() int64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
+type RemittanceAdviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZipContent    []byte                 `protobuf:"bytes,1,opt,name=zip_content,json=zipContent,proto3" json:"zip_content,omitempty"` // one advice per payment, named <trace number>.pdf or .html
+	AdviceCount   int32                  `protobuf:"varint,2,opt,name=advice_count,json=adviceCount,proto3" json:"advice_count,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EntryDetail) GetIndividualIdentificationNumber() string {
-	if x != nil {
-		return x.IndividualIdentificationNumber
-	}
-	return ""
+func (x *RemittanceAdviceResponse) Reset() {
+	*x = RemittanceAdviceResponse{}
+	mi := &file_api_proto_nacha_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *EntryDetail) GetIndividualName() string {
-	if x != nil {
-		return x.IndividualName
-	}
-	return ""
+func (x *RemittanceAdviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *EntryDetail) GetDiscretionaryData() string {
+func (*RemittanceAdviceResponse) ProtoMessage() {}
+
+func (x *RemittanceAdviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_nacha_proto_msgTypes[60]
 	if x != nil {
-		return x.DiscretionaryData
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *EntryDetail) GetAddendaRecordIndicator() string {
+// Deprecated: Use RemittanceAdviceResponse.ProtoReflect.Descriptor instead.
+func (*RemittanceAdviceResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_nacha_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RemittanceAdviceResponse) GetZipContent() []byte {
 	if x != nil {
-		return x.AddendaRecordIndicator
+		return x.ZipContent
 	}
-	return ""
+	return nil
 }
 
-func (x *EntryDetail) GetTraceNumber() string {
+func (x *RemittanceAdviceResponse) GetAdviceCount() int32 {
 	if x != nil {
-		return x.TraceNumber
+		return x.AdviceCount
 	}
-	return ""
+	return 0
 }
 
-func (x *EntryDetail) GetAddendaRecords() []*AddendaRecord {
+func (x *RemittanceAdviceResponse) GetMessage() string {
 	if x != nil {
-		return x.AddendaRecords
+		return x.Message
 	}
-	return nil
+	return ""
 }
 
 var File_api_proto_nacha_proto protoreflect.FileDescriptor
 
 const file_api_proto_nacha_proto_rawDesc = "" +
 	"\n" +
-	"\x15api/proto/nacha.proto\x12\x05nacha\"M\n" +
+	"\x15api/proto/nacha.proto\x12\x05nacha\x1a google/protobuf/field_mask.proto\"\xc3\x02\n" +
 	"\vFileRequest\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x1b\n" +
-	"\tfile_path\x18\x02 \x01(\tR\bfilePath\"_\n" +
+	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12&\n" +
+	"\x0fbatch_page_size\x18\x03 \x01(\x05R\rbatchPageSize\x12(\n" +
+	"\x10batch_page_token\x18\x04 \x01(\tR\x0ebatchPageToken\x12&\n" +
+	"\x0fentry_page_size\x18\x05 \x01(\x05R\rentryPageSize\x12(\n" +
+	"\x10entry_page_token\x18\x06 \x01(\tR\x0eentryPageToken\x127\n" +
+	"\tview_mask\x18\a \x01(\v2\x1a.google.protobuf.FieldMaskR\bviewMask\x12\x17\n" +
+	"\afile_id\x18\b \x01(\tR\x06fileId\"_\n" +
 	"\x12ValidationResponse\x12\x19\n" +
 	"\bis_valid\x18\x01 \x01(\bR\aisValid\x12.\n" +
 	"\x06errors\x18\x02 \x03(\v2\x16.nacha.ValidationErrorR\x06errors\"f\n" +
@@ -1739,34 +5139,102 @@ const file_api_proto_nacha_proto_rawDesc = "" +
 	"\breserved\x18\b \x01(\tR\breserved\"K\n" +
 	"\fFileResponse\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"_\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xe8\x01\n" +
 	"\rExportRequest\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12+\n" +
-	"\x06format\x18\x02 \x01(\x0e2\x13.nacha.ExportFormatR\x06format\"r\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x13.nacha.ExportFormatR\x06format\x12\x17\n" +
+	"\afile_id\x18\x03 \x01(\tR\x06fileId\x12\x1d\n" +
+	"\n" +
+	"chunk_size\x18\x04 \x01(\x05R\tchunkSize\x12\x1f\n" +
+	"\vformat_name\x18\x05 \x01(\tR\n" +
+	"formatName\x12.\n" +
+	"\aoptions\x18\x06 \x01(\v2\x14.nacha.ExportOptionsR\aoptions\"\x81\x05\n" +
+	"\rExportOptions\x12\x16\n" +
+	"\x06fields\x18\x01 \x03(\tR\x06fields\x120\n" +
+	"\x14mask_account_numbers\x18\x02 \x01(\bR\x12maskAccountNumbers\x12.\n" +
+	"\x13mask_visible_digits\x18\x03 \x01(\x05R\x11maskVisibleDigits\x12#\n" +
+	"\rbatch_numbers\x18\x04 \x03(\tR\fbatchNumbers\x12\x1b\n" +
+	"\tsec_codes\x18\x05 \x03(\tR\bsecCodes\x128\n" +
+	"\ramount_format\x18\x06 \x01(\x0e2\x13.nacha.AmountFormatR\famountFormat\x12\x16\n" +
+	"\x06locale\x18\a \x01(\tR\x06locale\x12\x1f\n" +
+	"\vdate_format\x18\b \x01(\tR\n" +
+	"dateFormat\x12\x1a\n" +
+	"\btimezone\x18\t \x01(\tR\btimezone\x122\n" +
+	"\vsql_dialect\x18\n" +
+	" \x01(\x0e2\x11.nacha.SqlDialectR\n" +
+	"sqlDialect\x12&\n" +
+	"\x0fskip_sql_schema\x18\v \x01(\bR\rskipSqlSchema\x12&\n" +
+	"\x04cnab\x18\f \x01(\v2\x12.nacha.CnabOptionsR\x04cnab\x12#\n" +
+	"\x03cpa\x18\r \x01(\v2\x11.nacha.CpaOptionsR\x03cpa\x122\n" +
+	"\btemplate\x18\x0e \x01(\v2\x16.nacha.TemplateOptionsR\btemplate\x12 \n" +
+	"\x02gl\x18\x0f \x01(\v2\x10.nacha.GlOptionsR\x02gl\x12&\n" +
+	"\x04pain\x18\x10 \x01(\v2\x12.nacha.PainOptionsR\x04pain\"\xa4\x01\n" +
+	"\vCnabOptions\x12)\n" +
+	"\x10company_document\x18\x01 \x01(\tR\x0fcompanyDocument\x12\x1c\n" +
+	"\tagreement\x18\x02 \x01(\tR\tagreement\x12'\n" +
+	"\x0fcompany_account\x18\x03 \x01(\tR\x0ecompanyAccount\x12#\n" +
+	"\rfile_sequence\x18\x04 \x01(\x05R\ffileSequence\"\xcc\x02\n" +
+	"\n" +
+	"CpaOptions\x12#\n" +
+	"\roriginator_id\x18\x01 \x01(\tR\foriginatorId\x120\n" +
+	"\x14file_creation_number\x18\x02 \x01(\x05R\x12fileCreationNumber\x126\n" +
+	"\x17destination_data_centre\x18\x03 \x01(\tR\x15destinationDataCentre\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x126\n" +
+	"\x17credit_transaction_type\x18\x05 \x01(\tR\x15creditTransactionType\x124\n" +
+	"\x16debit_transaction_type\x18\x06 \x01(\tR\x14debitTransactionType\x12%\n" +
+	"\x0ereturn_account\x18\a \x01(\tR\rreturnAccount\"t\n" +
+	"\x0fTemplateOptions\x12\x16\n" +
+	"\x06source\x18\x01 \x01(\tR\x06source\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04html\x18\x03 \x01(\bR\x04html\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\"\xaa\x01\n" +
+	"\tGlOptions\x12*\n" +
+	"\x05rules\x18\x01 \x03(\v2\x14.nacha.GlAccountRuleR\x05rules\x12#\n" +
+	"\rdebit_account\x18\x02 \x01(\tR\fdebitAccount\x12%\n" +
+	"\x0ecredit_account\x18\x03 \x01(\tR\rcreditAccount\x12%\n" +
+	"\x0eoffset_account\x18\x04 \x01(\tR\roffsetAccount\"\xb5\x01\n" +
+	"\rGlAccountRule\x12\x1d\n" +
+	"\n" +
+	"company_id\x18\x01 \x01(\tR\tcompanyId\x12\x19\n" +
+	"\bsec_code\x18\x02 \x01(\tR\asecCode\x12)\n" +
+	"\x10transaction_code\x18\x03 \x01(\tR\x0ftransactionCode\x12\x18\n" +
+	"\aaccount\x18\x04 \x01(\tR\aaccount\x12%\n" +
+	"\x0eoffset_account\x18\x05 \x01(\tR\roffsetAccount\"\xce\x01\n" +
+	"\vPainOptions\x12'\n" +
+	"\x0fcompany_account\x18\x01 \x01(\tR\x0ecompanyAccount\x12R\n" +
+	"\x10company_accounts\x18\x02 \x03(\v2'.nacha.PainOptions.CompanyAccountsEntryR\x0fcompanyAccounts\x1aB\n" +
+	"\x14CompanyAccountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"r\n" +
 	"\x0eExportResponse\x12)\n" +
 	"\x10exported_content\x18\x01 \x01(\fR\x0fexportedContent\x12\x1b\n" +
 	"\tfile_type\x18\x02 \x01(\tR\bfileType\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xae\x02\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x86\x03\n" +
 	"\x13FileDetailsResponse\x122\n" +
 	"\vfile_header\x18\x01 \x01(\v2\x11.nacha.FileHeaderR\n" +
 	"fileHeader\x12-\n" +
 	"\abatches\x18\x02 \x03(\v2\x13.nacha.BatchDetailsR\abatches\x125\n" +
 	"\ffile_control\x18\x03 \x01(\v2\x12.nacha.FileControlR\vfileControl\x12A\n" +
-	"\asummary\x18\x04 \x03(\v2'.nacha.FileDetailsResponse.SummaryEntryR\asummary\x1a:\n" +
+	"\asummary\x18\x04 \x03(\v2'.nacha.FileDetailsResponse.SummaryEntryR\asummary\x12#\n" +
+	"\rtotal_batches\x18\x05 \x01(\x05R\ftotalBatches\x121\n" +
+	"\x15next_batch_page_token\x18\x06 \x01(\tR\x12nextBatchPageToken\x1a:\n" +
 	"\fSummaryEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x97\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xef\x01\n" +
 	"\fBatchDetails\x12*\n" +
 	"\x06header\x18\x01 \x01(\v2\x12.nacha.BatchHeaderR\x06header\x12,\n" +
 	"\aentries\x18\x02 \x03(\v2\x12.nacha.EntryDetailR\aentries\x12-\n" +
-	"\acontrol\x18\x03 \x01(\v2\x13.nacha.BatchControlR\acontrol\"s\n" +
+	"\acontrol\x18\x03 \x01(\v2\x13.nacha.BatchControlR\acontrol\x12#\n" +
+	"\rtotal_entries\x18\x04 \x01(\x05R\ftotalEntries\x121\n" +
+	"\x15next_entry_page_token\x18\x05 \x01(\tR\x12nextEntryPageToken\"\x8c\x01\n" +
 	"\rDetailRequest\x12!\n" +
 	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x1f\n" +
 	"\vdetail_type\x18\x02 \x01(\tR\n" +
 	"detailType\x12\x1e\n" +
 	"\n" +
 	"identifier\x18\x03 \x01(\tR\n" +
-	"identifier\"s\n" +
+	"identifier\x12\x17\n" +
+	"\afile_id\x18\x04 \x01(\tR\x06fileId\"s\n" +
 	"\x0eDetailResponse\x12+\n" +
 	"\x05batch\x18\x01 \x01(\v2\x13.nacha.BatchDetailsH\x00R\x05batch\x12*\n" +
 	"\x05entry\x18\x02 \x01(\v2\x12.nacha.EntryDetailH\x00R\x05entryB\b\n" +
@@ -1784,7 +5252,230 @@ const file_api_proto_nacha_proto_rawDesc = "" +
 	"\x18addenda_record_indicator\x18\t \x01(\tR\x16addendaRecordIndicator\x12!\n" +
 	"\ftrace_number\x18\n" +
 	" \x01(\tR\vtraceNumber\x12=\n" +
-	"\x0faddenda_records\x18\v \x03(\v2\x14.nacha.AddendaRecordR\x0eaddendaRecords*S\n" +
+	"\x0faddenda_records\x18\v \x03(\v2\x14.nacha.AddendaRecordR\x0eaddendaRecords\"a\n" +
+	"\rImportRequest\x12!\n" +
+	"\fjson_content\x18\x01 \x01(\fR\vjsonContent\x12-\n" +
+	"\x12recompute_controls\x18\x02 \x01(\bR\x11recomputeControls\"\xeb\x01\n" +
+	"\fQueryRequest\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12*\n" +
+	"\x06filter\x18\x02 \x01(\v2\x12.nacha.EntryFilterR\x06filter\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x04 \x01(\bR\n" +
+	"descending\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12\x17\n" +
+	"\afile_id\x18\a \x01(\tR\x06fileId\"\xd8\x03\n" +
+	"\vEntryFilter\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x01 \x01(\x03R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x02 \x01(\x03R\tmaxAmount\x12+\n" +
+	"\x11transaction_codes\x18\x03 \x03(\tR\x10transactionCodes\x123\n" +
+	"\tdirection\x18\x04 \x01(\x0e2\x15.nacha.EntryDirectionR\tdirection\x12#\n" +
+	"\rreceiving_dfi\x18\x05 \x01(\tR\freceivingDfi\x12,\n" +
+	"\x12dfi_account_number\x18\x06 \x01(\tR\x10dfiAccountNumber\x12'\n" +
+	"\x0findividual_name\x18\a \x01(\tR\x0eindividualName\x12\x1d\n" +
+	"\n" +
+	"fuzzy_name\x18\b \x01(\bR\tfuzzyName\x124\n" +
+	"\x16standard_entry_classes\x18\t \x03(\tR\x14standardEntryClasses\x12!\n" +
+	"\fcompany_name\x18\n" +
+	" \x01(\tR\vcompanyName\x125\n" +
+	"\x16company_identification\x18\v \x01(\tR\x15companyIdentification\"\x89\x01\n" +
+	"\rQueryResponse\x12+\n" +
+	"\amatches\x18\x01 \x03(\v2\x11.nacha.EntryMatchR\amatches\x12#\n" +
+	"\rtotal_matches\x18\x02 \x01(\x05R\ftotalMatches\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\"\xaf\x01\n" +
+	"\n" +
+	"EntryMatch\x12(\n" +
+	"\x05entry\x18\x01 \x01(\v2\x12.nacha.EntryDetailR\x05entry\x125\n" +
+	"\fbatch_header\x18\x02 \x01(\v2\x12.nacha.BatchHeaderR\vbatchHeader\x12\x1f\n" +
+	"\vbatch_index\x18\x03 \x01(\x05R\n" +
+	"batchIndex\x12\x1f\n" +
+	"\ventry_index\x18\x04 \x01(\x05R\n" +
+	"entryIndex\"m\n" +
+	"\x0eSummaryRequest\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x1f\n" +
+	"\vtop_entries\x18\x02 \x01(\x05R\n" +
+	"topEntries\x12\x17\n" +
+	"\afile_id\x18\x03 \x01(\tR\x06fileId\"\xcb\x03\n" +
+	"\x0fSummaryResponse\x12(\n" +
+	"\x06totals\x18\x01 \x01(\v2\x10.nacha.AggregateR\x06totals\x120\n" +
+	"\vby_sec_code\x18\x02 \x03(\v2\x10.nacha.AggregateR\tbySecCode\x12/\n" +
+	"\n" +
+	"by_company\x18\x03 \x03(\v2\x10.nacha.AggregateR\tbyCompany\x12:\n" +
+	"\x10by_receiving_dfi\x18\x04 \x03(\v2\x10.nacha.AggregateR\x0ebyReceivingDfi\x12@\n" +
+	"\x13by_transaction_code\x18\x05 \x03(\v2\x10.nacha.AggregateR\x11byTransactionCode\x12<\n" +
+	"\x11by_effective_date\x18\x06 \x03(\v2\x10.nacha.AggregateR\x0fbyEffectiveDate\x123\n" +
+	"\fby_direction\x18\a \x03(\v2\x10.nacha.AggregateR\vbyDirection\x12:\n" +
+	"\x0flargest_entries\x18\b \x03(\v2\x11.nacha.EntryMatchR\x0elargestEntries\"\xe5\x02\n" +
+	"\tAggregate\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x1f\n" +
+	"\ventry_count\x18\x03 \x01(\x05R\n" +
+	"entryCount\x12#\n" +
+	"\raddenda_count\x18\x04 \x01(\x05R\faddendaCount\x120\n" +
+	"\x14entries_with_addenda\x18\x05 \x01(\x05R\x12entriesWithAddenda\x12,\n" +
+	"\x12addenda_usage_rate\x18\x06 \x01(\x01R\x10addendaUsageRate\x12\x1f\n" +
+	"\vdebit_count\x18\a \x01(\x05R\n" +
+	"debitCount\x12!\n" +
+	"\fdebit_amount\x18\b \x01(\x03R\vdebitAmount\x12!\n" +
+	"\fcredit_count\x18\t \x01(\x05R\vcreditCount\x12#\n" +
+	"\rcredit_amount\x18\n" +
+	" \x01(\x03R\fcreditAmount\"2\n" +
+	"\rUploadRequest\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\"!\n" +
+	"\vUploadChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x9e\x01\n" +
+	"\x0eUploadResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x1f\n" +
+	"\vbatch_count\x18\x04 \x01(\x05R\n" +
+	"batchCount\x12\x1f\n" +
+	"\ventry_count\x18\x05 \x01(\x05R\n" +
+	"entryCount\"\xef\x01\n" +
+	"\vExportChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1a\n" +
+	"\bsequence\x18\x03 \x01(\x05R\bsequence\x12\x12\n" +
+	"\x04last\x18\x04 \x01(\bR\x04last\x12\x1b\n" +
+	"\tfile_type\x18\x05 \x01(\tR\bfileType\x12#\n" +
+	"\rtotal_batches\x18\x06 \x01(\x05R\ftotalBatches\x12#\n" +
+	"\rtotal_entries\x18\a \x01(\x05R\ftotalEntries\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\b \x01(\x03R\ttotalSize\"\x1a\n" +
+	"\x18ListExportFormatsRequest\"N\n" +
+	"\x19ListExportFormatsResponse\x121\n" +
+	"\aformats\x18\x01 \x03(\v2\x17.nacha.ExportFormatInfoR\aformats\"\xa9\x02\n" +
+	"\x10ExportFormatInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1c\n" +
+	"\textension\x18\x03 \x01(\tR\textension\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06binary\x18\x05 \x01(\bR\x06binary\x12\x1e\n" +
+	"\n" +
+	"aggregated\x18\x06 \x01(\bR\n" +
+	"aggregated\x12\x1e\n" +
+	"\n" +
+	"importable\x18\a \x01(\bR\n" +
+	"importable\x12\x19\n" +
+	"\bhas_enum\x18\b \x01(\bR\ahasEnum\x12+\n" +
+	"\x06format\x18\t \x01(\x0e2\x13.nacha.ExportFormatR\x06format\"\xb8\x02\n" +
+	"\x11PainImportRequest\x12\x1f\n" +
+	"\vxml_content\x18\x01 \x01(\fR\n" +
+	"xmlContent\x123\n" +
+	"\x15immediate_destination\x18\x02 \x01(\tR\x14immediateDestination\x12<\n" +
+	"\x1aimmediate_destination_name\x18\x03 \x01(\tR\x18immediateDestinationName\x12)\n" +
+	"\x10immediate_origin\x18\x04 \x01(\tR\x0fimmediateOrigin\x12(\n" +
+	"\x10file_id_modifier\x18\x05 \x01(\tR\x0efileIdModifier\x12:\n" +
+	"\x19company_entry_description\x18\x06 \x01(\tR\x17companyEntryDescription\"\xa0\x01\n" +
+	"\x12PainImportResponse\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
+	"\fmessage_type\x18\x03 \x01(\tR\vmessageType\x12*\n" +
+	"\x06errors\x18\x04 \x03(\v2\x12.nacha.ImportErrorR\x06errors\"x\n" +
+	"\vImportError\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x01 \x01(\tR\terrorCode\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\blocation\x18\x03 \x01(\tR\blocation\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"\x8e\x02\n" +
+	"\x10CsvImportRequest\x12\x1f\n" +
+	"\vcsv_content\x18\x01 \x01(\fR\n" +
+	"csvContent\x122\n" +
+	"\vfile_header\x18\x02 \x01(\v2\x11.nacha.FileHeaderR\n" +
+	"fileHeader\x125\n" +
+	"\fbatch_header\x18\x03 \x01(\v2\x12.nacha.BatchHeaderR\vbatchHeader\x121\n" +
+	"\acolumns\x18\x04 \x01(\v2\x17.nacha.CsvColumnMappingR\acolumns\x12;\n" +
+	"\ramount_format\x18\x05 \x01(\x0e2\x16.nacha.CsvAmountFormatR\famountFormat\"\xf7\x01\n" +
+	"\x10CsvColumnMapping\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\arouting\x18\x02 \x01(\tR\arouting\x12\x18\n" +
+	"\aaccount\x18\x03 \x01(\tR\aaccount\x12!\n" +
+	"\faccount_type\x18\x04 \x01(\tR\vaccountType\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\tR\x06amount\x12\x18\n" +
+	"\aaddenda\x18\x06 \x01(\tR\aaddenda\x12\x1b\n" +
+	"\tid_number\x18\a \x01(\tR\bidNumber\x12)\n" +
+	"\x10transaction_code\x18\b \x01(\tR\x0ftransactionCode\"\x94\x01\n" +
+	"\x11CsvImportResponse\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06layout\x18\x03 \x01(\tR\x06layout\x12*\n" +
+	"\x06errors\x18\x04 \x03(\v2\x12.nacha.ImportErrorR\x06errors\"6\n" +
+	"\x11XlsxImportRequest\x12!\n" +
+	"\fxlsx_content\x18\x01 \x01(\fR\vxlsxContent\"}\n" +
+	"\x12XlsxImportResponse\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x06errors\x18\x03 \x03(\v2\x12.nacha.ImportErrorR\x06errors\"0\n" +
+	"\vCnabRequest\x12!\n" +
+	"\fcnab_content\x18\x01 \x01(\fR\vcnabContent\"m\n" +
+	"\x11CnabImportRequest\x12!\n" +
+	"\fcnab_content\x18\x01 \x01(\fR\vcnabContent\x125\n" +
+	"\x16company_identification\x18\x02 \x01(\tR\x15companyIdentification\"}\n" +
+	"\x12CnabImportResponse\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x06errors\x18\x03 \x03(\v2\x12.nacha.ImportErrorR\x06errors\"\xdc\x01\n" +
+	"\x10CnabViewResponse\x12)\n" +
+	"\x06header\x18\x01 \x01(\v2\x11.nacha.CnabRecordR\x06header\x12%\n" +
+	"\x05lotes\x18\x02 \x03(\v2\x0f.nacha.CnabLoteR\x05lotes\x12+\n" +
+	"\atrailer\x18\x03 \x01(\v2\x11.nacha.CnabRecordR\atrailer\x12\x19\n" +
+	"\bis_valid\x18\x04 \x01(\bR\aisValid\x12.\n" +
+	"\x06errors\x18\x05 \x03(\v2\x16.nacha.ValidationErrorR\x06errors\"\x8f\x01\n" +
+	"\bCnabLote\x12)\n" +
+	"\x06header\x18\x01 \x01(\v2\x11.nacha.CnabRecordR\x06header\x12+\n" +
+	"\adetails\x18\x02 \x03(\v2\x11.nacha.CnabRecordR\adetails\x12+\n" +
+	"\atrailer\x18\x03 \x01(\v2\x11.nacha.CnabRecordR\atrailer\"\x9d\x01\n" +
+	"\n" +
+	"CnabRecord\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x1f\n" +
+	"\vrecord_type\x18\x02 \x01(\tR\n" +
+	"recordType\x12\x18\n" +
+	"\asegment\x18\x03 \x01(\tR\asegment\x12\x16\n" +
+	"\x06layout\x18\x04 \x01(\tR\x06layout\x12(\n" +
+	"\x06fields\x18\x05 \x03(\v2\x10.nacha.CnabFieldR\x06fields\"]\n" +
+	"\tCnabField\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\x05R\x03end\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"-\n" +
+	"\n" +
+	"CpaRequest\x12\x1f\n" +
+	"\vcpa_content\x18\x01 \x01(\fR\n" +
+	"cpaContent\"\xa6\x01\n" +
+	"\x10CpaImportRequest\x12\x1f\n" +
+	"\vcpa_content\x18\x01 \x01(\fR\n" +
+	"cpaContent\x123\n" +
+	"\x15immediate_destination\x18\x02 \x01(\tR\x14immediateDestination\x12<\n" +
+	"\x1aimmediate_destination_name\x18\x03 \x01(\tR\x18immediateDestinationName\"|\n" +
+	"\x11CpaImportResponse\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12*\n" +
+	"\x06errors\x18\x03 \x03(\v2\x12.nacha.ImportErrorR\x06errors\"\xb6\x01\n" +
+	"\x17RemittanceAdviceRequest\x12!\n" +
+	"\ffile_content\x18\x01 \x01(\fR\vfileContent\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12/\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x17.nacha.RemittanceFormatR\x06format\x12.\n" +
+	"\aoptions\x18\x04 \x01(\v2\x14.nacha.ExportOptionsR\aoptions\"x\n" +
+	"\x18RemittanceAdviceResponse\x12\x1f\n" +
+	"\vzip_content\x18\x01 \x01(\fR\n" +
+	"zipContent\x12!\n" +
+	"\fadvice_count\x18\x02 \x01(\x05R\vadviceCount\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage*H\n" +
+	"\fAmountFormat\x12\x12\n" +
+	"\x0eAMOUNT_DEFAULT\x10\x00\x12\x10\n" +
+	"\fAMOUNT_CENTS\x10\x01\x12\x12\n" +
+	"\x0eAMOUNT_DECIMAL\x10\x02*r\n" +
+	"\n" +
+	"SqlDialect\x12\x1a\n" +
+	"\x16SQL_DIALECT_POSTGRESQL\x10\x00\x12\x15\n" +
+	"\x11SQL_DIALECT_MYSQL\x10\x01\x12\x16\n" +
+	"\x12SQL_DIALECT_SQLITE\x10\x02\x12\x19\n" +
+	"\x15SQL_DIALECT_SQLSERVER\x10\x03*v\n" +
 	"\fExportFormat\x12\b\n" +
 	"\x04JSON\x10\x00\x12\a\n" +
 	"\x03CSV\x10\x01\x12\a\n" +
@@ -1792,15 +5483,44 @@ const file_api_proto_nacha_proto_rawDesc = "" +
 	"\x04HTML\x10\x03\x12\a\n" +
 	"\x03PDF\x10\x04\x12\a\n" +
 	"\x03TXT\x10\x05\x12\v\n" +
-	"\aPARQUET\x10\x062\xc6\x02\n" +
+	"\aPARQUET\x10\x06\x12\x0f\n" +
+	"\vSUMMARY_CSV\x10\a\x12\x10\n" +
+	"\fSUMMARY_JSON\x10\b*N\n" +
+	"\x0eEntryDirection\x12\x11\n" +
+	"\rDIRECTION_ANY\x10\x00\x12\x13\n" +
+	"\x0fDIRECTION_DEBIT\x10\x01\x12\x14\n" +
+	"\x10DIRECTION_CREDIT\x10\x02*?\n" +
+	"\x0fCsvAmountFormat\x12\x16\n" +
+	"\x12CSV_AMOUNT_DECIMAL\x10\x00\x12\x14\n" +
+	"\x10CSV_AMOUNT_CENTS\x10\x01*;\n" +
+	"\x10RemittanceFormat\x12\x12\n" +
+	"\x0eREMITTANCE_PDF\x10\x00\x12\x13\n" +
+	"\x0fREMITTANCE_HTML\x10\x012\xa8\v\n" +
 	"\fNachaService\x12?\n" +
 	"\fValidateFile\x12\x12.nacha.FileRequest\x1a\x19.nacha.ValidationResponse\"\x00\x12<\n" +
 	"\n" +
 	"CreateFile\x12\x17.nacha.NachaFileRequest\x1a\x13.nacha.FileResponse\"\x00\x12;\n" +
 	"\n" +
-	"ExportFile\x12\x14.nacha.ExportRequest\x1a\x15.nacha.ExportResponse\"\x00\x12<\n" +
+	"ExportFile\x12\x14.nacha.ExportRequest\x1a\x15.nacha.ExportResponse\"\x00\x12=\n" +
+	"\x0eImportFromJson\x12\x14.nacha.ImportRequest\x1a\x13.nacha.FileResponse\"\x00\x12<\n" +
 	"\bViewFile\x12\x12.nacha.FileRequest\x1a\x1a.nacha.FileDetailsResponse\"\x00\x12<\n" +
-	"\vViewDetails\x12\x14.nacha.DetailRequest\x1a\x15.nacha.DetailResponse\"\x00B$Z\"github.com/nacha-service/api/protob\x06proto3"
+	"\vViewDetails\x12\x14.nacha.DetailRequest\x1a\x15.nacha.DetailResponse\"\x00\x12;\n" +
+	"\fQueryEntries\x12\x13.nacha.QueryRequest\x1a\x14.nacha.QueryResponse\"\x00\x12@\n" +
+	"\rSummarizeFile\x12\x15.nacha.SummaryRequest\x1a\x16.nacha.SummaryResponse\"\x00\x12;\n" +
+	"\n" +
+	"UploadFile\x12\x14.nacha.UploadRequest\x1a\x15.nacha.UploadResponse\"\x00\x12=\n" +
+	"\fUploadStream\x12\x12.nacha.UploadChunk\x1a\x15.nacha.UploadResponse\"\x00(\x01\x12<\n" +
+	"\fExportStream\x12\x14.nacha.ExportRequest\x1a\x12.nacha.ExportChunk\"\x000\x01\x12X\n" +
+	"\x11ListExportFormats\x12\x1f.nacha.ListExportFormatsRequest\x1a .nacha.ListExportFormatsResponse\"\x00\x12G\n" +
+	"\x0eImportFromPain\x12\x18.nacha.PainImportRequest\x1a\x19.nacha.PainImportResponse\"\x00\x12D\n" +
+	"\rImportFromCSV\x12\x17.nacha.CsvImportRequest\x1a\x18.nacha.CsvImportResponse\"\x00\x12G\n" +
+	"\x0eImportFromXLSX\x12\x18.nacha.XlsxImportRequest\x1a\x19.nacha.XlsxImportResponse\"\x00\x12J\n" +
+	"\x11ImportFromCNAB240\x12\x18.nacha.CnabImportRequest\x1a\x19.nacha.CnabImportResponse\"\x00\x12<\n" +
+	"\vViewCNAB240\x12\x12.nacha.CnabRequest\x1a\x17.nacha.CnabViewResponse\"\x00\x12B\n" +
+	"\x0fValidateCNAB240\x12\x12.nacha.CnabRequest\x1a\x19.nacha.ValidationResponse\"\x00\x12G\n" +
+	"\x10ImportFromCPA005\x12\x17.nacha.CpaImportRequest\x1a\x18.nacha.CpaImportResponse\"\x00\x12@\n" +
+	"\x0eValidateCPA005\x12\x11.nacha.CpaRequest\x1a\x19.nacha.ValidationResponse\"\x00\x12]\n" +
+	"\x18GenerateRemittanceAdvice\x12\x1e.nacha.RemittanceAdviceRequest\x1a\x1f.nacha.RemittanceAdviceResponse\"\x00B$Z\"github.com/nacha-service/api/protob\x06proto3"
 
 var (
 	file_api_proto_nacha_proto_rawDescOnce sync.Once
@@ -1814,66 +5534,192 @@ func file_api_proto_nacha_proto_rawDescGZIP() []byte {
 	return file_api_proto_nacha_proto_rawDescData
 }
 
-var file_api_proto_nacha_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_nacha_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_proto_nacha_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_proto_nacha_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_api_proto_nacha_proto_goTypes = []any{
-	(ExportFormat)(0),           // 0: nacha.ExportFormat
-	(*FileRequest)(nil),         // 1: nacha.FileRequest
-	(*ValidationResponse)(nil),  // 2: nacha.ValidationResponse
-	(*ValidationError)(nil),     // 3: nacha.ValidationError
-	(*NachaFileRequest)(nil),    // 4: nacha.NachaFileRequest
-	(*FileHeader)(nil),          // 5: nacha.FileHeader
-	(*BatchRequest)(nil),        // 6: nacha.BatchRequest
-	(*BatchHeader)(nil),         // 7: nacha.BatchHeader
-	(*EntryDetailRequest)(nil),  // 8: nacha.EntryDetailRequest
-	(*AddendaRecord)(nil),       // 9: nacha.AddendaRecord
-	(*BatchControl)(nil),        // 10: nacha.BatchControl
-	(*FileControl)(nil),         // 11: nacha.FileControl
-	(*FileResponse)(nil),        // 12: nacha.FileResponse
-	(*ExportRequest)(nil),       // 13: nacha.ExportRequest
-	(*ExportResponse)(nil),      // 14: nacha.ExportResponse
-	(*FileDetailsResponse)(nil), // 15: nacha.FileDetailsResponse
-	(*BatchDetails)(nil),        // 16: nacha.BatchDetails
-	(*DetailRequest)(nil),       // 17: nacha.DetailRequest
-	(*DetailResponse)(nil),      // 18: nacha.DetailResponse
-	(*EntryDetail)(nil),         // 19: nacha.EntryDetail
-	nil,                         // 20: nacha.FileDetailsResponse.SummaryEntry
+	(AmountFormat)(0),                 // 0: nacha.AmountFormat
+	(SqlDialect)(0),                   // 1: nacha.SqlDialect
+	(ExportFormat)(0),                 // 2: nacha.ExportFormat
+	(EntryDirection)(0),               // 3: nacha.EntryDirection
+	(CsvAmountFormat)(0),              // 4: nacha.CsvAmountFormat
+	(RemittanceFormat)(0),             // 5: nacha.RemittanceFormat
+	(*FileRequest)(nil),               // 6: nacha.FileRequest
+	(*ValidationResponse)(nil),        // 7: nacha.ValidationResponse
+	(*ValidationError)(nil),           // 8: nacha.ValidationError
+	(*NachaFileRequest)(nil),          // 9: nacha.NachaFileRequest
+	(*FileHeader)(nil),                // 10: nacha.FileHeader
+	(*BatchRequest)(nil),              // 11: nacha.BatchRequest
+	(*BatchHeader)(nil),               // 12: nacha.BatchHeader
+	(*EntryDetailRequest)(nil),        // 13: nacha.EntryDetailRequest
+	(*AddendaRecord)(nil),             // 14: nacha.AddendaRecord
+	(*BatchControl)(nil),              // 15: nacha.BatchControl
+	(*FileControl)(nil),               // 16: nacha.FileControl
+	(*FileResponse)(nil),              // 17: nacha.FileResponse
+	(*ExportRequest)(nil),             // 18: nacha.ExportRequest
+	(*ExportOptions)(nil),             // 19: nacha.ExportOptions
+	(*CnabOptions)(nil),               // 20: nacha.CnabOptions
+	(*CpaOptions)(nil),                // 21: nacha.CpaOptions
+	(*TemplateOptions)(nil),           // 22: nacha.TemplateOptions
+	(*GlOptions)(nil),                 // 23: nacha.GlOptions
+	(*GlAccountRule)(nil),             // 24: nacha.GlAccountRule
+	(*PainOptions)(nil),               // 25: nacha.PainOptions
+	(*ExportResponse)(nil),            // 26: nacha.ExportResponse
+	(*FileDetailsResponse)(nil),       // 27: nacha.FileDetailsResponse
+	(*BatchDetails)(nil),              // 28: nacha.BatchDetails
+	(*DetailRequest)(nil),             // 29: nacha.DetailRequest
+	(*DetailResponse)(nil),            // 30: nacha.DetailResponse
+	(*EntryDetail)(nil),               // 31: nacha.EntryDetail
+	(*ImportRequest)(nil),             // 32: nacha.ImportRequest
+	(*QueryRequest)(nil),              // 33: nacha.QueryRequest
+	(*EntryFilter)(nil),               // 34: nacha.EntryFilter
+	(*QueryResponse)(nil),             // 35: nacha.QueryResponse
+	(*EntryMatch)(nil),                // 36: nacha.EntryMatch
+	(*SummaryRequest)(nil),            // 37: nacha.SummaryRequest
+	(*SummaryResponse)(nil),           // 38: nacha.SummaryResponse
+	(*Aggregate)(nil),                 // 39: nacha.Aggregate
+	(*UploadRequest)(nil),             // 40: nacha.UploadRequest
+	(*UploadChunk)(nil),               // 41: nacha.UploadChunk
+	(*UploadResponse)(nil),            // 42: nacha.UploadResponse
+	(*ExportChunk)(nil),               // 43: nacha.ExportChunk
+	(*ListExportFormatsRequest)(nil),  // 44: nacha.ListExportFormatsRequest
+	(*ListExportFormatsResponse)(nil), // 45: nacha.ListExportFormatsResponse
+	(*ExportFormatInfo)(nil),          // 46: nacha.ExportFormatInfo
+	(*PainImportRequest)(nil),         // 47: nacha.PainImportRequest
+	(*PainImportResponse)(nil),        // 48: nacha.PainImportResponse
+	(*ImportError)(nil),               // 49: nacha.ImportError
+	(*CsvImportRequest)(nil),          // 50: nacha.CsvImportRequest
+	(*CsvColumnMapping)(nil),          // 51: nacha.CsvColumnMapping
+	(*CsvImportResponse)(nil),         // 52: nacha.CsvImportResponse
+	(*XlsxImportRequest)(nil),         // 53: nacha.XlsxImportRequest
+	(*XlsxImportResponse)(nil),        // 54: nacha.XlsxImportResponse
+	(*CnabRequest)(nil),               // 55: nacha.CnabRequest
+	(*CnabImportRequest)(nil),         // 56: nacha.CnabImportRequest
+	(*CnabImportResponse)(nil),        // 57: nacha.CnabImportResponse
+	(*CnabViewResponse)(nil),          // 58: nacha.CnabViewResponse
+	(*CnabLote)(nil),                  // 59: nacha.CnabLote
+	(*CnabRecord)(nil),                // 60: nacha.CnabRecord
+	(*CnabField)(nil),                 // 61: nacha.CnabField
+	(*CpaRequest)(nil),                // 62: nacha.CpaRequest
+	(*CpaImportRequest)(nil),          // 63: nacha.CpaImportRequest
+	(*CpaImportResponse)(nil),         // 64: nacha.CpaImportResponse
+	(*RemittanceAdviceRequest)(nil),   // 65: nacha.RemittanceAdviceRequest
+	(*RemittanceAdviceResponse)(nil),  // 66: nacha.RemittanceAdviceResponse
+	nil,                               // 67: nacha.PainOptions.CompanyAccountsEntry
+	nil,                               // 68: nacha.FileDetailsResponse.SummaryEntry
+	(*fieldmaskpb.FieldMask)(nil),     // 69: google.protobuf.FieldMask
 }
 var file_api_proto_nacha_proto_depIdxs = []int32{
-	3,  // 0: nacha.ValidationResponse.errors:type_name -> nacha.ValidationError
-	5,  // 1: nacha.NachaFileRequest.file_header:type_name -> nacha.FileHeader
-	6,  // 2: nacha.NachaFileRequest.batches:type_name -> nacha.BatchRequest
-	11, // 3: nacha.NachaFileRequest.file_control:type_name -> nacha.FileControl
-	7,  // 4: nacha.BatchRequest.header:type_name -> nacha.BatchHeader
-	8,  // 5: nacha.BatchRequest.entries:type_name -> nacha.EntryDetailRequest
-	10, // 6: nacha.BatchRequest.control:type_name -> nacha.BatchControl
-	9,  // 7: nacha.EntryDetailRequest.addenda_records:type_name -> nacha.AddendaRecord
-	0,  // 8: nacha.ExportRequest.format:type_name -> nacha.ExportFormat
-	5,  // 9: nacha.FileDetailsResponse.file_header:type_name -> nacha.FileHeader
-	16, // 10: nacha.FileDetailsResponse.batches:type_name -> nacha.BatchDetails
-	11, // 11: nacha.FileDetailsResponse.file_control:type_name -> nacha.FileControl
-	20, // 12: nacha.FileDetailsResponse.summary:type_name -> nacha.FileDetailsResponse.SummaryEntry
-	7,  // 13: nacha.BatchDetails.header:type_name -> nacha.BatchHeader
-	19, // 14: nacha.BatchDetails.entries:type_name -> nacha.EntryDetail
-	10, // 15: nacha.BatchDetails.control:type_name -> nacha.BatchControl
-	16, // 16: nacha.DetailResponse.batch:type_name -> nacha.BatchDetails
-	19, // 17: nacha.DetailResponse.entry:type_name -> nacha.EntryDetail
-	9,  // 18: nacha.EntryDetail.addenda_records:type_name -> nacha.AddendaRecord
-	1,  // 19: nacha.NachaService.ValidateFile:input_type -> nacha.FileRequest
-	4,  // 20: nacha.NachaService.CreateFile:input_type -> nacha.NachaFileRequest
-	13, // 21: nacha.NachaService.ExportFile:input_type -> nacha.ExportRequest
-	1,  // 22: nacha.NachaService.ViewFile:input_type -> nacha.FileRequest
-	17, // 23: nacha.NachaService.ViewDetails:input_type -> nacha.DetailRequest
-	2,  // 24: nacha.NachaService.ValidateFile:output_type -> nacha.ValidationResponse
-	12, // 25: nacha.NachaService.CreateFile:output_type -> nacha.FileResponse
-	14, // 26: nacha.NachaService.ExportFile:output_type -> nacha.ExportResponse
-	15, // 27: nacha.NachaService.ViewFile:output_type -> nacha.FileDetailsResponse
-	18, // 28: nacha.NachaService.ViewDetails:output_type -> nacha.DetailResponse
-	24, // [24:29] is the sub-list for method output_type
-	19, // [19:24] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	69, // 0: nacha.FileRequest.view_mask:type_name -> google.protobuf.FieldMask
+	8,  // 1: nacha.ValidationResponse.errors:type_name -> nacha.ValidationError
+	10, // 2: nacha.NachaFileRequest.file_header:type_name -> nacha.FileHeader
+	11, // 3: nacha.NachaFileRequest.batches:type_name -> nacha.BatchRequest
+	16, // 4: nacha.NachaFileRequest.file_control:type_name -> nacha.FileControl
+	12, // 5: nacha.BatchRequest.header:type_name -> nacha.BatchHeader
+	13, // 6: nacha.BatchRequest.entries:type_name -> nacha.EntryDetailRequest
+	15, // 7: nacha.BatchRequest.control:type_name -> nacha.BatchControl
+	14, // 8: nacha.EntryDetailRequest.addenda_records:type_name -> nacha.AddendaRecord
+	2,  // 9: nacha.ExportRequest.format:type_name -> nacha.ExportFormat
+	19, // 10: nacha.ExportRequest.options:type_name -> nacha.ExportOptions
+	0,  // 11: nacha.ExportOptions.amount_format:type_name -> nacha.AmountFormat
+	1,  // 12: nacha.ExportOptions.sql_dialect:type_name -> nacha.SqlDialect
+	20, // 13: nacha.ExportOptions.cnab:type_name -> nacha.CnabOptions
+	21, // 14: nacha.ExportOptions.cpa:type_name -> nacha.CpaOptions
+	22, // 15: nacha.ExportOptions.template:type_name -> nacha.TemplateOptions
+	23, // 16: nacha.ExportOptions.gl:type_name -> nacha.GlOptions
+	25, // 17: nacha.ExportOptions.pain:type_name -> nacha.PainOptions
+	24, // 18: nacha.GlOptions.rules:type_name -> nacha.GlAccountRule
+	67, // 19: nacha.PainOptions.company_accounts:type_name -> nacha.PainOptions.CompanyAccountsEntry
+	10, // 20: nacha.FileDetailsResponse.file_header:type_name -> nacha.FileHeader
+	28, // 21: nacha.FileDetailsResponse.batches:type_name -> nacha.BatchDetails
+	16, // 22: nacha.FileDetailsResponse.file_control:type_name -> nacha.FileControl
+	68, // 23: nacha.FileDetailsResponse.summary:type_name -> nacha.FileDetailsResponse.SummaryEntry
+	12, // 24: nacha.BatchDetails.header:type_name -> nacha.BatchHeader
+	31, // 25: nacha.BatchDetails.entries:type_name -> nacha.EntryDetail
+	15, // 26: nacha.BatchDetails.control:type_name -> nacha.BatchControl
+	28, // 27: nacha.DetailResponse.batch:type_name -> nacha.BatchDetails
+	31, // 28: nacha.DetailResponse.entry:type_name -> nacha.EntryDetail
+	14, // 29: nacha.EntryDetail.addenda_records:type_name -> nacha.AddendaRecord
+	34, // 30: nacha.QueryRequest.filter:type_name -> nacha.EntryFilter
+	3,  // 31: nacha.EntryFilter.direction:type_name -> nacha.EntryDirection
+	36, // 32: nacha.QueryResponse.matches:type_name -> nacha.EntryMatch
+	31, // 33: nacha.EntryMatch.entry:type_name -> nacha.EntryDetail
+	12, // 34: nacha.EntryMatch.batch_header:type_name -> nacha.BatchHeader
+	39, // 35: nacha.SummaryResponse.totals:type_name -> nacha.Aggregate
+	39, // 36: nacha.SummaryResponse.by_sec_code:type_name -> nacha.Aggregate
+	39, // 37: nacha.SummaryResponse.by_company:type_name -> nacha.Aggregate
+	39, // 38: nacha.SummaryResponse.by_receiving_dfi:type_name -> nacha.Aggregate
+	39, // 39: nacha.SummaryResponse.by_transaction_code:type_name -> nacha.Aggregate
+	39, // 40: nacha.SummaryResponse.by_effective_date:type_name -> nacha.Aggregate
+	39, // 41: nacha.SummaryResponse.by_direction:type_name -> nacha.Aggregate
+	36, // 42: nacha.SummaryResponse.largest_entries:type_name -> nacha.EntryMatch
+	46, // 43: nacha.ListExportFormatsResponse.formats:type_name -> nacha.ExportFormatInfo
+	2,  // 44: nacha.ExportFormatInfo.format:type_name -> nacha.ExportFormat
+	49, // 45: nacha.PainImportResponse.errors:type_name -> nacha.ImportError
+	10, // 46: nacha.CsvImportRequest.file_header:type_name -> nacha.FileHeader
+	12, // 47: nacha.CsvImportRequest.batch_header:type_name -> nacha.BatchHeader
+	51, // 48: nacha.CsvImportRequest.columns:type_name -> nacha.CsvColumnMapping
+	4,  // 49: nacha.CsvImportRequest.amount_format:type_name -> nacha.CsvAmountFormat
+	49, // 50: nacha.CsvImportResponse.errors:type_name -> nacha.ImportError
+	49, // 51: nacha.XlsxImportResponse.errors:type_name -> nacha.ImportError
+	49, // 52: nacha.CnabImportResponse.errors:type_name -> nacha.ImportError
+	60, // 53: nacha.CnabViewResponse.header:type_name -> nacha.CnabRecord
+	59, // 54: nacha.CnabViewResponse.lotes:type_name -> nacha.CnabLote
+	60, // 55: nacha.CnabViewResponse.trailer:type_name -> nacha.CnabRecord
+	8,  // 56: nacha.CnabViewResponse.errors:type_name -> nacha.ValidationError
+	60, // 57: nacha.CnabLote.header:type_name -> nacha.CnabRecord
+	60, // 58: nacha.CnabLote.details:type_name -> nacha.CnabRecord
+	60, // 59: nacha.CnabLote.trailer:type_name -> nacha.CnabRecord
+	61, // 60: nacha.CnabRecord.fields:type_name -> nacha.CnabField
+	49, // 61: nacha.CpaImportResponse.errors:type_name -> nacha.ImportError
+	5,  // 62: nacha.RemittanceAdviceRequest.format:type_name -> nacha.RemittanceFormat
+	19, // 63: nacha.RemittanceAdviceRequest.options:type_name -> nacha.ExportOptions
+	6,  // 64: nacha.NachaService.ValidateFile:input_type -> nacha.FileRequest
+	9,  // 65: nacha.NachaService.CreateFile:input_type -> nacha.NachaFileRequest
+	18, // 66: nacha.NachaService.ExportFile:input_type -> nacha.ExportRequest
+	32, // 67: nacha.NachaService.ImportFromJson:input_type -> nacha.ImportRequest
+	6,  // 68: nacha.NachaService.ViewFile:input_type -> nacha.FileRequest
+	29, // 69: nacha.NachaService.ViewDetails:input_type -> nacha.DetailRequest
+	33, // 70: nacha.NachaService.QueryEntries:input_type -> nacha.QueryRequest
+	37, // 71: nacha.NachaService.SummarizeFile:input_type -> nacha.SummaryRequest
+	40, // 72: nacha.NachaService.UploadFile:input_type -> nacha.UploadRequest
+	41, // 73: nacha.NachaService.UploadStream:input_type -> nacha.UploadChunk
+	18, // 74: nacha.NachaService.ExportStream:input_type -> nacha.ExportRequest
+	44, // 75: nacha.NachaService.ListExportFormats:input_type -> nacha.ListExportFormatsRequest
+	47, // 76: nacha.NachaService.ImportFromPain:input_type -> nacha.PainImportRequest
+	50, // 77: nacha.NachaService.ImportFromCSV:input_type -> nacha.CsvImportRequest
+	53, // 78: nacha.NachaService.ImportFromXLSX:input_type -> nacha.XlsxImportRequest
+	56, // 79: nacha.NachaService.ImportFromCNAB240:input_type -> nacha.CnabImportRequest
+	55, // 80: nacha.NachaService.ViewCNAB240:input_type -> nacha.CnabRequest
+	55, // 81: nacha.NachaService.ValidateCNAB240:input_type -> nacha.CnabRequest
+	63, // 82: nacha.NachaService.ImportFromCPA005:input_type -> nacha.CpaImportRequest
+	62, // 83: nacha.NachaService.ValidateCPA005:input_type -> nacha.CpaRequest
+	65, // 84: nacha.NachaService.GenerateRemittanceAdvice:input_type -> nacha.RemittanceAdviceRequest
+	7,  // 85: nacha.NachaService.ValidateFile:output_type -> nacha.ValidationResponse
+	17, // 86: nacha.NachaService.CreateFile:output_type -> nacha.FileResponse
+	26, // 87: nacha.NachaService.ExportFile:output_type -> nacha.ExportResponse
+	17, // 88: nacha.NachaService.ImportFromJson:output_type -> nacha.FileResponse
+	27, // 89: nacha.NachaService.ViewFile:output_type -> nacha.FileDetailsResponse
+	30, // 90: nacha.NachaService.ViewDetails:output_type -> nacha.DetailResponse
+	35, // 91: nacha.NachaService.QueryEntries:output_type -> nacha.QueryResponse
+	38, // 92: nacha.NachaService.SummarizeFile:output_type -> nacha.SummaryResponse
+	42, // 93: nacha.NachaService.UploadFile:output_type -> nacha.UploadResponse
+	42, // 94: nacha.NachaService.UploadStream:output_type -> nacha.UploadResponse
+	43, // 95: nacha.NachaService.ExportStream:output_type -> nacha.ExportChunk
+	45, // 96: nacha.NachaService.ListExportFormats:output_type -> nacha.ListExportFormatsResponse
+	48, // 97: nacha.NachaService.ImportFromPain:output_type -> nacha.PainImportResponse
+	52, // 98: nacha.NachaService.ImportFromCSV:output_type -> nacha.CsvImportResponse
+	54, // 99: nacha.NachaService.ImportFromXLSX:output_type -> nacha.XlsxImportResponse
+	57, // 100: nacha.NachaService.ImportFromCNAB240:output_type -> nacha.CnabImportResponse
+	58, // 101: nacha.NachaService.ViewCNAB240:output_type -> nacha.CnabViewResponse
+	7,  // 102: nacha.NachaService.ValidateCNAB240:output_type -> nacha.ValidationResponse
+	64, // 103: nacha.NachaService.ImportFromCPA005:output_type -> nacha.CpaImportResponse
+	7,  // 104: nacha.NachaService.ValidateCPA005:output_type -> nacha.ValidationResponse
+	66, // 105: nacha.NachaService.GenerateRemittanceAdvice:output_type -> nacha.RemittanceAdviceResponse
+	85, // [85:106] is the sub-list for method output_type
+	64, // [64:85] is the sub-list for method input_type
+	64, // [64:64] is the sub-list for extension type_name
+	64, // [64:64] is the sub-list for extension extendee
+	0,  // [0:64] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_nacha_proto_init() }
@@ -1881,7 +5727,7 @@ func file_api_proto_nacha_proto_init() {
 	if File_api_proto_nacha_proto != nil {
 		return
 	}
-	file_api_proto_nacha_proto_msgTypes[17].OneofWrappers = []any{
+	file_api_proto_nacha_proto_msgTypes[24].OneofWrappers = []any{
 		(*DetailResponse_Batch)(nil),
 		(*DetailResponse_Entry)(nil),
 	}
@@ -1890,8 +5736,8 @@ func file_api_proto_nacha_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_nacha_proto_rawDesc), len(file_api_proto_nacha_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   20,
+			NumEnums:      6,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
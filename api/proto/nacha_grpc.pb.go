@@ -19,11 +19,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NachaService_ValidateFile_FullMethodName = "/nacha.NachaService/ValidateFile"
-	NachaService_CreateFile_FullMethodName   = "/nacha.NachaService/CreateFile"
-	NachaService_ExportFile_FullMethodName   = "/nacha.NachaService/ExportFile"
-	NachaService_ViewFile_FullMethodName     = "/nacha.NachaService/ViewFile"
-	NachaService_ViewDetails_FullMethodName  = "/nacha.NachaService/ViewDetails"
+	NachaService_ValidateFile_FullMethodName             = "/nacha.NachaService/ValidateFile"
+	NachaService_CreateFile_FullMethodName               = "/nacha.NachaService/CreateFile"
+	NachaService_ExportFile_FullMethodName               = "/nacha.NachaService/ExportFile"
+	NachaService_ImportFromJson_FullMethodName           = "/nacha.NachaService/ImportFromJson"
+	NachaService_ViewFile_FullMethodName                 = "/nacha.NachaService/ViewFile"
+	NachaService_ViewDetails_FullMethodName              = "/nacha.NachaService/ViewDetails"
+	NachaService_QueryEntries_FullMethodName             = "/nacha.NachaService/QueryEntries"
+	NachaService_SummarizeFile_FullMethodName            = "/nacha.NachaService/SummarizeFile"
+	NachaService_UploadFile_FullMethodName               = "/nacha.NachaService/UploadFile"
+	NachaService_UploadStream_FullMethodName             = "/nacha.NachaService/UploadStream"
+	NachaService_ExportStream_FullMethodName             = "/nacha.NachaService/ExportStream"
+	NachaService_ListExportFormats_FullMethodName        = "/nacha.NachaService/ListExportFormats"
+	NachaService_ImportFromPain_FullMethodName           = "/nacha.NachaService/ImportFromPain"
+	NachaService_ImportFromCSV_FullMethodName            = "/nacha.NachaService/ImportFromCSV"
+	NachaService_ImportFromXLSX_FullMethodName           = "/nacha.NachaService/ImportFromXLSX"
+	NachaService_ImportFromCNAB240_FullMethodName        = "/nacha.NachaService/ImportFromCNAB240"
+	NachaService_ViewCNAB240_FullMethodName              = "/nacha.NachaService/ViewCNAB240"
+	NachaService_ValidateCNAB240_FullMethodName          = "/nacha.NachaService/ValidateCNAB240"
+	NachaService_ImportFromCPA005_FullMethodName         = "/nacha.NachaService/ImportFromCPA005"
+	NachaService_ValidateCPA005_FullMethodName           = "/nacha.NachaService/ValidateCPA005"
+	NachaService_GenerateRemittanceAdvice_FullMethodName = "/nacha.NachaService/GenerateRemittanceAdvice"
 )
 
 // NachaServiceClient is the client API for NachaService service.
@@ -36,10 +52,42 @@ type NachaServiceClient interface {
 	CreateFile(ctx context.Context, in *NachaFileRequest, opts ...grpc.CallOption) (*FileResponse, error)
 	// Export NACHA file to different formats
 	ExportFile(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+	// Import a JSON document in the NACHA JSON schema to NACHA format
+	ImportFromJson(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*FileResponse, error)
 	// View complete file details
 	ViewFile(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*FileDetailsResponse, error)
 	// View specific batch or entry details
 	ViewDetails(ctx context.Context, in *DetailRequest, opts ...grpc.CallOption) (*DetailResponse, error)
+	// Query and filter entries across all batches of a file
+	QueryEntries(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	// Summarize a file with typed aggregates
+	SummarizeFile(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error)
+	// Upload a file once and refer to it by file_id in later requests
+	UploadFile(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*UploadResponse, error)
+	// Upload a file in chunks, for files larger than the message size limit
+	UploadStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadChunk, UploadResponse], error)
+	// Export a file and receive the output in chunks
+	ExportStream(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
+	// List the registered export formats
+	ListExportFormats(ctx context.Context, in *ListExportFormatsRequest, opts ...grpc.CallOption) (*ListExportFormatsResponse, error)
+	// Import an ISO 20022 pain.001 or pain.008 message to NACHA format
+	ImportFromPain(ctx context.Context, in *PainImportRequest, opts ...grpc.CallOption) (*PainImportResponse, error)
+	// Import a CSV export or a spreadsheet of payees to NACHA format
+	ImportFromCSV(ctx context.Context, in *CsvImportRequest, opts ...grpc.CallOption) (*CsvImportResponse, error)
+	// Import an XLSX export to NACHA format
+	ImportFromXLSX(ctx context.Context, in *XlsxImportRequest, opts ...grpc.CallOption) (*XlsxImportResponse, error)
+	// Import a FEBRABAN CNAB 240 payment remittance to NACHA format
+	ImportFromCNAB240(ctx context.Context, in *CnabImportRequest, opts ...grpc.CallOption) (*CnabImportResponse, error)
+	// View the records and fields of a CNAB 240 file
+	ViewCNAB240(ctx context.Context, in *CnabRequest, opts ...grpc.CallOption) (*CnabViewResponse, error)
+	// Validate the structure of a CNAB 240 file
+	ValidateCNAB240(ctx context.Context, in *CnabRequest, opts ...grpc.CallOption) (*ValidationResponse, error)
+	// Import a Payments Canada CPA Standard 005 file to NACHA format
+	ImportFromCPA005(ctx context.Context, in *CpaImportRequest, opts ...grpc.CallOption) (*CpaImportResponse, error)
+	// Validate the structure of a CPA 005 file
+	ValidateCPA005(ctx context.Context, in *CpaRequest, opts ...grpc.CallOption) (*ValidationResponse, error)
+	// Generate a remittance advice for each payment of a file, returned as a zip
+	GenerateRemittanceAdvice(ctx context.Context, in *RemittanceAdviceRequest, opts ...grpc.CallOption) (*RemittanceAdviceResponse, error)
 }
 
 type nachaServiceClient struct {
@@ -80,6 +128,16 @@ func (c *nachaServiceClient) ExportFile(ctx context.Context, in *ExportRequest,
 	return out, nil
 }
 
+func (c *nachaServiceClient) ImportFromJson(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*FileResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FileResponse)
+	err := c.cc.Invoke(ctx, NachaService_ImportFromJson_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *nachaServiceClient) ViewFile(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (*FileDetailsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FileDetailsResponse)
@@ -100,6 +158,168 @@ func (c *nachaServiceClient) ViewDetails(ctx context.Context, in *DetailRequest,
 	return out, nil
 }
 
+func (c *nachaServiceClient) QueryEntries(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, NachaService_QueryEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) SummarizeFile(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SummaryResponse)
+	err := c.cc.Invoke(ctx, NachaService_SummarizeFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) UploadFile(ctx context.Context, in *UploadRequest, opts ...grpc.CallOption) (*UploadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadResponse)
+	err := c.cc.Invoke(ctx, NachaService_UploadFile_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) UploadStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadChunk, UploadResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NachaService_ServiceDesc.Streams[0], NachaService_UploadStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadChunk, UploadResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NachaService_UploadStreamClient = grpc.ClientStreamingClient[UploadChunk, UploadResponse]
+
+func (c *nachaServiceClient) ExportStream(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NachaService_ServiceDesc.Streams[1], NachaService_ExportStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NachaService_ExportStreamClient = grpc.ServerStreamingClient[ExportChunk]
+
+func (c *nachaServiceClient) ListExportFormats(ctx context.Context, in *ListExportFormatsRequest, opts ...grpc.CallOption) (*ListExportFormatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListExportFormatsResponse)
+	err := c.cc.Invoke(ctx, NachaService_ListExportFormats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ImportFromPain(ctx context.Context, in *PainImportRequest, opts ...grpc.CallOption) (*PainImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PainImportResponse)
+	err := c.cc.Invoke(ctx, NachaService_ImportFromPain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ImportFromCSV(ctx context.Context, in *CsvImportRequest, opts ...grpc.CallOption) (*CsvImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CsvImportResponse)
+	err := c.cc.Invoke(ctx, NachaService_ImportFromCSV_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ImportFromXLSX(ctx context.Context, in *XlsxImportRequest, opts ...grpc.CallOption) (*XlsxImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(XlsxImportResponse)
+	err := c.cc.Invoke(ctx, NachaService_ImportFromXLSX_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ImportFromCNAB240(ctx context.Context, in *CnabImportRequest, opts ...grpc.CallOption) (*CnabImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CnabImportResponse)
+	err := c.cc.Invoke(ctx, NachaService_ImportFromCNAB240_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ViewCNAB240(ctx context.Context, in *CnabRequest, opts ...grpc.CallOption) (*CnabViewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CnabViewResponse)
+	err := c.cc.Invoke(ctx, NachaService_ViewCNAB240_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ValidateCNAB240(ctx context.Context, in *CnabRequest, opts ...grpc.CallOption) (*ValidationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationResponse)
+	err := c.cc.Invoke(ctx, NachaService_ValidateCNAB240_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ImportFromCPA005(ctx context.Context, in *CpaImportRequest, opts ...grpc.CallOption) (*CpaImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CpaImportResponse)
+	err := c.cc.Invoke(ctx, NachaService_ImportFromCPA005_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) ValidateCPA005(ctx context.Context, in *CpaRequest, opts ...grpc.CallOption) (*ValidationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidationResponse)
+	err := c.cc.Invoke(ctx, NachaService_ValidateCPA005_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nachaServiceClient) GenerateRemittanceAdvice(ctx context.Context, in *RemittanceAdviceRequest, opts ...grpc.CallOption) (*RemittanceAdviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemittanceAdviceResponse)
+	err := c.cc.Invoke(ctx, NachaService_GenerateRemittanceAdvice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NachaServiceServer is the server API for NachaService service.
 // All implementations must embed UnimplementedNachaServiceServer
 // for forward compatibility.
@@ -110,10 +330,42 @@ type NachaServiceServer interface {
 	CreateFile(context.Context, *NachaFileRequest) (*FileResponse, error)
 	// Export NACHA file to different formats
 	ExportFile(context.Context, *ExportRequest) (*ExportResponse, error)
+	// Import a JSON document in the NACHA JSON schema to NACHA format
+	ImportFromJson(context.Context, *ImportRequest) (*FileResponse, error)
 	// View complete file details
 	ViewFile(context.Context, *FileRequest) (*FileDetailsResponse, error)
 	// View specific batch or entry details
 	ViewDetails(context.Context, *DetailRequest) (*DetailResponse, error)
+	// Query and filter entries across all batches of a file
+	QueryEntries(context.Context, *QueryRequest) (*QueryResponse, error)
+	// Summarize a file with typed aggregates
+	SummarizeFile(context.Context, *SummaryRequest) (*SummaryResponse, error)
+	// Upload a file once and refer to it by file_id in later requests
+	UploadFile(context.Context, *UploadRequest) (*UploadResponse, error)
+	// Upload a file in chunks, for files larger than the message size limit
+	UploadStream(grpc.ClientStreamingServer[UploadChunk, UploadResponse]) error
+	// Export a file and receive the output in chunks
+	ExportStream(*ExportRequest, grpc.ServerStreamingServer[ExportChunk]) error
+	// List the registered export formats
+	ListExportFormats(context.Context, *ListExportFormatsRequest) (*ListExportFormatsResponse, error)
+	// Import an ISO 20022 pain.001 or pain.008 message to NACHA format
+	ImportFromPain(context.Context, *PainImportRequest) (*PainImportResponse, error)
+	// Import a CSV export or a spreadsheet of payees to NACHA format
+	ImportFromCSV(context.Context, *CsvImportRequest) (*CsvImportResponse, error)
+	// Import an XLSX export to NACHA format
+	ImportFromXLSX(context.Context, *XlsxImportRequest) (*XlsxImportResponse, error)
+	// Import a FEBRABAN CNAB 240 payment remittance to NACHA format
+	ImportFromCNAB240(context.Context, *CnabImportRequest) (*CnabImportResponse, error)
+	// View the records and fields of a CNAB 240 file
+	ViewCNAB240(context.Context, *CnabRequest) (*CnabViewResponse, error)
+	// Validate the structure of a CNAB 240 file
+	ValidateCNAB240(context.Context, *CnabRequest) (*ValidationResponse, error)
+	// Import a Payments Canada CPA Standard 005 file to NACHA format
+	ImportFromCPA005(context.Context, *CpaImportRequest) (*CpaImportResponse, error)
+	// Validate the structure of a CPA 005 file
+	ValidateCPA005(context.Context, *CpaRequest) (*ValidationResponse, error)
+	// Generate a remittance advice for each payment of a file, returned as a zip
+	GenerateRemittanceAdvice(context.Context, *RemittanceAdviceRequest) (*RemittanceAdviceResponse, error)
 	mustEmbedUnimplementedNachaServiceServer()
 }
 
@@ -133,12 +385,60 @@ func (UnimplementedNachaServiceServer) CreateFile(context.Context, *NachaFileReq
 func (UnimplementedNachaServiceServer) ExportFile(context.Context, *ExportRequest) (*ExportResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExportFile not implemented")
 }
+func (UnimplementedNachaServiceServer) ImportFromJson(context.Context, *ImportRequest) (*FileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromJson not implemented")
+}
 func (UnimplementedNachaServiceServer) ViewFile(context.Context, *FileRequest) (*FileDetailsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ViewFile not implemented")
 }
 func (UnimplementedNachaServiceServer) ViewDetails(context.Context, *DetailRequest) (*DetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ViewDetails not implemented")
 }
+func (UnimplementedNachaServiceServer) QueryEntries(context.Context, *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryEntries not implemented")
+}
+func (UnimplementedNachaServiceServer) SummarizeFile(context.Context, *SummaryRequest) (*SummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeFile not implemented")
+}
+func (UnimplementedNachaServiceServer) UploadFile(context.Context, *UploadRequest) (*UploadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadFile not implemented")
+}
+func (UnimplementedNachaServiceServer) UploadStream(grpc.ClientStreamingServer[UploadChunk, UploadResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadStream not implemented")
+}
+func (UnimplementedNachaServiceServer) ExportStream(*ExportRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportStream not implemented")
+}
+func (UnimplementedNachaServiceServer) ListExportFormats(context.Context, *ListExportFormatsRequest) (*ListExportFormatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListExportFormats not implemented")
+}
+func (UnimplementedNachaServiceServer) ImportFromPain(context.Context, *PainImportRequest) (*PainImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromPain not implemented")
+}
+func (UnimplementedNachaServiceServer) ImportFromCSV(context.Context, *CsvImportRequest) (*CsvImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromCSV not implemented")
+}
+func (UnimplementedNachaServiceServer) ImportFromXLSX(context.Context, *XlsxImportRequest) (*XlsxImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromXLSX not implemented")
+}
+func (UnimplementedNachaServiceServer) ImportFromCNAB240(context.Context, *CnabImportRequest) (*CnabImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromCNAB240 not implemented")
+}
+func (UnimplementedNachaServiceServer) ViewCNAB240(context.Context, *CnabRequest) (*CnabViewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ViewCNAB240 not implemented")
+}
+func (UnimplementedNachaServiceServer) ValidateCNAB240(context.Context, *CnabRequest) (*ValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCNAB240 not implemented")
+}
+func (UnimplementedNachaServiceServer) ImportFromCPA005(context.Context, *CpaImportRequest) (*CpaImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFromCPA005 not implemented")
+}
+func (UnimplementedNachaServiceServer) ValidateCPA005(context.Context, *CpaRequest) (*ValidationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateCPA005 not implemented")
+}
+func (UnimplementedNachaServiceServer) GenerateRemittanceAdvice(context.Context, *RemittanceAdviceRequest) (*RemittanceAdviceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateRemittanceAdvice not implemented")
+}
 func (UnimplementedNachaServiceServer) mustEmbedUnimplementedNachaServiceServer() {}
 func (UnimplementedNachaServiceServer) testEmbeddedByValue()                      {}
 
@@ -214,6 +514,24 @@ func _NachaService_ExportFile_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NachaService_ImportFromJson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ImportFromJson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ImportFromJson_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ImportFromJson(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _NachaService_ViewFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FileRequest)
 	if err := dec(in); err != nil {
@@ -250,6 +568,258 @@ func _NachaService_ViewDetails_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NachaService_QueryEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).QueryEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_QueryEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).QueryEntries(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_SummarizeFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).SummarizeFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_SummarizeFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).SummarizeFile(ctx, req.(*SummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_UploadFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).UploadFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_UploadFile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).UploadFile(ctx, req.(*UploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_UploadStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NachaServiceServer).UploadStream(&grpc.GenericServerStream[UploadChunk, UploadResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NachaService_UploadStreamServer = grpc.ClientStreamingServer[UploadChunk, UploadResponse]
+
+func _NachaService_ExportStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NachaServiceServer).ExportStream(m, &grpc.GenericServerStream[ExportRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NachaService_ExportStreamServer = grpc.ServerStreamingServer[ExportChunk]
+
+func _NachaService_ListExportFormats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListExportFormatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ListExportFormats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ListExportFormats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ListExportFormats(ctx, req.(*ListExportFormatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ImportFromPain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PainImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ImportFromPain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ImportFromPain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ImportFromPain(ctx, req.(*PainImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ImportFromCSV_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CsvImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ImportFromCSV(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ImportFromCSV_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ImportFromCSV(ctx, req.(*CsvImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ImportFromXLSX_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(XlsxImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ImportFromXLSX(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ImportFromXLSX_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ImportFromXLSX(ctx, req.(*XlsxImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ImportFromCNAB240_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CnabImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ImportFromCNAB240(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ImportFromCNAB240_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ImportFromCNAB240(ctx, req.(*CnabImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ViewCNAB240_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CnabRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ViewCNAB240(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ViewCNAB240_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ViewCNAB240(ctx, req.(*CnabRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ValidateCNAB240_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CnabRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ValidateCNAB240(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ValidateCNAB240_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ValidateCNAB240(ctx, req.(*CnabRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ImportFromCPA005_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CpaImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ImportFromCPA005(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ImportFromCPA005_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ImportFromCPA005(ctx, req.(*CpaImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_ValidateCPA005_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CpaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).ValidateCPA005(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_ValidateCPA005_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).ValidateCPA005(ctx, req.(*CpaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NachaService_GenerateRemittanceAdvice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemittanceAdviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NachaServiceServer).GenerateRemittanceAdvice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NachaService_GenerateRemittanceAdvice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NachaServiceServer).GenerateRemittanceAdvice(ctx, req.(*RemittanceAdviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NachaService_ServiceDesc is the grpc.ServiceDesc for NachaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -269,6 +839,10 @@ var NachaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExportFile",
 			Handler:    _NachaService_ExportFile_Handler,
 		},
+		{
+			MethodName: "ImportFromJson",
+			Handler:    _NachaService_ImportFromJson_Handler,
+		},
 		{
 			MethodName: "ViewFile",
 			Handler:    _NachaService_ViewFile_Handler,
@@ -277,7 +851,70 @@ var NachaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ViewDetails",
 			Handler:    _NachaService_ViewDetails_Handler,
 		},
+		{
+			MethodName: "QueryEntries",
+			Handler:    _NachaService_QueryEntries_Handler,
+		},
+		{
+			MethodName: "SummarizeFile",
+			Handler:    _NachaService_SummarizeFile_Handler,
+		},
+		{
+			MethodName: "UploadFile",
+			Handler:    _NachaService_UploadFile_Handler,
+		},
+		{
+			MethodName: "ListExportFormats",
+			Handler:    _NachaService_ListExportFormats_Handler,
+		},
+		{
+			MethodName: "ImportFromPain",
+			Handler:    _NachaService_ImportFromPain_Handler,
+		},
+		{
+			MethodName: "ImportFromCSV",
+			Handler:    _NachaService_ImportFromCSV_Handler,
+		},
+		{
+			MethodName: "ImportFromXLSX",
+			Handler:    _NachaService_ImportFromXLSX_Handler,
+		},
+		{
+			MethodName: "ImportFromCNAB240",
+			Handler:    _NachaService_ImportFromCNAB240_Handler,
+		},
+		{
+			MethodName: "ViewCNAB240",
+			Handler:    _NachaService_ViewCNAB240_Handler,
+		},
+		{
+			MethodName: "ValidateCNAB240",
+			Handler:    _NachaService_ValidateCNAB240_Handler,
+		},
+		{
+			MethodName: "ImportFromCPA005",
+			Handler:    _NachaService_ImportFromCPA005_Handler,
+		},
+		{
+			MethodName: "ValidateCPA005",
+			Handler:    _NachaService_ValidateCPA005_Handler,
+		},
+		{
+			MethodName: "GenerateRemittanceAdvice",
+			Handler:    _NachaService_GenerateRemittanceAdvice_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadStream",
+			Handler:       _NachaService_UploadStream_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportStream",
+			Handler:       _NachaService_ExportStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/nacha.proto",
 }
@@ -7,10 +7,10 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
-	"strings"
 	"time"
 
 	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/pkg/models"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
@@ -216,7 +216,7 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 	entries := []*pb.EntryDetailRequest{
 		{
 			RecordType:                     "6",
-			TransactionCode:                "22", // Debit for demand deposit account
+			TransactionCode:                "22", // Credit to a demand deposit account
 			ReceivingDfiIdentification:     "07640125",
 			CheckDigit:                     "1",
 			DfiAccountNumber:               "123456789",
@@ -237,7 +237,7 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 		},
 		{
 			RecordType:                     "6",
-			TransactionCode:                "22", // Debit for demand deposit account
+			TransactionCode:                "22", // Credit to a demand deposit account
 			ReceivingDfiIdentification:     "07640125",
 			CheckDigit:                     "1",
 			DfiAccountNumber:               "234567890",
@@ -250,7 +250,7 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 		},
 		{
 			RecordType:                     "6",
-			TransactionCode:                "22", // Debit for demand deposit account
+			TransactionCode:                "22", // Credit to a demand deposit account
 			ReceivingDfiIdentification:     "07640125",
 			CheckDigit:                     "1",
 			DfiAccountNumber:               "345678901",
@@ -263,11 +263,11 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 		},
 	}
 
-	// Calculate total debit amount
-	var totalDebit int64
+	// Calculate total credit amount
+	var totalCredit int64
 	for _, entry := range entries {
-		if strings.HasPrefix(entry.TransactionCode, "2") { // Debit transactions
-			totalDebit += entry.Amount
+		if models.IsCredit(entry.TransactionCode) { // Credit transactions
+			totalCredit += entry.Amount
 		}
 	}
 
@@ -289,7 +289,7 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 	batch := &pb.BatchRequest{
 		Header: &pb.BatchHeader{
 			RecordType:                   "5",
-			ServiceClassCode:             "225", // 225 for debit only
+			ServiceClassCode:             "220", // 220 for credits only
 			CompanyName:                  "EMPRESA EXEMPLO",
 			CompanyDiscretionaryData:     "PAGAMENTO SALARIO",
 			CompanyIdentification:        "0764012512",
@@ -304,11 +304,11 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 		Entries: entries,
 		Control: &pb.BatchControl{
 			RecordType:                   "8",
-			ServiceClassCode:             "225", // Must match batch header
+			ServiceClassCode:             "220", // Must match batch header
 			EntryAddendaCount:            entryAddendaCount,
 			EntryHash:                    fmt.Sprintf("%010d", entryHash),
-			TotalDebitAmount:             totalDebit, // All transactions are debits
-			TotalCreditAmount:            0,          // No credits in this batch
+			TotalDebitAmount:             0,           // No debits in this batch
+			TotalCreditAmount:            totalCredit, // All transactions are credits
 			CompanyIdentification:        "0764012512",
 			MessageAuthenticationCode:    "",
 			Reserved:                     "",
@@ -324,8 +324,8 @@ func createTestFile(ctx context.Context, client pb.NachaServiceClient) (*pb.File
 		BlockCount:        ((int32(entryAddendaCount) + 4) + 9) / 10, // Round up to nearest 10
 		EntryAddendaCount: entryAddendaCount,
 		EntryHash:         fmt.Sprintf("%010d", entryHash),
-		TotalDebitAmount:  totalDebit, // All transactions are debits
-		TotalCreditAmount: 0,          // No credits in this file
+		TotalDebitAmount:  0,           // No debits in this file
+		TotalCreditAmount: totalCredit, // All transactions are credits
 		Reserved:          "",
 	}
 
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nacha-service/internal/datalake"
+)
+
+func main() {
+	input := flag.String("input", "", "directory of NACHA files to ingest")
+	output := flag.String("output", "datalake", "root directory of the data lake")
+	pattern := flag.String("pattern", "*", "glob pattern selecting the files in the input directory")
+	flag.Parse()
+
+	if *input == "" {
+		fmt.Fprintln(os.Stderr, "usage: datalake -input DIR [-output DIR] [-pattern GLOB]")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(*input, *pattern))
+	if err != nil {
+		log.Fatalf("Invalid pattern: %v", err)
+	}
+	sort.Strings(paths)
+
+	lake, err := datalake.Open(*output)
+	if err != nil {
+		log.Fatalf("Failed to open data lake: %v", err)
+	}
+
+	var ingested, skipped, failed int
+	for _, path := range paths {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+
+		record, added, err := lake.IngestFile(path)
+		switch {
+		case err != nil:
+			fmt.Printf("  ❌ %s: %v\n", path, err)
+			failed++
+		case !added:
+			fmt.Printf("  ⏭️ %s: already ingested\n", path)
+			skipped++
+		default:
+			fmt.Printf("  ✅ %s: %d entries in %d partitions\n", path, record.Entries, len(record.Parts))
+			ingested++
+		}
+	}
+
+	fmt.Printf("\nIngested: %d, skipped: %d, failed: %d\n", ingested, skipped, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
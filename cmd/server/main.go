@@ -6,10 +6,14 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
+	_ "time/tzdata" // timezones for export options on hosts without zoneinfo
 
 	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/cache"
+	"github.com/nacha-service/internal/exporters"
 	"github.com/nacha-service/internal/services"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -49,11 +53,29 @@ func main() {
 		}),
 	}
 
+	// Register the fixed-width bank layouts as export formats
+	if dir := os.Getenv("NACHA_LAYOUTS_DIR"); dir != "" {
+		names, err := exporters.LoadLayouts(dir)
+		if err != nil {
+			log.Fatalf("Failed to load layouts: %v", err)
+		}
+		log.Printf("Loaded layouts from %s: %v", dir, names)
+	}
+
+	// Register the templates TEMPLATE exports can select by name
+	if dir := os.Getenv("NACHA_TEMPLATES_DIR"); dir != "" {
+		names, err := exporters.LoadTemplates(dir)
+		if err != nil {
+			log.Fatalf("Failed to load templates: %v", err)
+		}
+		log.Printf("Loaded templates from %s: %v", dir, names)
+	}
+
 	// Create a new gRPC server
 	grpcServer := grpc.NewServer(opts...)
 
 	// Create and register services
-	nachaService := services.NewNachaService()
+	nachaService := services.NewNachaServiceWithCache(cacheConfigFromEnv())
 	pb.RegisterNachaServiceServer(grpcServer, nachaService)
 
 	// Register health service
@@ -71,6 +93,44 @@ func main() {
 	}
 }
 
+// cacheConfigFromEnv reads the uploaded file cache limits from the environment
+func cacheConfigFromEnv() cache.Config {
+	config := cache.DefaultConfig()
+
+	if v := os.Getenv("NACHA_CACHE_TTL"); v != "" {
+		if ttl, err := time.ParseDuration(v); err == nil {
+			config.TTL = ttl
+		} else {
+			log.Printf("Invalid NACHA_CACHE_TTL %q, using %v", v, config.TTL)
+		}
+	}
+	if v := os.Getenv("NACHA_CACHE_MAX_FILES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			config.MaxFiles = n
+		} else {
+			log.Printf("Invalid NACHA_CACHE_MAX_FILES %q, using %d", v, config.MaxFiles)
+		}
+	}
+	if v := os.Getenv("NACHA_CACHE_MAX_FILE_SIZE"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			config.MaxFileSize = n
+		} else {
+			log.Printf("Invalid NACHA_CACHE_MAX_FILE_SIZE %q, using %d", v, config.MaxFileSize)
+		}
+	}
+	if v := os.Getenv("NACHA_CACHE_MAX_TOTAL_SIZE"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			config.MaxTotalSize = n
+		} else {
+			log.Printf("Invalid NACHA_CACHE_MAX_TOTAL_SIZE %q, using %d", v, config.MaxTotalSize)
+		}
+	}
+
+	log.Printf("File cache: TTL=%v MaxFiles=%d MaxFileSize=%d MaxTotalSize=%d",
+		config.TTL, config.MaxFiles, config.MaxFileSize, config.MaxTotalSize)
+	return config
+}
+
 func handleShutdown(grpcServer *grpc.Server, healthServer *health.Server) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
@@ -0,0 +1,258 @@
+// Package cache keeps parsed NACHA files in memory so clients can upload a
+// file once and refer to it by ID in later requests
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// Default cache limits
+const (
+	DefaultTTL          = 30 * time.Minute
+	DefaultMaxFiles     = 100
+	DefaultMaxFileSize  = 64 << 20  // 64MB
+	DefaultMaxTotalSize = 512 << 20 // 512MB
+)
+
+// Config holds the cache limits. Zero values fall back to the defaults.
+type Config struct {
+	TTL          time.Duration
+	MaxFiles     int
+	MaxFileSize  int64
+	MaxTotalSize int64
+}
+
+// DefaultConfig returns the default cache configuration
+func DefaultConfig() Config {
+	return Config{
+		TTL:          DefaultTTL,
+		MaxFiles:     DefaultMaxFiles,
+		MaxFileSize:  DefaultMaxFileSize,
+		MaxTotalSize: DefaultMaxTotalSize,
+	}
+}
+
+// Entry is a cached file. The parsed file is shared between requests and
+// must not be modified.
+type Entry struct {
+	ID        string
+	File      *models.NachaFile
+	Size      int64
+	ExpiresAt time.Time
+}
+
+// FileCache is an LRU cache of parsed NACHA files keyed by content hash
+type FileCache struct {
+	config    Config
+	mutex     sync.Mutex
+	entries   map[string]*list.Element
+	lru       *list.List
+	totalSize int64
+	now       func() time.Time
+}
+
+// NewFileCache creates a new file cache
+func NewFileCache(config Config) *FileCache {
+	defaults := DefaultConfig()
+	if config.TTL <= 0 {
+		config.TTL = defaults.TTL
+	}
+	if config.MaxFiles <= 0 {
+		config.MaxFiles = defaults.MaxFiles
+	}
+	if config.MaxFileSize <= 0 {
+		config.MaxFileSize = defaults.MaxFileSize
+	}
+	if config.MaxTotalSize <= 0 {
+		config.MaxTotalSize = defaults.MaxTotalSize
+	}
+	return &FileCache{
+		config:  config,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// FileID returns the ID a file content is stored under
+func FileID(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Put parses and stores a file. Uploading the same content again returns the
+// same ID and extends its expiry.
+func (c *FileCache) Put(content []byte) (*Entry, error) {
+	size := int64(len(content))
+	if size == 0 {
+		return nil, fmt.Errorf("file content is empty")
+	}
+	if size > c.config.MaxFileSize {
+		return nil, fmt.Errorf("file size %d exceeds limit of %d bytes", size, c.config.MaxFileSize)
+	}
+
+	id := FileID(content)
+	if entry := c.refresh(id); entry != nil {
+		return entry, nil
+	}
+
+	file := models.FromBytes(content)
+	if file == nil {
+		return nil, fmt.Errorf("failed to parse NACHA file")
+	}
+
+	return c.add(id, file, size), nil
+}
+
+// PutReader parses and stores a file read from r. The content is parsed as it
+// is read and never held in memory as a whole.
+func (c *FileCache) PutReader(r io.Reader) (*Entry, error) {
+	hash := sha256.New()
+	limited := &limitReader{r: r, limit: c.config.MaxFileSize}
+
+	file, err := models.NewReader(io.TeeReader(limited, hash)).Read()
+	if err != nil {
+		return nil, err
+	}
+	if limited.size == 0 {
+		return nil, fmt.Errorf("file content is empty")
+	}
+
+	return c.add(hex.EncodeToString(hash.Sum(nil)), file, limited.size), nil
+}
+
+// MaxFileSize returns the largest file the cache accepts
+func (c *FileCache) MaxFileSize() int64 {
+	return c.config.MaxFileSize
+}
+
+// refresh extends the expiry of a cached file and returns it, or nil when the
+// ID is not cached
+func (c *FileCache) refresh(id string) *Entry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	c.removeExpired(now)
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil
+	}
+	entry := elem.Value.(*Entry)
+	entry.ExpiresAt = now.Add(c.config.TTL)
+	c.lru.MoveToFront(elem)
+	return entry
+}
+
+// add stores a parsed file, evicting least recently used files until it fits
+func (c *FileCache) add(id string, file *models.NachaFile, size int64) *Entry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	c.removeExpired(now)
+
+	if elem, ok := c.entries[id]; ok {
+		entry := elem.Value.(*Entry)
+		entry.ExpiresAt = now.Add(c.config.TTL)
+		c.lru.MoveToFront(elem)
+		return entry
+	}
+
+	for c.lru.Len() > 0 && (c.lru.Len() >= c.config.MaxFiles || c.totalSize+size > c.config.MaxTotalSize) {
+		c.remove(c.lru.Back())
+	}
+
+	entry := &Entry{
+		ID:        id,
+		File:      file,
+		Size:      size,
+		ExpiresAt: now.Add(c.config.TTL),
+	}
+	c.entries[id] = c.lru.PushFront(entry)
+	c.totalSize += size
+
+	return entry
+}
+
+// Get returns a cached file by ID
+func (c *FileCache) Get(id string) (*Entry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*Entry)
+	if !c.now().Before(entry.ExpiresAt) {
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry, true
+}
+
+// Delete removes a file from the cache
+func (c *FileCache) Delete(id string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.entries[id]
+	if ok {
+		c.remove(elem)
+	}
+	return ok
+}
+
+// Len returns the number of cached files
+func (c *FileCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.lru.Len()
+}
+
+// removeExpired drops every expired file. Callers must hold the lock.
+func (c *FileCache) removeExpired(now time.Time) {
+	for elem := c.lru.Back(); elem != nil; {
+		prev := elem.Prev()
+		if !now.Before(elem.Value.(*Entry).ExpiresAt) {
+			c.remove(elem)
+		}
+		elem = prev
+	}
+}
+
+// remove drops a single file. Callers must hold the lock.
+func (c *FileCache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*Entry)
+	delete(c.entries, entry.ID)
+	c.totalSize -= entry.Size
+}
+
+// limitReader counts the bytes read and fails once the limit is exceeded
+type limitReader struct {
+	r     io.Reader
+	limit int64
+	size  int64
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.size += int64(n)
+	if l.size > l.limit {
+		return n, fmt.Errorf("file size exceeds limit of %d bytes", l.limit)
+	}
+	return n, err
+}
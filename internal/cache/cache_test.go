@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCache(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	c := NewFileCache(Config{TTL: time.Minute, MaxFiles: 2, MaxFileSize: 100, MaxTotalSize: 150})
+	c.now = func() time.Time { return now }
+
+	// Test case 1: Same content returns the same ID
+	first, err := c.Put([]byte("101 first file"))
+	assert.NoError(t, err)
+	again, err := c.Put([]byte("101 first file"))
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, 1, c.Len())
+
+	entry, ok := c.Get(first.ID)
+	assert.True(t, ok)
+	assert.NotNil(t, entry.File)
+
+	// Test case 2: Size limits
+	_, err = c.Put(make([]byte, 101))
+	assert.Error(t, err)
+	_, err = c.Put(nil)
+	assert.Error(t, err)
+
+	// Test case 3: Least recently used file is evicted
+	second, err := c.Put([]byte("101 second file"))
+	assert.NoError(t, err)
+	_, ok = c.Get(first.ID)
+	assert.True(t, ok)
+	third, err := c.Put([]byte("101 third file"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.Len())
+	_, ok = c.Get(second.ID)
+	assert.False(t, ok)
+	_, ok = c.Get(first.ID)
+	assert.True(t, ok)
+
+	// Test case 4: Total size limit
+	big, err := c.Put(make([]byte, 100))
+	assert.NoError(t, err)
+	_, ok = c.Get(third.ID)
+	assert.False(t, ok)
+	_, ok = c.Get(big.ID)
+	assert.True(t, ok)
+
+	// Test case 5: Files expire after the TTL
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get(big.ID)
+	assert.False(t, ok)
+	assert.True(t, c.Len() <= 1)
+
+	// Test case 6: Delete
+	entry, err = c.Put([]byte("101 fourth file"))
+	assert.NoError(t, err)
+	assert.True(t, c.Delete(entry.ID))
+	assert.False(t, c.Delete(entry.ID))
+}
+
+func TestFileCache_PutReader(t *testing.T) {
+	c := NewFileCache(Config{MaxFileSize: 100})
+
+	// Test case 1: Streamed content gets the same ID as Put
+	content := "101 streamed file\n"
+	streamed, err := c.PutReader(strings.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, FileID([]byte(content)), streamed.ID)
+	assert.Equal(t, int64(len(content)), streamed.Size)
+
+	stored, err := c.Put([]byte(content))
+	assert.NoError(t, err)
+	assert.Equal(t, streamed.ID, stored.ID)
+	assert.Equal(t, 1, c.Len())
+
+	// Test case 2: Size limits
+	_, err = c.PutReader(strings.NewReader(strings.Repeat("x", 101)))
+	assert.Error(t, err)
+	_, err = c.PutReader(strings.NewReader(""))
+	assert.Error(t, err)
+}
@@ -58,8 +58,12 @@ func (c *Creator) AddEntry(batch *models.NachaBatch, entry models.EntryDetail) e
 	return nil
 }
 
-// AddAddenda adds an addenda record to an entry detail record
+// AddAddenda adds an addenda record to an entry detail record. The entry must
+// have a trace number, whose last seven digits the addenda refers to.
 func (c *Creator) AddAddenda(entry *models.EntryDetail, addenda models.AddendaRecord) error {
+	if len(entry.TraceNumber) < 7 {
+		return fmt.Errorf("trace number %q is shorter than 7 digits", entry.TraceNumber)
+	}
 	addenda.EntryDetailSequenceNumber = entry.TraceNumber[len(entry.TraceNumber)-7:]
 	addenda.AddendaSequenceNumber = fmt.Sprintf("%04d", len(entry.AddendaRecords)+1)
 	entry.AddendaRecords = append(entry.AddendaRecords, addenda)
@@ -118,9 +122,9 @@ func (c *Creator) finalizeBatch(batch *models.Batch) error {
 
 		entryAddendaCount += len(entry.AddendaRecords)
 
-		if strings.HasPrefix(entry.TransactionCode, "2") {
+		if models.IsDebit(entry.TransactionCode) {
 			totalDebit += entry.Amount
-		} else {
+		} else if models.IsCredit(entry.TransactionCode) {
 			totalCredit += entry.Amount
 		}
 	}
@@ -0,0 +1,305 @@
+// Package datalake appends NACHA files to a Hive-partitioned directory of
+// Parquet files, keeping a manifest so the same file is never loaded twice
+package datalake
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/exporters"
+	"github.com/nacha-service/pkg/models"
+)
+
+// ManifestName is the name of the manifest kept at the root of a lake
+const ManifestName = "_manifest.json"
+
+// DefaultPartition is the Hive name for a missing partition value
+const DefaultPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// Manifest lists the files ingested into a lake
+type Manifest struct {
+	Files []IngestedFile `json:"files"`
+}
+
+// IngestedFile records a NACHA file loaded into the lake
+type IngestedFile struct {
+	Fingerprint string    `json:"fingerprint"`
+	Source      string    `json:"source"`
+	IngestedAt  time.Time `json:"ingested_at"`
+	Entries     int       `json:"entries"`
+	Parts       []string  `json:"parts"`
+}
+
+// Lake is a Hive-partitioned directory of Parquet files laid out as
+// date=YYYY-MM-DD/company=ID/sec=CODE/part-FINGERPRINT.parquet. It is not
+// safe for concurrent use, nor for several processes writing the same root.
+type Lake struct {
+	root     string
+	manifest Manifest
+	ingested map[string]bool
+	now      func() time.Time
+}
+
+// Open opens the lake at root, creating the directory when it does not exist
+func Open(root string) (*Lake, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lake directory: %v", err)
+	}
+
+	lake := &Lake{
+		root:     root,
+		ingested: make(map[string]bool),
+		now:      time.Now,
+	}
+
+	data, err := os.ReadFile(filepath.Join(root, ManifestName))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	default:
+		if err := json.Unmarshal(data, &lake.manifest); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest: %v", err)
+		}
+	}
+
+	for _, f := range lake.manifest.Files {
+		lake.ingested[f.Fingerprint] = true
+	}
+	return lake, nil
+}
+
+// Manifest returns the files ingested so far
+func (l *Lake) Manifest() Manifest {
+	return l.manifest
+}
+
+// Fingerprint returns the fingerprint identifying a file's content. It is
+// computed over the normalized records, so the same file with CRLF or LF line
+// endings, trailing blanks or blank lines has the same fingerprint.
+func Fingerprint(content []byte) string {
+	sum := sha256.Sum256([]byte(strings.Join(records(content), "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// records returns the non-blank lines of a file padded or truncated to the
+// record length, the way the reader sees them
+func records(content []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if len(line) < models.RecordLength {
+			line = models.PadRight(line, models.RecordLength)
+		} else if len(line) > models.RecordLength {
+			line = line[:models.RecordLength]
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// checkRecords rejects content that is not a NACHA file: it must start with a
+// file header, end with a file control and hold only known record types
+func checkRecords(lines []string) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("not a NACHA file: no records")
+	}
+	if lines[0][0] != '1' {
+		return fmt.Errorf("not a NACHA file: line 1 is not a file header record")
+	}
+	control := false
+	for i, line := range lines {
+		switch line[0] {
+		case '1':
+			if i > 0 {
+				return fmt.Errorf("not a NACHA file: line %d is a second file header record", i+1)
+			}
+		case '5', '6', '7', '8':
+		case '9':
+			control = true
+		default:
+			return fmt.Errorf("not a NACHA file: line %d has unknown record type %q", i+1, line[0])
+		}
+	}
+	if !control {
+		return fmt.Errorf("not a NACHA file: no file control record")
+	}
+	return nil
+}
+
+// IngestFile reads a NACHA file from disk and ingests it
+func (l *Lake) IngestFile(path string) (*IngestedFile, bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return l.Ingest(path, content)
+}
+
+// Ingest appends the entries of a NACHA file to the lake. It returns false
+// without writing anything when a file with the same records was already
+// ingested, and an error when the content is not a NACHA file.
+func (l *Lake) Ingest(source string, content []byte) (*IngestedFile, bool, error) {
+	if err := checkRecords(records(content)); err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %v", source, err)
+	}
+
+	fingerprint := Fingerprint(content)
+	if l.ingested[fingerprint] {
+		return nil, false, nil
+	}
+
+	file, err := models.NewReader(bytes.NewReader(content)).Read()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %v", source, err)
+	}
+
+	record := IngestedFile{
+		Fingerprint: fingerprint,
+		Source:      source,
+		IngestedAt:  l.now().UTC(),
+	}
+
+	// Part names are derived from the fingerprint, so a run interrupted
+	// before the manifest is saved overwrites its parts when repeated
+	partitions := partition(file)
+	for _, key := range sortedKeys(partitions) {
+		part := filepath.Join(key, "part-"+fingerprint[:16]+".parquet")
+		if err := l.writePart(part, partitions[key], record); err != nil {
+			return nil, false, err
+		}
+		record.Parts = append(record.Parts, filepath.ToSlash(part))
+		for _, batch := range partitions[key].Batches {
+			record.Entries += len(batch.Entries)
+		}
+	}
+
+	l.manifest.Files = append(l.manifest.Files, record)
+	if err := l.saveManifest(); err != nil {
+		l.manifest.Files = l.manifest.Files[:len(l.manifest.Files)-1]
+		return nil, false, err
+	}
+	l.ingested[fingerprint] = true
+
+	return &record, true, nil
+}
+
+// partition splits a file into one file per date, company and SEC code.
+// Batches without entries have no rows and write no part. Each partition keeps
+// its batches at their position in the file, with the batches of the other
+// partitions left empty, so batch_index is the index in the original file.
+func partition(file *models.NachaFile) map[string]*models.NachaFile {
+	date := DefaultPartition
+	if !file.Header.FileCreationDate.IsZero() {
+		date = file.Header.FileCreationDate.Format("2006-01-02")
+	}
+
+	partitions := make(map[string]*models.NachaFile)
+	for i, batch := range file.Batches {
+		if len(batch.Entries) == 0 {
+			continue
+		}
+		key := filepath.Join(
+			"date="+date,
+			"company="+escape(batch.Header.CompanyIdentification),
+			"sec="+escape(batch.Header.StandardEntryClass),
+		)
+		p, ok := partitions[key]
+		if !ok {
+			p = &models.NachaFile{Header: file.Header, Batches: make([]models.Batch, len(file.Batches))}
+			partitions[key] = p
+		}
+		p.Batches[i] = batch
+	}
+	return partitions
+}
+
+// writePart writes a partition's entries with the Parquet exporter, each row
+// carrying the fingerprint and source of the ingested file. The part is written
+// to a temporary file first so readers never see a partial file.
+func (l *Lake) writePart(part string, file *models.NachaFile, record IngestedFile) error {
+	path := filepath.Join(l.root, part)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create partition %s: %v", filepath.Dir(part), err)
+	}
+
+	exporter := exporters.NewParquetExporter()
+	exporter.SetOptions(exporters.Options{
+		Parquet: exporters.ParquetOptions{SourceFingerprint: record.Fingerprint, SourceFile: record.Source},
+	})
+	var buf bytes.Buffer
+	if err := exporter.ExportTo(&buf, file); err != nil {
+		return fmt.Errorf("failed to export %s: %v", part, err)
+	}
+	return writeFileAtomic(path, buf.Bytes())
+}
+
+// saveManifest writes the manifest, replacing the previous one atomically
+func (l *Lake) saveManifest() error {
+	data, err := json.MarshalIndent(l.manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %v", err)
+	}
+	return writeFileAtomic(filepath.Join(l.root, ManifestName), data)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
+
+// escape makes a value safe for a partition directory name, percent-encoding
+// characters other than letters, digits, '_' and '-'
+func escape(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return DefaultPartition
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sortedKeys(partitions map[string]*models.NachaFile) []string {
+	keys := make([]string, 0, len(partitions))
+	for key := range partitions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,179 @@
+package datalake
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func testFile(day int, companies ...string) []byte {
+	file := &models.NachaFile{
+		Header: models.FileHeader{
+			ImmediateDestination: "076401251",
+			ImmediateOrigin:      "0764012512",
+			FileCreationDate:     time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC),
+			FileCreationTime:     "1200",
+		},
+	}
+	for i, company := range companies {
+		file.Batches = append(file.Batches, models.Batch{
+			Header: models.BatchHeader{
+				ServiceClassCode:      "220",
+				CompanyName:           "EMPRESA",
+				CompanyIdentification: company,
+				StandardEntryClass:    "PPD",
+				BatchNumber:           fmt.Sprintf("%07d", i+1),
+			},
+			Entries: []models.EntryDetail{
+				{TransactionCode: "22", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "111111", Amount: 1000, IndividualName: "JOAO", TraceNumber: "076401250000001"},
+			},
+		})
+	}
+	return file.ToBytes()
+}
+
+// readColumn returns the values of a column of a part. The reader renames
+// columns to Go field names, e.g. batch_index to Batch_index.
+func readColumn(t *testing.T, path, column string) []interface{} {
+	content, err := os.ReadFile(path)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	pf, err := buffer.NewBufferFile(content)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	pr, err := reader.NewParquetReader(pf, nil, 1)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	defer pr.ReadStop()
+
+	values, _, _, err := pr.ReadColumnByPath(common.ReformPathStr("Parquet_go_root."+column), pr.GetNumRows())
+	assert.NoError(t, err)
+	return values
+}
+
+func TestLake(t *testing.T) {
+	root := t.TempDir()
+	lake, err := Open(root)
+	assert.NoError(t, err)
+	lake.now = func() time.Time { return time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC) }
+
+	// Test case 1: Entries are partitioned by date, company and SEC code
+	first := testFile(17, "0764012512", "1234567890")
+	record, added, err := lake.Ingest("first.ach", first)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	if assert.NotNil(t, record) {
+		assert.Equal(t, 2, record.Entries)
+		assert.Equal(t, []string{
+			"date=2026-10-17/company=0764012512/sec=PPD/part-" + Fingerprint(first)[:16] + ".parquet",
+			"date=2026-10-17/company=1234567890/sec=PPD/part-" + Fingerprint(first)[:16] + ".parquet",
+		}, record.Parts)
+		for _, part := range record.Parts {
+			assert.FileExists(t, filepath.Join(root, part))
+		}
+	}
+
+	// Test case 2: The same file is not ingested twice
+	record, added, err = lake.Ingest("copy.ach", first)
+	assert.NoError(t, err)
+	assert.False(t, added)
+	assert.Nil(t, record)
+
+	// Test case 3: Other files append to existing partitions
+	second := testFile(17, "0764012512")
+	_, added, err = lake.Ingest("second.ach", second)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	parts, err := filepath.Glob(filepath.Join(root, "date=2026-10-17", "company=0764012512", "sec=PPD", "*.parquet"))
+	assert.NoError(t, err)
+	assert.Len(t, parts, 2)
+
+	// Test case 4: The manifest survives reopening the lake
+	reopened, err := Open(root)
+	assert.NoError(t, err)
+	assert.Len(t, reopened.Manifest().Files, 2)
+	_, added, err = reopened.Ingest("again.ach", second)
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	// Test case 5: Ingest a file from disk
+	path := filepath.Join(t.TempDir(), "third.ach")
+	assert.NoError(t, os.WriteFile(path, testFile(18, "0764012512"), 0644))
+	record, added, err = reopened.IngestFile(path)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	if assert.NotNil(t, record) {
+		assert.Equal(t, path, record.Source)
+		assert.Contains(t, record.Parts[0], "date=2026-10-18/")
+	}
+
+	// Test case 6: Line endings and trailing blanks do not change the fingerprint
+	crlf := strings.ReplaceAll(strings.TrimRight(string(second), "\n"), "\n", "  \r\n") + "\r\n\r\n"
+	assert.Equal(t, Fingerprint(second), Fingerprint([]byte(crlf)))
+	_, added, err = reopened.Ingest("crlf.ach", []byte(crlf))
+	assert.NoError(t, err)
+	assert.False(t, added)
+
+	// Test case 7: Content that is not a NACHA file is rejected
+	for name, content := range map[string]string{
+		"empty.ach":    "\r\n\n",
+		"notes.txt":    "hello world\n",
+		"data.csv":     "name,amount\nJOAO,10.00\n",
+		"header.ach":   string(second[:models.RecordLength]),
+		"twofiles.ach": string(second) + string(second),
+	} {
+		record, added, err = reopened.Ingest(name, []byte(content))
+		assert.Error(t, err, name)
+		assert.Contains(t, err.Error(), "not a NACHA file", name)
+		assert.False(t, added, name)
+		assert.Nil(t, record, name)
+	}
+	assert.Len(t, reopened.Manifest().Files, 3)
+
+	// Test case 8: Empty batches write no part and prenotes are ingested
+	file, err := models.NewReader(bytes.NewReader(testFile(18, "0764012512", "1234567890"))).Read()
+	if assert.NoError(t, err) {
+		file.Batches[0].Entries[0].TransactionCode = "23"
+		file.Batches[1].Entries = nil
+		record, added, err = reopened.Ingest("prenote.ach", file.ToBytes())
+		assert.NoError(t, err)
+		assert.True(t, added)
+		if assert.NotNil(t, record) {
+			assert.Equal(t, 1, record.Entries)
+			assert.Len(t, record.Parts, 1)
+			assert.NoDirExists(t, filepath.Join(root, "date=2026-10-18", "company=1234567890"))
+		}
+	}
+
+	// Test case 9: Rows keep the batch index of the original file and carry
+	// the fingerprint and source of the file
+	split := testFile(19, "0764012512", "1234567890")
+	record, added, err = reopened.Ingest("split.ach", split)
+	assert.NoError(t, err)
+	assert.True(t, added)
+	if assert.NotNil(t, record) && assert.Len(t, record.Parts, 2) {
+		for i, part := range record.Parts {
+			path := filepath.Join(root, part)
+			assert.Equal(t, []interface{}{int32(i)}, readColumn(t, path, "Batch_index"), part)
+			assert.Equal(t, []interface{}{Fingerprint(split)}, readColumn(t, path, "Source_fingerprint"), part)
+			assert.Equal(t, []interface{}{"split.ach"}, readColumn(t, path, "Source_file"), part)
+		}
+	}
+
+	// Test case 10: Partition values are escaped
+	assert.Equal(t, "ACME%20CO%2F1", escape(" ACME CO/1 "))
+	assert.Equal(t, DefaultPartition, escape(""))
+}
@@ -0,0 +1,273 @@
+package exporters
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/cnab240"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "CNAB240",
+		Extension:    ".rem",
+		Description:  "FEBRABAN CNAB 240 payment remittance for the credit entries",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewCNAB240Exporter() },
+	})
+}
+
+// ErrUnsupportedValue is returned by exporters when a value of the file
+// cannot be written in the export format
+var ErrUnsupportedValue = errors.New("value cannot be exported")
+
+// CNAB 240 service types (tipo de serviço) and forms of payment (forma de lançamento)
+const (
+	cnabServiceSuppliers = "20" // pagamento a fornecedores
+	cnabServiceOther     = "98" // pagamentos diversos
+	cnabFormSameBank     = "01" // crédito em conta corrente no mesmo banco
+	cnabFormTED          = "41" // TED para outra titularidade
+	cnabClearingSameBank = "000"
+	cnabClearingTED      = "018"
+)
+
+// CNABOptions holds the values of CNAB 240 exports that NACHA files do not carry
+type CNABOptions struct {
+	// CompanyDocument is the CNPJ or CPF of the company. By default the
+	// company identification of each batch is used.
+	CompanyDocument string
+	// Agreement is the agreement code (convênio) of the company with its bank
+	Agreement string
+	// CompanyAccount is the company's account, with the check digit after a
+	// dash, such as 12345-6
+	CompanyAccount string
+	// FileSequence is the file sequence number (NSA), 1 by default
+	FileSequence int
+}
+
+// CNAB240Exporter handles export of the credit entries to CNAB 240
+type CNAB240Exporter struct {
+	*BaseExporter
+}
+
+// NewCNAB240Exporter creates a new CNAB 240 exporter
+func NewCNAB240Exporter() *CNAB240Exporter {
+	return &CNAB240Exporter{
+		BaseExporter: NewBaseExporter("text/plain"),
+	}
+}
+
+// Export converts the credit entries of a NACHA file to CNAB 240
+func (e *CNAB240Exporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes the credit entries of a NACHA file to w as a CNAB 240
+// remittance. Each batch with credit entries becomes a lote of segment A and
+// B records. Routing numbers are read as the three digit bank code followed
+// by the five digit branch (agência) and its check digit.
+func (e *CNAB240Exporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	bank, branch, branchDigit, err := cnabRouting(strings.TrimSpace(file.Header.ImmediateDestination))
+	if err != nil {
+		return fmt.Errorf("immediate destination %w", err)
+	}
+	account, accountDigit, err := cnabAccount(opts.CNAB.CompanyAccount)
+	if err != nil {
+		return fmt.Errorf("company account %w", err)
+	}
+
+	created := file.Header.FileCreationDate
+	header := cnab240.NewRecord(cnab240.FileHeaderLayout)
+	header.Set("banco", bank)
+	cnabInscription(header, "tipo_inscricao", "numero_inscricao", opts.CNAB.CompanyDocument, file.Header.ImmediateOrigin)
+	header.Set("convenio", opts.CNAB.Agreement)
+	header.Set("agencia", branch)
+	header.Set("agencia_dv", branchDigit)
+	header.Set("conta", account)
+	header.Set("conta_dv", accountDigit)
+	header.Set("nome_empresa", file.Header.OriginName)
+	header.Set("nome_banco", file.Header.DestinationName)
+	header.SetDate("data_geracao", created)
+	header.Set("hora_geracao", padTime(file.Header.FileCreationTime))
+	header.SetInt("sequencial_arquivo", int64(max(opts.CNAB.FileSequence, 1)))
+
+	out := &cnab240.File{Header: header}
+	for i, batch := range file.Batches {
+		lote, err := cnabLote(&batch, bank, account, accountDigit, opts.CNAB)
+		if err != nil {
+			return fmt.Errorf("batch %d: %w", i+1, err)
+		}
+		if lote != nil {
+			out.Lotes = append(out.Lotes, lote)
+		}
+	}
+	if len(out.Lotes) == 0 {
+		return fmt.Errorf("CNAB 240 needs credit entries: %w", ErrNoEntries)
+	}
+
+	out.Finalize()
+	_, err = w.Write(out.Bytes())
+	return err
+}
+
+// cnabLote maps the credit entries of a batch to a lote, or returns nil when
+// the batch has none
+func cnabLote(batch *models.Batch, bank, account, accountDigit string, opts CNABOptions) (*cnab240.Lote, error) {
+	h := &batch.Header
+	date, err := time.Parse("060102", strings.TrimSpace(h.EffectiveEntryDate))
+	if err != nil {
+		return nil, fmt.Errorf("effective entry date %q is not YYMMDD: %w", h.EffectiveEntryDate, ErrUnsupportedValue)
+	}
+	odfiBank, odfiBranch, _, err := cnabRouting(h.OriginatingDFI)
+	if err != nil {
+		return nil, fmt.Errorf("originating DFI %w", err)
+	}
+	if odfiBank != bank {
+		return nil, fmt.Errorf("originating DFI bank %s differs from the immediate destination bank %s: %w", odfiBank, bank, ErrUnsupportedValue)
+	}
+
+	lote := &cnab240.Lote{}
+	sameBank := true
+	for _, entry := range batch.Entries {
+		if summary.Direction(entry.TransactionCode) != summary.DirectionCredit {
+			continue
+		}
+		a, b, err := cnabSegments(&entry, date)
+		if err != nil {
+			return nil, fmt.Errorf("entry %s: %w", entry.TraceNumber, err)
+		}
+		sameBank = sameBank && a.Get("banco_favorecido") == bank
+		lote.Details = append(lote.Details, a, b)
+	}
+	if len(lote.Details) == 0 {
+		return nil, nil
+	}
+
+	// One form of payment applies to the lote: a credit at the same bank, or
+	// a TED for any other bank
+	form, clearing := cnabFormSameBank, cnabClearingSameBank
+	if !sameBank {
+		form, clearing = cnabFormTED, cnabClearingTED
+	}
+	for _, detail := range lote.Details {
+		if detail.Layout == cnab240.SegmentALayout {
+			detail.Set("camara", clearing)
+		}
+	}
+
+	service := cnabServiceOther
+	switch strings.ToUpper(h.StandardEntryClass) {
+	case "CCD", "CTX":
+		service = cnabServiceSuppliers
+	}
+
+	header := cnab240.NewRecord(cnab240.LoteHeaderLayout)
+	header.Set("tipo_servico", service)
+	header.Set("forma_lancamento", form)
+	cnabInscription(header, "tipo_inscricao", "numero_inscricao", opts.CompanyDocument, h.CompanyIdentification)
+	header.Set("convenio", opts.Agreement)
+	header.Set("agencia", odfiBranch)
+	header.Set("conta", account)
+	header.Set("conta_dv", accountDigit)
+	header.Set("nome_empresa", h.CompanyName)
+	header.Set("mensagem", h.CompanyEntryDescription)
+	lote.Header = header
+	return lote, nil
+}
+
+// cnabSegments maps an entry to its segment A and B records
+func cnabSegments(entry *models.EntryDetail, date time.Time) (*cnab240.Record, *cnab240.Record, error) {
+	bank, branch, branchDigit, err := cnabRouting(entry.ReceivingDFI + entry.CheckDigit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("receiving DFI %w", err)
+	}
+	account, accountDigit, err := cnabAccount(entry.DFIAccountNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("account number %w", err)
+	}
+
+	a := cnab240.NewRecord(cnab240.SegmentALayout)
+	a.Set("banco_favorecido", bank)
+	a.Set("agencia_favorecido", branch)
+	a.Set("agencia_favorecido_dv", branchDigit)
+	a.Set("conta_favorecido", account)
+	a.Set("conta_favorecido_dv", accountDigit)
+	a.Set("nome_favorecido", entry.IndividualName)
+	a.Set("seu_numero", entry.TraceNumber)
+	a.SetDate("data_pagamento", date)
+	a.SetInt("valor_pagamento", entry.Amount)
+	if len(entry.AddendaRecords) > 0 {
+		a.Set("outras_informacoes", entry.AddendaRecords[0].PaymentRelatedInformation)
+	}
+	a.Set("finalidade_complemento", "CC")
+
+	b := cnab240.NewRecord(cnab240.SegmentBLayout)
+	cnabInscription(b, "tipo_inscricao_favorecido", "numero_inscricao_favorecido", entry.IndividualIDNumber, "")
+	b.SetDate("data_vencimento", date)
+	b.SetInt("valor_documento", entry.Amount)
+	return a, b, nil
+}
+
+// cnabRouting splits a routing number into the three digit bank code, the
+// five digit branch and the branch check digit
+func cnabRouting(routing string) (string, string, string, error) {
+	digits := strings.TrimSpace(routing[:min(len(routing), 8)])
+	if len(digits) != 8 || strings.Trim(digits, "0123456789") != "" {
+		return "", "", "", fmt.Errorf("%q is not a bank code and branch: %w", strings.TrimSpace(routing), ErrUnsupportedValue)
+	}
+	return digits[:3], digits[3:], strings.TrimSpace(routing[8:]), nil
+}
+
+// cnabAccount splits an account number into the number and the check digit
+// after a dash. Accounts without a dash have no check digit.
+func cnabAccount(account string) (string, string, error) {
+	number, digit, _ := strings.Cut(strings.TrimSpace(account), "-")
+	if len(number) > 12 || strings.Trim(number, "0123456789") != "" || len(digit) > 1 {
+		return "", "", fmt.Errorf("%q is not a CNAB 240 account: %w", account, ErrUnsupportedValue)
+	}
+	return number, digit, nil
+}
+
+// cnabInscription writes a CPF (11 digits) or CNPJ as the inscription of a
+// record. Without a document nothing is written.
+func cnabInscription(r *cnab240.Record, typeField, numberField, document, fallback string) {
+	digits := onlyDigits(document)
+	if digits == "" {
+		digits = onlyDigits(fallback)
+	}
+	if digits == "" {
+		return
+	}
+	kind := "2"
+	if len(digits) == 11 {
+		kind = "1"
+	}
+	r.Set(typeField, kind)
+	r.Set(numberField, digits)
+}
+
+func onlyDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// padTime completes an HHMM time with seconds
+func padTime(hhmm string) string {
+	hhmm = strings.TrimSpace(hhmm)
+	if len(hhmm) == 4 {
+		return hhmm + "00"
+	}
+	return hhmm
+}
@@ -0,0 +1,73 @@
+package exporters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/pkg/cnab240"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCNAB240Export(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Each batch with credit entries becomes a lote of segments A and B
+	data, contentType, err := export(t, file, "CNAB240", Options{CNAB: CNABOptions{Agreement: "123456", CompanyAccount: "98765-4", FileSequence: 7}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "text/plain", contentType)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	if !assert.Len(t, lines, 10) {
+		return
+	}
+	for _, line := range lines {
+		assert.Len(t, line, 240)
+	}
+
+	remittance, issues, err := cnab240.Parse(data)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, issues)
+	assert.Empty(t, remittance.Check())
+
+	header := remittance.Header
+	assert.Equal(t, "076", header.Get("banco"))
+	assert.Equal(t, "40125", header.Get("agencia"))
+	assert.Equal(t, "1", header.Get("agencia_dv"))
+	assert.Equal(t, "98765", strings.TrimLeft(header.Get("conta"), "0"))
+	assert.Equal(t, "17102026", header.Get("data_geracao"))
+	assert.Equal(t, "000007", header.Get("sequencial_arquivo"))
+
+	if !assert.Len(t, remittance.Lotes, 2) {
+		return
+	}
+	assert.Equal(t, "98", remittance.Lotes[0].Header.Get("tipo_servico"))
+	assert.Equal(t, "01", remittance.Lotes[0].Header.Get("forma_lancamento"))
+	assert.Len(t, remittance.Lotes[0].Details, 2)
+
+	lote := remittance.Lotes[1]
+	assert.Equal(t, "20", lote.Header.Get("tipo_servico"))
+	assert.Equal(t, "41", lote.Header.Get("forma_lancamento"))
+	if assert.Len(t, lote.Details, 2) {
+		a := lote.Details[0]
+		assert.Equal(t, cnab240.SegmentALayout, a.Layout)
+		assert.Equal(t, "018", a.Get("camara"))
+		assert.Equal(t, "021", a.Get("banco_favorecido"))
+		assert.Equal(t, "00002", a.Get("agencia_favorecido"))
+		assert.Equal(t, "444444", strings.TrimLeft(a.Get("conta_favorecido"), "0"))
+		assert.Equal(t, "ACME SUPPLIES", a.Get("nome_favorecido"))
+		assert.Equal(t, "076401250000004", a.Get("seu_numero"))
+		assert.Equal(t, "20102026", a.Get("data_pagamento"))
+		assert.Equal(t, "510000", strings.TrimLeft(a.Get("valor_pagamento"), "0"))
+		assert.Equal(t, "INV-1001 INV-1002", a.Get("outras_informacoes"))
+		assert.Equal(t, cnab240.SegmentBLayout, lote.Details[1].Layout)
+	}
+	assert.Equal(t, "510000", strings.TrimLeft(lote.Trailer.Get("somatoria_valores"), "0"))
+	assert.Equal(t, "000010", remittance.Trailer.Get("quantidade_registros"))
+
+	// Test case 2: Masked account numbers cannot be written
+	_, _, err = export(t, file, "CNAB240", Options{MaskAccountNumbers: true})
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+}
@@ -0,0 +1,220 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/cpa005"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "CPA005",
+		Extension:    ".cpa",
+		Description:  "Payments Canada CPA Standard 005 file of credit and debit transactions",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewCPA005Exporter() },
+	})
+}
+
+// Default CPA 005 transaction types
+const (
+	CPAPayrollDeposit       = "200"
+	CPAMiscellaneousPayment = "450"
+	CPABusinessPAD          = "700"
+)
+
+// CPAOptions holds the values of CPA 005 exports that NACHA files do not carry
+type CPAOptions struct {
+	// OriginatorID is the originator ID assigned by the direct clearer. By
+	// default the immediate origin is used.
+	OriginatorID string
+	// FileCreationNumber numbers the files of an originator, 1 by default
+	FileCreationNumber int
+	// DestinationDataCentre is the five digit data centre of the direct
+	// clearer receiving the file
+	DestinationDataCentre string
+	// Currency is CAD, the default, or USD
+	Currency string
+	// CreditTransactionType replaces the transaction type of credits, by
+	// default 200 (payroll deposit) in PPD batches and 450 (miscellaneous
+	// payment) in others
+	CreditTransactionType string
+	// DebitTransactionType replaces the transaction type of debits, 700
+	// (business pre-authorized debit) by default
+	DebitTransactionType string
+	// ReturnAccount is the originator's account for returned items. The
+	// originating DFI of each batch is the institution for returns.
+	ReturnAccount string
+}
+
+func (o CPAOptions) validate() error {
+	if o.FileCreationNumber < 0 || o.FileCreationNumber > 9999 {
+		return fmt.Errorf("file creation number %d is not 1 to 9999", o.FileCreationNumber)
+	}
+	if len(o.DestinationDataCentre) > 5 || strings.Trim(o.DestinationDataCentre, "0123456789") != "" {
+		return fmt.Errorf("destination data centre %q is not five digits", o.DestinationDataCentre)
+	}
+	switch strings.ToUpper(o.Currency) {
+	case "", cpa005.CurrencyCAD, cpa005.CurrencyUSD:
+	default:
+		return fmt.Errorf("currency %q is not CAD or USD", o.Currency)
+	}
+	for _, code := range []string{o.CreditTransactionType, o.DebitTransactionType} {
+		if code != "" && (len(code) != 3 || strings.Trim(code, "0123456789") != "") {
+			return fmt.Errorf("transaction type %q is not three digits", code)
+		}
+	}
+	if len(o.ReturnAccount) > 12 {
+		return fmt.Errorf("return account %q is longer than 12 characters", o.ReturnAccount)
+	}
+	return nil
+}
+
+// CPA005Exporter handles export to CPA Standard 005
+type CPA005Exporter struct {
+	*BaseExporter
+}
+
+// NewCPA005Exporter creates a new CPA 005 exporter
+func NewCPA005Exporter() *CPA005Exporter {
+	return &CPA005Exporter{
+		BaseExporter: NewBaseExporter("text/plain"),
+	}
+}
+
+// Export converts a NACHA file to CPA 005
+func (e *CPA005Exporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w in CPA 005. The credit and debit entries
+// of each batch become segments of C and D records, six to a record.
+// Receiving DFI identifications are read as the three digit institution
+// number followed by the five digit transit number.
+func (e *CPA005Exporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	originator := opts.CPA.OriginatorID
+	if originator == "" {
+		originator = strings.TrimSpace(file.Header.ImmediateOrigin)
+	}
+	if originator == "" || len(originator) > 10 {
+		return fmt.Errorf("originator ID %q is not 1 to 10 characters: %w", originator, ErrUnsupportedValue)
+	}
+
+	header := cpa005.NewRecord(cpa005.HeaderLayout)
+	header.Set("originator_id", originator)
+	header.SetInt("file_creation_number", int64(max(opts.CPA.FileCreationNumber, 1)))
+	header.SetDate("creation_date", file.Header.FileCreationDate)
+	header.Set("destination_data_centre", opts.CPA.DestinationDataCentre)
+	if opts.CPA.Currency != "" {
+		header.Set("currency", opts.CPA.Currency)
+	}
+
+	out := &cpa005.File{Header: header}
+	for i, batch := range file.Batches {
+		records, err := cpaRecords(&batch, file.Header.OriginName, opts.CPA)
+		if err != nil {
+			return fmt.Errorf("batch %d: %w", i+1, err)
+		}
+		out.Records = append(out.Records, records...)
+	}
+	if len(out.Records) == 0 {
+		return fmt.Errorf("CPA 005 needs entries: %w", ErrNoEntries)
+	}
+
+	out.Finalize()
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// cpaRecords maps the entries of a batch to C records for the credits and D
+// records for the debits. Records are not shared between batches, so the
+// batches can be told apart again on import.
+func cpaRecords(batch *models.Batch, longName string, opts CPAOptions) ([]*cpa005.Record, error) {
+	h := &batch.Header
+	date, err := time.Parse("060102", strings.TrimSpace(h.EffectiveEntryDate))
+	if err != nil {
+		return nil, fmt.Errorf("effective entry date %q is not YYMMDD: %w", h.EffectiveEntryDate, ErrUnsupportedValue)
+	}
+	returns, err := cpaInstitution(h.OriginatingDFI)
+	if err != nil {
+		return nil, fmt.Errorf("originating DFI %w", err)
+	}
+
+	credit, debit := opts.CreditTransactionType, opts.DebitTransactionType
+	if credit == "" {
+		credit = CPAMiscellaneousPayment
+		if strings.EqualFold(h.StandardEntryClass, "PPD") {
+			credit = CPAPayrollDeposit
+		}
+	}
+	if debit == "" {
+		debit = CPABusinessPAD
+	}
+
+	var records []*cpa005.Record
+	open := make(map[string]*cpa005.Record)
+	for _, entry := range batch.Entries {
+		recordType, transactionType := cpa005.TypeCredit, credit
+		switch summary.Direction(entry.TransactionCode) {
+		case summary.DirectionCredit:
+		case summary.DirectionDebit:
+			recordType, transactionType = cpa005.TypeDebit, debit
+		default:
+			return nil, fmt.Errorf("entry %s: transaction code %s is neither a credit nor a debit: %w", entry.TraceNumber, entry.TransactionCode, ErrUnsupportedValue)
+		}
+		if entry.Amount <= 0 {
+			return nil, fmt.Errorf("entry %s: CPA 005 has no zero dollar entries: %w", entry.TraceNumber, ErrUnsupportedValue)
+		}
+		institution, err := cpaInstitution(entry.ReceivingDFI)
+		if err != nil {
+			return nil, fmt.Errorf("entry %s: receiving DFI %w", entry.TraceNumber, err)
+		}
+		account := strings.TrimSpace(entry.DFIAccountNumber)
+		if account == "" || len(account) > 12 || strings.Trim(account, "0123456789") != "" {
+			return nil, fmt.Errorf("entry %s: account number %q is not 1 to 12 digits: %w", entry.TraceNumber, account, ErrUnsupportedValue)
+		}
+
+		var segment *cpa005.Record
+		if record := open[recordType]; record != nil {
+			segment = record.AddSegment()
+		}
+		if segment == nil {
+			record := cpa005.NewDetail(recordType)
+			open[recordType] = record
+			records = append(records, record)
+			segment = record.AddSegment()
+		}
+
+		segment.Set("transaction_type", transactionType)
+		segment.SetInt("amount", entry.Amount)
+		segment.SetDate("due_date", date)
+		segment.Set("institution_id", institution)
+		segment.Set("account_number", account)
+		segment.Set("originator_short_name", h.CompanyName)
+		segment.Set("payee_name", entry.IndividualName)
+		segment.Set("originator_long_name", longName)
+		segment.Set("cross_reference_number", entry.TraceNumber)
+		segment.Set("return_institution_id", returns)
+		segment.Set("return_account_number", opts.ReturnAccount)
+		segment.Set("sundry_information", entry.IndividualIDNumber)
+	}
+	return records, nil
+}
+
+// cpaInstitution turns an eight digit DFI identification into the nine
+// digit institution ID: a zero, the institution number and the transit number
+func cpaInstitution(dfi string) (string, error) {
+	dfi = strings.TrimSpace(dfi)
+	if len(dfi) != 8 || strings.Trim(dfi, "0123456789") != "" {
+		return "", fmt.Errorf("%q is not an institution and transit number: %w", dfi, ErrUnsupportedValue)
+	}
+	return "0" + dfi, nil
+}
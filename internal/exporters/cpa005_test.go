@@ -0,0 +1,88 @@
+package exporters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/pkg/cpa005"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCPA005Export(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Credits and debits of each batch become C and D records
+	data, _, err := export(t, file, "CPA005", Options{CPA: CPAOptions{
+		DestinationDataCentre: "86900",
+		FileCreationNumber:    12,
+		ReturnAccount:         "9876543",
+	}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	records := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	if !assert.Len(t, records, 5) {
+		return
+	}
+	for _, r := range records {
+		assert.Len(t, r, 1464)
+	}
+	header := records[0]
+	assert.Equal(t, "A000000001", header[0:10])
+	assert.Equal(t, "0764012512", header[10:20])
+	assert.Equal(t, "0012", header[20:24])
+	assert.Equal(t, "026290", header[24:30])
+	assert.Equal(t, "86900", header[30:35])
+	assert.Equal(t, "CAD", header[55:58])
+
+	assert.Equal(t, "D000000002", records[1][0:10])
+	assert.Equal(t, "C000000003", records[2][0:10])
+	assert.Equal(t, "C000000004", records[3][0:10])
+	acme := records[3][24:264]
+	assert.Equal(t, "450", acme[0:3])
+	assert.Equal(t, "0000510000", acme[3:13])
+	assert.Equal(t, "026293", acme[13:19])
+	assert.Equal(t, "002100002", acme[19:28])
+	assert.Equal(t, "444444", strings.TrimSpace(acme[28:40]))
+	assert.Equal(t, "OUTRA EMPRESA", strings.TrimSpace(acme[65:80]))
+	assert.Equal(t, "ACME SUPPLIES", strings.TrimSpace(acme[80:110]))
+	assert.Equal(t, "EMPRESA EXEMPLO", strings.TrimSpace(acme[110:140]))
+	assert.Equal(t, "076401250000004", strings.TrimSpace(acme[150:169]))
+	assert.Equal(t, "007640125", acme[169:178])
+	assert.Equal(t, "9876543", strings.TrimSpace(acme[178:190]))
+	assert.Equal(t, "200", records[2][24:27])
+	assert.Equal(t, "700", records[1][24:27])
+	assert.Equal(t, strings.Repeat(" ", 240), records[3][264:504])
+
+	trailer := records[4]
+	assert.Equal(t, "Z000000005", trailer[0:10])
+	assert.Equal(t, "00000000870000", trailer[24:38])
+	assert.Equal(t, "00000002", trailer[38:46])
+	assert.Equal(t, "00000001410000", trailer[46:60])
+	assert.Equal(t, "00000002", trailer[60:68])
+
+	issues, err := cpa005.Validate(data)
+	assert.NoError(t, err)
+	assert.Empty(t, issues)
+
+	// Test case 2: Records hold six segments
+	acmeEntry := file.Batches[1].Entries[0]
+	for i := 0; i < 6; i++ {
+		file.Batches[1].Entries = append(file.Batches[1].Entries, acmeEntry)
+	}
+	data, _, err = export(t, file, "CPA005", Options{})
+	if assert.NoError(t, err) {
+		records = strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+		if assert.Len(t, records, 6) {
+			assert.Equal(t, "C000000004", records[3][0:10])
+			assert.Equal(t, "C000000005", records[4][0:10])
+			assert.NotEqual(t, strings.Repeat(" ", 240), records[3][1224:1464])
+			assert.Equal(t, strings.Repeat(" ", 240), records[4][264:504])
+		}
+	}
+
+	// Test case 3: Values that do not fit the standard
+	_, _, err = export(t, testFile(t), "CPA005", Options{MaskAccountNumbers: true, MaskVisibleDigits: 2})
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+	assert.Error(t, Options{CPA: CPAOptions{Currency: "EUR"}}.Validate())
+}
@@ -1,14 +1,24 @@
 package exporters
 
 import (
-	"bytes"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"strconv"
 
 	"github.com/nacha-service/pkg/models"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:         "CSV",
+		Extension:    ".csv",
+		Description:  "Records as comma-separated rows for spreadsheets",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewCSVExporter() },
+	})
+}
+
 // CSVExporter handles export to CSV format
 type CSVExporter struct {
 	*BaseExporter
@@ -23,8 +33,17 @@ func NewCSVExporter() *CSVExporter {
 
 // Export converts a NACHA file to CSV format
 func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
-	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w in CSV format
+func (e *CSVExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+	fields := opts.fields()
+	creationDate, creationTime := opts.fileCreation(&file.Header, "2006-01-02")
+
+	writer := csv.NewWriter(w)
 
 	// Write file header
 	if err := writer.Write([]string{
@@ -42,7 +61,7 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 		"Origin Name",
 		"Reference Code",
 	}); err != nil {
-		return nil, fmt.Errorf("failed to write file header: %v", err)
+		return fmt.Errorf("failed to write file header: %v", err)
 	}
 
 	// Write file header data
@@ -51,8 +70,8 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 		file.Header.PriorityCode,
 		file.Header.ImmediateDestination,
 		file.Header.ImmediateOrigin,
-		file.Header.FileCreationDate.Format("2006-01-02"),
-		file.Header.FileCreationTime,
+		creationDate,
+		creationTime,
 		file.Header.FileIDModifier,
 		file.Header.RecordSize,
 		file.Header.BlockingFactor,
@@ -61,7 +80,7 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 		file.Header.OriginName,
 		file.Header.ReferenceCode,
 	}); err != nil {
-		return nil, fmt.Errorf("failed to write file header data: %v", err)
+		return fmt.Errorf("failed to write file header data: %v", err)
 	}
 
 	// Write batch header
@@ -74,11 +93,13 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 		"Standard Entry Class",
 		"Company Entry Description",
 		"Company Descriptive Date",
+		"Effective Entry Date",
 		"Settlement Date",
 		"Originator Status Code",
 		"Originating DFI",
+		"Batch Number",
 	}); err != nil {
-		return nil, fmt.Errorf("failed to write batch header: %v", err)
+		return fmt.Errorf("failed to write batch header: %v", err)
 	}
 
 	// Write batches
@@ -93,46 +114,32 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 			batch.Header.StandardEntryClass,
 			batch.Header.CompanyEntryDescription,
 			batch.Header.CompanyDescriptiveDate,
+			batch.Header.EffectiveEntryDate,
 			batch.Header.SettlementDate,
 			batch.Header.OriginatorStatusCode,
 			batch.Header.OriginatingDFI,
+			batch.Header.BatchNumber,
 		}); err != nil {
-			return nil, fmt.Errorf("failed to write batch header data: %v", err)
+			return fmt.Errorf("failed to write batch header data: %v", err)
 		}
 
 		// Write entry detail header
-		if err := writer.Write([]string{
-			"Record Type",
-			"Transaction Code",
-			"Receiving DFI",
-			"Check Digit",
-			"DFI Account Number",
-			"Amount",
-			"Individual ID Number",
-			"Individual Name",
-			"Discretionary Data",
-			"Addenda Record Indicator",
-			"Trace Number",
-		}); err != nil {
-			return nil, fmt.Errorf("failed to write entry detail header: %v", err)
+		header := []string{"Record Type"}
+		for _, f := range fields {
+			header = append(header, f.label)
+		}
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write entry detail header: %v", err)
 		}
 
 		// Write entries
 		for _, entry := range batch.Entries {
-			if err := writer.Write([]string{
-				"6",
-				entry.TransactionCode,
-				entry.ReceivingDFI,
-				entry.CheckDigit,
-				entry.DFIAccountNumber,
-				strconv.FormatInt(entry.Amount, 10),
-				entry.IndividualIDNumber,
-				entry.IndividualName,
-				entry.DiscretionaryData,
-				entry.AddendaRecordIndicator,
-				entry.TraceNumber,
-			}); err != nil {
-				return nil, fmt.Errorf("failed to write entry detail: %v", err)
+			row := []string{"6"}
+			for _, f := range fields {
+				row = append(row, opts.entryValue(f, &entry, centsAmount))
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write entry detail: %v", err)
 			}
 
 			// Write addenda records
@@ -144,7 +151,7 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 					addenda.AddendaSequenceNumber,
 					addenda.EntryDetailSequenceNumber,
 				}); err != nil {
-					return nil, fmt.Errorf("failed to write addenda record: %v", err)
+					return fmt.Errorf("failed to write addenda record: %v", err)
 				}
 			}
 		}
@@ -155,19 +162,19 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 			batch.Control.ServiceClassCode,
 			strconv.Itoa(batch.Control.EntryAddendaCount),
 			batch.Control.EntryHash,
-			strconv.FormatInt(batch.Control.TotalDebitAmount, 10),
-			strconv.FormatInt(batch.Control.TotalCreditAmount, 10),
+			opts.amount(batch.Control.TotalDebitAmount, centsAmount),
+			opts.amount(batch.Control.TotalCreditAmount, centsAmount),
 			batch.Control.CompanyIdentification,
 			batch.Control.OriginatingDFI,
 			batch.Control.BatchNumber,
 		}); err != nil {
-			return nil, fmt.Errorf("failed to write batch control: %v", err)
+			return fmt.Errorf("failed to write batch control: %v", err)
 		}
 
 		// Add blank line between batches
 		if i < len(file.Batches)-1 {
 			if err := writer.Write([]string{""}); err != nil {
-				return nil, fmt.Errorf("failed to write blank line: %v", err)
+				return fmt.Errorf("failed to write blank line: %v", err)
 			}
 		}
 	}
@@ -179,16 +186,16 @@ func (e *CSVExporter) Export(file *models.NachaFile) ([]byte, error) {
 		strconv.Itoa(file.Control.BlockCount),
 		strconv.Itoa(file.Control.EntryAddendaCount),
 		file.Control.EntryHash,
-		strconv.FormatInt(file.Control.TotalDebitAmount, 10),
-		strconv.FormatInt(file.Control.TotalCreditAmount, 10),
+		opts.amount(file.Control.TotalDebitAmount, centsAmount),
+		opts.amount(file.Control.TotalCreditAmount, centsAmount),
 	}); err != nil {
-		return nil, fmt.Errorf("failed to write file control: %v", err)
+		return fmt.Errorf("failed to write file control: %v", err)
 	}
 
 	writer.Flush()
 	if err := writer.Error(); err != nil {
-		return nil, fmt.Errorf("failed to flush writer: %v", err)
+		return fmt.Errorf("failed to flush writer: %v", err)
 	}
 
-	return buf.Bytes(), nil
+	return nil
 }
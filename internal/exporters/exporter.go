@@ -2,7 +2,10 @@
 package exporters
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"github.com/nacha-service/pkg/models"
@@ -12,13 +15,18 @@ import (
 type NachaExporter interface {
 	// Export converts a NACHA file to the target format
 	Export(file *models.NachaFile) ([]byte, error)
+	// ExportTo writes a NACHA file in the target format to w
+	ExportTo(w io.Writer, file *models.NachaFile) error
 	// GetContentType returns the MIME type of the exported content
 	GetContentType() string
+	// SetOptions sets the options used by later exports
+	SetOptions(options Options)
 }
 
 // BaseExporter provides common functionality for all exporters
 type BaseExporter struct {
 	contentType string
+	options     Options
 }
 
 // NewBaseExporter creates a new base exporter
@@ -42,29 +50,44 @@ func (e *BaseExporter) GetContentType() string {
 	return e.contentType
 }
 
-// CreateExporter creates an exporter based on the format.
-// Supported formats are: JSON, CSV, SQL, HTML, PDF, TXT, PARQUET
+// SetOptions sets the options used by later exports
+func (e *BaseExporter) SetOptions(options Options) {
+	e.options = options
+}
+
+// Options returns the export options
+func (e *BaseExporter) Options() Options {
+	return e.options
+}
+
+// exportBytes runs an exporter against an in-memory buffer
+func exportBytes(e NachaExporter, file *models.NachaFile) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := e.ExportTo(&buf, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// centsAmount writes an amount as integer cents
+func centsAmount(cents int64) string {
+	return strconv.FormatInt(cents, 10)
+}
+
+// dollarAmount writes an amount as dollars with a currency symbol
+func dollarAmount(cents int64) string {
+	return fmt.Sprintf("$%.2f", float64(cents)/100.0)
+}
+
+// CreateExporter creates an exporter for a registered format name
 func CreateExporter(format string) (NachaExporter, error) {
 	if format == "" {
 		return nil, fmt.Errorf("format cannot be empty")
 	}
 
-	switch strings.ToUpper(format) {
-	case "JSON":
-		return NewJSONExporter(), nil
-	case "CSV":
-		return NewCSVExporter(), nil
-	case "SQL":
-		return NewSQLExporter(), nil
-	case "HTML":
-		return NewHTMLExporter(), nil
-	case "PDF":
-		return NewPDFExporter(), nil
-	case "TXT":
-		return NewTXTExporter(), nil
-	case "PARQUET":
-		return NewParquetExporter(), nil
-	default:
-		return nil, fmt.Errorf("unsupported format: %s (supported formats: JSON, CSV, SQL, HTML, PDF, TXT, PARQUET)", format)
+	f, ok := Lookup(format)
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s (supported formats: %s)", format, strings.Join(formatNames(), ", "))
 	}
+	return f.New(), nil
 }
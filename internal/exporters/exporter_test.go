@@ -0,0 +1,138 @@
+package exporters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFile creates a NACHA file with two batches and reads it back the way
+// the service does, so exporters see the parsed records
+func testFile(t *testing.T) *models.NachaFile {
+	t.Helper()
+
+	c := creator.NewCreator()
+	file := c.CreateFile(models.FileHeader{
+		PriorityCode:         "01",
+		ImmediateDestination: "076401251",
+		ImmediateOrigin:      "0764012512",
+		FileCreationDate:     time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		FileCreationTime:     "1200",
+		FileIDModifier:       "A",
+		RecordSize:           "094",
+		BlockingFactor:       "10",
+		FormatCode:           "1",
+		DestinationName:      "BANCO DO BRASIL",
+		OriginName:           "EMPRESA EXEMPLO",
+	})
+
+	batches := []struct {
+		header  models.BatchHeader
+		entries []models.EntryDetail
+	}{
+		{
+			header: models.BatchHeader{
+				ServiceClassCode:        "200",
+				CompanyName:             "EMPRESA EXEMPLO",
+				CompanyIdentification:   "0764012512",
+				StandardEntryClass:      "PPD",
+				CompanyEntryDescription: "COBRANCA",
+				CompanyDescriptiveDate:  "261017",
+				EffectiveEntryDate:      "261019",
+				OriginatorStatusCode:    "1",
+				OriginatingDFI:          "07640125",
+			},
+			entries: []models.EntryDetail{
+				{TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "111111", Amount: 750000, IndividualName: "JOAO DA SILVA", TraceNumber: "076401250000001"},
+				{TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "222222", Amount: 120000, IndividualName: "MARIA SOUZA", TraceNumber: "076401250000002"},
+				{TransactionCode: "22", ReceivingDFI: "07640125", CheckDigit: "1", DFIAccountNumber: "333333", Amount: 900000, IndividualName: "PEDRO ALVARES", TraceNumber: "076401250000003"},
+			},
+		},
+		{
+			header: models.BatchHeader{
+				ServiceClassCode:        "220",
+				CompanyName:             "OUTRA EMPRESA",
+				CompanyIdentification:   "1234567890",
+				StandardEntryClass:      "CCD",
+				CompanyEntryDescription: "FORNECEDOR",
+				CompanyDescriptiveDate:  "261017",
+				EffectiveEntryDate:      "261020",
+				OriginatorStatusCode:    "1",
+				OriginatingDFI:          "07640125",
+			},
+			entries: []models.EntryDetail{
+				{TransactionCode: "32", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "444444", Amount: 510000, IndividualName: "ACME SUPPLIES", TraceNumber: "076401250000004",
+					AddendaRecords: []models.AddendaRecord{{AddendaTypeCode: "05", PaymentRelatedInformation: "INV-1001 INV-1002"}}},
+			},
+		},
+	}
+
+	for _, b := range batches {
+		c.AddBatch(file, b.header)
+		batch := &file.Batches[len(file.Batches)-1]
+		for _, entry := range b.entries {
+			entry.RecordType = "6"
+			entry.AddendaRecordIndicator = "0"
+			addenda := entry.AddendaRecords
+			entry.AddendaRecords = nil
+			for _, a := range addenda {
+				assert.NoError(t, c.AddAddenda(&entry, a))
+			}
+			assert.NoError(t, c.AddEntry(batch, entry))
+		}
+	}
+	assert.NoError(t, c.FinalizeFile(file))
+
+	return models.FromBytes(file.ToBytes())
+}
+
+// export runs the registered format with the given options
+func export(t *testing.T, file *models.NachaFile, format string, options Options) ([]byte, string, error) {
+	t.Helper()
+
+	exporter, err := CreateExporter(format)
+	if !assert.NoError(t, err, format) {
+		return nil, "", err
+	}
+	exporter.SetOptions(options)
+	data, err := exporter.Export(file)
+	return data, exporter.GetContentType(), err
+}
+
+// exportString runs the registered format and fails the test on errors
+func exportString(t *testing.T, file *models.NachaFile, format string, options Options) string {
+	t.Helper()
+
+	data, _, err := export(t, file, format, options)
+	assert.NoError(t, err, format)
+	return string(data)
+}
+
+func TestCreateExporter(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Names are case insensitive and ExportTo matches Export
+	for _, format := range Formats() {
+		exporter, err := CreateExporter(format.Name)
+		if !assert.NoError(t, err, format.Name) {
+			continue
+		}
+		assert.Equal(t, format.ContentType, exporter.GetContentType(), format.Name)
+	}
+	exporter, err := CreateExporter("csv")
+	assert.NoError(t, err)
+	data, err := exporter.Export(file)
+	assert.NoError(t, err)
+	streamed, err := exportBytes(exporter, file)
+	assert.NoError(t, err)
+	assert.Equal(t, data, streamed)
+
+	// Test case 2: Empty and unknown formats
+	_, err = CreateExporter("")
+	assert.Error(t, err)
+	_, err = CreateExporter("unknown")
+	assert.ErrorContains(t, err, "CSV")
+}
@@ -0,0 +1,107 @@
+package exporters
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nacha-service/pkg/fixedwidth"
+	"github.com/nacha-service/pkg/models"
+)
+
+// FixedWidthExporter renders NACHA files into a fixed-width layout declared
+// in YAML
+type FixedWidthExporter struct {
+	*BaseExporter
+	layout *fixedwidth.Layout
+}
+
+// NewFixedWidthExporter creates an exporter for a layout
+func NewFixedWidthExporter(layout *fixedwidth.Layout) *FixedWidthExporter {
+	return &FixedWidthExporter{
+		BaseExporter: NewBaseExporter("text/plain"),
+		layout:       layout,
+	}
+}
+
+// Export converts a NACHA file to the layout
+func (e *FixedWidthExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w in the layout. The layout fixes the
+// fields, amounts and dates, so only the batch restrictions and account
+// masking of the options apply.
+func (e *FixedWidthExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	data, err := e.layout.Render(e.options.apply(file))
+	if errors.Is(err, fixedwidth.ErrValueTooLong) {
+		return fmt.Errorf("%v: %w", err, ErrUnsupportedValue)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// RegisterLayout registers a fixed-width layout as an export format named
+// after the layout
+func RegisterLayout(layout *fixedwidth.Layout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	extension := layout.Extension
+	if extension == "" {
+		extension = ".txt"
+	}
+	description := layout.Description
+	if description == "" {
+		description = fmt.Sprintf("Fixed-width %s layout", layout.Name)
+	}
+	return Register(Format{
+		Name:        layout.Name,
+		Extension:   extension,
+		Description: description,
+		New:         func() NachaExporter { return NewFixedWidthExporter(layout) },
+	})
+}
+
+// LoadLayouts registers the fixed-width layouts of the .yaml and .yml files
+// of a directory and returns the names of their formats. Loading stops at the
+// first file that cannot be parsed or registered.
+func LoadLayouts(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layouts directory: %v", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	names := make([]string, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return names, fmt.Errorf("failed to read layout %s: %v", path, err)
+		}
+		layout, err := fixedwidth.Parse(data)
+		if err != nil {
+			return names, fmt.Errorf("%s: %v", path, err)
+		}
+		if err := RegisterLayout(layout); err != nil {
+			return names, fmt.Errorf("%s: %v", path, err)
+		}
+		names = append(names, strings.ToUpper(layout.Name))
+	}
+	return names, nil
+}
@@ -0,0 +1,115 @@
+package exporters
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/pkg/fixedwidth"
+	"github.com/stretchr/testify/assert"
+)
+
+// bankLayout is a fixed-width layout of payment and trailer records
+const bankLayout = `
+name: test_bank_flat
+description: Test bank flat file
+record_length: 60
+line_ending: crlf
+records:
+  - type: file_header
+    fields:
+      - {name: record_type, start: 1, length: 1, value: H}
+      - {name: origin, start: 2, length: 10, source: file.immediate_origin}
+      - {name: created, start: 12, length: 8, source: file.creation_date, format: YYYYMMDD}
+  - type: entry
+    fields:
+      - {name: record_type, start: 1, length: 1, value: D}
+      - {name: number, start: 2, length: 5, source: record.number}
+      - {name: direction, start: 7, length: 1, source: entry.direction}
+      - {name: routing, start: 8, length: 9, source: entry.routing_number}
+      - {name: account, start: 17, length: 10, source: entry.dfi_account_number, justify: right, pad: "0"}
+      - {name: amount, start: 27, length: 12, source: entry.amount, format: decimal, pad: "*"}
+      - {name: payee, start: 39, length: 10, source: entry.individual_name}
+      - {name: due, start: 49, length: 8, source: batch.effective_entry_date, format: DDMMYYYY}
+  - type: addenda
+    fields:
+      - {name: record_type, start: 1, length: 1, value: R}
+      - {name: info, start: 2, length: 40, source: addenda.payment_related_information}
+  - type: file_control
+    fields:
+      - {name: record_type, start: 1, length: 1, value: T}
+      - {name: records, start: 2, length: 5, source: file.record_count}
+      - {name: debits, start: 7, length: 12, source: file.total_debit_amount}
+      - {name: credits, start: 19, length: 12, source: file.total_credit_amount}
+`
+
+func TestFixedWidthExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: A registered layout is exported by name
+	// The registry is global, so only register once when the test is repeated
+	if _, ok := Lookup("TEST_BANK_FLAT"); !ok {
+		layout, err := fixedwidth.Parse([]byte(bankLayout))
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.NoError(t, RegisterLayout(layout))
+	}
+	data, contentType, err := export(t, file, "test_bank_flat", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "text/plain", contentType)
+	records := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	if !assert.Len(t, records, 7) {
+		return
+	}
+	for _, r := range records {
+		assert.Len(t, r, 60)
+	}
+	assert.Equal(t, "H076401251220261017", strings.TrimSpace(records[0]))
+	assert.Equal(t, "D00002D0210000210000111111*****7500.00JOAO DA SI19102026", strings.TrimSpace(records[1]))
+	assert.Equal(t, "D00005C0210000210000444444*****5100.00ACME SUPPL20102026", strings.TrimSpace(records[4]))
+	assert.Equal(t, "RINV-1001 INV-1002", strings.TrimSpace(records[5]))
+	assert.Equal(t, "T00007000000870000000001410000", strings.TrimSpace(records[6]))
+
+	// Test case 2: Batch restrictions and masking apply
+	data, _, err = export(t, file, "TEST_BANK_FLAT", Options{BatchNumbers: []string{"2"}, MaskAccountNumbers: true, MaskVisibleDigits: 2})
+	if assert.NoError(t, err) {
+		records = strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+		if assert.Len(t, records, 4) {
+			assert.Equal(t, "0000****44", records[1][16:26])
+			assert.Equal(t, "T00004000000000000000000510000", strings.TrimSpace(records[3]))
+		}
+	}
+
+	// Test case 3: Numbers that do not fit their field
+	if _, ok := Lookup("TEST_BANK_NARROW"); !ok {
+		narrow, err := fixedwidth.Parse([]byte("name: test_bank_narrow\nrecords:\n  - type: entry\n    fields:\n      - {start: 1, length: 5, source: entry.amount}\n"))
+		if assert.NoError(t, err) {
+			assert.NoError(t, RegisterLayout(narrow))
+		}
+	}
+	_, _, err = export(t, file, "TEST_BANK_NARROW", Options{})
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+
+	// Test case 4: Layouts are loaded from the YAML files of a directory
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a layout"), 0o644))
+	if _, ok := Lookup("TEST_BANK_DIR"); !ok {
+		layout := strings.Replace(bankLayout, "test_bank_flat", "test_bank_dir", 1)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "bank.yaml"), []byte(layout), 0o644))
+		names, err := LoadLayouts(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"TEST_BANK_DIR"}, names)
+	}
+	format, ok := Lookup("TEST_BANK_DIR")
+	if assert.True(t, ok) {
+		assert.Equal(t, ".txt", format.Extension)
+		assert.Equal(t, "Test bank flat file", format.Description)
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "broken.yml"), []byte("name: broken\nrecords: [{type: entry}]"), 0o644))
+	_, err = LoadLayouts(dir)
+	assert.Error(t, err)
+}
@@ -1,13 +1,26 @@
 package exporters
 
 import (
-	"bytes"
 	"fmt"
 	"html/template"
+	"io"
+	"strconv"
+	"strings"
 
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/internal/validator"
 	"github.com/nacha-service/pkg/models"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:        "HTML",
+		Extension:   ".html",
+		Description: "Self-contained interactive report for viewing in a browser",
+		New:         func() NachaExporter { return NewHTMLExporter() },
+	})
+}
+
 // HTMLExporter handles export to HTML format
 type HTMLExporter struct {
 	*BaseExporter
@@ -22,132 +35,406 @@ func NewHTMLExporter() *HTMLExporter {
 
 // Export converts a NACHA file to HTML format
 func (e *HTMLExporter) Export(file *models.NachaFile) ([]byte, error) {
-	var buf bytes.Buffer
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w as a single HTML page: totals charts by
+// SEC code and company, a collapsible section per batch with a sortable entry
+// table, a search over the entries and the validation findings highlighted on
+// the records they were found in. Styles and scripts are inline and the page
+// loads nothing else, so it can be emailed and opened offline.
+func (e *HTMLExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	report := newHTMLReport(e.options, e.options.apply(file))
+	if err := htmlTemplate.Execute(w, report); err != nil {
+		return fmt.Errorf("failed to execute template: %v", err)
+	}
+	return nil
+}
+
+// htmlReport is the view of a file rendered by the HTML template
+type htmlReport struct {
+	Header       *models.FileHeader
+	Control      *models.FileControl
+	CreationDate string
+	CreationTime string
+	TotalDebits  string
+	TotalCredits string
+	EntryCount   int
+	Columns      []htmlColumn
+	// Findings are the validation errors of the file records and
+	// FindingCount the number of findings in the whole file
+	Findings     []string
+	FindingCount int
+	Charts       []htmlChart
+	Batches      []htmlBatch
+}
+
+// htmlColumn is a column of the entry tables
+type htmlColumn struct {
+	Label   string
+	Numeric bool
+}
+
+// htmlChart is a bar chart of the debit and credit totals of groups of entries
+type htmlChart struct {
+	Title string
+	Bars  []htmlBar
+}
+
+// htmlBar is a group of a chart, with the widths of its bars in percent of
+// the largest total of the chart
+type htmlBar struct {
+	Label       string
+	EntryCount  int
+	Debits      string
+	Credits     string
+	DebitWidth  float64
+	CreditWidth float64
+}
+
+type htmlBatch struct {
+	Number        int
+	Header        *models.BatchHeader
+	Control       *models.BatchControl
+	EffectiveDate string
+	TotalDebits   string
+	TotalCredits  string
+	// Findings are the validation errors of the batch header and control and
+	// FindingCount the number of findings in the batch and its entries
+	Findings     []string
+	FindingCount int
+	Entries      []htmlEntry
+}
+
+type htmlEntry struct {
+	Number   int
+	Cells    []htmlCell
+	Addenda  []string
+	Findings []string
+}
+
+// htmlCell is a value of an entry with the key the tables sort it by
+type htmlCell struct {
+	Text    string
+	Sort    string
+	Numeric bool
+}
+
+func newHTMLReport(opts Options, file *models.NachaFile) *htmlReport {
+	money := func(cents int64) string { return opts.amount(cents, dollarAmount) }
+	fields := opts.fields()
+
+	report := &htmlReport{
+		Header:       &file.Header,
+		Control:      &file.Control,
+		TotalDebits:  money(file.Control.TotalDebitAmount),
+		TotalCredits: money(file.Control.TotalCreditAmount),
+	}
+	report.CreationDate, report.CreationTime = opts.fileCreation(&file.Header, "2006-01-02")
+	for _, f := range fields {
+		report.Columns = append(report.Columns, htmlColumn{Label: f.label, Numeric: f.name == "amount"})
+	}
+
+	for i := range file.Batches {
+		batch := &file.Batches[i]
+		view := htmlBatch{
+			Number:        i + 1,
+			Header:        &batch.Header,
+			Control:       &batch.Control,
+			EffectiveDate: opts.effectiveDate(batch.Header.EffectiveEntryDate, "2006-01-02"),
+			TotalDebits:   money(batch.Control.TotalDebitAmount),
+			TotalCredits:  money(batch.Control.TotalCreditAmount),
+		}
+		for j := range batch.Entries {
+			entry := &batch.Entries[j]
+			row := htmlEntry{Number: j + 1}
+			for _, f := range fields {
+				cell := htmlCell{Text: opts.entryValue(f, entry, dollarAmount)}
+				cell.Sort = strings.TrimSpace(cell.Text)
+				if f.name == "amount" {
+					cell.Sort, cell.Numeric = strconv.FormatInt(entry.Amount, 10), true
+				}
+				row.Cells = append(row.Cells, cell)
+			}
+			for _, addenda := range entry.AddendaRecords {
+				row.Addenda = append(row.Addenda, strings.TrimSpace(addenda.PaymentRelatedInformation))
+			}
+			view.Entries = append(view.Entries, row)
+		}
+		report.EntryCount += len(batch.Entries)
+		report.Batches = append(report.Batches, view)
+	}
 
-	// Define HTML template
-	tmpl := `<!DOCTYPE html>
-<html>
+	for _, finding := range validator.NewValidator().Findings(file) {
+		report.FindingCount++
+		message := finding.Err.Error()
+		switch {
+		case finding.Batch == 0 || finding.Batch > len(report.Batches):
+			report.Findings = append(report.Findings, message)
+		case finding.Entry == 0 || finding.Entry > len(report.Batches[finding.Batch-1].Entries):
+			batch := &report.Batches[finding.Batch-1]
+			batch.Findings = append(batch.Findings, message)
+			batch.FindingCount++
+		default:
+			batch := &report.Batches[finding.Batch-1]
+			entry := &batch.Entries[finding.Entry-1]
+			entry.Findings = append(entry.Findings, message)
+			batch.FindingCount++
+		}
+	}
+
+	s := summary.Summarize(file, 0)
+	report.Charts = []htmlChart{
+		newHTMLChart("Totals by SEC Code", s.BySECCode, money),
+		newHTMLChart("Totals by Company", s.ByCompany, money),
+	}
+	return report
+}
+
+// newHTMLChart charts the debit and credit totals of aggregates
+func newHTMLChart(title string, aggregates []summary.Aggregate, money func(int64) string) htmlChart {
+	var largest int64
+	for _, a := range aggregates {
+		largest = max(largest, a.DebitAmount, a.CreditAmount)
+	}
+	width := func(amount int64) float64 {
+		if largest == 0 {
+			return 0
+		}
+		return float64(amount*1000/largest) / 10
+	}
+
+	chart := htmlChart{Title: title}
+	for _, a := range aggregates {
+		label := strings.TrimSpace(a.Key)
+		if name := strings.TrimSpace(a.Label); name != "" {
+			label = name + " (" + label + ")"
+		}
+		chart.Bars = append(chart.Bars, htmlBar{
+			Label:       label,
+			EntryCount:  a.EntryCount,
+			Debits:      money(a.DebitAmount),
+			Credits:     money(a.CreditAmount),
+			DebitWidth:  width(a.DebitAmount),
+			CreditWidth: width(a.CreditAmount),
+		})
+	}
+	return chart
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
 <head>
-    <title>NACHA File Details</title>
+    <meta charset="utf-8">
+    <meta http-equiv="Content-Security-Policy" content="default-src 'none'; style-src 'unsafe-inline'; script-src 'unsafe-inline'">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>NACHA File Report - {{.Header.OriginName}}</title>
     <style>
-        body { font-family: Arial, sans-serif; margin: 20px; }
+        body { font-family: Arial, sans-serif; margin: 20px; color: #222; }
         h1, h2, h3 { color: #333; }
-        .section { margin: 20px 0; padding: 10px; border: 1px solid #ddd; }
-        .entry { margin: 10px 0; padding: 10px; background-color: #f9f9f9; }
-        .addenda { margin-left: 20px; padding: 5px; background-color: #f0f0f0; }
+        .section { margin: 20px 0; padding: 10px 15px; border: 1px solid #ddd; border-radius: 4px; }
+        .grid { display: grid; grid-template-columns: repeat(auto-fill, minmax(260px, 1fr)); gap: 4px 20px; }
         .label { font-weight: bold; }
-        .amount { color: #006400; }
+        .amount, .numeric { text-align: right; font-variant-numeric: tabular-nums; }
+        .findings { margin: 10px 0; padding: 8px 12px; border-left: 4px solid #c62828; background: #fdecea; }
+        .findings ul { margin: 4px 0; padding-left: 20px; }
+        .ok { margin: 10px 0; padding: 8px 12px; border-left: 4px solid #2e7d32; background: #edf7ed; }
+        .charts { display: grid; grid-template-columns: repeat(auto-fit, minmax(420px, 1fr)); gap: 20px; }
+        .bar-row { display: grid; grid-template-columns: 220px 1fr 200px; gap: 8px; align-items: center; margin: 6px 0; }
+        .bar-label { overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+        .bar { height: 9px; margin: 2px 0; min-width: 1px; }
+        .bar.debit { background: #c62828; }
+        .bar.credit { background: #2e7d32; }
+        .legend span { display: inline-block; width: 10px; height: 10px; margin: 0 4px 0 12px; }
+        .toolbar { position: sticky; top: 0; padding: 10px 0; background: #fff; border-bottom: 1px solid #ddd; z-index: 1; }
+        .toolbar input[type=search] { width: 320px; padding: 5px; }
+        details.batch { margin: 15px 0; border: 1px solid #ddd; border-radius: 4px; }
+        details.batch > summary { padding: 10px 15px; cursor: pointer; background: #f5f5f5; font-weight: bold; }
+        details.batch.has-findings > summary { background: #fdecea; }
+        details.batch > div { padding: 0 15px 10px; }
+        table { border-collapse: collapse; width: 100%; margin: 10px 0; font-size: 14px; }
+        th, td { border: 1px solid #ddd; padding: 5px 8px; text-align: left; vertical-align: top; }
+        th { background: #f0f0f0; cursor: pointer; user-select: none; white-space: nowrap; }
+        th[aria-sort=ascending]::after { content: " \25B2"; }
+        th[aria-sort=descending]::after { content: " \25BC"; }
+        tr.has-findings td { background: #fdecea; }
+        td.finding { color: #c62828; }
+        td ul { margin: 0; padding-left: 16px; }
     </style>
 </head>
 <body>
-    <h1>NACHA File Details</h1>
-    
+    <h1>NACHA File Report</h1>
+
     <div class="section">
-        <h2>File Header</h2>
-        <p><span class="label">Priority Code:</span> {{.Header.PriorityCode}}</p>
-        <p><span class="label">Immediate Destination:</span> {{.Header.ImmediateDestination}}</p>
-        <p><span class="label">Immediate Origin:</span> {{.Header.ImmediateOrigin}}</p>
-        <p><span class="label">File Creation Date:</span> {{.Header.FileCreationDate.Format "2006-01-02"}}</p>
-        <p><span class="label">File Creation Time:</span> {{.Header.FileCreationTime}}</p>
-        <p><span class="label">File ID Modifier:</span> {{.Header.FileIDModifier}}</p>
-        <p><span class="label">Record Size:</span> {{.Header.RecordSize}}</p>
-        <p><span class="label">Blocking Factor:</span> {{.Header.BlockingFactor}}</p>
-        <p><span class="label">Format Code:</span> {{.Header.FormatCode}}</p>
-        <p><span class="label">Destination Name:</span> {{.Header.DestinationName}}</p>
-        <p><span class="label">Origin Name:</span> {{.Header.OriginName}}</p>
-        <p><span class="label">Reference Code:</span> {{.Header.ReferenceCode}}</p>
+        <h2>File</h2>
+        <div class="grid">
+            <div><span class="label">Immediate Origin:</span> {{.Header.ImmediateOrigin}} {{.Header.OriginName}}</div>
+            <div><span class="label">Immediate Destination:</span> {{.Header.ImmediateDestination}} {{.Header.DestinationName}}</div>
+            <div><span class="label">File Creation:</span> {{.CreationDate}} {{.CreationTime}}</div>
+            <div><span class="label">File ID Modifier:</span> {{.Header.FileIDModifier}}</div>
+            <div><span class="label">Reference Code:</span> {{.Header.ReferenceCode}}</div>
+            <div><span class="label">Batches:</span> {{.Control.BatchCount}}</div>
+            <div><span class="label">Entries:</span> {{.EntryCount}}</div>
+            <div><span class="label">Entry/Addenda Count:</span> {{.Control.EntryAddendaCount}}</div>
+            <div><span class="label">Block Count:</span> {{.Control.BlockCount}}</div>
+            <div><span class="label">Entry Hash:</span> {{.Control.EntryHash}}</div>
+            <div><span class="label">Total Debit Amount:</span> <span class="amount">{{.TotalDebits}}</span></div>
+            <div><span class="label">Total Credit Amount:</span> <span class="amount">{{.TotalCredits}}</span></div>
+        </div>
+        {{- if .FindingCount}}
+        <div class="findings"><strong>{{.FindingCount}} validation finding(s)</strong> in this file, highlighted on the records they were found in.
+            {{- if .Findings}}
+            <ul>{{range .Findings}}<li>{{.}}</li>{{end}}</ul>
+            {{- end}}
+        </div>
+        {{- else}}
+        <div class="ok">No validation findings.</div>
+        {{- end}}
     </div>
 
-    {{range $batchIndex, $batch := .Batches}}
     <div class="section">
-        <h2>Batch {{add $batchIndex 1}}</h2>
-        <h3>Batch Header</h3>
-        <p><span class="label">Service Class Code:</span> {{.Header.ServiceClassCode}}</p>
-        <p><span class="label">Company Name:</span> {{.Header.CompanyName}}</p>
-        <p><span class="label">Company Discretionary Data:</span> {{.Header.CompanyDiscretionaryData}}</p>
-        <p><span class="label">Company Identification:</span> {{.Header.CompanyIdentification}}</p>
-        <p><span class="label">Standard Entry Class:</span> {{.Header.StandardEntryClass}}</p>
-        <p><span class="label">Company Entry Description:</span> {{.Header.CompanyEntryDescription}}</p>
-        <p><span class="label">Company Descriptive Date:</span> {{.Header.CompanyDescriptiveDate}}</p>
-        <p><span class="label">Settlement Date:</span> {{.Header.SettlementDate}}</p>
-        <p><span class="label">Originator Status Code:</span> {{.Header.OriginatorStatusCode}}</p>
-        <p><span class="label">Originating DFI:</span> {{.Header.OriginatingDFI}}</p>
-
-        <h3>Entries</h3>
-        {{range $entryIndex, $entry := .Entries}}
-        <div class="entry">
-            <h4>Entry {{add $entryIndex 1}}</h4>
-            <p><span class="label">Transaction Code:</span> {{.TransactionCode}}</p>
-            <p><span class="label">Receiving DFI:</span> {{.ReceivingDFI}}</p>
-            <p><span class="label">Check Digit:</span> {{.CheckDigit}}</p>
-            <p><span class="label">DFI Account Number:</span> {{.DFIAccountNumber}}</p>
-            <p><span class="label">Amount:</span> <span class="amount">${{formatAmount .Amount}}</span></p>
-            <p><span class="label">Individual ID Number:</span> {{.IndividualIDNumber}}</p>
-            <p><span class="label">Individual Name:</span> {{.IndividualName}}</p>
-            <p><span class="label">Discretionary Data:</span> {{.DiscretionaryData}}</p>
-            <p><span class="label">Addenda Record Indicator:</span> {{.AddendaRecordIndicator}}</p>
-            <p><span class="label">Trace Number:</span> {{.TraceNumber}}</p>
-
-            {{if .AddendaRecords}}
-            <div class="addenda">
-                <h4>Addenda Records</h4>
-                {{range $addendaIndex, $addenda := .AddendaRecords}}
-                <div>
-                    <p><span class="label">Type Code:</span> {{.AddendaTypeCode}}</p>
-                    <p><span class="label">Payment Related Information:</span> {{.PaymentRelatedInformation}}</p>
-                    <p><span class="label">Sequence Number:</span> {{.AddendaSequenceNumber}}</p>
-                    <p><span class="label">Entry Detail Sequence Number:</span> {{.EntryDetailSequenceNumber}}</p>
+        <h2>Totals</h2>
+        <div class="legend">Debits<span class="bar debit"></span> Credits<span class="bar credit"></span></div>
+        <div class="charts">
+            {{- range .Charts}}
+            <div class="chart">
+                <h3>{{.Title}}</h3>
+                {{- range .Bars}}
+                <div class="bar-row">
+                    <span class="bar-label" title="{{.Label}}">{{.Label}}</span>
+                    <div>
+                        <div class="bar debit" style="width: {{.DebitWidth}}%"></div>
+                        <div class="bar credit" style="width: {{.CreditWidth}}%"></div>
+                    </div>
+                    <span class="amount">{{.Debits}} / {{.Credits}} ({{.EntryCount}})</span>
                 </div>
-                {{end}}
+                {{- end}}
             </div>
-            {{end}}
+            {{- end}}
         </div>
-        {{end}}
-
-        <h3>Batch Control</h3>
-        <p><span class="label">Service Class Code:</span> {{.Control.ServiceClassCode}}</p>
-        <p><span class="label">Entry/Addenda Count:</span> {{.Control.EntryAddendaCount}}</p>
-        <p><span class="label">Entry Hash:</span> {{.Control.EntryHash}}</p>
-        <p><span class="label">Total Debit Amount:</span> <span class="amount">${{formatAmount .Control.TotalDebitAmount}}</span></p>
-        <p><span class="label">Total Credit Amount:</span> <span class="amount">${{formatAmount .Control.TotalCreditAmount}}</span></p>
-        <p><span class="label">Company Identification:</span> {{.Control.CompanyIdentification}}</p>
-        <p><span class="label">Originating DFI:</span> {{.Control.OriginatingDFI}}</p>
-        <p><span class="label">Batch Number:</span> {{.Control.BatchNumber}}</p>
     </div>
-    {{end}}
 
-    <div class="section">
-        <h2>File Control</h2>
-        <p><span class="label">Batch Count:</span> {{.Control.BatchCount}}</p>
-        <p><span class="label">Block Count:</span> {{.Control.BlockCount}}</p>
-        <p><span class="label">Entry/Addenda Count:</span> {{.Control.EntryAddendaCount}}</p>
-        <p><span class="label">Entry Hash:</span> {{.Control.EntryHash}}</p>
-        <p><span class="label">Total Debit Amount:</span> <span class="amount">${{formatAmount .Control.TotalDebitAmount}}</span></p>
-        <p><span class="label">Total Credit Amount:</span> <span class="amount">${{formatAmount .Control.TotalCreditAmount}}</span></p>
+    <div class="toolbar">
+        <input type="search" id="search" placeholder="Search entries" aria-label="Search entries">
+        <label><input type="checkbox" id="only-findings"> Only findings</label>
+        <button type="button" id="expand">Expand all</button>
+        <button type="button" id="collapse">Collapse all</button>
+        <span id="matches"></span>
     </div>
-</body>
-</html>`
-
-	// Create template with functions
-	t := template.New("nacha").Funcs(template.FuncMap{
-		"add": func(a, b int) int {
-			return a + b
-		},
-		"formatAmount": func(amount int64) string {
-			return fmt.Sprintf("%.2f", float64(amount)/100.0)
-		},
-	})
 
-	// Parse template
-	t, err := t.Parse(tmpl)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse template: %v", err)
-	}
+    {{- range .Batches}}
+    <details class="batch{{if .FindingCount}} has-findings{{end}}"{{if .FindingCount}} open{{end}}>
+        <summary>Batch {{.Header.BatchNumber}} - {{.Header.CompanyName}} - {{.Header.StandardEntryClass}} {{.Header.CompanyEntryDescription}} - {{len .Entries}} entries, debits {{.TotalDebits}}, credits {{.TotalCredits}}{{if .FindingCount}} - {{.FindingCount}} finding(s){{end}}</summary>
+        <div>
+            <div class="grid">
+                <div><span class="label">Service Class Code:</span> {{.Header.ServiceClassCode}}</div>
+                <div><span class="label">Company Identification:</span> {{.Header.CompanyIdentification}}</div>
+                <div><span class="label">Company Discretionary Data:</span> {{.Header.CompanyDiscretionaryData}}</div>
+                <div><span class="label">Company Descriptive Date:</span> {{.Header.CompanyDescriptiveDate}}</div>
+                <div><span class="label">Effective Entry Date:</span> {{.EffectiveDate}}</div>
+                <div><span class="label">Originating DFI:</span> {{.Header.OriginatingDFI}}</div>
+                <div><span class="label">Entry/Addenda Count:</span> {{.Control.EntryAddendaCount}}</div>
+                <div><span class="label">Entry Hash:</span> {{.Control.EntryHash}}</div>
+            </div>
+            {{- if .Findings}}
+            <div class="findings"><ul>{{range .Findings}}<li>{{.}}</li>{{end}}</ul></div>
+            {{- end}}
+            <table class="entries">
+                <thead>
+                    <tr>
+                        <th data-type="number">#</th>
+                        {{- range $.Columns}}
+                        <th{{if .Numeric}} data-type="number" class="numeric"{{end}}>{{.Label}}</th>
+                        {{- end}}
+                        <th>Addenda</th>
+                        <th>Findings</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{- range .Entries}}
+                    <tr class="entry{{if .Findings}} has-findings{{end}}">
+                        <td class="numeric" data-sort="{{.Number}}">{{.Number}}</td>
+                        {{- range .Cells}}
+                        <td{{if .Numeric}} class="numeric"{{end}} data-sort="{{.Sort}}">{{.Text}}</td>
+                        {{- end}}
+                        <td>{{if .Addenda}}<ul>{{range .Addenda}}<li>{{.}}</li>{{end}}</ul>{{end}}</td>
+                        <td class="finding">{{if .Findings}}<ul>{{range .Findings}}<li>{{.}}</li>{{end}}</ul>{{end}}</td>
+                    </tr>
+                    {{- end}}
+                </tbody>
+            </table>
+        </div>
+    </details>
+    {{- end}}
 
-	// Execute template
-	if err := t.Execute(&buf, file); err != nil {
-		return nil, fmt.Errorf("failed to execute template: %v", err)
-	}
+    <script>
+    (function () {
+        var search = document.getElementById("search");
+        var onlyFindings = document.getElementById("only-findings");
+        var matches = document.getElementById("matches");
+        var batches = Array.prototype.slice.call(document.querySelectorAll("details.batch"));
 
-	return buf.Bytes(), nil
-}
+        function filter() {
+            var query = search.value.trim().toLowerCase();
+            var filtering = query !== "" || onlyFindings.checked;
+            var shown = 0, total = 0;
+            batches.forEach(function (batch) {
+                var found = 0;
+                Array.prototype.forEach.call(batch.querySelectorAll("tr.entry"), function (row) {
+                    var match = row.textContent.toLowerCase().indexOf(query) >= 0 &&
+                        (!onlyFindings.checked || row.classList.contains("has-findings"));
+                    row.hidden = !match;
+                    total++;
+                    if (match) {
+                        found++;
+                    }
+                });
+                shown += found;
+                var keep = found > 0 || (onlyFindings.checked && query === "" && batch.classList.contains("has-findings"));
+                batch.hidden = filtering && !keep;
+                if (filtering && keep) {
+                    batch.open = true;
+                }
+            });
+            matches.textContent = filtering ? shown + " of " + total + " entries" : "";
+        }
+
+        function sort(th) {
+            var table = th.closest("table");
+            var body = table.tBodies[0];
+            var index = Array.prototype.indexOf.call(th.parentNode.children, th);
+            var numeric = th.getAttribute("data-type") === "number";
+            var ascending = th.getAttribute("aria-sort") !== "ascending";
+            Array.prototype.forEach.call(th.parentNode.children, function (other) {
+                other.removeAttribute("aria-sort");
+            });
+            th.setAttribute("aria-sort", ascending ? "ascending" : "descending");
+
+            var rows = Array.prototype.slice.call(body.rows);
+            rows.sort(function (a, b) {
+                var x = a.cells[index].getAttribute("data-sort") || a.cells[index].textContent;
+                var y = b.cells[index].getAttribute("data-sort") || b.cells[index].textContent;
+                var order = numeric ? Number(x) - Number(y) : x.localeCompare(y);
+                return ascending ? order : -order;
+            });
+            rows.forEach(function (row) {
+                body.appendChild(row);
+            });
+        }
+
+        search.addEventListener("input", filter);
+        onlyFindings.addEventListener("change", filter);
+        document.getElementById("expand").addEventListener("click", function () {
+            batches.forEach(function (batch) { batch.open = true; });
+        });
+        document.getElementById("collapse").addEventListener("click", function () {
+            batches.forEach(function (batch) { batch.open = false; });
+        });
+        Array.prototype.forEach.call(document.querySelectorAll("table.entries th"), function (th) {
+            th.addEventListener("click", function () { sort(th); });
+        });
+    })();
+    </script>
+</body>
+</html>
+`))
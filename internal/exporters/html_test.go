@@ -0,0 +1,57 @@
+package exporters
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLReport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: A single page that loads nothing from outside
+	data, contentType, err := export(t, file, "HTML", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "text/html", contentType)
+	page := string(data)
+	assert.Contains(t, page, `content="default-src 'none'; style-src 'unsafe-inline'; script-src 'unsafe-inline'"`)
+	assert.NotRegexp(t, `(?i)\b(src|href)=|https?:|@import|url\(`, page)
+
+	// Test case 2: Collapsible batches with sortable and searchable entry tables
+	assert.Equal(t, 2, strings.Count(page, `<details class="batch">`))
+	assert.Equal(t, 4, strings.Count(page, `<tr class="entry">`))
+	assert.Contains(t, page, `<th data-type="number" class="numeric">Amount</th>`)
+	assert.Contains(t, page, `<td class="numeric" data-sort="750000">$7500.00</td>`)
+	assert.Contains(t, page, `<input type="search" id="search"`)
+	assert.Contains(t, page, "<li>INV-1001 INV-1002</li>")
+	assert.Contains(t, page, "No validation findings.")
+
+	// Test case 3: Totals charts by SEC code and company, scaled to the largest total
+	assert.Contains(t, page, "<h3>Totals by SEC Code</h3>")
+	assert.Contains(t, page, "<h3>Totals by Company</h3>")
+	assert.Contains(t, page, `title="EMPRESA EXEMPLO (0764012512)"`)
+	assert.Contains(t, page, `<div class="bar credit" style="width: 100%"></div>`)
+	assert.Contains(t, page, `<div class="bar debit" style="width: 96.6%"></div>`)
+	assert.Contains(t, page, "$0.00 / $5100.00 (1)")
+
+	// Test case 4: Findings highlighted on their entry and batch, values escaped
+	file.Batches[1].Entries[0].IndividualName = ""
+	file.Batches[1].Entries[0].DiscretionaryData = "<script>x</script>"
+	file.Batches[1].Control.EntryAddendaCount = 9
+	data, err = NewHTMLExporter().Export(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	page = string(data)
+	assert.Contains(t, page, `<details class="batch has-findings" open>`)
+	assert.Contains(t, page, "2 finding(s)</summary>")
+	assert.Equal(t, 1, strings.Count(page, `<tr class="entry has-findings">`))
+	assert.Contains(t, page, `<td class="finding"><ul><li>individual name is required</li></ul></td>`)
+	assert.Contains(t, page, "<li>batch control entry count mismatch: expected 2, got 9</li>")
+	assert.NotContains(t, page, "No validation findings.")
+	assert.Contains(t, page, "&lt;script&gt;x&lt;/script&gt;")
+	assert.NotContains(t, page, "<script>x")
+}
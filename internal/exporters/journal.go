@@ -0,0 +1,381 @@
+package exporters
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:        "GL_CSV",
+		Extension:   ".csv",
+		Description: "General-ledger journal lines per batch, balanced by offsets, as CSV",
+		New:         func() NachaExporter { return NewGLCSVExporter() },
+	})
+	MustRegister(Format{
+		Name:        "GL_IIF",
+		Extension:   ".iif",
+		Description: "General-ledger journal entries per batch for QuickBooks, as IIF",
+		New:         func() NachaExporter { return NewGLIIFExporter() },
+	})
+}
+
+// ErrControlMismatch is returned by the journal exporters when the entries of
+// a batch do not add up to its batch control totals
+var ErrControlMismatch = errors.New("journal does not match the batch control")
+
+// Default accounts of journal exports
+const (
+	DefaultGLDebitAccount  = "ACH Receivable"
+	DefaultGLCreditAccount = "ACH Payable"
+	DefaultGLOffsetAccount = "ACH Clearing"
+)
+
+// GLOptions holds the chart of accounts of general-ledger journal exports
+type GLOptions struct {
+	// Rules map entries to accounts. The rule matching the most of company
+	// identification, SEC code and transaction code wins, and the first of
+	// them when several match as many.
+	Rules []GLAccountRule
+	// DebitAccount is the account of debit entries no rule maps, ACH
+	// Receivable by default
+	DebitAccount string
+	// CreditAccount is the account of credit entries no rule maps, ACH
+	// Payable by default
+	CreditAccount string
+	// OffsetAccount is the account of the offset lines that balance each
+	// batch, ACH Clearing by default
+	OffsetAccount string
+}
+
+// GLAccountRule maps the entries it matches to an account. Empty criteria
+// match every entry.
+type GLAccountRule struct {
+	CompanyID       string
+	SECCode         string
+	TransactionCode string
+	// Account is the account of the matching entries
+	Account string
+	// OffsetAccount replaces the offset account for the matching entries
+	OffsetAccount string
+}
+
+func (o GLOptions) validate() error {
+	for i, rule := range o.Rules {
+		if strings.TrimSpace(rule.Account) == "" {
+			return fmt.Errorf("rule %d has no account", i+1)
+		}
+		code := strings.TrimSpace(rule.TransactionCode)
+		if code != "" && (len(code) != 2 || strings.Trim(code, "0123456789") != "") {
+			return fmt.Errorf("transaction code %q of rule %d is not two digits", rule.TransactionCode, i+1)
+		}
+		if err := glAccountName(rule.Account); err != nil {
+			return err
+		}
+		if err := glAccountName(rule.OffsetAccount); err != nil {
+			return err
+		}
+	}
+	for _, account := range []string{o.DebitAccount, o.CreditAccount, o.OffsetAccount} {
+		if err := glAccountName(account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// glAccountName rejects account names that would break the IIF and CSV rows
+func glAccountName(account string) error {
+	if strings.ContainsAny(account, "\t\r\n") {
+		return fmt.Errorf("account %q has tabs or line breaks", account)
+	}
+	return nil
+}
+
+// accounts returns the account of an entry and the account of its offset
+func (o GLOptions) accounts(header *models.BatchHeader, entry *models.EntryDetail, direction string) (string, string) {
+	account, offset := o.CreditAccount, o.OffsetAccount
+	if account == "" {
+		account = DefaultGLCreditAccount
+	}
+	if direction == summary.DirectionDebit {
+		account = o.DebitAccount
+		if account == "" {
+			account = DefaultGLDebitAccount
+		}
+	}
+	if offset == "" {
+		offset = DefaultGLOffsetAccount
+	}
+
+	best := -1
+	var match GLAccountRule
+	for _, rule := range o.Rules {
+		score := 0
+		for _, criterion := range [][2]string{
+			{rule.CompanyID, header.CompanyIdentification},
+			{rule.SECCode, header.StandardEntryClass},
+			{rule.TransactionCode, entry.TransactionCode},
+		} {
+			want := strings.TrimSpace(criterion[0])
+			if want == "" {
+				continue
+			}
+			if !strings.EqualFold(want, strings.TrimSpace(criterion[1])) {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > best {
+			best, match = score, rule
+		}
+	}
+	if best >= 0 {
+		account = strings.TrimSpace(match.Account)
+		if match.OffsetAccount != "" {
+			offset = strings.TrimSpace(match.OffsetAccount)
+		}
+	}
+	return account, offset
+}
+
+// journal is the balanced journal entry of a batch
+type journal struct {
+	id    string
+	date  time.Time
+	batch *models.Batch
+	lines []journalLine
+}
+
+// journalLine debits or credits an account. Offset lines have no entry.
+type journalLine struct {
+	account string
+	debit   int64
+	credit  int64
+	entry   *models.EntryDetail
+	memo    string
+}
+
+// journals returns a journal per batch with money movements. Debit entries
+// credit their account and credit entries debit it; for each offset account
+// one line debits the debit entries and another credits the credit entries,
+// so each journal balances. The entry totals are checked against the batch
+// control totals.
+func journals(file *models.NachaFile, gl GLOptions) ([]journal, error) {
+	var result []journal
+	for i := range file.Batches {
+		batch := &file.Batches[i]
+		description := strings.TrimSpace(strings.TrimSpace(batch.Header.CompanyName) + " " + strings.TrimSpace(batch.Header.CompanyEntryDescription))
+
+		type offset struct{ debit, credit int64 }
+		offsets := make(map[string]*offset)
+		var order []string
+		var entries []journalLine
+		var debits, credits int64
+		for j := range batch.Entries {
+			entry := &batch.Entries[j]
+			direction := summary.Direction(entry.TransactionCode)
+			if direction == summary.DirectionOther || entry.Amount == 0 {
+				continue
+			}
+
+			account, offsetAccount := gl.accounts(&batch.Header, entry, direction)
+			o, ok := offsets[offsetAccount]
+			if !ok {
+				o = &offset{}
+				offsets[offsetAccount] = o
+				order = append(order, offsetAccount)
+			}
+			line := journalLine{account: account, entry: entry, memo: description}
+			if direction == summary.DirectionDebit {
+				line.credit = entry.Amount
+				o.debit += entry.Amount
+				debits += entry.Amount
+			} else {
+				line.debit = entry.Amount
+				o.credit += entry.Amount
+				credits += entry.Amount
+			}
+			entries = append(entries, line)
+		}
+
+		number := strings.TrimSpace(batch.Header.BatchNumber)
+		if debits != batch.Control.TotalDebitAmount {
+			return nil, fmt.Errorf("batch %s debit entries total %s, the batch control %s: %w",
+				number, decimalNumber(debits), decimalNumber(batch.Control.TotalDebitAmount), ErrControlMismatch)
+		}
+		if credits != batch.Control.TotalCreditAmount {
+			return nil, fmt.Errorf("batch %s credit entries total %s, the batch control %s: %w",
+				number, decimalNumber(credits), decimalNumber(batch.Control.TotalCreditAmount), ErrControlMismatch)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		j := journal{
+			id:    file.Header.FileCreationDate.Format("20060102") + file.Header.FileIDModifier + "-" + strings.TrimLeft(number, "0"),
+			date:  postingDate(file, batch),
+			batch: batch,
+		}
+		for _, account := range order {
+			o := offsets[account]
+			if o.debit != 0 {
+				j.lines = append(j.lines, journalLine{account: account, debit: o.debit, memo: description + " debit entries"})
+			}
+			if o.credit != 0 {
+				j.lines = append(j.lines, journalLine{account: account, credit: o.credit, memo: description + " credit entries"})
+			}
+		}
+		j.lines = append(j.lines, entries...)
+		result = append(result, j)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("a journal needs payment entries: %w", ErrNoEntries)
+	}
+	return result, nil
+}
+
+// GLCSVExporter handles export of the file as general-ledger journal lines in CSV
+type GLCSVExporter struct {
+	*BaseExporter
+}
+
+// NewGLCSVExporter creates a new journal CSV exporter
+func NewGLCSVExporter() *GLCSVExporter {
+	return &GLCSVExporter{
+		BaseExporter: NewBaseExporter("text/csv"),
+	}
+}
+
+// Export converts a NACHA file to journal CSV
+func (e *GLCSVExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a journal line per row to w: the offset lines of each batch
+// followed by a line per entry. Amounts are decimal unless another amount
+// format is requested, and dates YYYY-MM-DD unless a date format is.
+func (e *GLCSVExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	entries, err := journals(file, opts.GL)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"Journal", "Date", "Account", "Debit", "Credit", "Memo",
+		"Company ID", "SEC Code", "Batch Number", "Name", "Trace Number",
+	}); err != nil {
+		return fmt.Errorf("failed to write journal header: %v", err)
+	}
+
+	amount := func(cents int64) string {
+		if cents == 0 {
+			return ""
+		}
+		return opts.amount(cents, decimalNumber)
+	}
+	for _, j := range entries {
+		for _, line := range j.lines {
+			var name, trace string
+			if line.entry != nil {
+				name = strings.TrimSpace(line.entry.IndividualName)
+				trace = strings.TrimSpace(line.entry.TraceNumber)
+			}
+			if err := writer.Write([]string{
+				j.id,
+				opts.date(j.date, "2006-01-02"),
+				line.account,
+				amount(line.debit),
+				amount(line.credit),
+				line.memo,
+				strings.TrimSpace(j.batch.Header.CompanyIdentification),
+				strings.TrimSpace(j.batch.Header.StandardEntryClass),
+				strings.TrimSpace(j.batch.Header.BatchNumber),
+				name,
+				trace,
+			}); err != nil {
+				return fmt.Errorf("failed to write journal line: %v", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush writer: %v", err)
+	}
+	return nil
+}
+
+// GLIIFExporter handles export of the file as QuickBooks IIF journal entries
+type GLIIFExporter struct {
+	*BaseExporter
+}
+
+// NewGLIIFExporter creates a new journal IIF exporter
+func NewGLIIFExporter() *GLIIFExporter {
+	return &GLIIFExporter{
+		BaseExporter: NewBaseExporter("text/plain"),
+	}
+}
+
+// Export converts a NACHA file to IIF
+func (e *GLIIFExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes each batch journal to w as an IIF general journal
+// transaction: a TRNS row for the first line and SPL rows for the others,
+// with debits positive and credits negative. QuickBooks reads fixed dates and
+// amounts, so the formatting options do not apply.
+func (e *GLIIFExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	entries, err := journals(file, opts.GL)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprint(out, "!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n")
+	fmt.Fprint(out, "!SPL\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n")
+	fmt.Fprint(out, "!ENDTRNS\n")
+	for _, j := range entries {
+		for i, line := range j.lines {
+			row := "SPL"
+			if i == 0 {
+				row = "TRNS"
+			}
+			memo := line.memo
+			if line.entry != nil {
+				memo = strings.TrimSpace(line.entry.IndividualName) + " " + strings.TrimSpace(line.entry.TraceNumber)
+			}
+			fmt.Fprintf(out, "%s\tGENERAL JOURNAL\t%s\t%s\t%s\t%s\t%s\n",
+				row, j.date.Format("01/02/2006"), line.account, decimalNumber(line.debit-line.credit), j.id, iifText(memo))
+		}
+		fmt.Fprint(out, "ENDTRNS\n")
+	}
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write IIF: %v", err)
+	}
+	return nil
+}
+
+// iifText replaces the tabs and line breaks that would end an IIF field
+func iifText(s string) string {
+	return strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace(s)
+}
@@ -0,0 +1,133 @@
+package exporters
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGLJournalExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Balanced journal lines per batch with the default accounts
+	data, contentType, err := export(t, file, "GL_CSV", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "text/csv", contentType)
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"Journal", "Date", "Account", "Debit", "Credit", "Memo", "Company ID", "SEC Code", "Batch Number", "Name", "Trace Number"}, rows[0])
+	assert.Len(t, rows, 8)
+	assert.Equal(t, []string{"20261017A-1", "2026-10-19", "ACH Clearing", "8700.00", "", "EMPRESA EXEMPLO COBRANCA debit entries", "0764012512", "PPD", "0000001", "", ""}, rows[1])
+	assert.Equal(t, []string{"20261017A-1", "2026-10-19", "ACH Receivable", "", "7500.00", "EMPRESA EXEMPLO COBRANCA", "0764012512", "PPD", "0000001", "JOAO DA SILVA", "076401250000001"}, rows[3])
+	assert.Equal(t, []string{"20261017A-2", "2026-10-20", "ACH Payable", "5100.00", "", "OUTRA EMPRESA FORNECEDOR", "1234567890", "CCD", "0000002", "ACME SUPPLIES", "076401250000004"}, rows[7])
+	balance := make(map[string]float64)
+	for _, row := range rows[1:] {
+		debit, _ := strconv.ParseFloat(row[3], 64)
+		credit, _ := strconv.ParseFloat(row[4], 64)
+		balance[row[0]] += debit - credit
+	}
+	assert.Equal(t, map[string]float64{"20261017A-1": 0, "20261017A-2": 0}, balance)
+
+	// Test case 2: Chart of accounts rules, the most specific winning
+	data, _, err = export(t, file, "GL_CSV", Options{
+		AmountFormat: AmountCents,
+		GL: GLOptions{
+			OffsetAccount: "1010 Operating",
+			Rules: []GLAccountRule{
+				{SECCode: "ppd", Account: "1200 Receivables"},
+				{SECCode: "PPD", TransactionCode: "22", Account: "2100 Refunds"},
+				{CompanyID: "1234567890", Account: "2000 Vendors", OffsetAccount: "1020 Vendor Clearing"},
+			},
+		},
+	})
+	if assert.NoError(t, err) {
+		rows, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if assert.NoError(t, err) && assert.Len(t, rows, 8) {
+			assert.Equal(t, []string{"1010 Operating", "870000", ""}, rows[1][2:5])
+			assert.Equal(t, []string{"1010 Operating", "", "900000"}, rows[2][2:5])
+			assert.Equal(t, "1200 Receivables", rows[3][2])
+			assert.Equal(t, "1200 Receivables", rows[4][2])
+			assert.Equal(t, []string{"2100 Refunds", "900000", ""}, rows[5][2:5])
+			assert.Equal(t, []string{"1020 Vendor Clearing", "", "510000"}, rows[6][2:5])
+			assert.Equal(t, "2000 Vendors", rows[7][2])
+		}
+	}
+
+	// Test case 3: QuickBooks IIF general journal transactions
+	data, _, err = export(t, file, "GL_IIF", Options{})
+	if assert.NoError(t, err) {
+		document := string(data)
+		assert.True(t, strings.HasPrefix(document, "!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n!SPL\t"))
+		assert.Equal(t, 2, strings.Count(document, "\nTRNS\t"))
+		assert.Equal(t, 3, strings.Count(document, "ENDTRNS\n"))
+		assert.Contains(t, document, "TRNS\tGENERAL JOURNAL\t10/20/2026\tACH Clearing\t-5100.00\t20261017A-2\tOUTRA EMPRESA FORNECEDOR credit entries\n"+
+			"SPL\tGENERAL JOURNAL\t10/20/2026\tACH Payable\t5100.00\t20261017A-2\tACME SUPPLIES 076401250000004\nENDTRNS\n")
+	}
+
+	// Test case 4: Journals that do not match the batch control fail
+	file.Batches[1].Control.TotalCreditAmount++
+	_, err = NewGLIIFExporter().Export(file)
+	assert.ErrorIs(t, err, ErrControlMismatch)
+
+	// Test case 5: A 27 debit and a 22 credit balance against the clearing account
+	c := creator.NewCreator()
+	mixed := c.CreateFile(file.Header)
+	c.AddBatch(mixed, file.Batches[0].Header)
+	for _, entry := range []models.EntryDetail{
+		{RecordType: "6", TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "222222", Amount: 120000, IndividualName: "MARIA SOUZA", AddendaRecordIndicator: "0", TraceNumber: "076401250000001"},
+		{RecordType: "6", TransactionCode: "22", ReceivingDFI: "07640125", CheckDigit: "1", DFIAccountNumber: "333333", Amount: 900000, IndividualName: "PEDRO ALVARES", AddendaRecordIndicator: "0", TraceNumber: "076401250000002"},
+	} {
+		assert.NoError(t, c.AddEntry(&mixed.Batches[0], entry))
+	}
+	assert.NoError(t, c.FinalizeFile(mixed))
+	assert.Equal(t, int64(120000), mixed.Batches[0].Control.TotalDebitAmount)
+	assert.Equal(t, int64(900000), mixed.Batches[0].Control.TotalCreditAmount)
+	data, err = NewGLCSVExporter().Export(mixed)
+	if assert.NoError(t, err) {
+		rows, err = csv.NewReader(bytes.NewReader(data)).ReadAll()
+		if assert.NoError(t, err) && assert.Len(t, rows, 5) {
+			assert.Equal(t, []string{"ACH Clearing", "1200.00", ""}, rows[1][2:5])
+			assert.Equal(t, []string{"ACH Clearing", "", "9000.00"}, rows[2][2:5])
+			assert.Equal(t, []string{"ACH Receivable", "", "1200.00", "MARIA SOUZA"}, []string{rows[3][2], rows[3][3], rows[3][4], rows[3][9]})
+			assert.Equal(t, []string{"ACH Payable", "9000.00", "", "PEDRO ALVARES"}, []string{rows[4][2], rows[4][3], rows[4][4], rows[4][9]})
+			var debits, credits float64
+			for _, row := range rows[1:] {
+				debit, _ := strconv.ParseFloat(row[3], 64)
+				credit, _ := strconv.ParseFloat(row[4], 64)
+				debits += debit
+				credits += credit
+			}
+			assert.Equal(t, 10200.0, debits)
+			assert.Equal(t, debits, credits)
+		}
+	}
+	data, err = NewGLIIFExporter().Export(mixed)
+	if assert.NoError(t, err) {
+		var total int64
+		for _, line := range strings.Split(string(data), "\n") {
+			fields := strings.Split(line, "\t")
+			if len(fields) > 4 && (fields[0] == "TRNS" || fields[0] == "SPL") {
+				cents, err := strconv.ParseInt(strings.Replace(fields[4], ".", "", 1), 10, 64)
+				assert.NoError(t, err)
+				total += cents
+			}
+		}
+		assert.Zero(t, total)
+		assert.Contains(t, string(data), "\tACH Payable\t9000.00\t")
+		assert.Contains(t, string(data), "\tACH Receivable\t-1200.00\t")
+	}
+
+	// Test case 6: Invalid chart of accounts
+	assert.Error(t, Options{GL: GLOptions{Rules: []GLAccountRule{{SECCode: "PPD"}}}}.Validate())
+	assert.Error(t, Options{GL: GLOptions{OffsetAccount: "Bank\tAccount"}}.Validate())
+}
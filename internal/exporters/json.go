@@ -1,11 +1,25 @@
 package exporters
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 
 	"github.com/nacha-service/pkg/models"
+	"github.com/nacha-service/pkg/nachajson"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:         "JSON",
+		Extension:    ".json",
+		Description:  "Complete file structure as indented JSON in the versioned NACHA JSON schema",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewJSONExporter() },
+	})
+}
+
 // JSONExporter handles export to JSON format
 type JSONExporter struct {
 	*BaseExporter
@@ -20,5 +34,123 @@ func NewJSONExporter() *JSONExporter {
 
 // Export converts a NACHA file to JSON format
 func (e *JSONExporter) Export(file *models.NachaFile) ([]byte, error) {
-	return json.MarshalIndent(file, "", "  ")
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w in the versioned JSON schema of
+// pkg/nachajson. Dates are always ISO 8601, so the date format option does
+// not apply.
+func (e *JSONExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+	doc := nachajson.FromFileIn(file, opts.Location)
+
+	// Without field selection or amounts in cents the document is written as is
+	if len(opts.Fields) == 0 && opts.AmountFormat != AmountCents {
+		return writeJSON(w, doc)
+	}
+	return writeJSON(w, jsonFile(file, doc, opts))
+}
+
+// jsonObject is a JSON object that keeps its keys in order
+type jsonObject []jsonField
+
+type jsonField struct {
+	Key   string
+	Value interface{}
+}
+
+// MarshalJSON writes the fields in order
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonFile builds the JSON document of a file with the selected entry fields
+// and amount format. The layout matches the plain export, whose keys are the
+// entry field names.
+func jsonFile(file *models.NachaFile, doc *nachajson.File, opts Options) jsonObject {
+	amount := func(cents int64) interface{} {
+		if opts.AmountFormat == AmountCents {
+			return cents
+		}
+		return nachajson.Amount(cents)
+	}
+
+	batches := make([]jsonObject, 0, len(file.Batches))
+	for i, batch := range file.Batches {
+		entries := make([]jsonObject, 0, len(batch.Entries))
+		for j, entry := range batch.Entries {
+			var object jsonObject
+			for _, f := range opts.fields() {
+				if f.value == nil {
+					object = append(object, jsonField{f.name, amount(entry.Amount)})
+				} else {
+					object = append(object, jsonField{f.name, f.value(&entry)})
+				}
+			}
+			object = append(object, jsonField{"addenda", doc.Batches[i].Entries[j].Addenda})
+			entries = append(entries, object)
+		}
+
+		c := doc.Batches[i].Control
+		batches = append(batches, jsonObject{
+			{"header", doc.Batches[i].Header},
+			{"entries", entries},
+			{"control", jsonObject{
+				{"service_class_code", c.ServiceClassCode},
+				{"entry_addenda_count", c.EntryAddendaCount},
+				{"entry_hash", c.EntryHash},
+				{"total_debit_amount", amount(int64(c.TotalDebitAmount))},
+				{"total_credit_amount", amount(int64(c.TotalCreditAmount))},
+				{"company_identification", c.CompanyIdentification},
+				{"message_authentication_code", c.MessageAuthenticationCode},
+				{"originating_dfi", c.OriginatingDFI},
+				{"batch_number", c.BatchNumber},
+			}},
+		})
+	}
+
+	c := doc.Control
+	return jsonObject{
+		{"schema_version", doc.SchemaVersion},
+		{"file_header", doc.Header},
+		{"batches", batches},
+		{"file_control", jsonObject{
+			{"batch_count", c.BatchCount},
+			{"block_count", c.BlockCount},
+			{"entry_addenda_count", c.EntryAddendaCount},
+			{"entry_hash", c.EntryHash},
+			{"total_debit_amount", amount(int64(c.TotalDebitAmount))},
+			{"total_credit_amount", amount(int64(c.TotalCreditAmount))},
+		}},
+	}
+}
+
+// writeJSON encodes v as indented JSON
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %v", err)
+	}
+	return nil
 }
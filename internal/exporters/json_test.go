@@ -0,0 +1,38 @@
+package exporters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nacha-service/pkg/nachajson"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: The export is valid against the published schema
+	schema, err := jsonschema.CompileString("nacha-file.json", string(nachajson.Schema))
+	if !assert.NoError(t, err) {
+		return
+	}
+	data, contentType, err := export(t, file, "JSON", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/json", contentType)
+	var document interface{}
+	assert.NoError(t, json.Unmarshal(data, &document))
+	assert.NoError(t, schema.Validate(document))
+
+	// Test case 2: Dates, amounts and controls
+	var doc nachajson.File
+	if assert.NoError(t, json.Unmarshal(data, &doc)) && assert.Len(t, doc.Batches, 2) {
+		assert.Equal(t, "2026-10-19", doc.Batches[0].Header.EffectiveEntryDate)
+		assert.Equal(t, nachajson.Amount(750000), doc.Batches[0].Entries[0].Amount)
+		if assert.NotNil(t, doc.Control) {
+			assert.Equal(t, 2, doc.Control.BatchCount)
+		}
+	}
+}
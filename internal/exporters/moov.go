@@ -0,0 +1,46 @@
+package exporters
+
+import (
+	"io"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/nacha-service/pkg/moovjson"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "MOOV_JSON",
+		Extension:    ".json",
+		Description:  "Complete file structure in the JSON layout of moov-io/ach",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewMoovJSONExporter() },
+	})
+}
+
+// MoovJSONExporter handles export to the JSON layout of moov-io/ach
+type MoovJSONExporter struct {
+	*BaseExporter
+}
+
+// NewMoovJSONExporter creates a new moov-io/ach JSON exporter
+func NewMoovJSONExporter() *MoovJSONExporter {
+	return &MoovJSONExporter{
+		BaseExporter: NewBaseExporter("application/json"),
+	}
+}
+
+// Export converts a NACHA file to the moov-io/ach JSON layout
+func (e *MoovJSONExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w in the moov-io/ach JSON layout. The layout
+// fixes the fields, amounts and dates, so only the batch restrictions and
+// account masking apply.
+func (e *MoovJSONExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	doc, err := moovjson.FromFile(e.options.apply(file))
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, doc)
+}
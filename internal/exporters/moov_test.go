@@ -0,0 +1,59 @@
+package exporters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoovJSONExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Field mapping of the moov-io/ach layout
+	data, _, err := export(t, file, "MOOV_JSON", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	var doc map[string]interface{}
+	if !assert.NoError(t, json.Unmarshal(data, &doc)) {
+		return
+	}
+	header := doc["fileHeader"].(map[string]interface{})
+	assert.Equal(t, "076401251", header["immediateDestination"])
+	assert.Equal(t, "261017", header["fileCreationDate"])
+	assert.Equal(t, "1200", header["fileCreationTime"])
+	assert.Equal(t, "BANCO DO BRASIL", header["immediateDestinationName"])
+
+	batches := doc["batches"].([]interface{})
+	assert.Len(t, batches, 2)
+	batch := batches[1].(map[string]interface{})
+	batchHeader := batch["batchHeader"].(map[string]interface{})
+	assert.Equal(t, float64(220), batchHeader["serviceClassCode"])
+	assert.Equal(t, "CCD", batchHeader["standardEntryClassCode"])
+	assert.Equal(t, "07640125", batchHeader["ODFIIdentification"])
+	assert.Equal(t, float64(2), batchHeader["batchNumber"])
+
+	entry := batch["entryDetails"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(32), entry["transactionCode"])
+	assert.Equal(t, "02100002", entry["RDFIIdentification"])
+	assert.Equal(t, "1", entry["checkDigit"])
+	assert.Equal(t, "444444", entry["DFIAccountNumber"])
+	assert.Equal(t, float64(510000), entry["amount"])
+	assert.Equal(t, "ACME SUPPLIES", entry["individualName"])
+	assert.Equal(t, float64(1), entry["addendaRecordIndicator"])
+	assert.Equal(t, "076401250000004", entry["traceNumber"])
+	addenda := entry["addenda05"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "05", addenda["typeCode"])
+	assert.Equal(t, "INV-1001 INV-1002", addenda["paymentRelatedInformation"])
+	assert.Equal(t, float64(1), addenda["sequenceNumber"])
+
+	batchControl := batch["batchControl"].(map[string]interface{})
+	assert.Equal(t, float64(2100002), batchControl["entryHash"])
+	assert.Equal(t, float64(510000), batchControl["totalCredit"])
+	assert.Equal(t, float64(2), batchControl["batchNumber"])
+	fileControl := doc["fileControl"].(map[string]interface{})
+	assert.Equal(t, float64(2), fileControl["batchCount"])
+	assert.Equal(t, float64(870000), fileControl["totalDebit"])
+	assert.Equal(t, float64(1410000), fileControl["totalCredit"])
+}
@@ -0,0 +1,196 @@
+package exporters
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:        "OFX",
+		Extension:   ".ofx",
+		Description: "OFX 2.2 bank statement with a transaction per entry, for accounting software",
+		New:         func() NachaExporter { return NewOFXExporter() },
+	})
+}
+
+// ofxHeader is the OFX 2 processing instruction that follows the XML declaration
+const ofxHeader = `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+// OFXExporter handles export of the entries to an OFX bank statement
+type OFXExporter struct {
+	*BaseExporter
+}
+
+// NewOFXExporter creates a new OFX exporter
+func NewOFXExporter() *OFXExporter {
+	return &OFXExporter{
+		BaseExporter: NewBaseExporter("application/x-ofx"),
+	}
+}
+
+// Export converts the entries of a NACHA file to OFX
+func (e *OFXExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes the entries of a NACHA file to w as OFX 2.2. Each
+// originator, told apart by originating DFI and company identification,
+// gets a bank statement whose account is the company identification at the
+// originating DFI, with a transaction for each entry. The ledger balance is
+// the net of the transactions, since the file carries no balances.
+func (e *OFXExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	transactions := statementTransactions(file)
+	if len(transactions) == 0 {
+		return fmt.Errorf("OFX needs payment entries: %w", ErrNoEntries)
+	}
+
+	created := opts.fileCreationTime(&file.Header)
+	document := ofxDocument{
+		SignOn: ofxSignOn{
+			Status:     ofxStatusOK,
+			ServerDate: created.Format("20060102150405"),
+			Language:   "ENG",
+		},
+	}
+
+	statements := make(map[ofxAccount]*ofxStatement)
+	var order []ofxAccount
+	for _, t := range transactions {
+		account := ofxAccount{
+			BankID:      abaRoutingNumber(t.batch.Header.OriginatingDFI),
+			AccountID:   strings.TrimSpace(t.batch.Header.CompanyIdentification),
+			AccountType: "CHECKING",
+		}
+		statement, ok := statements[account]
+		if !ok {
+			statement = &ofxStatement{Currency: "USD", Account: account}
+			statements[account] = statement
+			order = append(order, account)
+		}
+		statement.Transactions.Transactions = append(statement.Transactions.Transactions, ofxTransaction{
+			Type:   ofxTransactionType(t.direction),
+			Posted: t.posted.Format("20060102"),
+			Amount: decimalNumber(t.amount),
+			ID:     t.id,
+			Name:   painText(t.name, 32),
+			Memo:   painText(t.memo, 255),
+		})
+		statement.balance += t.amount
+		posted := t.posted.Format("20060102")
+		if statement.Transactions.Start == "" || posted < statement.Transactions.Start {
+			statement.Transactions.Start = posted
+		}
+		if posted > statement.Transactions.End {
+			statement.Transactions.End = posted
+		}
+	}
+
+	prefix := created.Format("20060102150405") + file.Header.FileIDModifier
+	for i, account := range order {
+		statement := statements[account]
+		statement.LedgerBalance = ofxBalance{
+			Amount: decimalNumber(statement.balance),
+			AsOf:   statement.Transactions.End,
+		}
+		document.Statements = append(document.Statements, ofxStatementResponse{
+			TransactionID: prefix + "-" + strconv.Itoa(i+1),
+			Status:        ofxStatusOK,
+			Statement:     *statement,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header+ofxHeader); err != nil {
+		return fmt.Errorf("failed to write OFX: %v", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to write OFX: %v", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write OFX: %v", err)
+	}
+	return nil
+}
+
+// ofxTransactionType is CREDIT for money into the account of the originator
+// and DEBIT for money out of it
+func ofxTransactionType(direction string) string {
+	switch direction {
+	case summary.DirectionDebit:
+		return "CREDIT"
+	case summary.DirectionCredit:
+		return "DEBIT"
+	default:
+		return "OTHER"
+	}
+}
+
+var ofxStatusOK = ofxStatus{Code: 0, Severity: "INFO"}
+
+type ofxDocument struct {
+	XMLName    xml.Name               `xml:"OFX"`
+	SignOn     ofxSignOn              `xml:"SIGNONMSGSRSV1>SONRS"`
+	Statements []ofxStatementResponse `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxSignOn struct {
+	Status     ofxStatus `xml:"STATUS"`
+	ServerDate string    `xml:"DTSERVER"`
+	Language   string    `xml:"LANGUAGE"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxStatementResponse struct {
+	TransactionID string       `xml:"TRNUID"`
+	Status        ofxStatus    `xml:"STATUS"`
+	Statement     ofxStatement `xml:"STMTRS"`
+}
+
+type ofxStatement struct {
+	Currency      string             `xml:"CURDEF"`
+	Account       ofxAccount         `xml:"BANKACCTFROM"`
+	Transactions  ofxTransactionList `xml:"BANKTRANLIST"`
+	LedgerBalance ofxBalance         `xml:"LEDGERBAL"`
+	balance       int64
+}
+
+type ofxAccount struct {
+	BankID      string `xml:"BANKID"`
+	AccountID   string `xml:"ACCTID"`
+	AccountType string `xml:"ACCTTYPE"`
+}
+
+type ofxTransactionList struct {
+	Start        string           `xml:"DTSTART"`
+	End          string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	ID     string `xml:"FITID"`
+	Name   string `xml:"NAME,omitempty"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
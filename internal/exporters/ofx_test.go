@@ -0,0 +1,125 @@
+package exporters
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOFXQIFExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: An OFX statement per originator with signed transactions
+	data, contentType, err := export(t, file, "OFX", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/x-ofx", contentType)
+	document := string(data)
+	assert.True(t, strings.HasPrefix(document, xml.Header+`<?OFX OFXHEADER="200" VERSION="220"`))
+	var ofx struct {
+		Server     string `xml:"SIGNONMSGSRSV1>SONRS>DTSERVER"`
+		Statements []struct {
+			BankID       string `xml:"STMTRS>BANKACCTFROM>BANKID"`
+			AccountID    string `xml:"STMTRS>BANKACCTFROM>ACCTID"`
+			Balance      string `xml:"STMTRS>LEDGERBAL>BALAMT"`
+			Transactions []struct {
+				Type   string `xml:"TRNTYPE"`
+				Posted string `xml:"DTPOSTED"`
+				Amount string `xml:"TRNAMT"`
+				ID     string `xml:"FITID"`
+				Name   string `xml:"NAME"`
+				Memo   string `xml:"MEMO"`
+			} `xml:"STMTRS>BANKTRANLIST>STMTTRN"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS"`
+	}
+	if !assert.NoError(t, xml.Unmarshal(data, &ofx)) {
+		return
+	}
+	assert.Equal(t, "20261017120000", ofx.Server)
+	if assert.Len(t, ofx.Statements, 2) {
+		assert.Equal(t, "076401251", ofx.Statements[0].BankID)
+		assert.Equal(t, "1234567890", ofx.Statements[1].AccountID)
+		assert.Equal(t, "-300.00", ofx.Statements[0].Balance)
+		assert.Len(t, ofx.Statements[0].Transactions, 3)
+		first := ofx.Statements[0].Transactions[0]
+		assert.Equal(t, "CREDIT", first.Type)
+		assert.Equal(t, "20261019", first.Posted)
+		assert.Equal(t, "7500.00", first.Amount)
+		assert.Equal(t, "20261019076401250000001", first.ID)
+		assert.Equal(t, "JOAO DA SILVA", first.Name)
+		payment := ofx.Statements[1].Transactions[0]
+		assert.Equal(t, "DEBIT", payment.Type)
+		assert.Equal(t, "-5100.00", payment.Amount)
+		assert.Equal(t, "20261020076401250000004", payment.ID)
+		assert.Equal(t, "FORNECEDOR INV-1001 INV-1002", payment.Memo)
+	}
+
+	// Test case 2: QIF with the same amounts and IDs
+	data, contentType, err = export(t, file, "QIF", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/qif", contentType)
+	document = string(data)
+	assert.True(t, strings.HasPrefix(document, "!Type:Bank\n"))
+	assert.Equal(t, 4, strings.Count(document, "^\n"))
+	assert.Contains(t, document, "D10/20/2026\nT-5100.00\nN20261020076401250000004\nPACME SUPPLIES\nMFORNECEDOR INV-1001 INV-1002\n^\n")
+
+	// Test case 3: Options select the batches and the date format
+	data, _, err = export(t, file, "QIF", Options{BatchNumbers: []string{"1"}, DateFormat: "DD/MM/YYYY"})
+	if assert.NoError(t, err) {
+		document = string(data)
+		assert.Equal(t, 3, strings.Count(document, "^\n"))
+		assert.Contains(t, document, "D19/10/2026\nT-9000.00\n")
+		assert.NotContains(t, document, "ACME")
+	}
+
+	// Test case 4: Prenotes are left out and shared trace numbers told apart
+	file.Batches[0].Entries[0].TransactionCode = "28"
+	file.Batches[0].Entries[2].TraceNumber = file.Batches[0].Entries[1].TraceNumber
+	data, err = NewQIFExporter().Export(file)
+	if assert.NoError(t, err) {
+		document = string(data)
+		assert.Equal(t, 3, strings.Count(document, "^\n"))
+		assert.NotContains(t, document, "JOAO DA SILVA")
+		assert.Contains(t, document, "N20261019076401250000002\n")
+		assert.Contains(t, document, "N20261019076401250000002-2\n")
+	}
+	for i := range file.Batches {
+		for j := range file.Batches[i].Entries {
+			file.Batches[i].Entries[j].TransactionCode = "33"
+		}
+	}
+	_, err = NewOFXExporter().Export(file)
+	assert.ErrorIs(t, err, ErrNoEntries)
+
+	// Test case 5: A 27 debit is money in and a 22 credit money out for the originator
+	file = testFile(t)
+	file.Batches = file.Batches[:1]
+	file.Batches[0].Entries = file.Batches[0].Entries[1:]
+	assert.Equal(t, "27", file.Batches[0].Entries[0].TransactionCode)
+	assert.Equal(t, "22", file.Batches[0].Entries[1].TransactionCode)
+	ofx.Statements = nil
+	data, err = NewOFXExporter().Export(file)
+	if assert.NoError(t, err) && assert.NoError(t, xml.Unmarshal(data, &ofx)) && assert.Len(t, ofx.Statements, 1) {
+		statement := ofx.Statements[0]
+		assert.Equal(t, "-7800.00", statement.Balance)
+		if assert.Len(t, statement.Transactions, 2) {
+			assert.Equal(t, "CREDIT", statement.Transactions[0].Type)
+			assert.Equal(t, "1200.00", statement.Transactions[0].Amount)
+			assert.Equal(t, "DEBIT", statement.Transactions[1].Type)
+			assert.Equal(t, "-9000.00", statement.Transactions[1].Amount)
+		}
+	}
+	data, err = NewQIFExporter().Export(file)
+	if assert.NoError(t, err) {
+		document = string(data)
+		assert.Contains(t, document, "T1200.00\nN20261019076401250000002\nPMARIA SOUZA\n")
+		assert.Contains(t, document, "T-9000.00\nN20261019076401250000003\nPPEDRO ALVARES\n")
+	}
+	_, _, err = export(t, testFile(t), "OFX", Options{SECCodes: []string{"WEB"}})
+	assert.ErrorIs(t, err, ErrNoEntries)
+}
@@ -0,0 +1,410 @@
+package exporters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// AmountFormat selects how amounts are written
+type AmountFormat int
+
+const (
+	// AmountDefault keeps the exporter's own amount format
+	AmountDefault AmountFormat = iota
+	// AmountCents writes amounts as integer cents
+	AmountCents
+	// AmountDecimal writes amounts as decimal currency units
+	AmountDecimal
+)
+
+// SQLDialect selects the database the SQL export is written for
+type SQLDialect int
+
+const (
+	// SQLDialectPostgreSQL writes SQL for PostgreSQL
+	SQLDialectPostgreSQL SQLDialect = iota
+	// SQLDialectMySQL writes SQL for MySQL and MariaDB
+	SQLDialectMySQL
+	// SQLDialectSQLite writes SQL for SQLite
+	SQLDialectSQLite
+	// SQLDialectSQLServer writes SQL for Microsoft SQL Server
+	SQLDialectSQLServer
+)
+
+// Supported locales for decimal amounts
+const (
+	LocaleEnUS = "en-US" // 1,234.56
+	LocalePtBR = "pt-BR" // 1.234,56
+)
+
+// DefaultMaskVisibleDigits is the number of account number characters left
+// visible when masking
+const DefaultMaskVisibleDigits = 4
+
+// Options controls the content and formatting of an export. The zero value
+// exports every field of every batch in the exporter's own layout.
+type Options struct {
+	// Fields selects and orders the entry fields, see EntryFields
+	Fields []string
+	// MaskAccountNumbers replaces all but the last characters of DFI account numbers
+	MaskAccountNumbers bool
+	// MaskVisibleDigits is the number of characters left visible when masking
+	MaskVisibleDigits int
+	// BatchNumbers restricts the export to the given batches
+	BatchNumbers []string
+	// SECCodes restricts the export to batches with the given standard entry classes
+	SECCodes []string
+	// AmountFormat selects cents or decimal amounts
+	AmountFormat AmountFormat
+	// Locale selects the separators of decimal amounts
+	Locale string
+	// DateFormat is a pattern such as YYYY-MM-DD or DD/MM/YYYY
+	DateFormat string
+	// Location is the timezone the file creation date and time are shown in.
+	// The file creation timestamp is taken as UTC.
+	Location *time.Location
+	// SQLDialect selects the database of SQL exports
+	SQLDialect SQLDialect
+	// SkipSQLSchema leaves the CREATE TABLE statements out of SQL exports
+	SkipSQLSchema bool
+	// CNAB holds the company values of CNAB 240 exports
+	CNAB CNABOptions
+	// CPA holds the originator values of CPA 005 exports
+	CPA CPAOptions
+	// Template selects the template of TEMPLATE exports
+	Template TemplateOptions
+	// GL holds the chart of accounts of journal exports
+	GL GLOptions
+	// Pain holds the originator accounts of pain.001 and pain.008 exports
+	Pain PainOptions
+	// Parquet holds the source of the rows of PARQUET exports
+	Parquet ParquetOptions
+}
+
+// entryField is a selectable entry field
+type entryField struct {
+	name  string
+	label string
+	value func(entry *models.EntryDetail) string
+}
+
+// entryFields lists the entry fields in their default order
+var entryFields = []entryField{
+	{"transaction_code", "Transaction Code", func(e *models.EntryDetail) string { return e.TransactionCode }},
+	{"receiving_dfi", "Receiving DFI", func(e *models.EntryDetail) string { return e.ReceivingDFI }},
+	{"check_digit", "Check Digit", func(e *models.EntryDetail) string { return e.CheckDigit }},
+	{"dfi_account_number", "DFI Account Number", func(e *models.EntryDetail) string { return e.DFIAccountNumber }},
+	{"amount", "Amount", nil},
+	{"individual_id_number", "Individual ID Number", func(e *models.EntryDetail) string { return e.IndividualIDNumber }},
+	{"individual_name", "Individual Name", func(e *models.EntryDetail) string { return e.IndividualName }},
+	{"discretionary_data", "Discretionary Data", func(e *models.EntryDetail) string { return e.DiscretionaryData }},
+	{"addenda_record_indicator", "Addenda Record Indicator", func(e *models.EntryDetail) string { return e.AddendaRecordIndicator }},
+	{"trace_number", "Trace Number", func(e *models.EntryDetail) string { return e.TraceNumber }},
+}
+
+// EntryFields returns the names of the selectable entry fields in their default order
+func EntryFields() []string {
+	names := make([]string, len(entryFields))
+	for i, f := range entryFields {
+		names[i] = f.name
+	}
+	return names
+}
+
+// Validate checks the options for unknown fields, locales and date patterns
+func (o Options) Validate() error {
+	seen := make(map[string]bool)
+	for _, name := range o.Fields {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := lookupEntryField(name); !ok {
+			return fmt.Errorf("unknown field: %s (supported fields: %s)", name, strings.Join(EntryFields(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate field: %s", name)
+		}
+		seen[name] = true
+	}
+
+	if o.MaskVisibleDigits < 0 {
+		return fmt.Errorf("invalid mask visible digits: %d", o.MaskVisibleDigits)
+	}
+
+	switch o.AmountFormat {
+	case AmountDefault, AmountCents, AmountDecimal:
+	default:
+		return fmt.Errorf("invalid amount format: %d", o.AmountFormat)
+	}
+
+	if _, ok := sqlDialects[o.SQLDialect]; !ok {
+		return fmt.Errorf("invalid SQL dialect: %d", o.SQLDialect)
+	}
+
+	switch o.Locale {
+	case "", LocaleEnUS, LocalePtBR:
+	default:
+		return fmt.Errorf("unsupported locale: %s (supported locales: %s, %s)", o.Locale, LocaleEnUS, LocalePtBR)
+	}
+
+	if o.DateFormat != "" && dateLayout(o.DateFormat) == o.DateFormat {
+		return fmt.Errorf("invalid date format: %s (use YYYY, YY, MM, DD, HH, mm and ss)", o.DateFormat)
+	}
+
+	if err := o.CPA.validate(); err != nil {
+		return fmt.Errorf("invalid CPA 005 options: %v", err)
+	}
+
+	if err := o.Template.validate(); err != nil {
+		return fmt.Errorf("invalid template options: %v", err)
+	}
+
+	if err := o.GL.validate(); err != nil {
+		return fmt.Errorf("invalid GL options: %v", err)
+	}
+
+	if err := o.Pain.validate(); err != nil {
+		return fmt.Errorf("invalid pain options: %v", err)
+	}
+
+	return nil
+}
+
+func lookupEntryField(name string) (entryField, bool) {
+	for _, f := range entryFields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	return entryField{}, false
+}
+
+// fields returns the selected entry fields, or every field when none is selected
+func (o Options) fields() []entryField {
+	if len(o.Fields) == 0 {
+		return entryFields
+	}
+
+	fields := make([]entryField, 0, len(o.Fields))
+	for _, name := range o.Fields {
+		if f, ok := lookupEntryField(strings.ToLower(strings.TrimSpace(name))); ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// entryValue returns the text of an entry field. native formats amounts when
+// no amount format is requested.
+func (o Options) entryValue(f entryField, entry *models.EntryDetail, native func(int64) string) string {
+	if f.value == nil {
+		return o.amount(entry.Amount, native)
+	}
+	return f.value(entry)
+}
+
+// amount formats an amount in cents. native is used when no amount format is requested.
+func (o Options) amount(cents int64, native func(int64) string) string {
+	switch o.AmountFormat {
+	case AmountCents:
+		return strconv.FormatInt(cents, 10)
+	case AmountDecimal:
+		return o.decimal(cents)
+	default:
+		return native(cents)
+	}
+}
+
+// decimal formats an amount in cents as currency units with the locale's separators
+func (o Options) decimal(cents int64) string {
+	thousands, point := ",", "."
+	if o.Locale == LocalePtBR {
+		thousands, point = ".", ","
+	}
+
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	units := strconv.FormatInt(cents/100, 10)
+	var grouped strings.Builder
+	for i, digit := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			grouped.WriteString(thousands)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return fmt.Sprintf("%s%s%s%02d", sign, grouped.String(), point, cents%100)
+}
+
+// decimalNumber formats an amount in cents as a plain decimal number, without
+// thousands separators and with a decimal point
+func decimalNumber(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// fileCreation returns the file creation date and time, shown in the
+// requested timezone and date format. nativeLayout is used when no date format
+// is requested.
+func (o Options) fileCreation(header *models.FileHeader, nativeLayout string) (string, string) {
+	if o.Location == nil {
+		return o.date(header.FileCreationDate, nativeLayout), header.FileCreationTime
+	}
+
+	timestamp := o.fileCreationTime(header)
+	return o.date(timestamp, nativeLayout), timestamp.Format("1504")
+}
+
+// fileCreationTime returns the file creation date and time as a timestamp in
+// the requested timezone, or in UTC
+func (o Options) fileCreationTime(header *models.FileHeader) time.Time {
+	timestamp := header.FileCreationDate
+	if t, err := time.Parse("1504", header.FileCreationTime); err == nil {
+		timestamp = timestamp.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+	if o.Location != nil {
+		timestamp = timestamp.In(o.Location)
+	}
+	return timestamp
+}
+
+// date formats a date with the requested date format
+func (o Options) date(t time.Time, nativeLayout string) string {
+	if o.DateFormat == "" {
+		return t.Format(nativeLayout)
+	}
+	return t.Format(dateLayout(o.DateFormat))
+}
+
+// effectiveDate formats a YYMMDD batch date with the requested date format,
+// or leaves it as it is when it is not a date
+func (o Options) effectiveDate(value string, nativeLayout string) string {
+	date, err := time.Parse("060102", strings.TrimSpace(value))
+	if err != nil {
+		return value
+	}
+	return o.date(date, nativeLayout)
+}
+
+// dateLayout converts a pattern such as DD/MM/YYYY to a Go time layout
+func dateLayout(pattern string) string {
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MM", "01",
+		"DD", "02",
+		"HH", "15",
+		"mm", "04",
+		"ss", "05",
+	).Replace(pattern)
+}
+
+// apply returns the part of a file selected by the options, with account
+// numbers masked. The input file is not modified, since it may be shared.
+func (o Options) apply(file *models.NachaFile) *models.NachaFile {
+	filtered := len(o.BatchNumbers) > 0 || len(o.SECCodes) > 0
+	if !filtered && !o.MaskAccountNumbers {
+		return file
+	}
+
+	result := &models.NachaFile{
+		Header:  file.Header,
+		Control: file.Control,
+	}
+
+	for _, batch := range file.Batches {
+		if !o.selectBatch(&batch.Header) {
+			continue
+		}
+		if o.MaskAccountNumbers {
+			entries := make([]models.EntryDetail, len(batch.Entries))
+			for i, entry := range batch.Entries {
+				entry.DFIAccountNumber = o.mask(entry.DFIAccountNumber)
+				entries[i] = entry
+			}
+			batch.Entries = entries
+		}
+		result.Batches = append(result.Batches, batch)
+	}
+
+	if filtered {
+		result.Control = fileControl(file.Control, result.Batches)
+	}
+	return result
+}
+
+// selectBatch checks a batch against the batch number and SEC code restrictions
+func (o Options) selectBatch(header *models.BatchHeader) bool {
+	if len(o.SECCodes) > 0 && !models.ContainsFold(o.SECCodes, header.StandardEntryClass) {
+		return false
+	}
+	if len(o.BatchNumbers) == 0 {
+		return true
+	}
+	for _, number := range o.BatchNumbers {
+		if sameBatchNumber(number, header.BatchNumber) {
+			return true
+		}
+	}
+	return false
+}
+
+// mask replaces all but the last characters of an account number
+func (o Options) mask(account string) string {
+	visible := o.MaskVisibleDigits
+	if visible == 0 {
+		visible = DefaultMaskVisibleDigits
+	}
+
+	account = strings.TrimSpace(account)
+	if len(account) <= visible {
+		return account
+	}
+	return strings.Repeat("*", len(account)-visible) + account[len(account)-visible:]
+}
+
+// fileControl recomputes the file control totals for a subset of batches
+func fileControl(control models.FileControl, batches []models.Batch) models.FileControl {
+	control.BatchCount = len(batches)
+	control.EntryAddendaCount = 0
+	control.TotalDebitAmount = 0
+	control.TotalCreditAmount = 0
+
+	var hash int64
+	for _, batch := range batches {
+		control.EntryAddendaCount += batch.Control.EntryAddendaCount
+		control.TotalDebitAmount += batch.Control.TotalDebitAmount
+		control.TotalCreditAmount += batch.Control.TotalCreditAmount
+		if h, err := strconv.ParseInt(strings.TrimSpace(batch.Control.EntryHash), 10, 64); err == nil {
+			hash += h
+		}
+	}
+	control.EntryHash = fmt.Sprintf("%010d", hash%10000000000)
+
+	// File header and control, plus a header and control per batch
+	records := 2 + 2*len(batches) + control.EntryAddendaCount
+	control.BlockCount = (records + models.BlockingFactor - 1) / models.BlockingFactor
+
+	return control
+}
+
+// sameBatchNumber compares batch numbers, ignoring leading zeros
+func sameBatchNumber(a, b string) bool {
+	a, b = strings.TrimSpace(a), strings.TrimSpace(b)
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return x == y
+	}
+	return a == b
+}
@@ -0,0 +1,83 @@
+package exporters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Field selection, masking and pt-BR decimal amounts
+	csv := exportString(t, file, "CSV", Options{
+		Fields:             []string{"trace_number", "amount", "dfi_account_number"},
+		MaskAccountNumbers: true,
+		MaskVisibleDigits:  2,
+		AmountFormat:       AmountDecimal,
+		Locale:             LocalePtBR,
+	})
+	assert.Contains(t, csv, "Record Type,Trace Number,Amount,DFI Account Number\n")
+	assert.Contains(t, csv, "6,076401250000001,\"7.500,00\",****11\n")
+	assert.NotContains(t, csv, "111111")
+	assert.NotContains(t, csv, "JOAO DA SILVA")
+
+	txt := exportString(t, file, "TXT", Options{AmountFormat: AmountDecimal})
+	assert.Contains(t, txt, "Amount: 7,500.00\n")
+
+	// Test case 2: Batch restrictions recompute the file totals
+	json := exportString(t, file, "JSON", Options{SECCodes: []string{"ccd"}})
+	assert.Contains(t, json, "ACME SUPPLIES")
+	assert.NotContains(t, json, "JOAO DA SILVA")
+	assert.Contains(t, json, "\"batch_count\": 1")
+
+	txt = exportString(t, file, "TXT", Options{BatchNumbers: []string{"1"}})
+	assert.Contains(t, txt, "JOAO DA SILVA")
+	assert.NotContains(t, txt, "ACME SUPPLIES")
+
+	// Test case 3: Date format and timezone
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	if assert.NoError(t, err) {
+		txt = exportString(t, file, "TXT", Options{DateFormat: "DD/MM/YYYY", Location: location})
+		assert.Contains(t, txt, "File Creation Date: 17/10/2026\n")
+		assert.Contains(t, txt, "File Creation Time: 0900\n")
+	}
+
+	// Test case 4: SQL and JSON keep numeric amounts
+	sql := exportString(t, file, "SQL", Options{
+		Fields:       []string{"trace_number", "amount"},
+		AmountFormat: AmountDecimal,
+		Locale:       LocalePtBR,
+	})
+	assert.Contains(t, sql, "amount NUMERIC(12,2)")
+	assert.Contains(t, sql, "'076401250000004',\n    5100.00\n")
+	assert.NotContains(t, sql, "individual_name")
+
+	json = exportString(t, file, "JSON", Options{Fields: []string{"amount"}, AmountFormat: AmountDecimal})
+	assert.Contains(t, json, "\"amount\": \"7500.00\"")
+	assert.NotContains(t, json, "individual_name")
+
+	// Test case 5: The export leaves the file untouched
+	assert.Len(t, file.Batches, 2)
+	assert.Equal(t, "111111", file.Batches[0].Entries[0].DFIAccountNumber)
+}
+
+func TestOptionsValidate(t *testing.T) {
+	// Test case 1: Valid options
+	assert.NoError(t, Options{}.Validate())
+	assert.NoError(t, Options{Fields: []string{" Amount ", "trace_number"}, Locale: LocalePtBR, DateFormat: "YYYY/MM/DD"}.Validate())
+
+	// Test case 2: Invalid options
+	for _, invalid := range []Options{
+		{Fields: []string{"unknown"}},
+		{Fields: []string{"amount", "amount"}},
+		{Locale: "fr-FR"},
+		{DateFormat: "tomorrow"},
+		{MaskVisibleDigits: -1},
+		{AmountFormat: 99},
+		{SQLDialect: 99},
+	} {
+		assert.Error(t, invalid.Validate(), "%+v", invalid)
+	}
+}
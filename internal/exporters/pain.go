@@ -0,0 +1,417 @@
+package exporters
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/iso20022"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "PAIN001",
+		Extension:    ".xml",
+		Description:  "ISO 20022 pain.001.001.03 credit transfers for the credit entries",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewPain001Exporter() },
+	})
+	MustRegister(Format{
+		Name:         "PAIN008",
+		Extension:    ".xml",
+		Description:  "ISO 20022 pain.008.001.02 direct debits for the debit entries",
+		Capabilities: Capabilities{Importable: true},
+		New:          func() NachaExporter { return NewPain008Exporter() },
+	})
+}
+
+// PainOptions holds the originator accounts of pain.001 and pain.008 exports.
+// NACHA files do not carry the originator's account, so one is required for
+// every company with entries to export.
+type PainOptions struct {
+	// CompanyAccount is the originator's account, the debtor account of
+	// pain.001 and the creditor account of pain.008
+	CompanyAccount string
+	// CompanyAccounts maps company identifications to their accounts, for
+	// files with batches of several companies. Companies that are not listed
+	// use CompanyAccount.
+	CompanyAccounts map[string]string
+}
+
+func (o PainOptions) validate() error {
+	accounts := []string{o.CompanyAccount}
+	for _, account := range o.CompanyAccounts {
+		accounts = append(accounts, account)
+	}
+	for _, account := range accounts {
+		if len(strings.TrimSpace(account)) > 34 {
+			return fmt.Errorf("company account %q is longer than 34 characters", account)
+		}
+	}
+	for company, account := range o.CompanyAccounts {
+		if strings.TrimSpace(account) == "" {
+			return fmt.Errorf("company %q has no account", company)
+		}
+	}
+	return nil
+}
+
+// ErrNoEntries is returned by exporters that only export part of the entries
+// when a file has none of them
+var ErrNoEntries = errors.New("no entries to export")
+
+// Pain001Exporter handles export of the credit entries to ISO 20022 pain.001
+type Pain001Exporter struct {
+	*BaseExporter
+}
+
+// NewPain001Exporter creates a new pain.001 exporter
+func NewPain001Exporter() *Pain001Exporter {
+	return &Pain001Exporter{
+		BaseExporter: NewBaseExporter("application/xml"),
+	}
+}
+
+// Export converts the credit entries of a NACHA file to pain.001
+func (e *Pain001Exporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes the credit entries of a NACHA file to w as pain.001. Each
+// batch with credit entries becomes a payment information block with the
+// company as debtor.
+func (e *Pain001Exporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	var initiation iso20022.CustomerCreditTransferInitiation
+	var count int
+	var total int64
+
+	for _, batch := range file.Batches {
+		info := iso20022.CreditTransferPaymentInformation{
+			PaymentInformationID:   painPaymentInformationID(&batch.Header),
+			PaymentMethod:          iso20022.PaymentMethodTransfer,
+			PaymentTypeInformation: painPaymentType(&batch.Header),
+			RequestedExecutionDate: painDate(batch.Header.EffectiveEntryDate, file.Header.FileCreationDate),
+			Debtor:                 painCompany(&batch.Header),
+			DebtorAgent:            painAgent(batch.Header.OriginatingDFI),
+		}
+
+		var batchTotal int64
+		for _, entry := range batch.Entries {
+			if summary.Direction(entry.TransactionCode) != summary.DirectionCredit {
+				continue
+			}
+			info.Transactions = append(info.Transactions, iso20022.CreditTransferTransaction{
+				PaymentID: painPaymentID(&entry),
+				Amount: iso20022.InstructedAmount{
+					InstructedAmount: painAmount(entry.Amount),
+				},
+				CreditorAgent:         painEntryAgent(&entry),
+				Creditor:              painIndividual(&entry),
+				CreditorAccount:       painEntryAccount(&entry),
+				RemittanceInformation: painRemittance(&entry),
+			})
+			batchTotal += entry.Amount
+		}
+		if len(info.Transactions) == 0 {
+			continue
+		}
+		account, err := painCompanyAccount(&batch.Header, opts.Pain)
+		if err != nil {
+			return fmt.Errorf("pain.001 needs the debtor account: %w", err)
+		}
+
+		info.DebtorAccount = account
+		info.NumberOfTransactions = strconv.Itoa(len(info.Transactions))
+		info.ControlSum = decimalNumber(batchTotal)
+		initiation.PaymentInformation = append(initiation.PaymentInformation, info)
+		count += len(info.Transactions)
+		total += batchTotal
+	}
+
+	if count == 0 {
+		return fmt.Errorf("pain.001 needs credit entries: %w", ErrNoEntries)
+	}
+
+	initiation.GroupHeader = painGroupHeader(&file.Header, count, total)
+	return writePain(w, &iso20022.Pain001Document{Initiation: initiation})
+}
+
+// Pain008Exporter handles export of the debit entries to ISO 20022 pain.008
+type Pain008Exporter struct {
+	*BaseExporter
+}
+
+// NewPain008Exporter creates a new pain.008 exporter
+func NewPain008Exporter() *Pain008Exporter {
+	return &Pain008Exporter{
+		BaseExporter: NewBaseExporter("application/xml"),
+	}
+}
+
+// Export converts the debit entries of a NACHA file to pain.008
+func (e *Pain008Exporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes the debit entries of a NACHA file to w as pain.008. Each
+// batch with debit entries becomes a payment information block with the
+// company as creditor.
+func (e *Pain008Exporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	var initiation iso20022.CustomerDirectDebitInitiation
+	var count int
+	var total int64
+
+	for _, batch := range file.Batches {
+		info := iso20022.DirectDebitPaymentInformation{
+			PaymentInformationID:    painPaymentInformationID(&batch.Header),
+			PaymentMethod:           iso20022.PaymentMethodDirectDebit,
+			PaymentTypeInformation:  painPaymentType(&batch.Header),
+			RequestedCollectionDate: painDate(batch.Header.EffectiveEntryDate, file.Header.FileCreationDate),
+			Creditor:                painCompany(&batch.Header),
+			CreditorAgent:           painAgent(batch.Header.OriginatingDFI),
+		}
+
+		var batchTotal int64
+		for _, entry := range batch.Entries {
+			if summary.Direction(entry.TransactionCode) != summary.DirectionDebit {
+				continue
+			}
+			info.Transactions = append(info.Transactions, iso20022.DirectDebitTransaction{
+				PaymentID:             painPaymentID(&entry),
+				InstructedAmount:      painAmount(entry.Amount),
+				DebtorAgent:           *painEntryAgent(&entry),
+				Debtor:                *painIndividual(&entry),
+				DebtorAccount:         *painEntryAccount(&entry),
+				RemittanceInformation: painRemittance(&entry),
+			})
+			batchTotal += entry.Amount
+		}
+		if len(info.Transactions) == 0 {
+			continue
+		}
+		account, err := painCompanyAccount(&batch.Header, opts.Pain)
+		if err != nil {
+			return fmt.Errorf("pain.008 needs the creditor account: %w", err)
+		}
+
+		info.CreditorAccount = account
+		info.NumberOfTransactions = strconv.Itoa(len(info.Transactions))
+		info.ControlSum = decimalNumber(batchTotal)
+		initiation.PaymentInformation = append(initiation.PaymentInformation, info)
+		count += len(info.Transactions)
+		total += batchTotal
+	}
+
+	if count == 0 {
+		return fmt.Errorf("pain.008 needs debit entries: %w", ErrNoEntries)
+	}
+
+	initiation.GroupHeader = painGroupHeader(&file.Header, count, total)
+	return writePain(w, &iso20022.Pain008Document{Initiation: initiation})
+}
+
+func writePain(w io.Writer, document interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write XML: %v", err)
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return fmt.Errorf("failed to write XML: %v", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write XML: %v", err)
+	}
+	return nil
+}
+
+// painGroupHeader identifies the message by the file's origin, creation
+// date, time and ID modifier
+func painGroupHeader(header *models.FileHeader, count int, total int64) iso20022.GroupHeader {
+	created := header.FileCreationDate
+	if t, err := time.Parse("1504", header.FileCreationTime); err == nil {
+		created = created.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+
+	messageID := strings.TrimSpace(header.ImmediateOrigin) + "-" + created.Format("060102-1504") + strings.TrimSpace(header.FileIDModifier)
+
+	party := iso20022.Party{Name: painText(header.OriginName, 140)}
+	if origin := strings.TrimSpace(header.ImmediateOrigin); origin != "" {
+		party.ID = painOrganisation(origin)
+	}
+
+	return iso20022.GroupHeader{
+		MessageID:            painText(messageID, 35),
+		CreationDateTime:     created.Format("2006-01-02T15:04:05"),
+		NumberOfTransactions: strconv.Itoa(count),
+		ControlSum:           decimalNumber(total),
+		InitiatingParty:      party,
+	}
+}
+
+func painPaymentInformationID(header *models.BatchHeader) string {
+	return painText(strings.TrimSpace(header.CompanyIdentification)+"-"+strings.TrimSpace(header.BatchNumber), 35)
+}
+
+// painPaymentType carries the SEC code as a proprietary local instrument
+func painPaymentType(header *models.BatchHeader) *iso20022.PaymentTypeInformation {
+	info := &iso20022.PaymentTypeInformation{
+		ServiceLevel: &iso20022.Code{Code: iso20022.ServiceLevelNonUrgent},
+	}
+	if sec := strings.TrimSpace(header.StandardEntryClass); sec != "" {
+		info.LocalInstrument = &iso20022.Code{Proprietary: sec}
+	}
+	return info
+}
+
+func painCompany(header *models.BatchHeader) iso20022.Party {
+	return iso20022.Party{
+		Name: painText(header.CompanyName, 140),
+		ID:   painOrganisation(strings.TrimSpace(header.CompanyIdentification)),
+	}
+}
+
+// painCompanyAccount identifies the company's account from the options, since
+// NACHA files do not carry the originator's account
+func painCompanyAccount(header *models.BatchHeader, opts PainOptions) (iso20022.Account, error) {
+	company := strings.TrimSpace(header.CompanyIdentification)
+	account, ok := opts.CompanyAccounts[company]
+	if !ok {
+		account = opts.CompanyAccount
+	}
+	if strings.TrimSpace(account) == "" {
+		return iso20022.Account{}, fmt.Errorf("no account for company %q, set the company account option: %w", company, ErrUnsupportedValue)
+	}
+	return *painAccount(account), nil
+}
+
+func painOrganisation(id string) *iso20022.PartyIdentification {
+	return &iso20022.PartyIdentification{
+		Organisation: &iso20022.GenericIdentifications{
+			Other: []iso20022.GenericIdentification{{ID: painText(id, 35)}},
+		},
+	}
+}
+
+func painIndividual(entry *models.EntryDetail) *iso20022.Party {
+	party := &iso20022.Party{Name: painText(entry.IndividualName, 140)}
+	if id := strings.TrimSpace(entry.IndividualIDNumber); id != "" {
+		party.ID = &iso20022.PartyIdentification{
+			Private: &iso20022.GenericIdentifications{
+				Other: []iso20022.GenericIdentification{{ID: painText(id, 35)}},
+			},
+		}
+	}
+	return party
+}
+
+func painAccount(account string) *iso20022.Account {
+	return &iso20022.Account{
+		ID: iso20022.AccountIdentification{
+			Other: &iso20022.GenericIdentification{ID: painText(account, 34)},
+		},
+	}
+}
+
+// painEntryAccount identifies the receiver's account, with the account type
+// of checking and savings transaction codes
+func painEntryAccount(entry *models.EntryDetail) *iso20022.Account {
+	account := painAccount(entry.DFIAccountNumber)
+	switch {
+	case strings.HasPrefix(entry.TransactionCode, "2"):
+		account.Type = &iso20022.Code{Code: iso20022.AccountTypeCurrent}
+	case strings.HasPrefix(entry.TransactionCode, "3"):
+		account.Type = &iso20022.Code{Code: iso20022.AccountTypeSavings}
+	}
+	return account
+}
+
+// painAgent identifies a financial institution by its ABA routing number
+func painAgent(routing string) iso20022.Agent {
+	return iso20022.Agent{
+		FinancialInstitution: iso20022.FinancialInstitution{
+			ClearingSystemMemberID: &iso20022.ClearingSystemMember{
+				ClearingSystemID: &iso20022.Code{Code: iso20022.ClearingSystemUSABA},
+				MemberID:         abaRoutingNumber(routing),
+			},
+		},
+	}
+}
+
+func painEntryAgent(entry *models.EntryDetail) *iso20022.Agent {
+	routing := strings.TrimSpace(entry.ReceivingDFI)
+	if len(routing) == 8 {
+		routing += strings.TrimSpace(entry.CheckDigit)
+	}
+	agent := painAgent(routing)
+	return &agent
+}
+
+func painPaymentID(entry *models.EntryDetail) iso20022.PaymentID {
+	trace := strings.TrimSpace(entry.TraceNumber)
+	return iso20022.PaymentID{
+		InstructionID: trace,
+		EndToEndID:    trace,
+	}
+}
+
+func painAmount(cents int64) iso20022.Amount {
+	return iso20022.Amount{Currency: iso20022.CurrencyUSD, Value: decimalNumber(cents)}
+}
+
+// painRemittance maps payment related information of 05 addenda to
+// unstructured remittance lines
+func painRemittance(entry *models.EntryDetail) *iso20022.RemittanceInformation {
+	var lines []string
+	for _, addenda := range entry.AddendaRecords {
+		if addenda.AddendaTypeCode != "05" {
+			continue
+		}
+		if line := painText(addenda.PaymentRelatedInformation, 140); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return &iso20022.RemittanceInformation{Unstructured: lines}
+}
+
+// painDate converts a YYMMDD effective entry date to an ISO date, falling
+// back to the file creation date
+func painDate(effective string, fallback time.Time) string {
+	if t, err := time.Parse("060102", strings.TrimSpace(effective)); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return fallback.Format("2006-01-02")
+}
+
+// painText trims a value and cuts it to the maximum length of an element
+func painText(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > max {
+		s = strings.TrimSpace(s[:max])
+	}
+	return s
+}
+
+// abaRoutingNumber completes an eight digit routing number with its check digit
+func abaRoutingNumber(routing string) string {
+	routing = strings.TrimSpace(routing)
+	if check, ok := models.CheckDigit(routing); ok {
+		return routing + check
+	}
+	return routing
+}
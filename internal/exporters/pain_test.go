@@ -0,0 +1,125 @@
+package exporters
+
+import (
+	"encoding/xml"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/pkg/iso20022"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPainOptions holds the accounts of the companies of testFile
+var testPainOptions = PainOptions{
+	CompanyAccount:  "9876543210",
+	CompanyAccounts: map[string]string{"1234567890": "5555500001"},
+}
+
+func TestPainExport(t *testing.T) {
+	file := testFile(t)
+	options := Options{Pain: testPainOptions}
+
+	// Test case 1: Credit entries become pain.001 credit transfers
+	data, contentType, err := export(t, file, "PAIN001", options)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/xml", contentType)
+
+	var credits iso20022.Pain001Document
+	assert.NoError(t, xml.Unmarshal(data, &credits))
+	assert.Equal(t, iso20022.Pain001Namespace, credits.XMLName.Space)
+
+	header := credits.Initiation.GroupHeader
+	assert.Equal(t, "2", header.NumberOfTransactions)
+	assert.Equal(t, "14100.00", header.ControlSum)
+	assert.Equal(t, "2026-10-17T12:00:00", header.CreationDateTime)
+
+	if assert.Len(t, credits.Initiation.PaymentInformation, 2) {
+		info := credits.Initiation.PaymentInformation[1]
+		assert.Equal(t, "TRF", info.PaymentMethod)
+		assert.Equal(t, "CCD", info.PaymentTypeInformation.LocalInstrument.Proprietary)
+		assert.Equal(t, "2026-10-20", info.RequestedExecutionDate)
+		assert.Equal(t, "OUTRA EMPRESA", info.Debtor.Name)
+		assert.Equal(t, "5555500001", info.DebtorAccount.ID.Other.ID)
+		assert.Equal(t, "076401251", info.DebtorAgent.FinancialInstitution.ClearingSystemMemberID.MemberID)
+
+		if assert.Len(t, info.Transactions, 1) {
+			tx := info.Transactions[0]
+			assert.Equal(t, "076401250000004", tx.PaymentID.EndToEndID)
+			assert.Equal(t, iso20022.Amount{Currency: "USD", Value: "5100.00"}, tx.Amount.InstructedAmount)
+			assert.Equal(t, "021000021", tx.CreditorAgent.FinancialInstitution.ClearingSystemMemberID.MemberID)
+			assert.Equal(t, "USABA", tx.CreditorAgent.FinancialInstitution.ClearingSystemMemberID.ClearingSystemID.Code)
+			assert.Equal(t, "ACME SUPPLIES", tx.Creditor.Name)
+			assert.Equal(t, "444444", tx.CreditorAccount.ID.Other.ID)
+			assert.Equal(t, []string{"INV-1001 INV-1002"}, tx.RemittanceInformation.Unstructured)
+		}
+	}
+
+	// Test case 2: Debit entries become pain.008 direct debits
+	data, _, err = export(t, file, "PAIN008", options)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var debits iso20022.Pain008Document
+	assert.NoError(t, xml.Unmarshal(data, &debits))
+	assert.Equal(t, iso20022.Pain008Namespace, debits.XMLName.Space)
+	assert.Equal(t, "8700.00", debits.Initiation.GroupHeader.ControlSum)
+	if assert.Len(t, debits.Initiation.PaymentInformation, 1) {
+		info := debits.Initiation.PaymentInformation[0]
+		assert.Equal(t, "DD", info.PaymentMethod)
+		assert.Equal(t, "2026-10-19", info.RequestedCollectionDate)
+		assert.Equal(t, "EMPRESA EXEMPLO", info.Creditor.Name)
+		assert.Equal(t, "9876543210", info.CreditorAccount.ID.Other.ID)
+		if assert.Len(t, info.Transactions, 2) {
+			assert.Equal(t, "JOAO DA SILVA", info.Transactions[0].Debtor.Name)
+			assert.Equal(t, "1200.00", info.Transactions[1].InstructedAmount.Value)
+		}
+	}
+
+	// Test case 3: Nothing to export
+	_, _, err = export(t, file, "PAIN008", Options{SECCodes: []string{"CCD"}, Pain: testPainOptions})
+	assert.ErrorIs(t, err, ErrNoEntries)
+
+	// Test case 4: The originator's account is required
+	for _, format := range []string{"PAIN001", "PAIN008"} {
+		_, _, err = export(t, file, format, Options{})
+		assert.ErrorIs(t, err, ErrUnsupportedValue, format)
+		assert.ErrorContains(t, err, "company account", format)
+	}
+	_, _, err = export(t, file, "PAIN001", Options{Pain: PainOptions{CompanyAccounts: map[string]string{"0764012512": "9876543210"}}})
+	assert.ErrorIs(t, err, ErrUnsupportedValue)
+	assert.ErrorContains(t, err, `"1234567890"`)
+
+	// Test case 5: Invalid account
+	assert.Error(t, Options{Pain: PainOptions{CompanyAccount: strings.Repeat("1", 35)}}.Validate())
+}
+
+func TestPainExportSchema(t *testing.T) {
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("schema validation requires xmllint")
+	}
+
+	file := testFile(t)
+	dir := t.TempDir()
+
+	// Test case 1: Exports validate against their message schema
+	for format, schema := range map[string]string{
+		"PAIN001": "testdata/pain.001.001.03.xsd",
+		"PAIN008": "testdata/pain.008.001.02.xsd",
+	} {
+		data, _, err := export(t, file, format, Options{Pain: testPainOptions})
+		if !assert.NoError(t, err, format) {
+			continue
+		}
+		path := filepath.Join(dir, format+".xml")
+		assert.NoError(t, os.WriteFile(path, data, 0644))
+		out, err := exec.Command(xmllint, "--noout", "--schema", schema, path).CombinedOutput()
+		assert.NoError(t, err, "%s: %s", format, out)
+	}
+}
@@ -1,40 +1,59 @@
 package exporters
 
 import (
+	"archive/zip"
 	"fmt"
-	"os"
-	"path/filepath"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/nacha-service/pkg/models"
-	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go-source/writerfile"
 	"github.com/xitongsys/parquet-go/parquet"
 	"github.com/xitongsys/parquet-go/writer"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:         "PARQUET",
+		Extension:    ".parquet",
+		Description:  "Columnar entry and addenda data for analytics",
+		Capabilities: Capabilities{Binary: true},
+		New:          func() NachaExporter { return NewParquetExporter() },
+	})
+	MustRegister(Format{
+		Name:         "PARQUET_DATASET",
+		Extension:    ".zip",
+		Description:  "Zipped file, batch, entry and addenda Parquet tables",
+		Capabilities: Capabilities{Binary: true},
+		New:          func() NachaExporter { return NewParquetDatasetExporter() },
+	})
+}
+
+// Record types of the rows of the Parquet export
+const (
+	parquetEntryRecord   = "6"
+	parquetAddendaRecord = "7"
+)
+
+// ParquetOptions identifies the file the rows of a PARQUET export came from,
+// for rows of several files stored together such as the parts of a data lake
+type ParquetOptions struct {
+	// SourceFingerprint and SourceFile fill the source_fingerprint and
+	// source_file columns, which are left out when both are empty
+	SourceFingerprint string
+	SourceFile        string
+}
+
+func (o ParquetOptions) hasSource() bool {
+	return o.SourceFingerprint != "" || o.SourceFile != ""
+}
+
 // ParquetExporter handles export to Parquet format
 type ParquetExporter struct {
 	*BaseExporter
 }
 
-// NachaEntry represents a NACHA entry in a format suitable for Parquet
-type NachaEntry struct {
-	TransactionCode        string  `parquet:"name=transaction_code, type=BYTE_ARRAY, convertedtype=UTF8"`
-	ReceivingDFI           string  `parquet:"name=receiving_dfi, type=BYTE_ARRAY, convertedtype=UTF8"`
-	CheckDigit             string  `parquet:"name=check_digit, type=BYTE_ARRAY, convertedtype=UTF8"`
-	DFIAccountNumber       string  `parquet:"name=dfi_account_number, type=BYTE_ARRAY, convertedtype=UTF8"`
-	Amount                 float64 `parquet:"name=amount, type=DOUBLE"`
-	IndividualIDNumber     string  `parquet:"name=individual_id_number, type=BYTE_ARRAY, convertedtype=UTF8"`
-	IndividualName         string  `parquet:"name=individual_name, type=BYTE_ARRAY, convertedtype=UTF8"`
-	DiscretionaryData      string  `parquet:"name=discretionary_data, type=BYTE_ARRAY, convertedtype=UTF8"`
-	AddendaRecordIndicator string  `parquet:"name=addenda_record_indicator, type=BYTE_ARRAY, convertedtype=UTF8"`
-	TraceNumber            string  `parquet:"name=trace_number, type=BYTE_ARRAY, convertedtype=UTF8"`
-	BatchNumber            string  `parquet:"name=batch_number, type=BYTE_ARRAY, convertedtype=UTF8"`
-	CompanyName            string  `parquet:"name=company_name, type=BYTE_ARRAY, convertedtype=UTF8"`
-	CompanyIdentification  string  `parquet:"name=company_identification, type=BYTE_ARRAY, convertedtype=UTF8"`
-	TotalDebitAmount       float64 `parquet:"name=total_debit_amount, type=DOUBLE"`
-	TotalCreditAmount      float64 `parquet:"name=total_credit_amount, type=DOUBLE"`
-}
-
 // NewParquetExporter creates a new Parquet exporter
 func NewParquetExporter() *ParquetExporter {
 	return &ParquetExporter{
@@ -44,68 +63,389 @@ func NewParquetExporter() *ParquetExporter {
 
 // Export converts a NACHA file to Parquet format
 func (e *ParquetExporter) Export(file *models.NachaFile) ([]byte, error) {
-	// Create a temporary file
-	tmpPath := filepath.Join(os.TempDir(), fmt.Sprintf("nacha-%d.parquet", os.Getpid()))
-	defer os.Remove(tmpPath)
+	return exportBytes(e, file)
+}
 
-	// Create file writer
-	fw, err := local.NewLocalFileWriter(tmpPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create file writer: %v", err)
+// ExportTo writes a NACHA file to w in Parquet format. Each entry is a row
+// with record type 6, followed by a row with record type 7 for each of its
+// addenda. Entry columns are null on addenda rows and the other way around.
+// With a source in the Parquet options every row ends with its source columns.
+func (e *ParquetExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+	fields := opts.fields()
+
+	columns := []string{
+		utf8Column("record_type", false),
+		int32Column("batch_index", false),
+		int32Column("entry_index", false),
+		utf8Column("batch_number", false),
+		utf8Column("company_name", false),
+		utf8Column("company_identification", false),
+		utf8Column("standard_entry_class", false),
+	}
+	for _, f := range fields {
+		if f.value == nil {
+			columns = append(columns, amountColumn(f.name, opts, true))
+		} else {
+			columns = append(columns, utf8Column(f.name, true))
+		}
+	}
+	columns = append(columns,
+		int32Column("addenda_index", true),
+		utf8Column("addenda_type_code", true),
+		utf8Column("payment_related_information", true),
+		utf8Column("addenda_sequence_number", true),
+	)
+	var source []interface{}
+	if opts.Parquet.hasSource() {
+		columns = append(columns,
+			utf8Column("source_fingerprint", false),
+			utf8Column("source_file", false),
+		)
+		source = []interface{}{opts.Parquet.SourceFingerprint, opts.Parquet.SourceFile}
 	}
-	defer fw.Close()
 
-	// Create Parquet writer
-	pw, err := writer.NewParquetWriter(fw, new(NachaEntry), 4)
+	pw, err := newParquetWriter(w, columns)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Parquet writer: %v", err)
+		return err
 	}
 
-	// Set compression
-	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	for i, batch := range file.Batches {
+		for j, entry := range batch.Entries {
+			context := []interface{}{
+				int32(i),
+				int32(j),
+				batch.Header.BatchNumber,
+				batch.Header.CompanyName,
+				batch.Header.CompanyIdentification,
+				batch.Header.StandardEntryClass,
+			}
 
-	// Convert entries to Parquet format
-	for _, batch := range file.Batches {
-		for _, entry := range batch.Entries {
-			// Use raw amounts without validation
-			amount := entry.Amount
-			totalDebit := batch.Control.TotalDebitAmount
-			totalCredit := batch.Control.TotalCreditAmount
-
-			pEntry := &NachaEntry{
-				TransactionCode:        entry.TransactionCode,
-				ReceivingDFI:           entry.ReceivingDFI,
-				CheckDigit:             entry.CheckDigit,
-				DFIAccountNumber:       entry.DFIAccountNumber,
-				Amount:                 float64(amount),
-				IndividualIDNumber:     entry.IndividualIDNumber,
-				IndividualName:         entry.IndividualName,
-				DiscretionaryData:      entry.DiscretionaryData,
-				AddendaRecordIndicator: entry.AddendaRecordIndicator,
-				TraceNumber:            entry.TraceNumber,
-				BatchNumber:            batch.Header.BatchNumber,
-				CompanyName:            batch.Header.CompanyName,
-				CompanyIdentification:  batch.Header.CompanyIdentification,
-				TotalDebitAmount:       float64(totalDebit),
-				TotalCreditAmount:      float64(totalCredit),
+			// Entry row
+			row := append([]interface{}{parquetEntryRecord}, context...)
+			for _, f := range fields {
+				if f.value == nil {
+					row = append(row, entry.Amount)
+				} else {
+					row = append(row, f.value(&entry))
+				}
+			}
+			row = append(row, nil, nil, nil, nil)
+			row = append(row, source...)
+			if err := pw.Write(row); err != nil {
+				return fmt.Errorf("failed to write entry: %v", err)
 			}
 
-			if err := pw.Write(pEntry); err != nil {
-				return nil, fmt.Errorf("failed to write entry: %v", err)
+			// Addenda rows
+			for k, addenda := range entry.AddendaRecords {
+				row := append([]interface{}{parquetAddendaRecord}, context...)
+				for range fields {
+					row = append(row, nil)
+				}
+				row = append(row,
+					int32(k),
+					addenda.AddendaTypeCode,
+					addenda.PaymentRelatedInformation,
+					addenda.AddendaSequenceNumber,
+				)
+				row = append(row, source...)
+				if err := pw.Write(row); err != nil {
+					return fmt.Errorf("failed to write addenda record: %v", err)
+				}
 			}
 		}
 	}
 
 	// Close writer
 	if err := pw.WriteStop(); err != nil {
-		return nil, fmt.Errorf("failed to close writer: %v", err)
+		return fmt.Errorf("failed to close writer: %v", err)
 	}
 
-	// Read the file back into memory
-	content, err := os.ReadFile(tmpPath)
+	return nil
+}
+
+// ParquetDatasetExporter handles export to a zip of normalized Parquet tables
+type ParquetDatasetExporter struct {
+	*BaseExporter
+}
+
+// NewParquetDatasetExporter creates a new Parquet dataset exporter
+func NewParquetDatasetExporter() *ParquetDatasetExporter {
+	return &ParquetDatasetExporter{
+		BaseExporter: NewBaseExporter("application/zip"),
+	}
+}
+
+// Export converts a NACHA file to a zipped Parquet dataset
+func (e *ParquetDatasetExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a zip with file.parquet, batches.parquet, entries.parquet
+// and addenda.parquet to w. Tables are linked by batch_index and entry_index.
+func (e *ParquetDatasetExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	tables := []struct {
+		name  string
+		write func(io.Writer, *models.NachaFile, Options) error
+	}{
+		{"file.parquet", writeFileTable},
+		{"batches.parquet", writeBatchTable},
+		{"entries.parquet", writeEntryTable},
+		{"addenda.parquet", writeAddendaTable},
+	}
+
+	archive := zip.NewWriter(w)
+	for _, table := range tables {
+		// Parquet pages are already compressed
+		out, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     table.name,
+			Method:   zip.Store,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %v", table.name, err)
+		}
+		if err := table.write(out, file, opts); err != nil {
+			return fmt.Errorf("failed to write %s: %v", table.name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to close zip: %v", err)
+	}
+	return nil
+}
+
+// writeFileTable writes the file header and control as a single row
+func writeFileTable(w io.Writer, file *models.NachaFile, opts Options) error {
+	pw, err := newParquetWriter(w, []string{
+		utf8Column("immediate_destination", false),
+		utf8Column("immediate_origin", false),
+		dateColumn("file_creation_date"),
+		utf8Column("file_creation_time", false),
+		utf8Column("file_id_modifier", false),
+		utf8Column("destination_name", false),
+		utf8Column("origin_name", false),
+		utf8Column("reference_code", false),
+		int32Column("batch_count", false),
+		int32Column("block_count", false),
+		int32Column("entry_addenda_count", false),
+		utf8Column("entry_hash", false),
+		amountColumn("total_debit_amount", opts, false),
+		amountColumn("total_credit_amount", opts, false),
+	})
+	if err != nil {
+		return err
+	}
+
+	h, c := file.Header, file.Control
+	if err := pw.Write([]interface{}{
+		h.ImmediateDestination,
+		h.ImmediateOrigin,
+		parquetDate(h.FileCreationDate),
+		h.FileCreationTime,
+		h.FileIDModifier,
+		h.DestinationName,
+		h.OriginName,
+		strings.TrimSpace(h.ReferenceCode),
+		int32(c.BatchCount),
+		int32(c.BlockCount),
+		int32(c.EntryAddendaCount),
+		c.EntryHash,
+		c.TotalDebitAmount,
+		c.TotalCreditAmount,
+	}); err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+
+	return pw.WriteStop()
+}
+
+// writeBatchTable writes one row per batch with its header and control
+func writeBatchTable(w io.Writer, file *models.NachaFile, opts Options) error {
+	pw, err := newParquetWriter(w, []string{
+		int32Column("batch_index", false),
+		utf8Column("batch_number", false),
+		utf8Column("service_class_code", false),
+		utf8Column("company_name", false),
+		utf8Column("company_discretionary_data", false),
+		utf8Column("company_identification", false),
+		utf8Column("standard_entry_class", false),
+		utf8Column("company_entry_description", false),
+		utf8Column("company_descriptive_date", false),
+		dateColumn("effective_entry_date"),
+		utf8Column("settlement_date", false),
+		utf8Column("originator_status_code", false),
+		utf8Column("originating_dfi", false),
+		int32Column("entry_addenda_count", false),
+		utf8Column("entry_hash", false),
+		amountColumn("total_debit_amount", opts, false),
+		amountColumn("total_credit_amount", opts, false),
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read temporary file: %v", err)
+		return err
 	}
 
-	return content, nil
+	for i, batch := range file.Batches {
+		h, c := batch.Header, batch.Control
+
+		var effective interface{}
+		if date, err := time.Parse("060102", h.EffectiveEntryDate); err == nil {
+			effective = parquetDate(date)
+		}
+
+		if err := pw.Write([]interface{}{
+			int32(i),
+			h.BatchNumber,
+			h.ServiceClassCode,
+			h.CompanyName,
+			h.CompanyDiscretionaryData,
+			h.CompanyIdentification,
+			h.StandardEntryClass,
+			h.CompanyEntryDescription,
+			h.CompanyDescriptiveDate,
+			effective,
+			h.SettlementDate,
+			h.OriginatorStatusCode,
+			h.OriginatingDFI,
+			int32(c.EntryAddendaCount),
+			c.EntryHash,
+			c.TotalDebitAmount,
+			c.TotalCreditAmount,
+		}); err != nil {
+			return fmt.Errorf("failed to write batch: %v", err)
+		}
+	}
+
+	return pw.WriteStop()
+}
+
+// writeEntryTable writes one row per entry with the selected entry fields
+func writeEntryTable(w io.Writer, file *models.NachaFile, opts Options) error {
+	fields := opts.fields()
+
+	columns := []string{int32Column("batch_index", false), int32Column("entry_index", false)}
+	for _, f := range fields {
+		if f.value == nil {
+			columns = append(columns, amountColumn(f.name, opts, false))
+		} else {
+			columns = append(columns, utf8Column(f.name, false))
+		}
+	}
+
+	pw, err := newParquetWriter(w, columns)
+	if err != nil {
+		return err
+	}
+
+	for i, batch := range file.Batches {
+		for j, entry := range batch.Entries {
+			row := []interface{}{int32(i), int32(j)}
+			for _, f := range fields {
+				if f.value == nil {
+					row = append(row, entry.Amount)
+				} else {
+					row = append(row, f.value(&entry))
+				}
+			}
+			if err := pw.Write(row); err != nil {
+				return fmt.Errorf("failed to write entry: %v", err)
+			}
+		}
+	}
+
+	return pw.WriteStop()
+}
+
+// writeAddendaTable writes one row per addenda record
+func writeAddendaTable(w io.Writer, file *models.NachaFile, opts Options) error {
+	pw, err := newParquetWriter(w, []string{
+		int32Column("batch_index", false),
+		int32Column("entry_index", false),
+		int32Column("addenda_index", false),
+		utf8Column("addenda_type_code", false),
+		utf8Column("payment_related_information", false),
+		utf8Column("addenda_sequence_number", false),
+		utf8Column("entry_detail_sequence_number", false),
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, batch := range file.Batches {
+		for j, entry := range batch.Entries {
+			for k, addenda := range entry.AddendaRecords {
+				if err := pw.Write([]interface{}{
+					int32(i),
+					int32(j),
+					int32(k),
+					addenda.AddendaTypeCode,
+					addenda.PaymentRelatedInformation,
+					addenda.AddendaSequenceNumber,
+					addenda.EntryDetailSequenceNumber,
+				}); err != nil {
+					return fmt.Errorf("failed to write addenda record: %v", err)
+				}
+			}
+		}
+	}
+
+	return pw.WriteStop()
+}
+
+// newParquetWriter creates a Snappy compressed Parquet writer for the given
+// column metadata. Parquet only needs sequential writes, so no temporary file
+// is required.
+func newParquetWriter(w io.Writer, columns []string) (*writer.CSVWriter, error) {
+	pw, err := writer.NewCSVWriter(columns, writerfile.NewWriterFile(w), 4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Parquet writer: %v", err)
+	}
+	pw.CompressionType = parquet.CompressionCodec_SNAPPY
+	return pw, nil
+}
+
+// utf8Column describes a string column
+func utf8Column(name string, optional bool) string {
+	return column(name, "type=BYTE_ARRAY, convertedtype=UTF8", optional)
+}
+
+// int32Column describes an integer column
+func int32Column(name string, optional bool) string {
+	return column(name, "type=INT32", optional)
+}
+
+// dateColumn describes an optional DATE column, null when the date is unknown
+func dateColumn(name string) string {
+	return column(name, "type=INT32, convertedtype=DATE", true)
+}
+
+// amountColumn describes an amount column. Amounts are INT64 cents, or
+// DECIMAL(12,2) when decimal amounts are requested. Both store exact cents.
+func amountColumn(name string, opts Options, optional bool) string {
+	if opts.AmountFormat == AmountDecimal {
+		return column(name, "type=INT64, convertedtype=DECIMAL, scale=2, precision=12", optional)
+	}
+	return column(name, "type=INT64", optional)
+}
+
+func column(name, kind string, optional bool) string {
+	repetition := "REQUIRED"
+	if optional {
+		repetition = "OPTIONAL"
+	}
+	return fmt.Sprintf("name=%s, %s, repetitiontype=%s", name, kind, repetition)
+}
+
+// parquetDate converts a date to days since the Unix epoch, or null when unset
+func parquetDate(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	days := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400
+	return int32(days)
 }
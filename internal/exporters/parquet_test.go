@@ -0,0 +1,100 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// readParquet opens Parquet content and returns its row count and column schema
+// by lowercase name, since the reader renames columns to Go field names
+func readParquet(t *testing.T, content []byte) (int64, map[string]*parquet.SchemaElement) {
+	pf, err := buffer.NewBufferFile(content)
+	if !assert.NoError(t, err) {
+		return 0, nil
+	}
+	pr, err := reader.NewParquetReader(pf, nil, 1)
+	if !assert.NoError(t, err) {
+		return 0, nil
+	}
+	defer pr.ReadStop()
+
+	columns := make(map[string]*parquet.SchemaElement)
+	for _, element := range pr.Footer.Schema[1:] {
+		columns[strings.ToLower(element.Name)] = element
+	}
+	return pr.GetNumRows(), columns
+}
+
+func TestParquetExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: Entries and addenda rows with INT64 cents
+	data, _, err := export(t, file, "PARQUET", Options{})
+	assert.NoError(t, err)
+	rows, columns := readParquet(t, data)
+	assert.Equal(t, int64(5), rows)
+	if assert.Contains(t, columns, "amount") {
+		assert.Equal(t, parquet.Type_INT64, columns["amount"].GetType())
+		assert.Nil(t, columns["amount"].ConvertedType)
+	}
+	assert.Contains(t, columns, "payment_related_information")
+	assert.NotContains(t, columns, "total_debit_amount")
+	assert.NotContains(t, columns, "source_file")
+
+	// Test case 2: Decimal amounts use DECIMAL(12,2)
+	data, _, err = export(t, file, "PARQUET", Options{AmountFormat: AmountDecimal})
+	assert.NoError(t, err)
+	_, columns = readParquet(t, data)
+	if assert.Contains(t, columns, "amount") {
+		assert.Equal(t, parquet.ConvertedType_DECIMAL, columns["amount"].GetConvertedType())
+		assert.Equal(t, int32(2), columns["amount"].GetScale())
+		assert.Equal(t, int32(12), columns["amount"].GetPrecision())
+	}
+
+	// Test case 3: Source columns
+	data, _, err = export(t, file, "PARQUET", Options{Parquet: ParquetOptions{SourceFingerprint: "abc", SourceFile: "in.ach"}})
+	assert.NoError(t, err)
+	_, columns = readParquet(t, data)
+	assert.Contains(t, columns, "source_fingerprint")
+	assert.Contains(t, columns, "source_file")
+
+	// Test case 4: Normalized dataset
+	data, contentType, err := export(t, file, "PARQUET_DATASET", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/zip", contentType)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if !assert.NoError(t, err) {
+		return
+	}
+	expected := map[string]int64{
+		"file.parquet":    1,
+		"batches.parquet": 2,
+		"entries.parquet": 4,
+		"addenda.parquet": 1,
+	}
+	assert.Len(t, archive.File, len(expected))
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		assert.NoError(t, err)
+
+		rows, columns := readParquet(t, content)
+		assert.Equal(t, expected[f.Name], rows, f.Name)
+		if f.Name == "batches.parquet" && assert.Contains(t, columns, "effective_entry_date") {
+			assert.Equal(t, parquet.ConvertedType_DATE, columns["effective_entry_date"].GetConvertedType())
+		}
+	}
+}
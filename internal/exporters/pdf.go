@@ -1,14 +1,28 @@
 package exporters
 
 import (
-	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/jung-kurt/gofpdf"
+	"github.com/nacha-service/internal/summary"
 	"github.com/nacha-service/pkg/models"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:         "PDF",
+		Extension:    ".pdf",
+		Description:  "Printable ACH control report with batch subtotals and a sign-off page",
+		Capabilities: Capabilities{Binary: true},
+		New:          func() NachaExporter { return NewPDFExporter() },
+	})
+}
+
 // PDFExporter handles export to PDF format
 type PDFExporter struct {
 	*BaseExporter
@@ -23,129 +37,369 @@ func NewPDFExporter() *PDFExporter {
 
 // Export converts a NACHA file to PDF format
 func (e *PDFExporter) Export(file *models.NachaFile) ([]byte, error) {
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetMargins(10, 10, 10)
-	pdf.AddPage()
-
-	// Title
-	pdf.SetFont("Arial", "B", 16)
-	pdf.Cell(190, 10, "NACHA File Details")
-	pdf.Ln(15)
-
-	// File Header
-	e.addSection(pdf, "File Header")
-	e.addField(pdf, "Priority Code", file.Header.PriorityCode)
-	e.addField(pdf, "Immediate Destination", file.Header.ImmediateDestination)
-	e.addField(pdf, "Immediate Origin", file.Header.ImmediateOrigin)
-	e.addField(pdf, "File Creation Date", file.Header.FileCreationDate.Format("2006-01-02"))
-	e.addField(pdf, "File Creation Time", file.Header.FileCreationTime)
-	e.addField(pdf, "File ID Modifier", file.Header.FileIDModifier)
-	e.addField(pdf, "Record Size", file.Header.RecordSize)
-	e.addField(pdf, "Blocking Factor", file.Header.BlockingFactor)
-	e.addField(pdf, "Format Code", file.Header.FormatCode)
-	e.addField(pdf, "Destination Name", file.Header.DestinationName)
-	e.addField(pdf, "Origin Name", file.Header.OriginName)
-	e.addField(pdf, "Reference Code", file.Header.ReferenceCode)
-	pdf.Ln(10)
-
-	// Batches
-	for i, batch := range file.Batches {
-		e.addSection(pdf, fmt.Sprintf("Batch %d", i+1))
-
-		// Batch Header
-		e.addSubSection(pdf, "Batch Header")
-		e.addField(pdf, "Service Class Code", batch.Header.ServiceClassCode)
-		e.addField(pdf, "Company Name", batch.Header.CompanyName)
-		e.addField(pdf, "Company Discretionary Data", batch.Header.CompanyDiscretionaryData)
-		e.addField(pdf, "Company Identification", batch.Header.CompanyIdentification)
-		e.addField(pdf, "Standard Entry Class", batch.Header.StandardEntryClass)
-		e.addField(pdf, "Company Entry Description", batch.Header.CompanyEntryDescription)
-		e.addField(pdf, "Company Descriptive Date", batch.Header.CompanyDescriptiveDate)
-		e.addField(pdf, "Settlement Date", batch.Header.SettlementDate)
-		e.addField(pdf, "Originator Status Code", batch.Header.OriginatorStatusCode)
-		e.addField(pdf, "Originating DFI", batch.Header.OriginatingDFI)
-		pdf.Ln(5)
-
-		// Entries
-		e.addSubSection(pdf, "Entries")
-		for j, entry := range batch.Entries {
-			e.addField(pdf, fmt.Sprintf("Entry %d", j+1), "")
-			e.addField(pdf, "Transaction Code", entry.TransactionCode)
-			e.addField(pdf, "Receiving DFI", entry.ReceivingDFI)
-			e.addField(pdf, "Check Digit", entry.CheckDigit)
-			e.addField(pdf, "DFI Account Number", entry.DFIAccountNumber)
-			e.addField(pdf, "Amount", fmt.Sprintf("$%.2f", float64(entry.Amount)/100.0))
-			e.addField(pdf, "Individual ID Number", entry.IndividualIDNumber)
-			e.addField(pdf, "Individual Name", entry.IndividualName)
-			e.addField(pdf, "Discretionary Data", entry.DiscretionaryData)
-			e.addField(pdf, "Addenda Record Indicator", entry.AddendaRecordIndicator)
-			e.addField(pdf, "Trace Number", entry.TraceNumber)
-
-			// Addenda Records
-			if len(entry.AddendaRecords) > 0 {
-				e.addSubSection(pdf, "Addenda Records")
-				for k, addenda := range entry.AddendaRecords {
-					e.addField(pdf, fmt.Sprintf("Addenda %d", k+1), "")
-					e.addField(pdf, "Type Code", addenda.AddendaTypeCode)
-					e.addField(pdf, "Payment Related Information", addenda.PaymentRelatedInformation)
-					e.addField(pdf, "Sequence Number", addenda.AddendaSequenceNumber)
-					e.addField(pdf, "Entry Detail Sequence Number", addenda.EntryDetailSequenceNumber)
-				}
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w as an ACH control report: a cover page
+// with the file totals and hashes, a table of the entries of each batch with
+// running subtotals, and a sign-off page for dual control before the file is
+// transmitted. Every page has a header and a page X of Y footer. Account
+// numbers are always masked. The SHA-256 hash is of the whole file as the
+// service writes it, before the options select batches.
+func (e *PDFExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	digest := sha256.Sum256(file.ToBytes())
+	report := newPDFReport(e.options, e.options.apply(file), hex.EncodeToString(digest[:]))
+
+	report.cover()
+	for i := range report.file.Batches {
+		report.batch(&report.file.Batches[i], i == 0)
+	}
+	report.signOff()
+
+	if err := report.pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to generate PDF: %v", err)
+	}
+	return nil
+}
+
+// Page geometry of the PDF documents, in millimetres
+const (
+	pdfMargin       = 10.0
+	pdfFooterSpace  = 15.0
+	pdfRowHeight    = 6.0
+	pdfHeadingSpace = 40.0
+)
+
+// pdfColumn is a column of a report table
+type pdfColumn struct {
+	title string
+	width float64
+	align string
+}
+
+// pdfEntryColumns are the columns of the entry tables
+var pdfEntryColumns = []pdfColumn{
+	{"#", 10, "R"},
+	{"Trace Number", 32, "L"},
+	{"Name", 50, "L"},
+	{"Routing", 22, "L"},
+	{"Account", 28, "L"},
+	{"TC", 10, "C"},
+	{"Debit", 27, "R"},
+	{"Credit", 27, "R"},
+	{"Running Debits", 35.5, "R"},
+	{"Running Credits", 35.5, "R"},
+}
+
+// pdfBatchColumns are the columns of the batch summary of the cover page
+var pdfBatchColumns = []pdfColumn{
+	{"Batch", 20, "L"},
+	{"Company", 50, "L"},
+	{"Company ID", 28, "L"},
+	{"SEC", 14, "C"},
+	{"Description", 30, "L"},
+	{"Effective", 25, "L"},
+	{"Entries", 20, "R"},
+	{"Debits", 45, "R"},
+	{"Credits", 45, "R"},
+}
+
+// pdfDocument is an A4 PDF with the helpers the PDF documents share
+type pdfDocument struct {
+	pdf  *gofpdf.Fpdf
+	tr   func(string) string
+	opts Options
+	// width is the printable width and bottom the lowest position rows may
+	// reach above the footer
+	width, bottom float64
+}
+
+// newPDFDocument creates an A4 document in portrait (P) or landscape (L)
+// orientation, numbering its pages out of the total
+func newPDFDocument(opts Options, orientation string) *pdfDocument {
+	pdf := gofpdf.New(orientation, "mm", "A4", "")
+	width, height := pdf.GetPageSize()
+	d := &pdfDocument{
+		pdf:    pdf,
+		tr:     pdf.UnicodeTranslatorFromDescriptor(""),
+		opts:   opts,
+		width:  width - 2*pdfMargin,
+		bottom: height - pdfFooterSpace,
+	}
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AliasNbPages("")
+	return d
+}
+
+// pageHeader writes a title and a subtitle above a rule at the top of a page
+func (d *pdfDocument) pageHeader(title, subtitle string) {
+	d.pdf.SetFont("Arial", "B", 10)
+	d.pdf.CellFormat(d.width/2, 6, d.tr(title), "", 0, "L", false, 0, "")
+	d.pdf.SetFont("Arial", "", 9)
+	d.pdf.CellFormat(d.width/2, 6, d.tr(subtitle), "", 1, "R", false, 0, "")
+	y := d.pdf.GetY() + 1
+	d.pdf.Line(pdfMargin, y, pdfMargin+d.width, y)
+	d.pdf.SetY(y + 4)
+}
+
+// pageFooter writes text and the page number at the bottom of a page
+func (d *pdfDocument) pageFooter(text string) {
+	d.pdf.SetY(-12)
+	d.pdf.SetFont("Arial", "", 8)
+	d.pdf.CellFormat(d.width/2, 5, d.tr(text), "T", 0, "L", false, 0, "")
+	d.pdf.CellFormat(d.width/2, 5, fmt.Sprintf("Page %d of {nb}", d.pdf.PageNo()), "T", 0, "R", false, 0, "")
+}
+
+// money formats an amount in the requested amount format, with the locale's
+// separators by default
+func (d *pdfDocument) money(cents int64) string {
+	return d.opts.amount(cents, d.opts.decimal)
+}
+
+// pdfReport writes the pages of a control report
+type pdfReport struct {
+	*pdfDocument
+	file   *models.NachaFile
+	digest string
+	// created is the file creation date and time
+	created string
+}
+
+func newPDFReport(opts Options, file *models.NachaFile, digest string) *pdfReport {
+	r := &pdfReport{
+		pdfDocument: newPDFDocument(opts, "L"),
+		file:        file,
+		digest:      digest,
+	}
+	created := opts.fileCreationTime(&file.Header)
+	r.created = opts.date(created, "2006-01-02") + " " + created.Format("15:04")
+
+	r.pdf.SetHeaderFunc(r.header)
+	r.pdf.SetFooterFunc(r.footer)
+	return r
+}
+
+// header identifies the file at the top of every page
+func (r *pdfReport) header() {
+	h := &r.file.Header
+	r.pageHeader("ACH Control Report", fmt.Sprintf("%s to %s - %s - File ID %s",
+		strings.TrimSpace(h.OriginName), strings.TrimSpace(h.DestinationName), r.created, h.FileIDModifier))
+}
+
+// footer writes the file digest and the page number
+func (r *pdfReport) footer() {
+	r.pageFooter("SHA-256 " + r.digest)
+}
+
+// cover writes the first page: the file identification, its totals and
+// hashes, and a summary of the batches
+func (r *pdfReport) cover() {
+	h, c := &r.file.Header, &r.file.Control
+	r.pdf.AddPage()
+
+	r.pdf.SetFont("Arial", "B", 18)
+	r.pdf.CellFormat(r.width, 10, "ACH Control Report", "", 1, "L", false, 0, "")
+	r.pdf.SetFont("Arial", "", 11)
+	r.pdf.CellFormat(r.width, 7, r.tr(fmt.Sprintf("%s to %s", strings.TrimSpace(h.OriginName), strings.TrimSpace(h.DestinationName))), "", 1, "L", false, 0, "")
+	r.pdf.Ln(4)
+
+	var entries int
+	for _, batch := range r.file.Batches {
+		entries += len(batch.Entries)
+	}
+
+	top := r.pdf.GetY()
+	r.section("File")
+	r.pairs(pdfMargin, [][2]string{
+		{"Immediate Origin", strings.TrimSpace(h.ImmediateOrigin)},
+		{"Origin Name", strings.TrimSpace(h.OriginName)},
+		{"Immediate Destination", strings.TrimSpace(h.ImmediateDestination)},
+		{"Destination Name", strings.TrimSpace(h.DestinationName)},
+		{"File Creation", r.created},
+		{"File ID Modifier", h.FileIDModifier},
+		{"Reference Code", strings.TrimSpace(h.ReferenceCode)},
+	})
+	left := r.pdf.GetY()
+
+	r.pdf.SetXY(pdfMargin+r.width/2, top)
+	r.section("Totals")
+	r.pairs(pdfMargin+r.width/2, [][2]string{
+		{"Batches", strconv.Itoa(c.BatchCount)},
+		{"Entries", strconv.Itoa(entries)},
+		{"Entry/Addenda Records", strconv.Itoa(c.EntryAddendaCount)},
+		{"Blocks", strconv.Itoa(c.BlockCount)},
+		{"Total Debits", r.money(c.TotalDebitAmount)},
+		{"Total Credits", r.money(c.TotalCreditAmount)},
+		{"Entry Hash", c.EntryHash},
+	})
+	r.pdf.SetY(max(left, r.pdf.GetY()) + 2)
+	r.pdf.SetFont("Arial", "B", 9)
+	r.pdf.CellFormat(45, pdfRowHeight, "File SHA-256:", "", 0, "L", false, 0, "")
+	r.pdf.SetFont("Courier", "", 9)
+	r.pdf.CellFormat(r.width-45, pdfRowHeight, r.digest, "", 1, "L", false, 0, "")
+	r.pdf.Ln(6)
+
+	r.section("Batches")
+	r.tableHeader(pdfBatchColumns)
+	var debits, credits int64
+	for _, batch := range r.file.Batches {
+		bh, bc := &batch.Header, &batch.Control
+		if r.pdf.GetY()+pdfRowHeight > r.bottom {
+			r.pdf.AddPage()
+			r.tableHeader(pdfBatchColumns)
+		}
+		r.row(pdfBatchColumns, "", false,
+			bh.BatchNumber, strings.TrimSpace(bh.CompanyName), strings.TrimSpace(bh.CompanyIdentification),
+			bh.StandardEntryClass, strings.TrimSpace(bh.CompanyEntryDescription), r.opts.effectiveDate(bh.EffectiveEntryDate, "2006-01-02"),
+			strconv.Itoa(len(batch.Entries)), r.money(bc.TotalDebitAmount), r.money(bc.TotalCreditAmount))
+		debits += bc.TotalDebitAmount
+		credits += bc.TotalCreditAmount
+	}
+	r.row(pdfBatchColumns, "T", true, "Total", "", "", "", "", "", strconv.Itoa(entries), r.money(debits), r.money(credits))
+}
+
+// batch writes the table of the entries of a batch with running subtotals,
+// followed by the subtotals and the batch control they are checked against
+func (r *pdfReport) batch(batch *models.Batch, first bool) {
+	bh, bc := &batch.Header, &batch.Control
+	if first || r.pdf.GetY()+pdfHeadingSpace > r.bottom {
+		r.pdf.AddPage()
+	}
+
+	title := fmt.Sprintf("Batch %s - %s", bh.BatchNumber, strings.TrimSpace(bh.CompanyName))
+	r.section(title)
+	r.pdf.SetFont("Arial", "", 9)
+	details := fmt.Sprintf("Company ID %s   SEC %s   Description %s   Service Class %s   Effective %s   Originating DFI %s",
+		strings.TrimSpace(bh.CompanyIdentification), bh.StandardEntryClass, strings.TrimSpace(bh.CompanyEntryDescription),
+		bh.ServiceClassCode, r.opts.effectiveDate(bh.EffectiveEntryDate, "2006-01-02"), bh.OriginatingDFI)
+	r.pdf.CellFormat(r.width, pdfRowHeight, r.tr(details), "", 1, "L", false, 0, "")
+	r.pdf.Ln(1)
+	r.tableHeader(pdfEntryColumns)
+
+	var debits, credits int64
+	for i, entry := range batch.Entries {
+		height := pdfRowHeight * float64(1+len(entry.AddendaRecords))
+		if r.pdf.GetY()+height > r.bottom {
+			r.pdf.AddPage()
+			r.section(title + " (continued)")
+			r.tableHeader(pdfEntryColumns)
+		}
+
+		debit, credit := "", ""
+		switch summary.Direction(entry.TransactionCode) {
+		case summary.DirectionDebit:
+			debits += entry.Amount
+			debit = r.money(entry.Amount)
+		case summary.DirectionCredit:
+			credits += entry.Amount
+			credit = r.money(entry.Amount)
+		}
+		r.row(pdfEntryColumns, "", false,
+			strconv.Itoa(i+1), strings.TrimSpace(entry.TraceNumber), strings.TrimSpace(entry.IndividualName),
+			strings.TrimSpace(entry.ReceivingDFI)+strings.TrimSpace(entry.CheckDigit), r.opts.mask(entry.DFIAccountNumber),
+			entry.TransactionCode, debit, credit, r.money(debits), r.money(credits))
+
+		r.pdf.SetFont("Arial", "I", 8)
+		for _, addenda := range entry.AddendaRecords {
+			r.pdf.CellFormat(pdfEntryColumns[0].width, pdfRowHeight, "", "", 0, "L", false, 0, "")
+			r.pdf.CellFormat(r.width-pdfEntryColumns[0].width, pdfRowHeight,
+				r.tr("Addenda "+addenda.AddendaTypeCode+": "+strings.TrimSpace(addenda.PaymentRelatedInformation)), "", 1, "L", false, 0, "")
+		}
+	}
+
+	if r.pdf.GetY()+2*pdfRowHeight > r.bottom {
+		r.pdf.AddPage()
+		r.section(title + " (continued)")
+		r.tableHeader(pdfEntryColumns)
+	}
+	r.row(pdfEntryColumns, "T", true, "", "Batch total", fmt.Sprintf("%d entries", len(batch.Entries)), "", "", "",
+		r.money(debits), r.money(credits), "", "")
+	check := "matches the entries"
+	if debits != bc.TotalDebitAmount || credits != bc.TotalCreditAmount {
+		check = "DOES NOT MATCH the entries"
+	}
+	r.row(pdfEntryColumns, "", false, "", "Batch control", "Entry hash "+bc.EntryHash, "", "", "",
+		r.money(bc.TotalDebitAmount), r.money(bc.TotalCreditAmount), check, "")
+	r.pdf.Ln(8)
+}
+
+// signOff writes the last page: the totals being approved and a block for
+// the signatures of dual control
+func (r *pdfReport) signOff() {
+	c := &r.file.Control
+	r.pdf.AddPage()
+	r.section("Approval")
+
+	r.pdf.SetFont("Arial", "", 10)
+	statement := fmt.Sprintf("The undersigned have reviewed this report and approve the transmission of the file created %s "+
+		"with %d batches, %d entry and addenda records, total debits of %s and total credits of %s, "+
+		"entry hash %s and SHA-256 %s.",
+		r.created, c.BatchCount, c.EntryAddendaCount,
+		r.money(c.TotalDebitAmount), r.money(c.TotalCreditAmount), c.EntryHash, r.digest)
+	r.pdf.MultiCell(r.width, 6, r.tr(statement), "", "L", false)
+	r.pdf.Ln(8)
+
+	columns := []pdfColumn{
+		{"Role", 50, "L"},
+		{"Name", 75, "L"},
+		{"Signature", 102, "L"},
+		{"Date", 50, "L"},
+	}
+	r.tableHeader(columns)
+	r.pdf.SetFont("Arial", "", 10)
+	for _, role := range []string{"Prepared by", "Reviewed by", "Approved by"} {
+		for i, col := range columns {
+			text := ""
+			if i == 0 {
+				text = role
+			}
+			ln := 0
+			if i == len(columns)-1 {
+				ln = 1
 			}
-			pdf.Ln(5)
+			r.pdf.CellFormat(col.width, 16, text, "1", ln, "L", false, 0, "")
 		}
+	}
+}
 
-		// Batch Control
-		e.addSubSection(pdf, "Batch Control")
-		e.addField(pdf, "Service Class Code", batch.Control.ServiceClassCode)
-		e.addField(pdf, "Entry/Addenda Count", strconv.Itoa(batch.Control.EntryAddendaCount))
-		e.addField(pdf, "Entry Hash", batch.Control.EntryHash)
-		e.addField(pdf, "Total Debit Amount", fmt.Sprintf("$%.2f", float64(batch.Control.TotalDebitAmount)/100.0))
-		e.addField(pdf, "Total Credit Amount", fmt.Sprintf("$%.2f", float64(batch.Control.TotalCreditAmount)/100.0))
-		e.addField(pdf, "Company Identification", batch.Control.CompanyIdentification)
-		e.addField(pdf, "Originating DFI", batch.Control.OriginatingDFI)
-		e.addField(pdf, "Batch Number", batch.Control.BatchNumber)
-		pdf.Ln(10)
-	}
-
-	// File Control
-	e.addSection(pdf, "File Control")
-	e.addField(pdf, "Batch Count", strconv.Itoa(file.Control.BatchCount))
-	e.addField(pdf, "Block Count", strconv.Itoa(file.Control.BlockCount))
-	e.addField(pdf, "Entry/Addenda Count", strconv.Itoa(file.Control.EntryAddendaCount))
-	e.addField(pdf, "Entry Hash", file.Control.EntryHash)
-	e.addField(pdf, "Total Debit Amount", fmt.Sprintf("$%.2f", float64(file.Control.TotalDebitAmount)/100.0))
-	e.addField(pdf, "Total Credit Amount", fmt.Sprintf("$%.2f", float64(file.Control.TotalCreditAmount)/100.0))
-
-	// Write to buffer
-	var buf bytes.Buffer
-	err := pdf.Output(&buf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %v", err)
-	}
-
-	return buf.Bytes(), nil
-}
-
-// Helper functions
-func (e *PDFExporter) addSection(pdf *gofpdf.Fpdf, title string) {
-	pdf.SetFont("Arial", "B", 14)
-	pdf.Cell(190, 10, title)
-	pdf.Ln(8)
-	pdf.SetFont("Arial", "", 10)
-}
-
-func (e *PDFExporter) addSubSection(pdf *gofpdf.Fpdf, title string) {
-	pdf.SetFont("Arial", "B", 12)
-	pdf.Cell(190, 8, title)
-	pdf.Ln(6)
-	pdf.SetFont("Arial", "", 10)
-}
-
-func (e *PDFExporter) addField(pdf *gofpdf.Fpdf, label, value string) {
-	pdf.SetFont("Arial", "B", 10)
-	pdf.Cell(60, 6, label+":")
-	pdf.SetFont("Arial", "", 10)
-	pdf.Cell(130, 6, value)
-	pdf.Ln(6)
+// section writes a heading
+func (d *pdfDocument) section(title string) {
+	d.pdf.SetFont("Arial", "B", 12)
+	d.pdf.CellFormat(d.width, 8, d.tr(title), "", 1, "L", false, 0, "")
+}
+
+// pairs writes labels and values one below the other in a half-page column
+// starting at x
+func (d *pdfDocument) pairs(x float64, pairs [][2]string) {
+	for _, pair := range pairs {
+		d.pdf.SetX(x)
+		d.pdf.SetFont("Arial", "B", 9)
+		d.pdf.CellFormat(45, pdfRowHeight, pair[0]+":", "", 0, "L", false, 0, "")
+		d.pdf.SetFont("Arial", "", 9)
+		d.pdf.CellFormat(d.width/2-45, pdfRowHeight, d.tr(pair[1]), "", 1, "L", false, 0, "")
+	}
+}
+
+// tableHeader writes the titles of the columns of a table
+func (d *pdfDocument) tableHeader(columns []pdfColumn) {
+	d.pdf.SetFont("Arial", "B", 9)
+	d.pdf.SetFillColor(230, 230, 230)
+	for _, col := range columns {
+		d.pdf.CellFormat(col.width, pdfRowHeight+1, col.title, "1", 0, col.align, true, 0, "")
+	}
+	d.pdf.Ln(-1)
+}
+
+// row writes a row of a table, with a border such as T above it
+func (d *pdfDocument) row(columns []pdfColumn, border string, bold bool, values ...string) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	d.pdf.SetFont("Arial", style, 9)
+	for i, col := range columns {
+		d.pdf.CellFormat(col.width, pdfRowHeight, d.tr(values[i]), border, 0, col.align, false, 0, "")
+	}
+	d.pdf.Ln(-1)
 }
@@ -0,0 +1,77 @@
+package exporters
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pdfText returns the uncompressed page content of a PDF
+func pdfText(t *testing.T, content []byte) string {
+	var text strings.Builder
+	streams := regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`).FindAllSubmatch(content, -1)
+	for _, stream := range streams {
+		r, err := zlib.NewReader(bytes.NewReader(stream[1]))
+		if err != nil {
+			continue
+		}
+		data, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		text.Write(data)
+	}
+	return text.String()
+}
+
+func TestPDFControlReport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: A cover page, the batch tables and a sign-off page, each
+	// numbered out of the total
+	data, contentType, err := export(t, file, "PDF", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/pdf", contentType)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF")))
+	text := pdfText(t, data)
+	assert.Contains(t, text, "(Page 1 of 3)")
+	assert.Contains(t, text, "(Page 3 of 3)")
+	assert.NotContains(t, text, "{nb}")
+
+	// Test case 2: File totals and hashes on the cover page
+	assert.Contains(t, text, "(Total Debits:)")
+	assert.Contains(t, text, "(2026-10-17 12:00)")
+	assert.Contains(t, text, "(8,700.00)")
+	assert.Contains(t, text, "(14,100.00)")
+	digest := sha256.Sum256(file.ToBytes())
+	assert.Contains(t, text, "("+hex.EncodeToString(digest[:])+")")
+
+	// Test case 3: Running subtotals, masked accounts and the batch controls
+	assert.Contains(t, text, "(Running Debits)")
+	assert.Contains(t, text, "(7,500.00)")
+	assert.Contains(t, text, "(**1111)")
+	assert.NotContains(t, text, "111111")
+	assert.Contains(t, text, "(Addenda 05: INV-1001 INV-1002)")
+	assert.Contains(t, text, "(matches the entries)")
+	assert.NotContains(t, text, "DOES NOT MATCH")
+
+	// Test case 4: The sign-off block for dual control
+	assert.Contains(t, text, "(Prepared by)")
+	assert.Contains(t, text, "(Approved by)")
+
+	// Test case 5: Amounts in cents and a single batch
+	data, _, err = export(t, file, "PDF", Options{AmountFormat: AmountCents, BatchNumbers: []string{"2"}})
+	if assert.NoError(t, err) {
+		text = pdfText(t, data)
+		assert.Contains(t, text, "(510000)")
+		assert.Contains(t, text, "(ACME SUPPLIES)")
+		assert.NotContains(t, text, "JOAO DA SILVA")
+	}
+}
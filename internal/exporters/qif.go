@@ -0,0 +1,66 @@
+package exporters
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:        "QIF",
+		Extension:   ".qif",
+		Description: "Quicken Interchange Format bank transactions, one per entry",
+		New:         func() NachaExporter { return NewQIFExporter() },
+	})
+}
+
+// QIFExporter handles export of the entries to QIF
+type QIFExporter struct {
+	*BaseExporter
+}
+
+// NewQIFExporter creates a new QIF exporter
+func NewQIFExporter() *QIFExporter {
+	return &QIFExporter{
+		BaseExporter: NewBaseExporter("application/qif"),
+	}
+}
+
+// Export converts the entries of a NACHA file to QIF
+func (e *QIFExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes the entries of a NACHA file to w as a QIF bank account
+// list. Each transaction has the date (MM/DD/YYYY unless a date format is
+// requested), the signed amount, the payee, a memo and, as its number, the
+// same ID as the OFX FITID.
+func (e *QIFExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	transactions := statementTransactions(file)
+	if len(transactions) == 0 {
+		return fmt.Errorf("QIF needs payment entries: %w", ErrNoEntries)
+	}
+
+	out := bufio.NewWriter(w)
+	fmt.Fprintln(out, "!Type:Bank")
+	for _, t := range transactions {
+		fmt.Fprintf(out, "D%s\n", opts.date(t.posted, "01/02/2006"))
+		fmt.Fprintf(out, "T%s\n", decimalNumber(t.amount))
+		fmt.Fprintf(out, "N%s\n", t.id)
+		fmt.Fprintf(out, "P%s\n", t.name)
+		if t.memo != "" {
+			fmt.Fprintf(out, "M%s\n", t.memo)
+		}
+		fmt.Fprintln(out, "^")
+	}
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write QIF: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package exporters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Capabilities describes what the output of an export format contains
+type Capabilities struct {
+	// Binary is set when the output is not text
+	Binary bool
+	// Aggregated is set when the output holds aggregates instead of records
+	Aggregated bool
+	// Importable is set when the output can be imported back into a NACHA file
+	Importable bool
+}
+
+// Format describes a registered export format
+type Format struct {
+	Name         string
+	ContentType  string
+	Extension    string
+	Description  string
+	Capabilities Capabilities
+	// New creates an exporter for the format
+	New func() NachaExporter
+}
+
+var registry = struct {
+	sync.RWMutex
+	formats map[string]Format
+}{formats: make(map[string]Format)}
+
+// Register adds an export format to the registry. Names are case-insensitive
+// and must be unique. The content type defaults to the one reported by the
+// exporter.
+func Register(format Format) error {
+	name := strings.ToUpper(strings.TrimSpace(format.Name))
+	if name == "" {
+		return fmt.Errorf("format name cannot be empty")
+	}
+	if format.New == nil {
+		return fmt.Errorf("format %s has no constructor", name)
+	}
+
+	format.Name = name
+	if format.ContentType == "" {
+		format.ContentType = format.New().GetContentType()
+	}
+	if format.Extension != "" && !strings.HasPrefix(format.Extension, ".") {
+		format.Extension = "." + format.Extension
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.formats[name]; ok {
+		return fmt.Errorf("format %s is already registered", name)
+	}
+	registry.formats[name] = format
+	return nil
+}
+
+// MustRegister adds an export format to the registry and panics on failure.
+// It is meant to be called from init functions.
+func MustRegister(format Format) {
+	if err := Register(format); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns a registered export format by name
+func Lookup(name string) (Format, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	format, ok := registry.formats[strings.ToUpper(strings.TrimSpace(name))]
+	return format, ok
+}
+
+// Formats returns every registered export format sorted by name
+func Formats() []Format {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	formats := make([]Format, 0, len(registry.formats))
+	for _, format := range registry.formats {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		return formats[i].Name < formats[j].Name
+	})
+	return formats
+}
+
+// formatNames returns the names of every registered export format
+func formatNames() []string {
+	formats := Formats()
+	names := make([]string, len(formats))
+	for i, format := range formats {
+		names[i] = format.Name
+	}
+	return names
+}
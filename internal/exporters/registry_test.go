@@ -0,0 +1,33 @@
+package exporters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	// Test case 1: Names are normalized and the content type comes from the exporter
+	// The registry is global, so only register once when the test is repeated
+	if _, ok := Lookup("TEST_LINES"); !ok {
+		err := Register(Format{
+			Name:      " test_lines ",
+			Extension: "txt",
+			New:       func() NachaExporter { return NewTXTExporter() },
+		})
+		assert.NoError(t, err)
+	}
+	format, ok := Lookup("Test_Lines")
+	if assert.True(t, ok) {
+		assert.Equal(t, "TEST_LINES", format.Name)
+		assert.Equal(t, ".txt", format.Extension)
+		assert.Equal(t, NewTXTExporter().GetContentType(), format.ContentType)
+	}
+	assert.Contains(t, formatNames(), "TEST_LINES")
+
+	// Test case 2: Duplicate, empty and incomplete formats
+	assert.Error(t, Register(Format{Name: "csv", New: func() NachaExporter { return NewCSVExporter() }}))
+	assert.Error(t, Register(Format{Name: " ", New: func() NachaExporter { return NewCSVExporter() }}))
+	assert.Error(t, Register(Format{Name: "NO_CONSTRUCTOR"}))
+	assert.Panics(t, func() { MustRegister(Format{Name: "CSV", New: func() NachaExporter { return NewCSVExporter() }}) })
+}
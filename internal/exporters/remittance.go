@@ -0,0 +1,339 @@
+package exporters
+
+import (
+	"archive/zip"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+	"github.com/nacha-service/pkg/remittance"
+)
+
+// Remittance advice formats
+const (
+	RemittancePDF  = "PDF"
+	RemittanceHTML = "HTML"
+)
+
+// RemittanceExporter writes a zip with a remittance advice for each payment
+// of a file, named after the trace number of its entry. It is not a
+// registered format: the GenerateRemittanceAdvice RPC uses it.
+type RemittanceExporter struct {
+	*BaseExporter
+	format string
+}
+
+// NewRemittanceExporter creates an exporter of PDF or HTML remittance advices
+func NewRemittanceExporter(format string) (*RemittanceExporter, error) {
+	switch format {
+	case RemittancePDF, RemittanceHTML:
+	default:
+		return nil, fmt.Errorf("unsupported remittance advice format: %s (supported formats: %s, %s)", format, RemittancePDF, RemittanceHTML)
+	}
+	return &RemittanceExporter{
+		BaseExporter: NewBaseExporter("application/zip"),
+		format:       format,
+	}, nil
+}
+
+// Export converts a NACHA file to a zip of remittance advices
+func (e *RemittanceExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a zip of remittance advices to w, one for each entry of
+// the file except prenotes, which move no money. An advice shows the payer
+// and payee, the amount, effective date, masked account and trace number of
+// the payment and the invoice lines decoded from its 05 addenda. Entries that
+// share a trace number are told apart by a -2, -3... suffix.
+func (e *RemittanceExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+
+	var advices []*remittanceAdvice
+	for i := range file.Batches {
+		batch := &file.Batches[i]
+		for j := range batch.Entries {
+			if !models.IsPrenote(batch.Entries[j].TransactionCode) {
+				advices = append(advices, newRemittanceAdvice(opts, file, batch, &batch.Entries[j]))
+			}
+		}
+	}
+	if len(advices) == 0 {
+		return fmt.Errorf("remittance advices need payment entries: %w", ErrNoEntries)
+	}
+
+	extension := ".pdf"
+	if e.format == RemittanceHTML {
+		extension = ".html"
+	}
+
+	archive := zip.NewWriter(w)
+	seen := make(map[string]int)
+	for _, advice := range advices {
+		name := advice.TraceNumber
+		if name == "" {
+			name = "entry"
+		}
+		seen[name]++
+		if n := seen[name]; n > 1 {
+			name += "-" + strconv.Itoa(n)
+		}
+
+		out, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     name + extension,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %v", name+extension, err)
+		}
+		if e.format == RemittanceHTML {
+			err = remittanceTemplate.Execute(out, advice)
+		} else {
+			err = writeRemittancePDF(out, opts, advice)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write remittance advice %s: %v", name, err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to close zip: %v", err)
+	}
+	return nil
+}
+
+// remittanceAdvice is the view of a payment rendered into an advice
+type remittanceAdvice struct {
+	Payer         string
+	PayerID       string
+	Payee         string
+	PayeeID       string
+	Direction     string
+	Amount        string
+	EffectiveDate string
+	Account       string
+	Routing       string
+	TraceNumber   string
+	Description   string
+	SECCode       string
+	Lines         []remittanceLine
+	// Text is the payment related information of the addenda as sent
+	Text []string
+}
+
+type remittanceLine struct {
+	Kind        string
+	Reference   string
+	Description string
+	Gross       string
+	Discount    string
+	Paid        string
+}
+
+func newRemittanceAdvice(opts Options, file *models.NachaFile, batch *models.Batch, entry *models.EntryDetail) *remittanceAdvice {
+	money := func(cents int64) string { return opts.amount(cents, dollarAmount) }
+	optional := func(cents int64) string {
+		if cents == 0 {
+			return ""
+		}
+		return money(cents)
+	}
+
+	payer := strings.TrimSpace(batch.Header.CompanyName)
+	if payer == "" {
+		payer = strings.TrimSpace(file.Header.OriginName)
+	}
+	advice := &remittanceAdvice{
+		Payer:         payer,
+		PayerID:       strings.TrimSpace(batch.Header.CompanyIdentification),
+		Payee:         strings.TrimSpace(entry.IndividualName),
+		PayeeID:       strings.TrimSpace(entry.IndividualIDNumber),
+		Direction:     "Credit to your account",
+		Amount:        money(entry.Amount),
+		EffectiveDate: opts.effectiveDate(batch.Header.EffectiveEntryDate, "2006-01-02"),
+		Account:       opts.mask(entry.DFIAccountNumber),
+		Routing:       strings.TrimSpace(entry.ReceivingDFI) + strings.TrimSpace(entry.CheckDigit),
+		TraceNumber:   strings.TrimSpace(entry.TraceNumber),
+		Description:   strings.TrimSpace(batch.Header.CompanyEntryDescription),
+		SECCode:       batch.Header.StandardEntryClass,
+	}
+	if summary.Direction(entry.TransactionCode) == summary.DirectionDebit {
+		advice.Direction = "Debit from your account"
+	}
+
+	var texts []string
+	for _, addenda := range entry.AddendaRecords {
+		if addenda.AddendaTypeCode == "05" {
+			texts = append(texts, addenda.PaymentRelatedInformation)
+			advice.Text = append(advice.Text, strings.TrimSpace(addenda.PaymentRelatedInformation))
+		}
+	}
+	for _, line := range remittance.Parse(texts...) {
+		advice.Lines = append(advice.Lines, remittanceLine{
+			Kind:        line.Kind(),
+			Reference:   line.Reference,
+			Description: line.Description,
+			Gross:       optional(line.Gross),
+			Discount:    optional(line.Discount),
+			Paid:        optional(line.Paid),
+		})
+	}
+	return advice
+}
+
+// pdfRemittanceColumns are the columns of the remittance lines
+var pdfRemittanceColumns = []pdfColumn{
+	{"Type", 28, "L"},
+	{"Reference", 36, "L"},
+	{"Description", 66, "L"},
+	{"Invoice Amount", 22, "R"},
+	{"Discount", 16, "R"},
+	{"Paid", 22, "R"},
+}
+
+// writeRemittancePDF writes an advice as a portrait A4 PDF
+func writeRemittancePDF(w io.Writer, opts Options, advice *remittanceAdvice) error {
+	d := newPDFDocument(opts, "P")
+	d.pdf.SetHeaderFunc(func() { d.pageHeader("Remittance Advice", advice.Payer) })
+	d.pdf.SetFooterFunc(func() { d.pageFooter("Trace number " + advice.TraceNumber) })
+	d.pdf.AddPage()
+
+	d.pdf.SetFont("Arial", "B", 18)
+	d.pdf.CellFormat(d.width, 10, "Remittance Advice", "", 1, "L", false, 0, "")
+	d.pdf.SetFont("Arial", "", 11)
+	d.pdf.CellFormat(d.width, 7, d.tr(fmt.Sprintf("From %s to %s", advice.Payer, advice.Payee)), "", 1, "L", false, 0, "")
+	d.pdf.Ln(4)
+
+	top := d.pdf.GetY()
+	d.section("Payee")
+	d.pairs(pdfMargin, [][2]string{
+		{"Name", advice.Payee},
+		{"ID", advice.PayeeID},
+		{"Account", advice.Account},
+		{"Bank Routing", advice.Routing},
+	})
+	left := d.pdf.GetY()
+	d.pdf.SetXY(pdfMargin+d.width/2, top)
+	d.section("Payment")
+	d.pairs(pdfMargin+d.width/2, [][2]string{
+		{"Amount", advice.Amount},
+		{"Type", advice.Direction},
+		{"Effective Date", advice.EffectiveDate},
+		{"Trace Number", advice.TraceNumber},
+		{"Payer", advice.Payer + " " + advice.PayerID},
+		{"Description", advice.Description + " (" + advice.SECCode + ")"},
+	})
+	d.pdf.SetY(max(left, d.pdf.GetY()) + 6)
+
+	d.section("Remittance Detail")
+	if len(advice.Lines) == 0 {
+		d.pdf.SetFont("Arial", "", 10)
+		d.pdf.CellFormat(d.width, pdfRowHeight, "No remittance information was sent with this payment.", "", 1, "L", false, 0, "")
+	} else {
+		d.tableHeader(pdfRemittanceColumns)
+		for _, line := range advice.Lines {
+			if d.pdf.GetY()+pdfRowHeight > d.bottom {
+				d.pdf.AddPage()
+				d.section("Remittance Detail (continued)")
+				d.tableHeader(pdfRemittanceColumns)
+			}
+			d.row(pdfRemittanceColumns, "", false, line.Kind, line.Reference, line.Description, line.Gross, line.Discount, line.Paid)
+		}
+		d.row(pdfRemittanceColumns, "T", true, "Total", "", "", "", "", advice.Amount)
+	}
+
+	if len(advice.Text) > 0 {
+		if d.pdf.GetY()+pdfHeadingSpace > d.bottom {
+			d.pdf.AddPage()
+		}
+		d.pdf.Ln(6)
+		d.section("Remittance Information as Sent")
+		d.pdf.SetFont("Courier", "", 8)
+		for _, text := range advice.Text {
+			d.pdf.MultiCell(d.width, 4, d.tr(text), "", "L", false)
+		}
+	}
+
+	if err := d.pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to generate PDF: %v", err)
+	}
+	return nil
+}
+
+var remittanceTemplate = template.Must(template.New("remittance").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="utf-8">
+    <meta http-equiv="Content-Security-Policy" content="default-src 'none'; style-src 'unsafe-inline'">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>Remittance Advice - {{.TraceNumber}}</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 20px auto; max-width: 900px; color: #222; }
+        h1, h2 { color: #333; }
+        .grid { display: grid; grid-template-columns: 1fr 1fr; gap: 20px; }
+        .label { font-weight: bold; display: inline-block; min-width: 130px; }
+        table { border-collapse: collapse; width: 100%; margin: 10px 0; }
+        th, td { border: 1px solid #ddd; padding: 6px 8px; text-align: left; }
+        th { background: #f0f0f0; }
+        .amount { text-align: right; font-variant-numeric: tabular-nums; }
+        tfoot td { font-weight: bold; }
+        pre { background: #f7f7f7; padding: 8px; white-space: pre-wrap; }
+    </style>
+</head>
+<body>
+    <h1>Remittance Advice</h1>
+    <p>From {{.Payer}} to {{.Payee}}</p>
+
+    <div class="grid">
+        <div>
+            <h2>Payee</h2>
+            <div><span class="label">Name:</span> {{.Payee}}</div>
+            <div><span class="label">ID:</span> {{.PayeeID}}</div>
+            <div><span class="label">Account:</span> {{.Account}}</div>
+            <div><span class="label">Bank Routing:</span> {{.Routing}}</div>
+        </div>
+        <div>
+            <h2>Payment</h2>
+            <div><span class="label">Amount:</span> {{.Amount}}</div>
+            <div><span class="label">Type:</span> {{.Direction}}</div>
+            <div><span class="label">Effective Date:</span> {{.EffectiveDate}}</div>
+            <div><span class="label">Trace Number:</span> {{.TraceNumber}}</div>
+            <div><span class="label">Payer:</span> {{.Payer}} {{.PayerID}}</div>
+            <div><span class="label">Description:</span> {{.Description}} ({{.SECCode}})</div>
+        </div>
+    </div>
+
+    <h2>Remittance Detail</h2>
+    {{- if .Lines}}
+    <table>
+        <thead>
+            <tr><th>Type</th><th>Reference</th><th>Description</th><th class="amount">Invoice Amount</th><th class="amount">Discount</th><th class="amount">Paid</th></tr>
+        </thead>
+        <tbody>
+            {{- range .Lines}}
+            <tr><td>{{.Kind}}</td><td>{{.Reference}}</td><td>{{.Description}}</td><td class="amount">{{.Gross}}</td><td class="amount">{{.Discount}}</td><td class="amount">{{.Paid}}</td></tr>
+            {{- end}}
+        </tbody>
+        <tfoot>
+            <tr><td colspan="5">Total</td><td class="amount">{{.Amount}}</td></tr>
+        </tfoot>
+    </table>
+    {{- else}}
+    <p>No remittance information was sent with this payment.</p>
+    {{- end}}
+    {{- if .Text}}
+
+    <h2>Remittance Information as Sent</h2>
+    <pre>{{range .Text}}{{.}}
+{{end}}</pre>
+    {{- end}}
+</body>
+</html>
+`))
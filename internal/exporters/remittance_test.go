@@ -0,0 +1,113 @@
+package exporters
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// readZip returns the files of a ZIP archive by name
+func readZip(t *testing.T, data []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if assert.NoError(t, err) {
+			data, err := io.ReadAll(r)
+			r.Close()
+			assert.NoError(t, err)
+			files[f.Name] = string(data)
+		}
+	}
+	return files
+}
+
+func TestRemittanceExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: A PDF advice for each payment, named after its trace number
+	exporter, err := NewRemittanceExporter(RemittancePDF)
+	if !assert.NoError(t, err) {
+		return
+	}
+	data, err := exporter.Export(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	files := readZip(t, data)
+	assert.Len(t, files, 4)
+	advice, ok := files["076401250000004.pdf"]
+	if assert.True(t, ok) {
+		assert.True(t, strings.HasPrefix(advice, "%PDF"))
+		text := pdfText(t, []byte(advice))
+		assert.Contains(t, text, "(From OUTRA EMPRESA to ACME SUPPLIES)")
+		assert.Contains(t, text, "($5100.00)")
+		assert.Contains(t, text, "(2026-10-20)")
+		assert.Contains(t, text, "(**4444)")
+		assert.NotContains(t, text, "444444")
+		assert.Contains(t, text, "(INV-1001 INV-1002)")
+		assert.Contains(t, text, "(Page 1 of 1)")
+	}
+
+	// Test case 2: Batch selection and amount format options
+	exporter, err = NewRemittanceExporter(RemittanceHTML)
+	if !assert.NoError(t, err) {
+		return
+	}
+	exporter.SetOptions(Options{BatchNumbers: []string{"1"}, AmountFormat: AmountDecimal})
+	data, err = exporter.Export(file)
+	if assert.NoError(t, err) {
+		files = readZip(t, data)
+		assert.Len(t, files, 3)
+		assert.Contains(t, files["076401250000001.html"], "<span class=\"label\">Amount:</span> 7,500.00")
+		assert.Contains(t, files["076401250000001.html"], "No remittance information was sent with this payment.")
+		assert.NotContains(t, files, "076401250000004.html")
+	}
+
+	// Test case 3: X12 remittance spanning addenda decoded into invoice lines
+	exporter.SetOptions(Options{})
+	entry := &file.Batches[1].Entries[0]
+	entry.AddendaRecords = []models.AddendaRecord{
+		{AddendaTypeCode: "05", PaymentRelatedInformation: `RMR*IV*INV-1001**1500.00*1550.00*50.00\RMR*IV*INV-10`, AddendaSequenceNumber: "0001"},
+		{AddendaTypeCode: "05", PaymentRelatedInformation: `02**3600.00\NTE*INV*MARCH <SERVICES>\`, AddendaSequenceNumber: "0002"},
+	}
+	data, err = exporter.Export(file)
+	if assert.NoError(t, err) {
+		page := readZip(t, data)["076401250000004.html"]
+		assert.Contains(t, page, `<tr><td>Invoice</td><td>INV-1001</td><td></td><td class="amount">$1550.00</td><td class="amount">$50.00</td><td class="amount">$1500.00</td></tr>`)
+		assert.Contains(t, page, `<tr><td>Invoice</td><td>INV-1002</td><td>MARCH &lt;SERVICES&gt;</td><td class="amount"></td><td class="amount"></td><td class="amount">$3600.00</td></tr>`)
+		assert.Contains(t, page, `<tr><td colspan="5">Total</td><td class="amount">$5100.00</td></tr>`)
+		assert.Contains(t, page, `content="default-src 'none'; style-src 'unsafe-inline'"`)
+	}
+
+	// Test case 4: Prenotes are skipped and shared trace numbers told apart
+	file.Batches[0].Entries[0].TransactionCode = "23"
+	file.Batches[0].Entries[2].TraceNumber = file.Batches[0].Entries[1].TraceNumber
+	data, err = exporter.Export(file)
+	if assert.NoError(t, err) {
+		files = readZip(t, data)
+		assert.Len(t, files, 3)
+		assert.Contains(t, files, "076401250000002.html")
+		assert.Contains(t, files, "076401250000002-2.html")
+		assert.NotContains(t, files, "076401250000001.html")
+	}
+	for i := range file.Batches {
+		for j := range file.Batches[i].Entries {
+			file.Batches[i].Entries[j].TransactionCode = "33"
+		}
+	}
+	_, err = exporter.Export(file)
+	assert.ErrorIs(t, err, ErrNoEntries)
+
+	// Test case 5: Unknown advice formats
+	_, err = NewRemittanceExporter("TXT")
+	assert.Error(t, err)
+}
@@ -1,13 +1,25 @@
 package exporters
 
 import (
-	"bytes"
+	"bufio"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nacha-service/pkg/models"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:        "SQL",
+		Extension:   ".sql",
+		Description: "Schema and INSERT statements for PostgreSQL, MySQL, SQLite or SQL Server",
+		New:         func() NachaExporter { return NewSQLExporter() },
+	})
+}
+
 // SQLExporter handles export to SQL format
 type SQLExporter struct {
 	*BaseExporter
@@ -22,139 +34,131 @@ func NewSQLExporter() *SQLExporter {
 
 // Export converts a NACHA file to SQL format
 func (e *SQLExporter) Export(file *models.NachaFile) ([]byte, error) {
-	var buf bytes.Buffer
-
-	// Write schema creation
-	buf.WriteString("-- NACHA SQL Schema\n\n")
-
-	// File Header table
-	buf.WriteString(`CREATE TABLE IF NOT EXISTS file_header (
-    id SERIAL PRIMARY KEY,
-    priority_code VARCHAR(2),
-    immediate_destination VARCHAR(10),
-    immediate_origin VARCHAR(10),
-    file_creation_date DATE,
-    file_creation_time VARCHAR(4),
-    file_id_modifier VARCHAR(1),
-    record_size VARCHAR(3),
-    blocking_factor VARCHAR(2),
-    format_code VARCHAR(1),
-    destination_name VARCHAR(23),
-    origin_name VARCHAR(23),
-    reference_code VARCHAR(8)
-);
-
-`)
-
-	// Batch Header table
-	buf.WriteString(`CREATE TABLE IF NOT EXISTS batch_header (
-    id SERIAL PRIMARY KEY,
-    file_id INTEGER REFERENCES file_header(id),
-    service_class_code VARCHAR(3),
-    company_name VARCHAR(16),
-    company_discretionary_data VARCHAR(20),
-    company_identification VARCHAR(10),
-    standard_entry_class VARCHAR(3),
-    company_entry_description VARCHAR(10),
-    company_descriptive_date VARCHAR(6),
-    settlement_date VARCHAR(3),
-    originator_status_code VARCHAR(1),
-    originating_dfi VARCHAR(8)
-);
-
-`)
-
-	// Entry Detail table
-	buf.WriteString(`CREATE TABLE IF NOT EXISTS entry_detail (
-    id SERIAL PRIMARY KEY,
-    batch_id INTEGER REFERENCES batch_header(id),
-    transaction_code VARCHAR(2),
-    receiving_dfi VARCHAR(8),
-    check_digit VARCHAR(1),
-    dfi_account_number VARCHAR(17),
-    amount BIGINT,
-    individual_id_number VARCHAR(15),
-    individual_name VARCHAR(22),
-    discretionary_data VARCHAR(2),
-    addenda_record_indicator VARCHAR(1),
-    trace_number VARCHAR(15)
-);
-
-`)
-
-	// Addenda Record table
-	buf.WriteString(`CREATE TABLE IF NOT EXISTS addenda_record (
-    id SERIAL PRIMARY KEY,
-    entry_id INTEGER REFERENCES entry_detail(id),
-    addenda_type_code VARCHAR(2),
-    payment_related_information VARCHAR(80),
-    addenda_sequence_number VARCHAR(4),
-    entry_detail_sequence_number VARCHAR(7)
-);
-
-`)
-
-	// Batch Control table
-	buf.WriteString(`CREATE TABLE IF NOT EXISTS batch_control (
-    id SERIAL PRIMARY KEY,
-    batch_id INTEGER REFERENCES batch_header(id),
-    service_class_code VARCHAR(3),
-    entry_addenda_count INTEGER,
-    entry_hash VARCHAR(10),
-    total_debit_amount BIGINT,
-    total_credit_amount BIGINT,
-    company_identification VARCHAR(10),
-    originating_dfi VARCHAR(8),
-    batch_number INTEGER
-);
-
-`)
-
-	// File Control table
-	buf.WriteString(`CREATE TABLE IF NOT EXISTS file_control (
-    id SERIAL PRIMARY KEY,
-    file_id INTEGER REFERENCES file_header(id),
-    batch_count INTEGER,
-    block_count INTEGER,
-    entry_addenda_count INTEGER,
-    entry_hash VARCHAR(10),
-    total_debit_amount BIGINT,
-    total_credit_amount BIGINT
-);
-
-`)
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w as SQL in the dialect selected by the
+// options. Child rows are linked to their parents with the id generated by
+// the parent's INSERT, so the script can be run against a database that
+// already holds other files.
+func (e *SQLExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+	fields := opts.fields()
+	dialect := sqlDialects[opts.SQLDialect]
+
+	// SQL dates are always ISO; only the timezone applies
+	dateOpts := opts
+	dateOpts.DateFormat = ""
+	creationDate, creationTime := dateOpts.fileCreation(&file.Header, "2006-01-02")
+
+	amountType := "BIGINT"
+	if opts.AmountFormat == AmountDecimal {
+		amountType = "NUMERIC(12,2)"
+	}
+
+	buf := bufio.NewWriter(w)
+
+	if !opts.SkipSQLSchema {
+		buf.WriteString("-- NACHA SQL Schema (" + dialect.name + ")\n\n")
+
+		entryColumns := make([]string, 0, len(fields))
+		for _, f := range fields {
+			columnType := sqlColumnTypes[f.name]
+			if f.name == "amount" {
+				columnType = amountType
+			}
+			entryColumns = append(entryColumns, f.name+" "+columnType)
+		}
+
+		for _, table := range []sqlTable{
+			{"file_header", "", []string{
+				"priority_code VARCHAR(2)",
+				"immediate_destination VARCHAR(10)",
+				"immediate_origin VARCHAR(10)",
+				"file_creation_date DATE",
+				"file_creation_time VARCHAR(4)",
+				"file_id_modifier VARCHAR(1)",
+				"record_size VARCHAR(3)",
+				"blocking_factor VARCHAR(2)",
+				"format_code VARCHAR(1)",
+				"destination_name VARCHAR(23)",
+				"origin_name VARCHAR(23)",
+				"reference_code VARCHAR(8)",
+			}},
+			{"batch_header", "file_header", []string{
+				"service_class_code VARCHAR(3)",
+				"company_name VARCHAR(16)",
+				"company_discretionary_data VARCHAR(20)",
+				"company_identification VARCHAR(10)",
+				"standard_entry_class VARCHAR(3)",
+				"company_entry_description VARCHAR(10)",
+				"company_descriptive_date VARCHAR(6)",
+				"effective_entry_date DATE",
+				"settlement_date VARCHAR(3)",
+				"originator_status_code VARCHAR(1)",
+				"originating_dfi VARCHAR(8)",
+				"batch_number INTEGER",
+			}},
+			{"entry_detail", "batch_header", entryColumns},
+			{"addenda_record", "entry_detail", []string{
+				"addenda_type_code VARCHAR(2)",
+				"payment_related_information VARCHAR(80)",
+				"addenda_sequence_number VARCHAR(4)",
+				"entry_detail_sequence_number VARCHAR(7)",
+			}},
+			{"batch_control", "batch_header", []string{
+				"service_class_code VARCHAR(3)",
+				"entry_addenda_count INTEGER",
+				"entry_hash VARCHAR(10)",
+				"total_debit_amount " + amountType,
+				"total_credit_amount " + amountType,
+				"company_identification VARCHAR(10)",
+				"originating_dfi VARCHAR(8)",
+				"batch_number INTEGER",
+			}},
+			{"file_control", "file_header", []string{
+				"batch_count INTEGER",
+				"block_count INTEGER",
+				"entry_addenda_count INTEGER",
+				"entry_hash VARCHAR(10)",
+				"total_debit_amount " + amountType,
+				"total_credit_amount " + amountType,
+			}},
+		} {
+			buf.WriteString(dialect.createTable(table))
+		}
+	}
 
 	// Write data insertion
 	buf.WriteString("-- NACHA Data Insertion\n\n")
+	if dialect.prelude != "" {
+		buf.WriteString(dialect.prelude + "\n\n")
+	}
+	buf.WriteString(dialect.begin + "\n\n")
 
 	// Insert file header
 	buf.WriteString("-- Insert file header\n")
-	buf.WriteString(fmt.Sprintf(`INSERT INTO file_header (
-    priority_code, immediate_destination, immediate_origin,
-    file_creation_date, file_creation_time, file_id_modifier,
-    record_size, blocking_factor, format_code,
-    destination_name, origin_name, reference_code
-) VALUES (
-    '%s', '%s', '%s',
-    '%s', '%s', '%s',
-    '%s', '%s', '%s',
-    '%s', '%s', '%s'
-);
-
-`,
-		escape(file.Header.PriorityCode),
-		escape(file.Header.ImmediateDestination),
-		escape(file.Header.ImmediateOrigin),
-		file.Header.FileCreationDate.Format("2006-01-02"),
-		escape(file.Header.FileCreationTime),
-		escape(file.Header.FileIDModifier),
-		escape(file.Header.RecordSize),
-		escape(file.Header.BlockingFactor),
-		escape(file.Header.FormatCode),
-		escape(file.Header.DestinationName),
-		escape(file.Header.OriginName),
-		escape(file.Header.ReferenceCode),
-	))
+	buf.WriteString(sqlInsert("file_header", []string{
+		"priority_code", "immediate_destination", "immediate_origin",
+		"file_creation_date", "file_creation_time", "file_id_modifier",
+		"record_size", "blocking_factor", "format_code",
+		"destination_name", "origin_name", "reference_code",
+	}, []string{
+		dialect.literal(file.Header.PriorityCode),
+		dialect.literal(file.Header.ImmediateDestination),
+		dialect.literal(file.Header.ImmediateOrigin),
+		dialect.literal(creationDate),
+		dialect.literal(creationTime),
+		dialect.literal(file.Header.FileIDModifier),
+		dialect.literal(file.Header.RecordSize),
+		dialect.literal(file.Header.BlockingFactor),
+		dialect.literal(file.Header.FormatCode),
+		dialect.literal(file.Header.DestinationName),
+		dialect.literal(file.Header.OriginName),
+		dialect.literal(file.Header.ReferenceCode),
+	}))
+	buf.WriteString(dialect.saveID("file_header"))
 
 	// Insert batches
 	for i, batch := range file.Batches {
@@ -162,144 +166,268 @@ func (e *SQLExporter) Export(file *models.NachaFile) ([]byte, error) {
 
 		// Insert batch header
 		buf.WriteString(fmt.Sprintf("-- Insert batch %d header\n", batchNum))
-		buf.WriteString(fmt.Sprintf(`INSERT INTO batch_header (
-    file_id, service_class_code, company_name,
-    company_discretionary_data, company_identification,
-    standard_entry_class, company_entry_description,
-    company_descriptive_date, settlement_date,
-    originator_status_code, originating_dfi
-) VALUES (
-    (SELECT id FROM file_header ORDER BY id DESC LIMIT 1),
-    '%s', '%s',
-    '%s', '%s',
-    '%s', '%s',
-    '%s', '%s',
-    '%s', '%s'
-);
-
-`,
-			escape(batch.Header.ServiceClassCode),
-			escape(batch.Header.CompanyName),
-			escape(batch.Header.CompanyDiscretionaryData),
-			escape(batch.Header.CompanyIdentification),
-			escape(batch.Header.StandardEntryClass),
-			escape(batch.Header.CompanyEntryDescription),
-			escape(batch.Header.CompanyDescriptiveDate),
-			escape(batch.Header.SettlementDate),
-			escape(batch.Header.OriginatorStatusCode),
-			escape(batch.Header.OriginatingDFI),
-		))
+		buf.WriteString(sqlInsert("batch_header", []string{
+			"file_id", "service_class_code", "company_name",
+			"company_discretionary_data", "company_identification",
+			"standard_entry_class", "company_entry_description",
+			"company_descriptive_date", "effective_entry_date",
+			"settlement_date", "originator_status_code",
+			"originating_dfi", "batch_number",
+		}, []string{
+			dialect.lastID("file_header"),
+			dialect.literal(batch.Header.ServiceClassCode),
+			dialect.literal(batch.Header.CompanyName),
+			dialect.literal(batch.Header.CompanyDiscretionaryData),
+			dialect.literal(batch.Header.CompanyIdentification),
+			dialect.literal(batch.Header.StandardEntryClass),
+			dialect.literal(batch.Header.CompanyEntryDescription),
+			dialect.literal(batch.Header.CompanyDescriptiveDate),
+			dialect.date(batch.Header.EffectiveEntryDate),
+			dialect.literal(batch.Header.SettlementDate),
+			dialect.literal(batch.Header.OriginatorStatusCode),
+			dialect.literal(batch.Header.OriginatingDFI),
+			sqlInteger(batch.Header.BatchNumber),
+		}))
+		buf.WriteString(dialect.saveID("batch_header"))
 
 		// Insert entries
 		for j, entry := range batch.Entries {
+			columns := []string{"batch_id"}
+			values := []string{dialect.lastID("batch_header")}
+			for _, f := range fields {
+				columns = append(columns, f.name)
+				if f.name == "amount" {
+					values = append(values, sqlAmount(entry.Amount, opts))
+				} else {
+					values = append(values, dialect.literal(f.value(&entry)))
+				}
+			}
+
 			buf.WriteString(fmt.Sprintf("-- Insert entry %d\n", j+1))
-			buf.WriteString(fmt.Sprintf(`INSERT INTO entry_detail (
-    batch_id, transaction_code, receiving_dfi,
-    check_digit, dfi_account_number, amount,
-    individual_id_number, individual_name,
-    discretionary_data, addenda_record_indicator,
-    trace_number
-) VALUES (
-    (SELECT id FROM batch_header ORDER BY id DESC LIMIT 1),
-    '%s', '%s',
-    '%s', '%s', %d,
-    '%s', '%s',
-    '%s', '%s',
-    '%s'
-);
-
-`,
-				escape(entry.TransactionCode),
-				escape(entry.ReceivingDFI),
-				escape(entry.CheckDigit),
-				escape(entry.DFIAccountNumber),
-				entry.Amount,
-				escape(entry.IndividualIDNumber),
-				escape(entry.IndividualName),
-				escape(entry.DiscretionaryData),
-				escape(entry.AddendaRecordIndicator),
-				escape(entry.TraceNumber),
-			))
+			buf.WriteString(sqlInsert("entry_detail", columns, values))
+
+			if len(entry.AddendaRecords) == 0 {
+				continue
+			}
+			buf.WriteString(dialect.saveID("entry_detail"))
 
 			// Insert addenda records
 			for k, addenda := range entry.AddendaRecords {
 				buf.WriteString(fmt.Sprintf("-- Insert addenda record %d\n", k+1))
-				buf.WriteString(fmt.Sprintf(`INSERT INTO addenda_record (
-    entry_id, addenda_type_code,
-    payment_related_information,
-    addenda_sequence_number,
-    entry_detail_sequence_number
-) VALUES (
-    (SELECT id FROM entry_detail ORDER BY id DESC LIMIT 1),
-    '%s',
-    '%s',
-    '%s',
-    '%s'
-);
-
-`,
-					escape(addenda.AddendaTypeCode),
-					escape(addenda.PaymentRelatedInformation),
-					escape(addenda.AddendaSequenceNumber),
-					escape(addenda.EntryDetailSequenceNumber),
-				))
+				buf.WriteString(sqlInsert("addenda_record", []string{
+					"entry_id", "addenda_type_code",
+					"payment_related_information",
+					"addenda_sequence_number",
+					"entry_detail_sequence_number",
+				}, []string{
+					dialect.lastID("entry_detail"),
+					dialect.literal(addenda.AddendaTypeCode),
+					dialect.literal(addenda.PaymentRelatedInformation),
+					dialect.literal(addenda.AddendaSequenceNumber),
+					dialect.literal(addenda.EntryDetailSequenceNumber),
+				}))
 			}
 		}
 
 		// Insert batch control
 		buf.WriteString(fmt.Sprintf("-- Insert batch %d control\n", batchNum))
-		buf.WriteString(fmt.Sprintf(`INSERT INTO batch_control (
-    batch_id, service_class_code,
-    entry_addenda_count, entry_hash,
-    total_debit_amount, total_credit_amount,
-    company_identification, originating_dfi,
-    batch_number
-) VALUES (
-    (SELECT id FROM batch_header ORDER BY id DESC LIMIT 1),
-    '%s',
-    %d, '%s',
-    %d, %d,
-    '%s', '%s',
-    '%s'
-);
-
-`,
-			escape(batch.Control.ServiceClassCode),
-			batch.Control.EntryAddendaCount,
-			escape(batch.Control.EntryHash),
-			batch.Control.TotalDebitAmount,
-			batch.Control.TotalCreditAmount,
-			escape(batch.Control.CompanyIdentification),
-			escape(batch.Control.OriginatingDFI),
-			escape(batch.Control.BatchNumber),
-		))
+		buf.WriteString(sqlInsert("batch_control", []string{
+			"batch_id", "service_class_code",
+			"entry_addenda_count", "entry_hash",
+			"total_debit_amount", "total_credit_amount",
+			"company_identification", "originating_dfi",
+			"batch_number",
+		}, []string{
+			dialect.lastID("batch_header"),
+			dialect.literal(batch.Control.ServiceClassCode),
+			strconv.Itoa(batch.Control.EntryAddendaCount),
+			dialect.literal(batch.Control.EntryHash),
+			sqlAmount(batch.Control.TotalDebitAmount, opts),
+			sqlAmount(batch.Control.TotalCreditAmount, opts),
+			dialect.literal(batch.Control.CompanyIdentification),
+			dialect.literal(batch.Control.OriginatingDFI),
+			sqlInteger(batch.Control.BatchNumber),
+		}))
 	}
 
 	// Insert file control
 	buf.WriteString("-- Insert file control\n")
-	buf.WriteString(fmt.Sprintf(`INSERT INTO file_control (
-    file_id, batch_count, block_count,
-    entry_addenda_count, entry_hash,
-    total_debit_amount, total_credit_amount
-) VALUES (
-    (SELECT id FROM file_header ORDER BY id DESC LIMIT 1),
-    %d, %d,
-    %d, '%s',
-    %d, %d
-);
-`,
-		file.Control.BatchCount,
-		file.Control.BlockCount,
-		file.Control.EntryAddendaCount,
-		escape(file.Control.EntryHash),
-		file.Control.TotalDebitAmount,
-		file.Control.TotalCreditAmount,
-	))
-
-	return buf.Bytes(), nil
+	buf.WriteString(sqlInsert("file_control", []string{
+		"file_id", "batch_count", "block_count",
+		"entry_addenda_count", "entry_hash",
+		"total_debit_amount", "total_credit_amount",
+	}, []string{
+		dialect.lastID("file_header"),
+		strconv.Itoa(file.Control.BatchCount),
+		strconv.Itoa(file.Control.BlockCount),
+		strconv.Itoa(file.Control.EntryAddendaCount),
+		dialect.literal(file.Control.EntryHash),
+		sqlAmount(file.Control.TotalDebitAmount, opts),
+		sqlAmount(file.Control.TotalCreditAmount, opts),
+	}))
+
+	buf.WriteString(dialect.commit + "\n")
+
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write SQL: %v", err)
+	}
+	return nil
+}
+
+// sqlTable describes a table of the schema. Every table has a generated id,
+// and a foreign key to its parent when it has one.
+type sqlTable struct {
+	name    string
+	parent  string
+	columns []string
+}
+
+// sqlDialect holds the syntax that differs between databases
+type sqlDialect struct {
+	name string
+	// primaryKey is the definition of the generated id column
+	primaryKey string
+	// ifNotExists prefixes CREATE TABLE so existing tables are kept
+	ifNotExists func(table string) string
+	// prelude is written once before the inserts
+	prelude string
+	begin   string
+	commit  string
+	// saveID is written after the INSERT of a parent row and lastID
+	// returns the expression its children use to refer to it
+	saveID func(table string) string
+	lastID func(table string) string
+	// backslash is set when backslashes escape characters in string literals
+	backslash bool
 }
 
-// Helper function to escape single quotes in SQL strings
-func escape(s string) string {
-	return strings.Replace(s, "'", "''", -1)
+// sqlDialects holds the dialect of each SQLDialect
+var sqlDialects = map[SQLDialect]sqlDialect{
+	SQLDialectPostgreSQL: {
+		name:        "PostgreSQL",
+		primaryKey:  "id SERIAL PRIMARY KEY",
+		ifNotExists: func(table string) string { return "CREATE TABLE IF NOT EXISTS " + table },
+		begin:       "BEGIN;",
+		commit:      "COMMIT;",
+		saveID:      func(table string) string { return "" },
+		lastID: func(table string) string {
+			return fmt.Sprintf("currval(pg_get_serial_sequence('%s', 'id'))", table)
+		},
+	},
+	SQLDialectMySQL: {
+		name:        "MySQL",
+		primaryKey:  "id INTEGER AUTO_INCREMENT PRIMARY KEY",
+		ifNotExists: func(table string) string { return "CREATE TABLE IF NOT EXISTS " + table },
+		begin:       "START TRANSACTION;",
+		commit:      "COMMIT;",
+		saveID:      func(table string) string { return "SET @" + table + "_id = LAST_INSERT_ID();\n\n" },
+		lastID:      func(table string) string { return "@" + table + "_id" },
+		backslash:   true,
+	},
+	SQLDialectSQLite: {
+		name:        "SQLite",
+		primaryKey:  "id INTEGER PRIMARY KEY AUTOINCREMENT",
+		ifNotExists: func(table string) string { return "CREATE TABLE IF NOT EXISTS " + table },
+		prelude:     "CREATE TEMP TABLE IF NOT EXISTS nacha_last_id (table_name TEXT PRIMARY KEY, id INTEGER);",
+		begin:       "BEGIN;",
+		commit:      "COMMIT;",
+		saveID: func(table string) string {
+			return fmt.Sprintf("INSERT OR REPLACE INTO nacha_last_id (table_name, id) VALUES ('%s', last_insert_rowid());\n\n", table)
+		},
+		lastID: func(table string) string {
+			return fmt.Sprintf("(SELECT id FROM nacha_last_id WHERE table_name = '%s')", table)
+		},
+	},
+	SQLDialectSQLServer: {
+		name:       "SQL Server",
+		primaryKey: "id INTEGER IDENTITY(1,1) PRIMARY KEY",
+		ifNotExists: func(table string) string {
+			return fmt.Sprintf("IF OBJECT_ID(N'%s', N'U') IS NULL\nCREATE TABLE %s", table, table)
+		},
+		prelude: "DECLARE @file_header_id INTEGER, @batch_header_id INTEGER, @entry_detail_id INTEGER;",
+		begin:   "BEGIN TRANSACTION;",
+		commit:  "COMMIT;",
+		saveID:  func(table string) string { return "SET @" + table + "_id = SCOPE_IDENTITY();\n\n" },
+		lastID:  func(table string) string { return "@" + table + "_id" },
+	},
+}
+
+// sqlForeignKeys holds the column children use to refer to each parent table
+var sqlForeignKeys = map[string]string{
+	"file_header":  "file_id",
+	"batch_header": "batch_id",
+	"entry_detail": "entry_id",
+}
+
+// createTable writes the CREATE TABLE statement of a table
+func (d sqlDialect) createTable(table sqlTable) string {
+	columns := []string{d.primaryKey}
+	foreignKey := sqlForeignKeys[table.parent]
+	if table.parent != "" {
+		columns = append(columns, foreignKey+" INTEGER NOT NULL")
+	}
+	columns = append(columns, table.columns...)
+	if table.parent != "" {
+		columns = append(columns, fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s(id)", foreignKey, table.parent))
+	}
+	return fmt.Sprintf("%s (\n    %s\n);\n\n", d.ifNotExists(table.name), strings.Join(columns, ",\n    "))
+}
+
+// literal quotes a string literal
+func (d sqlDialect) literal(s string) string {
+	if d.backslash {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// date writes a YYMMDD date such as the effective entry date as an ISO date
+// literal, or NULL when it is not a date
+func (d sqlDialect) date(value string) string {
+	date, err := time.Parse("060102", strings.TrimSpace(value))
+	if err != nil {
+		return "NULL"
+	}
+	return d.literal(date.Format("2006-01-02"))
+}
+
+// sqlInsert writes an INSERT statement of a single row
+func sqlInsert(table string, columns, values []string) string {
+	return fmt.Sprintf("INSERT INTO %s (\n    %s\n) VALUES (\n    %s\n);\n\n",
+		table,
+		strings.Join(columns, ", "),
+		strings.Join(values, ",\n    "),
+	)
+}
+
+// sqlColumnTypes holds the column types of the entry_detail fields
+var sqlColumnTypes = map[string]string{
+	"transaction_code":         "VARCHAR(2)",
+	"receiving_dfi":            "VARCHAR(8)",
+	"check_digit":              "VARCHAR(1)",
+	"dfi_account_number":       "VARCHAR(17)",
+	"individual_id_number":     "VARCHAR(15)",
+	"individual_name":          "VARCHAR(22)",
+	"discretionary_data":       "VARCHAR(2)",
+	"addenda_record_indicator": "VARCHAR(1)",
+	"trace_number":             "VARCHAR(15)",
+}
+
+// sqlAmount writes an amount literal. Decimal amounts always use a decimal
+// point, whatever the locale, since SQL numeric literals do not depend on it.
+func sqlAmount(cents int64, opts Options) string {
+	if opts.AmountFormat != AmountDecimal {
+		return strconv.FormatInt(cents, 10)
+	}
+	return decimalNumber(cents)
+}
+
+// sqlInteger writes a numeric field such as the batch number as an integer
+// literal, or NULL when it is not a number
+func sqlInteger(s string) string {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return "NULL"
+	}
+	return strconv.Itoa(n)
 }
@@ -0,0 +1,91 @@
+package exporters
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: PostgreSQL links children with the parent's sequence
+	postgres := exportString(t, file, "SQL", Options{})
+	assert.Contains(t, postgres, "id SERIAL PRIMARY KEY")
+	assert.Contains(t, postgres, "FOREIGN KEY (batch_id) REFERENCES batch_header(id)")
+	assert.Contains(t, postgres, "currval(pg_get_serial_sequence('batch_header', 'id'))")
+	assert.Contains(t, postgres, "effective_entry_date DATE,\n    settlement_date VARCHAR(3)")
+	assert.Contains(t, postgres, "originating_dfi VARCHAR(8),\n    batch_number INTEGER,\n    FOREIGN KEY (file_id)")
+	assert.Contains(t, postgres, "company_descriptive_date, effective_entry_date, settlement_date, originator_status_code, originating_dfi, batch_number\n")
+	assert.Contains(t, postgres, "    '2026-10-19',\n")
+	assert.Contains(t, postgres, "    '2026-10-20',\n")
+
+	// Test case 2: MySQL keeps the generated ids in session variables
+	mysql := exportString(t, file, "SQL", Options{SQLDialect: SQLDialectMySQL})
+	assert.Contains(t, mysql, "id INTEGER AUTO_INCREMENT PRIMARY KEY")
+	assert.Contains(t, mysql, "SET @entry_detail_id = LAST_INSERT_ID();")
+	assert.Contains(t, mysql, "    @entry_detail_id,\n")
+	assert.NotContains(t, mysql, "SERIAL")
+
+	// Test case 3: SQL Server checks for existing tables
+	sqlServer := exportString(t, file, "SQL", Options{SQLDialect: SQLDialectSQLServer})
+	assert.Contains(t, sqlServer, "IF OBJECT_ID(N'file_header', N'U') IS NULL\nCREATE TABLE file_header (")
+	assert.Contains(t, sqlServer, "SET @batch_header_id = SCOPE_IDENTITY();")
+	assert.NotContains(t, sqlServer, "IF NOT EXISTS")
+
+	// Test case 4: Schema can be left out
+	inserts := exportString(t, file, "SQL", Options{SQLDialect: SQLDialectSQLite, SkipSQLSchema: true})
+	assert.NotContains(t, inserts, "CREATE TABLE file_header")
+	assert.Contains(t, inserts, "INSERT INTO file_header")
+}
+
+func TestSQLiteExport(t *testing.T) {
+	if _, ok := Lookup("SQLITE"); !ok {
+		t.Skip("SQLite export requires cgo")
+	}
+	file := testFile(t)
+
+	// Test case 1: The database holds the file with linked records
+	data, contentType, err := export(t, file, "SQLITE", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/vnd.sqlite3", contentType)
+
+	path := filepath.Join(t.TempDir(), "nacha.sqlite")
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+	db, err := sql.Open("sqlite3", path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer db.Close()
+
+	var count int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM entry_detail").Scan(&count))
+	assert.Equal(t, 4, count)
+
+	var company, info string
+	assert.NoError(t, db.QueryRow(`SELECT b.company_name, a.payment_related_information
+		FROM addenda_record a
+		JOIN entry_detail e ON e.id = a.entry_id
+		JOIN batch_header b ON b.id = e.batch_id`).Scan(&company, &info))
+	assert.Equal(t, "OUTRA EMPRESA", strings.TrimSpace(company))
+	assert.Contains(t, info, "INV-1001")
+
+	var effective time.Time
+	var batchNumber int
+	assert.NoError(t, db.QueryRow(`SELECT effective_entry_date, batch_number FROM batch_header
+		WHERE company_name LIKE 'OUTRA EMPRESA%'`).Scan(&effective, &batchNumber))
+	assert.Equal(t, "2026-10-20", effective.Format("2006-01-02"))
+	assert.Equal(t, 2, batchNumber)
+
+	var total int64
+	assert.NoError(t, db.QueryRow("SELECT SUM(amount) FROM entry_detail").Scan(&total))
+	assert.Equal(t, int64(2280000), total)
+}
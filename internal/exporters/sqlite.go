@@ -0,0 +1,88 @@
+//go:build cgo
+
+package exporters
+
+import (
+	"bytes"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3" // SQLite driver
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "SQLITE",
+		Extension:    ".sqlite",
+		Description:  "SQLite database with file, batch, entry and addenda tables",
+		Capabilities: Capabilities{Binary: true},
+		New:          func() NachaExporter { return NewSQLiteExporter() },
+	})
+}
+
+// SQLiteExporter handles export to a SQLite database file
+type SQLiteExporter struct {
+	*BaseExporter
+}
+
+// NewSQLiteExporter creates a new SQLite exporter
+func NewSQLiteExporter() *SQLiteExporter {
+	return &SQLiteExporter{
+		BaseExporter: NewBaseExporter("application/vnd.sqlite3"),
+	}
+}
+
+// Export converts a NACHA file to a SQLite database
+func (e *SQLiteExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a SQLite database holding a NACHA file to w. The database
+// is built from the SQL export in the SQLite dialect.
+func (e *SQLiteExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	opts.SQLDialect = SQLDialectSQLite
+	opts.SkipSQLSchema = false
+
+	sqlExporter := NewSQLExporter()
+	sqlExporter.SetOptions(opts)
+	var script bytes.Buffer
+	if err := sqlExporter.ExportTo(&script, file); err != nil {
+		return err
+	}
+
+	// SQLite needs a file on disk to build the database in
+	dir, err := os.MkdirTemp("", "nacha-sqlite-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "nacha.sqlite")
+	db, err := sql.Open("sqlite3", "file:"+path+"?_foreign_keys=on")
+	if err != nil {
+		return fmt.Errorf("failed to create SQLite database: %v", err)
+	}
+	if _, err := db.Exec(script.String()); err != nil {
+		db.Close()
+		return fmt.Errorf("failed to load SQLite database: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("failed to close SQLite database: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read SQLite database: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to write SQLite database: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package exporters
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+)
+
+// statementTransaction is an entry as a transaction of the account of its
+// originator, for the statement formats
+type statementTransaction struct {
+	batch *models.Batch
+	entry *models.EntryDetail
+	// amount is signed from the point of view of the originator: debit
+	// entries collect money into the account and are positive, credit
+	// entries pay it out and are negative
+	amount int64
+	// direction is the direction of the entry, as in the batch controls
+	direction string
+	// posted is the effective entry date, or the file creation date when the
+	// batch has none
+	posted time.Time
+	// id identifies the transaction across statements: the posted date and
+	// the trace number
+	id   string
+	name string
+	memo string
+}
+
+// statementTransactions returns the entries of a file as statement
+// transactions, in file order. Prenotes move no money and are left out.
+func statementTransactions(file *models.NachaFile) []statementTransaction {
+	var transactions []statementTransaction
+	seen := make(map[string]int)
+	for i := range file.Batches {
+		batch := &file.Batches[i]
+		posted := postingDate(file, batch)
+
+		for j := range batch.Entries {
+			entry := &batch.Entries[j]
+			if models.IsPrenote(entry.TransactionCode) {
+				continue
+			}
+
+			t := statementTransaction{
+				batch:     batch,
+				entry:     entry,
+				amount:    entry.Amount,
+				direction: summary.Direction(entry.TransactionCode),
+				posted:    posted,
+				name:      strings.TrimSpace(entry.IndividualName),
+			}
+			if t.direction == summary.DirectionCredit {
+				t.amount = -t.amount
+			}
+
+			t.id = posted.Format("20060102") + strings.TrimSpace(entry.TraceNumber)
+			seen[t.id]++
+			if n := seen[t.id]; n > 1 {
+				t.id += "-" + strconv.Itoa(n)
+			}
+
+			memo := []string{strings.TrimSpace(batch.Header.CompanyEntryDescription)}
+			for _, addenda := range entry.AddendaRecords {
+				memo = append(memo, strings.TrimSpace(addenda.PaymentRelatedInformation))
+			}
+			t.memo = strings.TrimSpace(strings.Join(memo, " "))
+
+			transactions = append(transactions, t)
+		}
+	}
+	return transactions
+}
+
+// postingDate is the effective entry date of a batch, or the file creation
+// date when the batch has none
+func postingDate(file *models.NachaFile, batch *models.Batch) time.Time {
+	posted, err := time.Parse("060102", strings.TrimSpace(batch.Header.EffectiveEntryDate))
+	if err != nil {
+		return file.Header.FileCreationDate
+	}
+	return posted
+}
@@ -0,0 +1,157 @@
+package exporters
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "SUMMARY_CSV",
+		Extension:    ".csv",
+		Description:  "Aggregates by SEC code, company, RDFI, transaction code, date and direction",
+		Capabilities: Capabilities{Aggregated: true},
+		New:          func() NachaExporter { return NewSummaryCSVExporter() },
+	})
+	MustRegister(Format{
+		Name:         "SUMMARY_JSON",
+		Extension:    ".json",
+		Description:  "Aggregates by SEC code, company, RDFI, transaction code, date and direction",
+		Capabilities: Capabilities{Aggregated: true},
+		New:          func() NachaExporter { return NewSummaryJSONExporter() },
+	})
+}
+
+// SummaryCSVExporter handles export of the file aggregates to CSV format
+type SummaryCSVExporter struct {
+	*BaseExporter
+}
+
+// NewSummaryCSVExporter creates a new summary CSV exporter
+func NewSummaryCSVExporter() *SummaryCSVExporter {
+	return &SummaryCSVExporter{
+		BaseExporter: NewBaseExporter("text/csv"),
+	}
+}
+
+// Export converts the aggregates of a NACHA file to CSV format
+func (e *SummaryCSVExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes one row per aggregate, with the dimension in the first column
+func (e *SummaryCSVExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	s := summary.Summarize(e.options.apply(file), summary.DefaultTopEntries)
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{
+		"Dimension",
+		"Key",
+		"Label",
+		"Entry Count",
+		"Addenda Count",
+		"Entries With Addenda",
+		"Addenda Usage Rate",
+		"Debit Count",
+		"Debit Amount",
+		"Credit Count",
+		"Credit Amount",
+	}); err != nil {
+		return fmt.Errorf("failed to write summary header: %v", err)
+	}
+
+	dimensions := []struct {
+		name       string
+		aggregates []summary.Aggregate
+	}{
+		{"total", []summary.Aggregate{s.Totals}},
+		{"sec_code", s.BySECCode},
+		{"company", s.ByCompany},
+		{"receiving_dfi", s.ByReceivingDFI},
+		{"transaction_code", s.ByTransactionCode},
+		{"effective_date", s.ByEffectiveDate},
+		{"direction", s.ByDirection},
+	}
+
+	for _, d := range dimensions {
+		for _, a := range d.aggregates {
+			if err := writer.Write([]string{
+				d.name,
+				a.Key,
+				a.Label,
+				strconv.Itoa(a.EntryCount),
+				strconv.Itoa(a.AddendaCount),
+				strconv.Itoa(a.EntriesWithAddenda),
+				strconv.FormatFloat(a.AddendaUsageRate, 'f', 4, 64),
+				strconv.Itoa(a.DebitCount),
+				strconv.FormatInt(a.DebitAmount, 10),
+				strconv.Itoa(a.CreditCount),
+				strconv.FormatInt(a.CreditAmount, 10),
+			}); err != nil {
+				return fmt.Errorf("failed to write %s aggregate: %v", d.name, err)
+			}
+		}
+	}
+
+	// Largest entries are listed as single entry aggregates keyed by trace number
+	for _, entry := range s.LargestEntries {
+		debitCount, debitAmount, creditCount, creditAmount := 0, int64(0), 0, int64(0)
+		switch summary.Direction(entry.TransactionCode) {
+		case summary.DirectionDebit:
+			debitCount, debitAmount = 1, entry.Amount
+		case summary.DirectionCredit:
+			creditCount, creditAmount = 1, entry.Amount
+		}
+
+		if err := writer.Write([]string{
+			"largest_entry",
+			entry.TraceNumber,
+			entry.IndividualName,
+			"1",
+			"",
+			"",
+			"",
+			strconv.Itoa(debitCount),
+			strconv.FormatInt(debitAmount, 10),
+			strconv.Itoa(creditCount),
+			strconv.FormatInt(creditAmount, 10),
+		}); err != nil {
+			return fmt.Errorf("failed to write largest entry: %v", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush writer: %v", err)
+	}
+
+	return nil
+}
+
+// SummaryJSONExporter handles export of the file aggregates to JSON format
+type SummaryJSONExporter struct {
+	*BaseExporter
+}
+
+// NewSummaryJSONExporter creates a new summary JSON exporter
+func NewSummaryJSONExporter() *SummaryJSONExporter {
+	return &SummaryJSONExporter{
+		BaseExporter: NewBaseExporter("application/json"),
+	}
+}
+
+// Export converts the aggregates of a NACHA file to JSON format
+func (e *SummaryJSONExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo writes the aggregates of a NACHA file to w in JSON format
+func (e *SummaryJSONExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	return writeJSON(w, summary.Summarize(e.options.apply(file), summary.DefaultTopEntries))
+}
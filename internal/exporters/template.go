@@ -0,0 +1,423 @@
+package exporters
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:        "TEMPLATE",
+		Extension:   ".txt",
+		Description: "Output of a Go text/template or html/template supplied with the request or kept on the server",
+		New:         func() NachaExporter { return NewTemplateExporter() },
+	})
+}
+
+// ErrInvalidTemplate is returned by the TEMPLATE exporter when the template is
+// missing, cannot be parsed or fails on the file
+var ErrInvalidTemplate = errors.New("invalid template")
+
+// TemplateOptions selects the template of TEMPLATE exports
+type TemplateOptions struct {
+	// Source is the text of the template
+	Source string
+	// Name selects a template registered on the server in place of Source
+	Name string
+	// HTML parses Source with html/template, which escapes the values it
+	// writes. Registered templates are HTML when their file is .html.tmpl.
+	HTML bool
+	// ContentType replaces the content type of the export, text/html for
+	// HTML templates and text/plain otherwise
+	ContentType string
+}
+
+func (o TemplateOptions) validate() error {
+	if o.Source != "" && o.Name != "" {
+		return fmt.Errorf("set either a template source or a template name")
+	}
+	if o.Name != "" {
+		if _, ok := lookupTemplate(o.Name); !ok {
+			return fmt.Errorf("unknown template: %s", o.Name)
+		}
+	}
+	if o.Source != "" {
+		if _, err := parseTemplate("request", o.Source, o.HTML, Options{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namedTemplate is a template registered on the server
+type namedTemplate struct {
+	source string
+	html   bool
+}
+
+var templates = struct {
+	sync.RWMutex
+	byName map[string]namedTemplate
+}{byName: make(map[string]namedTemplate)}
+
+// RegisterTemplate registers a template that TEMPLATE exports can select by
+// name. Names are case-insensitive and must be unique.
+func RegisterTemplate(name, source string, html bool) error {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if _, err := parseTemplate(key, source, html, Options{}); err != nil {
+		return err
+	}
+
+	templates.Lock()
+	defer templates.Unlock()
+
+	if _, ok := templates.byName[key]; ok {
+		return fmt.Errorf("template %s is already registered", key)
+	}
+	templates.byName[key] = namedTemplate{source: source, html: html}
+	return nil
+}
+
+// Templates returns the names of the registered templates, sorted
+func Templates() []string {
+	templates.RLock()
+	defer templates.RUnlock()
+
+	names := make([]string, 0, len(templates.byName))
+	for name := range templates.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupTemplate(name string) (namedTemplate, bool) {
+	templates.RLock()
+	defer templates.RUnlock()
+
+	t, ok := templates.byName[strings.ToLower(strings.TrimSpace(name))]
+	return t, ok
+}
+
+// LoadTemplates registers the .tmpl files of a directory and returns their
+// names: the file name without its extensions, so remittance.html.tmpl is
+// the HTML template remittance. Loading stops at the first file that cannot
+// be parsed or registered.
+func LoadTemplates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %v", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".tmpl") {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return names, fmt.Errorf("failed to read template %s: %v", file, err)
+		}
+		base := strings.TrimSuffix(file, ".tmpl")
+		html := strings.HasSuffix(base, ".html")
+		name, _, _ := strings.Cut(base, ".")
+		if err := RegisterTemplate(name, string(data), html); err != nil {
+			return names, fmt.Errorf("%s: %v", file, err)
+		}
+		names = append(names, strings.ToLower(name))
+	}
+	return names, nil
+}
+
+// TemplateExporter renders NACHA files with a Go template
+type TemplateExporter struct {
+	*BaseExporter
+}
+
+// NewTemplateExporter creates a new template exporter
+func NewTemplateExporter() *TemplateExporter {
+	return &TemplateExporter{
+		BaseExporter: NewBaseExporter("text/plain"),
+	}
+}
+
+// GetContentType returns the content type of the selected template
+func (e *TemplateExporter) GetContentType() string {
+	opts := e.options.Template
+	switch {
+	case opts.ContentType != "":
+		return opts.ContentType
+	case e.template().html:
+		return "text/html"
+	default:
+		return e.BaseExporter.GetContentType()
+	}
+}
+
+// template returns the template selected by the options
+func (e *TemplateExporter) template() namedTemplate {
+	opts := e.options.Template
+	if opts.Name != "" {
+		t, _ := lookupTemplate(opts.Name)
+		return t
+	}
+	return namedTemplate{source: opts.Source, html: opts.HTML}
+}
+
+// Export renders a NACHA file with the template
+func (e *TemplateExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// ExportTo renders a NACHA file with the template to w. The template is run
+// against a TemplateFile; batch restrictions, account masking and the
+// timezone of the options apply to it, and the locale and date format to
+// the money and date functions.
+func (e *TemplateExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	selected := e.template()
+	if selected.source == "" {
+		if opts.Template.Name != "" {
+			return fmt.Errorf("unknown template %s: %w", opts.Template.Name, ErrInvalidTemplate)
+		}
+		return fmt.Errorf("no template source or name: %w", ErrInvalidTemplate)
+	}
+
+	t, err := parseTemplate("template", selected.source, selected.html, opts)
+	if err != nil {
+		return err
+	}
+	if err := t.Execute(w, newTemplateFile(opts.apply(file), opts)); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrInvalidTemplate)
+	}
+	return nil
+}
+
+// executor is a parsed text/template or html/template
+type executor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// parseTemplate parses a template with the functions of the options
+func parseTemplate(name, source string, html bool, opts Options) (executor, error) {
+	funcs := templateFuncs(opts)
+	var t executor
+	var err error
+	if html {
+		t, err = htmltemplate.New(name).Funcs(funcs).Parse(source)
+	} else {
+		t, err = template.New(name).Funcs(funcs).Parse(source)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", err, ErrInvalidTemplate)
+	}
+	return t, nil
+}
+
+// templateFuncs returns the functions available to templates:
+//
+//	money     formats cents as currency units with the locale's separators
+//	date      formats a date with a pattern such as DD/MM/YYYY, or with the
+//	          date format of the options when the pattern is empty
+//	mask      masks an account number, leaving the last digits visible
+//	total     sums the amounts of entries
+//	debits    sums the amounts of the debit entries
+//	credits   sums the amounts of the credit entries
+//	upper, lower, trim
+func templateFuncs(opts Options) map[string]interface{} {
+	return map[string]interface{}{
+		"money": opts.decimal,
+		"date": func(pattern string, t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			if pattern == "" {
+				pattern = opts.DateFormat
+			}
+			if pattern == "" {
+				pattern = "YYYY-MM-DD"
+			}
+			return t.Format(dateLayout(pattern))
+		},
+		"mask": opts.mask,
+		"total": func(entries []TemplateEntry) int64 {
+			return sumEntries(entries, "")
+		},
+		"debits": func(entries []TemplateEntry) int64 {
+			return sumEntries(entries, summary.DirectionDebit)
+		},
+		"credits": func(entries []TemplateEntry) int64 {
+			return sumEntries(entries, summary.DirectionCredit)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+	}
+}
+
+// sumEntries sums the amounts of the entries in a direction, or of every
+// entry when direction is empty
+func sumEntries(entries []TemplateEntry, direction string) int64 {
+	var total int64
+	for _, entry := range entries {
+		if direction == "" || entry.Direction == direction {
+			total += entry.Amount
+		}
+	}
+	return total
+}
+
+// TemplateFile is the view of a NACHA file that templates are rendered
+// against. Text is trimmed of the padding of the records, amounts are cents
+// and dates are zero when the file has none. Its fields are kept stable so
+// templates do not depend on the record models.
+type TemplateFile struct {
+	ImmediateDestination string
+	ImmediateOrigin      string
+	DestinationName      string
+	OriginName           string
+	// Created is the file creation date and time in the requested timezone
+	Created       time.Time
+	IDModifier    string
+	ReferenceCode string
+
+	Batches []TemplateBatch
+	// Entries holds the entries of every batch
+	Entries []TemplateEntry
+
+	BatchCount        int
+	EntryCount        int
+	EntryAddendaCount int
+	EntryHash         string
+	TotalDebit        int64
+	TotalCredit       int64
+}
+
+// TemplateBatch is a batch of a TemplateFile
+type TemplateBatch struct {
+	Number            string
+	ServiceClassCode  string
+	CompanyName       string
+	CompanyID         string
+	DiscretionaryData string
+	SECCode           string
+	EntryDescription  string
+	DescriptiveDate   string
+	EffectiveDate     time.Time
+	OriginatingDFI    string
+
+	Entries []TemplateEntry
+
+	EntryCount        int
+	EntryAddendaCount int
+	EntryHash         string
+	TotalDebit        int64
+	TotalCredit       int64
+}
+
+// TemplateEntry is an entry of a TemplateFile
+type TemplateEntry struct {
+	BatchNumber     string
+	CompanyName     string
+	EffectiveDate   time.Time
+	TransactionCode string
+	// Direction is DEBIT, CREDIT or OTHER, as in the batch controls
+	Direction         string
+	ReceivingDFI      string
+	RoutingNumber     string
+	AccountNumber     string
+	Amount            int64
+	IndividualID      string
+	Name              string
+	DiscretionaryData string
+	TraceNumber       string
+	// Addenda holds the payment related information of the addenda records
+	Addenda []string
+}
+
+// newTemplateFile builds the template view of a file
+func newTemplateFile(file *models.NachaFile, opts Options) *TemplateFile {
+	trim := strings.TrimSpace
+	h, c := &file.Header, &file.Control
+	view := &TemplateFile{
+		ImmediateDestination: trim(h.ImmediateDestination),
+		ImmediateOrigin:      trim(h.ImmediateOrigin),
+		DestinationName:      trim(h.DestinationName),
+		OriginName:           trim(h.OriginName),
+		Created:              opts.fileCreationTime(h),
+		IDModifier:           trim(h.FileIDModifier),
+		ReferenceCode:        trim(h.ReferenceCode),
+		BatchCount:           c.BatchCount,
+		EntryAddendaCount:    c.EntryAddendaCount,
+		EntryHash:            trim(c.EntryHash),
+		TotalDebit:           c.TotalDebitAmount,
+		TotalCredit:          c.TotalCreditAmount,
+	}
+
+	for _, batch := range file.Batches {
+		bh, bc := &batch.Header, &batch.Control
+		effective, _ := time.Parse("060102", trim(bh.EffectiveEntryDate))
+		b := TemplateBatch{
+			Number:            trim(bh.BatchNumber),
+			ServiceClassCode:  trim(bh.ServiceClassCode),
+			CompanyName:       trim(bh.CompanyName),
+			CompanyID:         trim(bh.CompanyIdentification),
+			DiscretionaryData: trim(bh.CompanyDiscretionaryData),
+			SECCode:           trim(bh.StandardEntryClass),
+			EntryDescription:  trim(bh.CompanyEntryDescription),
+			DescriptiveDate:   trim(bh.CompanyDescriptiveDate),
+			EffectiveDate:     effective,
+			OriginatingDFI:    trim(bh.OriginatingDFI),
+			EntryCount:        len(batch.Entries),
+			EntryAddendaCount: bc.EntryAddendaCount,
+			EntryHash:         trim(bc.EntryHash),
+			TotalDebit:        bc.TotalDebitAmount,
+			TotalCredit:       bc.TotalCreditAmount,
+		}
+		for _, entry := range batch.Entries {
+			e := TemplateEntry{
+				BatchNumber:       b.Number,
+				CompanyName:       b.CompanyName,
+				EffectiveDate:     effective,
+				TransactionCode:   trim(entry.TransactionCode),
+				Direction:         summary.Direction(entry.TransactionCode),
+				ReceivingDFI:      trim(entry.ReceivingDFI),
+				RoutingNumber:     trim(entry.ReceivingDFI) + trim(entry.CheckDigit),
+				AccountNumber:     trim(entry.DFIAccountNumber),
+				Amount:            entry.Amount,
+				IndividualID:      trim(entry.IndividualIDNumber),
+				Name:              trim(entry.IndividualName),
+				DiscretionaryData: trim(entry.DiscretionaryData),
+				TraceNumber:       trim(entry.TraceNumber),
+			}
+			for _, a := range entry.AddendaRecords {
+				e.Addenda = append(e.Addenda, trim(a.PaymentRelatedInformation))
+			}
+			b.Entries = append(b.Entries, e)
+		}
+		view.EntryCount += b.EntryCount
+		view.Entries = append(view.Entries, b.Entries...)
+		view.Batches = append(view.Batches, b)
+	}
+	return view
+}
@@ -0,0 +1,94 @@
+package exporters
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// batchListTemplate lists the entries of each batch with their totals
+const batchListTemplate = `{{range .Batches}}{{.CompanyName}} {{date "DD/MM/YYYY" .EffectiveDate}}
+{{range .Entries}}{{.Name}}|{{mask .AccountNumber}}|{{money .Amount}}|{{.Direction}}{{range .Addenda}}|{{.}}{{end}}
+{{end}}{{end}}Debits {{money (debits .Entries)}} Credits {{money (credits .Entries)}} Total {{money (total .Entries)}}
+`
+
+func TestTemplateExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: A text template from the options
+	data, contentType, err := export(t, file, "TEMPLATE", Options{Template: TemplateOptions{Source: batchListTemplate}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "text/plain", contentType)
+	assert.Equal(t, "EMPRESA EXEMPLO 19/10/2026\n"+
+		"JOAO DA SILVA|**1111|7,500.00|DEBIT\n"+
+		"MARIA SOUZA|**2222|1,200.00|DEBIT\n"+
+		"PEDRO ALVARES|**3333|9,000.00|CREDIT\n"+
+		"OUTRA EMPRESA 20/10/2026\n"+
+		"ACME SUPPLIES|**4444|5,100.00|CREDIT|INV-1001 INV-1002\n"+
+		"Debits 8,700.00 Credits 14,100.00 Total 22,800.00\n", string(data))
+
+	// Test case 2: Options apply to the view and the functions
+	location, err := time.LoadLocation("America/Sao_Paulo")
+	if assert.NoError(t, err) {
+		text := exportString(t, file, "TEMPLATE", Options{
+			BatchNumbers: []string{"1"},
+			Locale:       LocalePtBR,
+			DateFormat:   "DD.MM.YYYY",
+			Location:     location,
+			Template:     TemplateOptions{Source: `{{len .Batches}} {{date "" .Created}} {{.Created.Format "15:04"}} {{money .TotalDebit}}`},
+		})
+		assert.Equal(t, "1 17.10.2026 09:00 8.700,00", text)
+	}
+
+	// Test case 3: HTML templates escape values
+	data, contentType, err = export(t, file, "TEMPLATE", Options{Template: TemplateOptions{Source: `<p>{{.OriginName}} {{"R&D <ops>"}}</p>`, HTML: true}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "text/html", contentType)
+		assert.Equal(t, "<p>EMPRESA EXEMPLO R&amp;D &lt;ops&gt;</p>", string(data))
+	}
+
+	// Test case 4: Templates of the server template directory are selected by name
+	// The registry is global, so only load the directory once when the test is repeated
+	if !slices.Contains(Templates(), "test_remittance") {
+		dir := t.TempDir()
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "test_remittance.html.tmpl"), []byte(`<h1>{{upper .DestinationName}}</h1>`), 0o644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "test_summary.tmpl"), []byte(`{{.EntryCount}} entries`), 0o644))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(`{{`), 0o644))
+		names, err := LoadTemplates(dir)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"test_remittance", "test_summary"}, names)
+	}
+	data, contentType, err = export(t, file, "TEMPLATE", Options{Template: TemplateOptions{Name: "TEST_REMITTANCE"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "text/html", contentType)
+		assert.Equal(t, "<h1>BANCO DO BRASIL</h1>", string(data))
+	}
+	data, contentType, err = export(t, file, "TEMPLATE", Options{Template: TemplateOptions{Name: "test_summary", ContentType: "text/markdown"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "text/markdown", contentType)
+		assert.Equal(t, "4 entries", string(data))
+	}
+
+	// Test case 5: Templates that are missing, cannot be parsed or fail on the file
+	for _, template := range []TemplateOptions{
+		{},
+		{Source: "{{.OriginName"},
+		{Source: "{{.Unknown}}"},
+		{Source: "{{money .OriginName}}"},
+		{Name: "unknown"},
+		{Name: "test_summary", Source: "x"},
+	} {
+		options := Options{Template: template}
+		if options.Validate() != nil {
+			continue
+		}
+		_, _, err = export(t, file, "TEMPLATE", options)
+		assert.ErrorIs(t, err, ErrInvalidTemplate, "%+v", template)
+	}
+}
@@ -1,12 +1,22 @@
 package exporters
 
 import (
-	"bytes"
+	"bufio"
 	"fmt"
+	"io"
 
 	"github.com/nacha-service/pkg/models"
 )
 
+func init() {
+	MustRegister(Format{
+		Name:        "TXT",
+		Extension:   ".txt",
+		Description: "Human-readable plain text",
+		New:         func() NachaExporter { return NewTXTExporter() },
+	})
+}
+
 // TXTExporter handles export to TXT format
 type TXTExporter struct {
 	*BaseExporter
@@ -21,15 +31,24 @@ func NewTXTExporter() *TXTExporter {
 
 // Export converts a NACHA file to TXT format
 func (e *TXTExporter) Export(file *models.NachaFile) ([]byte, error) {
-	var buf bytes.Buffer
+	return exportBytes(e, file)
+}
+
+// ExportTo writes a NACHA file to w in TXT format
+func (e *TXTExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+	creationDate, creationTime := opts.fileCreation(&file.Header, "2006-01-02")
+
+	buf := bufio.NewWriter(w)
 
 	// Write file header
 	buf.WriteString("=== FILE HEADER ===\n")
 	buf.WriteString(fmt.Sprintf("Priority Code: %s\n", file.Header.PriorityCode))
 	buf.WriteString(fmt.Sprintf("Immediate Destination: %s\n", file.Header.ImmediateDestination))
 	buf.WriteString(fmt.Sprintf("Immediate Origin: %s\n", file.Header.ImmediateOrigin))
-	buf.WriteString(fmt.Sprintf("File Creation Date: %s\n", file.Header.FileCreationDate.Format("2006-01-02")))
-	buf.WriteString(fmt.Sprintf("File Creation Time: %s\n", file.Header.FileCreationTime))
+	buf.WriteString(fmt.Sprintf("File Creation Date: %s\n", creationDate))
+	buf.WriteString(fmt.Sprintf("File Creation Time: %s\n", creationTime))
 	buf.WriteString(fmt.Sprintf("File ID Modifier: %s\n", file.Header.FileIDModifier))
 	buf.WriteString(fmt.Sprintf("Record Size: %s\n", file.Header.RecordSize))
 	buf.WriteString(fmt.Sprintf("Blocking Factor: %s\n", file.Header.BlockingFactor))
@@ -60,16 +79,9 @@ func (e *TXTExporter) Export(file *models.NachaFile) ([]byte, error) {
 		// Entries
 		for j, entry := range batch.Entries {
 			buf.WriteString(fmt.Sprintf("--- Entry %d ---\n", j+1))
-			buf.WriteString(fmt.Sprintf("Transaction Code: %s\n", entry.TransactionCode))
-			buf.WriteString(fmt.Sprintf("Receiving DFI: %s\n", entry.ReceivingDFI))
-			buf.WriteString(fmt.Sprintf("Check Digit: %s\n", entry.CheckDigit))
-			buf.WriteString(fmt.Sprintf("DFI Account Number: %s\n", entry.DFIAccountNumber))
-			buf.WriteString(fmt.Sprintf("Amount: $%.2f\n", float64(entry.Amount)/100.0))
-			buf.WriteString(fmt.Sprintf("Individual ID Number: %s\n", entry.IndividualIDNumber))
-			buf.WriteString(fmt.Sprintf("Individual Name: %s\n", entry.IndividualName))
-			buf.WriteString(fmt.Sprintf("Discretionary Data: %s\n", entry.DiscretionaryData))
-			buf.WriteString(fmt.Sprintf("Addenda Record Indicator: %s\n", entry.AddendaRecordIndicator))
-			buf.WriteString(fmt.Sprintf("Trace Number: %s\n", entry.TraceNumber))
+			for _, f := range opts.fields() {
+				buf.WriteString(fmt.Sprintf("%s: %s\n", f.label, opts.entryValue(f, &entry, dollarAmount)))
+			}
 
 			// Addenda Records
 			if len(entry.AddendaRecords) > 0 {
@@ -90,8 +102,8 @@ func (e *TXTExporter) Export(file *models.NachaFile) ([]byte, error) {
 		buf.WriteString(fmt.Sprintf("Service Class Code: %s\n", batch.Control.ServiceClassCode))
 		buf.WriteString(fmt.Sprintf("Entry/Addenda Count: %d\n", batch.Control.EntryAddendaCount))
 		buf.WriteString(fmt.Sprintf("Entry Hash: %s\n", batch.Control.EntryHash))
-		buf.WriteString(fmt.Sprintf("Total Debit Amount: $%.2f\n", float64(batch.Control.TotalDebitAmount)/100.0))
-		buf.WriteString(fmt.Sprintf("Total Credit Amount: $%.2f\n", float64(batch.Control.TotalCreditAmount)/100.0))
+		buf.WriteString(fmt.Sprintf("Total Debit Amount: %s\n", opts.amount(batch.Control.TotalDebitAmount, dollarAmount)))
+		buf.WriteString(fmt.Sprintf("Total Credit Amount: %s\n", opts.amount(batch.Control.TotalCreditAmount, dollarAmount)))
 		buf.WriteString(fmt.Sprintf("Company Identification: %s\n", batch.Control.CompanyIdentification))
 		buf.WriteString(fmt.Sprintf("Originating DFI: %s\n", batch.Control.OriginatingDFI))
 		buf.WriteString(fmt.Sprintf("Batch Number: %s\n", batch.Control.BatchNumber))
@@ -104,8 +116,11 @@ func (e *TXTExporter) Export(file *models.NachaFile) ([]byte, error) {
 	buf.WriteString(fmt.Sprintf("Block Count: %d\n", file.Control.BlockCount))
 	buf.WriteString(fmt.Sprintf("Entry/Addenda Count: %d\n", file.Control.EntryAddendaCount))
 	buf.WriteString(fmt.Sprintf("Entry Hash: %s\n", file.Control.EntryHash))
-	buf.WriteString(fmt.Sprintf("Total Debit Amount: $%.2f\n", float64(file.Control.TotalDebitAmount)/100.0))
-	buf.WriteString(fmt.Sprintf("Total Credit Amount: $%.2f\n", float64(file.Control.TotalCreditAmount)/100.0))
+	buf.WriteString(fmt.Sprintf("Total Debit Amount: %s\n", opts.amount(file.Control.TotalDebitAmount, dollarAmount)))
+	buf.WriteString(fmt.Sprintf("Total Credit Amount: %s\n", opts.amount(file.Control.TotalCreditAmount, dollarAmount)))
 
-	return buf.Bytes(), nil
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write TXT: %v", err)
+	}
+	return nil
 }
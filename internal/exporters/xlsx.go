@@ -0,0 +1,307 @@
+package exporters
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/xuri/excelize/v2"
+)
+
+func init() {
+	MustRegister(Format{
+		Name:         "XLSX",
+		Extension:    ".xlsx",
+		Description:  "Excel workbook with sheets for the file header, batches, entries and addenda",
+		Capabilities: Capabilities{Binary: true, Importable: true},
+		New:          func() NachaExporter { return NewXLSXExporter() },
+	})
+}
+
+// Sheets of the XLSX export
+const (
+	XLSXFileHeaderSheet = "File Header"
+	XLSXBatchesSheet    = "Batches"
+	XLSXEntriesSheet    = "Entries"
+	XLSXAddendaSheet    = "Addenda"
+)
+
+// xlsxAmountFormat is the built-in number format #,##0.00
+const xlsxAmountFormat = 4
+
+// XLSXExporter handles export to Excel workbooks
+type XLSXExporter struct {
+	*BaseExporter
+}
+
+// NewXLSXExporter creates a new XLSX exporter
+func NewXLSXExporter() *XLSXExporter {
+	return &XLSXExporter{
+		BaseExporter: NewBaseExporter("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"),
+	}
+}
+
+// Export converts a NACHA file to an XLSX workbook
+func (e *XLSXExporter) Export(file *models.NachaFile) ([]byte, error) {
+	return exportBytes(e, file)
+}
+
+// xlsxSheet collects the rows of a sheet. Amount columns are written as
+// numbers in dollars.
+type xlsxSheet struct {
+	name    string
+	labels  []string
+	amounts map[string]bool
+	rows    [][]interface{}
+}
+
+func newXLSXSheet(name string, labels ...string) *xlsxSheet {
+	return &xlsxSheet{name: name, labels: labels, amounts: make(map[string]bool)}
+}
+
+// column returns the column name of a label, or an empty string when the
+// sheet has no such column
+func (s *xlsxSheet) column(label string) string {
+	for i, l := range s.labels {
+		if l == label {
+			name, _ := excelize.ColumnNumberToName(i + 1)
+			return name
+		}
+	}
+	return ""
+}
+
+// ExportTo writes a NACHA file to w as an XLSX workbook. Every sheet has a
+// frozen header row with an autofilter, amounts are numeric cells in dollars
+// and the batch and entry sheets end with totals computed by formulas.
+func (e *XLSXExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	opts := e.options
+	file = opts.apply(file)
+	fields := opts.fields()
+	creationDate, creationTime := opts.fileCreation(&file.Header, "2006-01-02")
+
+	header := newXLSXSheet(XLSXFileHeaderSheet,
+		"Priority Code", "Immediate Destination", "Immediate Origin", "File Creation Date", "File Creation Time",
+		"File ID Modifier", "Record Size", "Blocking Factor", "Format Code", "Destination Name", "Origin Name",
+		"Reference Code", "Batch Count", "Block Count", "Entry Addenda Count", "Entry Hash",
+		"Total Debit Amount", "Total Credit Amount")
+	header.amounts["Total Debit Amount"] = true
+	header.amounts["Total Credit Amount"] = true
+	h, c := &file.Header, &file.Control
+	header.rows = append(header.rows, []interface{}{
+		h.PriorityCode, h.ImmediateDestination, h.ImmediateOrigin, creationDate, creationTime,
+		h.FileIDModifier, h.RecordSize, h.BlockingFactor, h.FormatCode, h.DestinationName, h.OriginName,
+		h.ReferenceCode, c.BatchCount, c.BlockCount, c.EntryAddendaCount, c.EntryHash,
+		dollars(c.TotalDebitAmount), dollars(c.TotalCreditAmount),
+	})
+
+	batches := newXLSXSheet(XLSXBatchesSheet,
+		"Batch Number", "Service Class Code", "Company Name", "Company Discretionary Data",
+		"Company Identification", "Standard Entry Class", "Company Entry Description",
+		"Company Descriptive Date", "Effective Entry Date", "Settlement Date", "Originator Status Code",
+		"Originating DFI", "Entry Addenda Count", "Entry Hash", "Total Debit Amount", "Total Credit Amount")
+	batches.amounts["Total Debit Amount"] = true
+	batches.amounts["Total Credit Amount"] = true
+
+	entryLabels := []string{"Batch Number"}
+	for _, f := range fields {
+		entryLabels = append(entryLabels, f.label)
+	}
+	entries := newXLSXSheet(XLSXEntriesSheet, entryLabels...)
+	entries.amounts["Amount"] = true
+
+	addenda := newXLSXSheet(XLSXAddendaSheet,
+		"Batch Number", "Trace Number", "Addenda Type Code", "Payment Related Information",
+		"Addenda Sequence Number", "Entry Detail Sequence Number")
+
+	for _, batch := range file.Batches {
+		bh, bc := &batch.Header, &batch.Control
+		batches.rows = append(batches.rows, []interface{}{
+			bh.BatchNumber, bh.ServiceClassCode, bh.CompanyName, bh.CompanyDiscretionaryData,
+			bh.CompanyIdentification, bh.StandardEntryClass, bh.CompanyEntryDescription,
+			bh.CompanyDescriptiveDate, bh.EffectiveEntryDate, bh.SettlementDate, bh.OriginatorStatusCode,
+			bh.OriginatingDFI, bc.EntryAddendaCount, bc.EntryHash,
+			dollars(bc.TotalDebitAmount), dollars(bc.TotalCreditAmount),
+		})
+
+		for _, entry := range batch.Entries {
+			row := []interface{}{bh.BatchNumber}
+			for _, f := range fields {
+				if f.value == nil {
+					row = append(row, dollars(entry.Amount))
+				} else {
+					row = append(row, f.value(&entry))
+				}
+			}
+			entries.rows = append(entries.rows, row)
+
+			for _, a := range entry.AddendaRecords {
+				addenda.rows = append(addenda.rows, []interface{}{
+					bh.BatchNumber, entry.TraceNumber, a.AddendaTypeCode, a.PaymentRelatedInformation,
+					a.AddendaSequenceNumber, a.EntryDetailSequenceNumber,
+				})
+			}
+		}
+	}
+
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+	if err := workbook.SetSheetName("Sheet1", XLSXFileHeaderSheet); err != nil {
+		return fmt.Errorf("failed to name sheet: %v", err)
+	}
+	styles, err := newXLSXStyles(workbook)
+	if err != nil {
+		return err
+	}
+
+	for _, sheet := range []*xlsxSheet{header, batches, entries, addenda} {
+		if err := writeXLSXSheet(workbook, sheet, styles); err != nil {
+			return fmt.Errorf("failed to write %s sheet: %v", sheet.name, err)
+		}
+	}
+	batchTotals := xlsxTotal{label: "Total", columns: []string{"Entry Addenda Count", "Total Debit Amount", "Total Credit Amount"}}
+	if err := writeXLSXTotals(workbook, batches, styles, batchTotals); err != nil {
+		return fmt.Errorf("failed to write %s totals: %v", batches.name, err)
+	}
+	if err := writeXLSXTotals(workbook, entries, styles, entryTotals(entries)...); err != nil {
+		return fmt.Errorf("failed to write %s totals: %v", entries.name, err)
+	}
+
+	if _, err := workbook.WriteTo(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %v", err)
+	}
+	return nil
+}
+
+// xlsxStyles holds the style IDs of the workbook
+type xlsxStyles struct {
+	header, amount, total, totalAmount int
+}
+
+func newXLSXStyles(workbook *excelize.File) (xlsxStyles, error) {
+	var styles xlsxStyles
+	var err error
+	bold := &excelize.Font{Bold: true}
+	fill := excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"D9E1F2"}}
+	if styles.header, err = workbook.NewStyle(&excelize.Style{Font: bold, Fill: fill}); err != nil {
+		return styles, fmt.Errorf("failed to create style: %v", err)
+	}
+	if styles.amount, err = workbook.NewStyle(&excelize.Style{NumFmt: xlsxAmountFormat}); err != nil {
+		return styles, fmt.Errorf("failed to create style: %v", err)
+	}
+	if styles.total, err = workbook.NewStyle(&excelize.Style{Font: bold}); err != nil {
+		return styles, fmt.Errorf("failed to create style: %v", err)
+	}
+	if styles.totalAmount, err = workbook.NewStyle(&excelize.Style{Font: bold, NumFmt: xlsxAmountFormat}); err != nil {
+		return styles, fmt.Errorf("failed to create style: %v", err)
+	}
+	return styles, nil
+}
+
+// writeXLSXSheet writes the header row and rows of a sheet, freezes the
+// header row and adds an autofilter over the rows
+func writeXLSXSheet(workbook *excelize.File, sheet *xlsxSheet, styles xlsxStyles) error {
+	if _, err := workbook.NewSheet(sheet.name); err != nil {
+		return err
+	}
+	if err := workbook.SetSheetRow(sheet.name, "A1", &sheet.labels); err != nil {
+		return err
+	}
+	for i, row := range sheet.rows {
+		if err := workbook.SetSheetRow(sheet.name, "A"+strconv.Itoa(i+2), &row); err != nil {
+			return err
+		}
+	}
+
+	last, _ := excelize.ColumnNumberToName(len(sheet.labels))
+	lastRow := strconv.Itoa(len(sheet.rows) + 1)
+	if err := workbook.SetCellStyle(sheet.name, "A1", last+"1", styles.header); err != nil {
+		return err
+	}
+	for label := range sheet.amounts {
+		if column := sheet.column(label); column != "" && len(sheet.rows) > 0 {
+			if err := workbook.SetCellStyle(sheet.name, column+"2", column+lastRow, styles.amount); err != nil {
+				return err
+			}
+		}
+	}
+	if err := workbook.SetColWidth(sheet.name, "A", last, 18); err != nil {
+		return err
+	}
+	if err := workbook.SetPanes(sheet.name, &excelize.Panes{Freeze: true, YSplit: 1, TopLeftCell: "A2", ActivePane: "bottomLeft"}); err != nil {
+		return err
+	}
+	return workbook.AutoFilter(sheet.name, "A1:"+last+lastRow, nil)
+}
+
+// xlsxTotal is a totals row below the rows of a sheet
+type xlsxTotal struct {
+	label string
+	// condition selects the rows summed, as an Excel expression over a
+	// range of the rows; empty sums every row
+	condition string
+	columns   []string
+}
+
+// span returns the range of the rows of a column, such as E2:E9
+func (s *xlsxSheet) span(column string) string {
+	return fmt.Sprintf("%s2:%s%d", column, column, len(s.rows)+1)
+}
+
+// writeXLSXTotals writes totals rows below the rows of a sheet, with a SUM of
+// each of their columns, or a SUMPRODUCT of the rows that meet the condition
+func writeXLSXTotals(workbook *excelize.File, sheet *xlsxSheet, styles xlsxStyles, totals ...xlsxTotal) error {
+	if len(sheet.rows) == 0 {
+		return nil
+	}
+	last, _ := excelize.ColumnNumberToName(len(sheet.labels))
+	for i, total := range totals {
+		row := strconv.Itoa(len(sheet.rows) + 2 + i)
+		if err := workbook.SetCellStr(sheet.name, "A"+row, total.label); err != nil {
+			return err
+		}
+		if err := workbook.SetCellStyle(sheet.name, "A"+row, last+row, styles.total); err != nil {
+			return err
+		}
+
+		for _, label := range total.columns {
+			column := sheet.column(label)
+			if column == "" {
+				continue
+			}
+			formula := fmt.Sprintf("SUM(%s)", sheet.span(column))
+			if total.condition != "" {
+				formula = fmt.Sprintf("SUMPRODUCT((%s)*%s)", total.condition, sheet.span(column))
+			}
+			if err := workbook.SetCellFormula(sheet.name, column+row, formula); err != nil {
+				return err
+			}
+			if sheet.amounts[label] {
+				if err := workbook.SetCellStyle(sheet.name, column+row, column+row, styles.totalAmount); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// entryTotals returns the totals rows of the entries sheet: debits and
+// credits told apart by transaction code as in the batch controls, or a
+// single total when the transaction code is not exported
+func entryTotals(entries *xlsxSheet) []xlsxTotal {
+	code := entries.column("Transaction Code")
+	if code == "" {
+		return []xlsxTotal{{label: "Total", columns: []string{"Amount"}}}
+	}
+	return []xlsxTotal{
+		{label: "Total Debit", condition: fmt.Sprintf(`(MID(%[1]s,2,1)>="5")*(MID(%[1]s,2,1)<="9")`, entries.span(code)), columns: []string{"Amount"}},
+		{label: "Total Credit", condition: fmt.Sprintf(`(MID(%[1]s,2,1)>="1")*(MID(%[1]s,2,1)<="4")`, entries.span(code)), columns: []string{"Amount"}},
+	}
+}
+
+// dollars converts cents to a number of dollars for a numeric cell
+func dollars(cents int64) float64 {
+	return float64(cents) / 100
+}
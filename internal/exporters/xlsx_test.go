@@ -0,0 +1,77 @@
+package exporters
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestXLSXExport(t *testing.T) {
+	file := testFile(t)
+
+	// Test case 1: A sheet per record kind with numeric amounts and formula totals
+	data, contentType, err := export(t, file, "XLSX", Options{})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", contentType)
+	workbook, err := excelize.OpenReader(bytes.NewReader(data))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer workbook.Close()
+	assert.Equal(t, []string{"File Header", "Batches", "Entries", "Addenda"}, workbook.GetSheetList())
+
+	cell := func(sheet, name string) string {
+		value, err := workbook.GetCellValue(sheet, name, excelize.Options{RawCellValue: true})
+		assert.NoError(t, err)
+		return value
+	}
+	assert.Equal(t, "Total Debit Amount", cell("File Header", "Q1"))
+	assert.Equal(t, "8700", cell("File Header", "Q2"))
+	assert.Equal(t, "14100", cell("File Header", "R2"))
+
+	assert.Equal(t, "Batch Number", cell("Entries", "A1"))
+	assert.Equal(t, "Amount", cell("Entries", "F1"))
+	assert.Equal(t, "7500", cell("Entries", "F2"))
+	// Numeric cells carry no type attribute, text cells are shared strings
+	cellType, err := workbook.GetCellType("Entries", "F2")
+	assert.NoError(t, err)
+	assert.Equal(t, excelize.CellTypeUnset, cellType)
+	cellType, err = workbook.GetCellType("Entries", "B2")
+	assert.NoError(t, err)
+	assert.Equal(t, excelize.CellTypeSharedString, cellType)
+	assert.Equal(t, "Total Debit", cell("Entries", "A6"))
+	formula, err := workbook.GetCellFormula("Entries", "F6")
+	assert.NoError(t, err)
+	assert.Equal(t, `SUMPRODUCT(((MID(B2:B5,2,1)>="5")*(MID(B2:B5,2,1)<="9"))*F2:F5)`, formula)
+	assert.Equal(t, "Total Credit", cell("Entries", "A7"))
+	formula, err = workbook.GetCellFormula("Entries", "F7")
+	assert.NoError(t, err)
+	assert.Equal(t, `SUMPRODUCT(((MID(B2:B5,2,1)>="1")*(MID(B2:B5,2,1)<="4"))*F2:F5)`, formula)
+
+	assert.Equal(t, "Total", cell("Batches", "A4"))
+	formula, err = workbook.GetCellFormula("Batches", "P4")
+	assert.NoError(t, err)
+	assert.Equal(t, "SUM(P2:P3)", formula)
+
+	assert.Equal(t, "076401250000004", cell("Addenda", "B2"))
+	assert.Equal(t, "INV-1001 INV-1002", cell("Addenda", "D2"))
+
+	// Test case 2: Frozen header rows and filters on every sheet
+	for _, sheet := range workbook.GetSheetList() {
+		panes, err := workbook.GetPanes(sheet)
+		if assert.NoError(t, err) {
+			assert.True(t, panes.Freeze, sheet)
+			assert.Equal(t, 1, panes.YSplit, sheet)
+		}
+	}
+	filters := make(map[string]string)
+	for _, name := range workbook.GetDefinedName() {
+		filters[name.Scope] = name.RefersTo
+	}
+	assert.Equal(t, "'Entries'!$A$1:$K$5", filters["Entries"])
+	assert.Equal(t, "'Addenda'!$A$1:$F$2", filters["Addenda"])
+}
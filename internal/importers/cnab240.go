@@ -0,0 +1,257 @@
+package importers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/cnab240"
+	"github.com/nacha-service/pkg/models"
+)
+
+// Transaction code of imported entries, counted as a credit in the batch
+// control totals
+const cnabCreditTransactionCode = "32"
+
+// CNAB240Options holds the NACHA values of a CNAB 240 import that the file
+// does not carry
+type CNAB240Options struct {
+	// CompanyIdentification replaces the company inscription (CNPJ or CPF)
+	// in the batches and as the immediate origin. Inscriptions only fit the
+	// ten character NACHA field when they have leading zeros.
+	CompanyIdentification string
+}
+
+// CNAB240Result is the outcome of a CNAB 240 import. File is only complete
+// when Errors is empty.
+type CNAB240Result struct {
+	File   *models.NachaFile
+	Errors []ImportError
+}
+
+// ImportCNAB240 builds a NACHA file from a CNAB 240 payment remittance. Each
+// credit lote becomes a batch of service class 220 and each segment A an
+// entry, completed by the segment B that follows it. The bank code and
+// branch become the eight digit DFI identification and the branch check
+// digit the check digit, the reverse of the CNAB240 export. Structural
+// problems of the file are reported as import errors; only content that is
+// not CNAB 240 at all fails the import.
+func ImportCNAB240(data []byte, opts CNAB240Options) (*CNAB240Result, error) {
+	file, issues, err := cnab240.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs errorList
+	for _, issue := range append(issues, file.Check()...) {
+		code := ErrorInvalidFile
+		if issue.Code == cnab240.IssueControlMismatch {
+			code = ErrorControlMismatch
+		}
+		errs.add(code, issue.Location(), "", "%s", issue.Message)
+	}
+	if len(errs) > 0 {
+		return &CNAB240Result{Errors: errs}, nil
+	}
+
+	result := &CNAB240Result{File: cnabFile(file, opts, &errs)}
+	result.Errors = errs
+	return result, nil
+}
+
+// cnabFile maps a parsed CNAB 240 file to a NACHA file with the controls
+// computed by the creator
+func cnabFile(file *cnab240.File, opts CNAB240Options, errs *errorList) *models.NachaFile {
+	h := file.Header
+	created, err := time.Parse("02012006150405", h.Get("data_geracao")+h.Get("hora_geracao"))
+	if err != nil {
+		errs.add(ErrorInvalidValue, cnabLocation(h, "data_geracao"), h.Get("data_geracao"), "file generation date and time are not valid")
+	}
+
+	fileHeader := models.FileHeader{
+		PriorityCode:         "01",
+		ImmediateDestination: cnabRouting(h.Raw("banco"), h.Raw("agencia"), h.Get("agencia_dv")),
+		ImmediateOrigin:      cnabCompany(h, opts, errs),
+		FileCreationDate:     time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC),
+		FileCreationTime:     created.Format("1504"),
+		FileIDModifier:       "A",
+		RecordSize:           "094",
+		BlockingFactor:       "10",
+		FormatCode:           "1",
+		DestinationName:      cut(h.Get("nome_banco"), 23),
+		OriginName:           cut(h.Get("nome_empresa"), 23),
+	}
+
+	c := creator.NewCreator()
+	nacha := c.CreateFile(fileHeader)
+	for _, lote := range file.Lotes {
+		batchHeader, ok := cnabBatchHeader(lote, opts, errs)
+		if !ok {
+			continue
+		}
+		c.AddBatch(nacha, batchHeader)
+		batch := &nacha.Batches[len(nacha.Batches)-1]
+
+		for i, detail := range lote.Details {
+			if detail.Layout != cnab240.SegmentALayout {
+				if detail.Layout != cnab240.SegmentBLayout {
+					errs.add(ErrorInvalidValue, cnabLocation(detail, "segmento"), detail.Get("segmento"), "segment %s is not supported, only segments A and B", detail.Get("segmento"))
+				}
+				continue
+			}
+			var b *cnab240.Record
+			if i+1 < len(lote.Details) && lote.Details[i+1].Layout == cnab240.SegmentBLayout {
+				b = lote.Details[i+1]
+			}
+			entry, addenda, ok := cnabEntry(detail, b, batchHeader, len(batch.Entries), errs)
+			if !ok {
+				continue
+			}
+			if err := addEntry(c, batch, entry, addenda); err != nil {
+				errs.add(ErrorInvalidValue, cnabLocation(detail, ""), "", "%v", err)
+			}
+		}
+	}
+
+	if err := c.FinalizeFile(nacha); err != nil {
+		errs.add(ErrorInvalidFile, "", "", "%v", err)
+	}
+	return nacha
+}
+
+// cnabBatchHeader maps a lote header to a batch header. The effective entry
+// date is the payment date of the lote's segments A, which must agree.
+func cnabBatchHeader(lote *cnab240.Lote, opts CNAB240Options, errs *errorList) (models.BatchHeader, bool) {
+	h := lote.Header
+	if operation := h.Get("tipo_operacao"); operation != "C" {
+		errs.add(ErrorInvalidValue, cnabLocation(h, "tipo_operacao"), operation, "only credit lotes (C) can be imported")
+		return models.BatchHeader{}, false
+	}
+
+	header := models.BatchHeader{
+		ServiceClassCode:        "220",
+		CompanyName:             cut(h.Get("nome_empresa"), 16),
+		CompanyIdentification:   cnabCompany(h, opts, errs),
+		StandardEntryClass:      "PPD",
+		CompanyEntryDescription: cut(h.Get("mensagem"), 10),
+		OriginatorStatusCode:    "1",
+		OriginatingDFI:          h.Raw("banco") + h.Raw("agencia"),
+	}
+	if h.Get("tipo_servico") == "20" {
+		header.StandardEntryClass = "CCD"
+	}
+	if header.CompanyEntryDescription == "" {
+		header.CompanyEntryDescription = DefaultCreditEntryDescription
+	}
+
+	var effective time.Time
+	for _, detail := range lote.Details {
+		if detail.Layout != cnab240.SegmentALayout {
+			continue
+		}
+		date, _ := detail.Date("data_pagamento")
+		switch {
+		case date.IsZero():
+			errs.add(ErrorMissingField, cnabLocation(detail, "data_pagamento"), "", "payment date is required")
+		case effective.IsZero():
+			effective = date
+		case !date.Equal(effective):
+			errs.add(ErrorInvalidValue, cnabLocation(detail, "data_pagamento"), detail.Get("data_pagamento"),
+				"payment date differs from %s of the lote, and a NACHA batch has one effective entry date", effective.Format("02012006"))
+		}
+	}
+	header.EffectiveEntryDate = effective.Format("060102")
+	return header, true
+}
+
+// cnabEntry maps a segment A and its segment B to an entry and its addenda
+func cnabEntry(a, b *cnab240.Record, batchHeader models.BatchHeader, index int, errs *errorList) (models.EntryDetail, []models.AddendaRecord, bool) {
+	before := len(*errs)
+
+	entry := models.EntryDetail{
+		RecordType:             "6",
+		TransactionCode:        cnabCreditTransactionCode,
+		ReceivingDFI:           a.Raw("banco_favorecido") + a.Raw("agencia_favorecido"),
+		IndividualName:         cut(a.Get("nome_favorecido"), 22),
+		AddendaRecordIndicator: "0",
+	}
+	entry.CheckDigit = cnabRouting(a.Raw("banco_favorecido"), a.Raw("agencia_favorecido"), a.Get("agencia_favorecido_dv"))[8:]
+	if entry.IndividualName == "" {
+		errs.add(ErrorMissingField, cnabLocation(a, "nome_favorecido"), "", "payee name is required")
+	}
+
+	account := strings.TrimLeft(a.Get("conta_favorecido"), "0")
+	if account == "" {
+		errs.add(ErrorMissingField, cnabLocation(a, "conta_favorecido"), "", "account number is required")
+	}
+	if digit := a.Get("conta_favorecido_dv"); digit != "" {
+		account += "-" + digit
+	}
+	entry.DFIAccountNumber = account
+
+	if currency := a.Get("tipo_moeda"); currency != "" && currency != "BRL" {
+		errs.add(ErrorUnsupportedCurrency, cnabLocation(a, "tipo_moeda"), currency, "only BRL payments can be imported")
+	}
+	amount := a.Int("valor_pagamento")
+	if amount <= 0 || amount > 9999999999 {
+		errs.add(ErrorInvalidAmount, cnabLocation(a, "valor_pagamento"), a.Get("valor_pagamento"), "payment must be positive and fit the ten digit NACHA amount")
+	}
+	entry.Amount = amount
+
+	// The trace number written by the CNAB240 export is kept
+	entry.TraceNumber = a.Get("seu_numero")
+	if len(entry.TraceNumber) != 15 || !models.IsDigits(entry.TraceNumber) {
+		entry.TraceNumber = fmt.Sprintf("%s%07d", batchHeader.OriginatingDFI, index+1)
+	}
+
+	if b != nil {
+		digits := b.Get("numero_inscricao_favorecido")
+		switch b.Get("tipo_inscricao_favorecido") {
+		case "1":
+			entry.IndividualIDNumber = digits[len(digits)-11:]
+		case "2":
+			entry.IndividualIDNumber = digits
+		}
+	}
+
+	addenda := paymentAddenda(a.Get("outras_informacoes"))
+	return entry, addenda, len(*errs) == before
+}
+
+// cnabCompany returns the company identification of a header record: the
+// option when given, otherwise the last ten digits of the inscription, which
+// must only drop leading zeros
+func cnabCompany(r *cnab240.Record, opts CNAB240Options, errs *errorList) string {
+	if opts.CompanyIdentification != "" {
+		return cut(opts.CompanyIdentification, 10)
+	}
+	inscription := r.Get("numero_inscricao")
+	significant := strings.TrimLeft(inscription, "0")
+	switch {
+	case significant == "":
+		errs.add(ErrorMissingField, cnabLocation(r, "numero_inscricao"), "", "company inscription is required")
+		return ""
+	case len(significant) > 10:
+		errs.add(ErrorInvalidValue, cnabLocation(r, "numero_inscricao"), inscription,
+			"company inscription does not fit the ten character NACHA company identification; set company_identification")
+		return ""
+	}
+	return inscription[len(inscription)-10:]
+}
+
+// cnabRouting joins a three digit bank code, five digit branch and branch
+// check digit into a nine digit routing number. Branches without a numeric
+// check digit get the ABA check digit.
+func cnabRouting(bank, branch, digit string) string {
+	eight := bank + branch
+	if len(digit) == 1 && models.IsDigits(digit) {
+		return eight + digit
+	}
+	check, _ := models.CheckDigit(eight)
+	return eight + check
+}
+
+func cnabLocation(r *cnab240.Record, field string) string {
+	return cnab240.Issue{Line: r.Line, Field: field}.Location()
+}
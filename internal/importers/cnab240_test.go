@@ -0,0 +1,69 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/internal/exporters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCNAB240(t *testing.T) {
+	source := testFile(t)
+	data := exportFile(t, source, "CNAB240", exporters.Options{CNAB: exporters.CNABOptions{Agreement: "123456", CompanyAccount: "98765-4", FileSequence: 7}})
+
+	// Test case 1: The remittance imports back to the credit entries
+	result, err := ImportCNAB240(data, CNAB240Options{})
+	if !assert.NoError(t, err) || !assert.Empty(t, result.Errors) {
+		return
+	}
+	file := result.File
+	assert.Equal(t, "076401251", strings.TrimSpace(file.Header.ImmediateDestination))
+	assert.Equal(t, "0764012512", strings.TrimSpace(file.Header.ImmediateOrigin))
+	if assert.Len(t, file.Batches, 2) {
+		batch := file.Batches[1]
+		assert.Equal(t, "CCD", batch.Header.StandardEntryClass)
+		assert.Equal(t, "1234567890", batch.Header.CompanyIdentification)
+		assert.Equal(t, "261020", batch.Header.EffectiveEntryDate)
+		if assert.Len(t, batch.Entries, 1) {
+			entry := batch.Entries[0]
+			assert.Equal(t, "32", entry.TransactionCode)
+			assert.Equal(t, "02100002", entry.ReceivingDFI)
+			assert.Equal(t, "1", entry.CheckDigit)
+			assert.Equal(t, "444444", strings.TrimSpace(entry.DFIAccountNumber))
+			assert.Equal(t, int64(510000), entry.Amount)
+			assert.Equal(t, "076401250000004", entry.TraceNumber)
+			if assert.Len(t, entry.AddendaRecords, 1) {
+				assert.Equal(t, "INV-1001 INV-1002", strings.TrimSpace(entry.AddendaRecords[0].PaymentRelatedInformation))
+			}
+		}
+		assert.Equal(t, int64(510000), batch.Control.TotalCreditAmount)
+	}
+
+	// Test case 2: A trailer that disagrees with the records is located
+	tampered := strings.Split(string(data), "\r\n")
+	tampered[8] = tampered[8][:23] + "000000000000999999" + tampered[8][41:]
+	result, err = ImportCNAB240([]byte(strings.Join(tampered, "\r\n")), CNAB240Options{})
+	if assert.NoError(t, err) {
+		assert.Nil(t, result.File)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, ErrorControlMismatch, result.Errors[0].Code)
+			assert.Equal(t, "line[9]/somatoria_valores", result.Errors[0].Location)
+		}
+	}
+
+	// Test case 3: A CNPJ needs the company identification of the import
+	data = exportFile(t, source, "CNAB240", exporters.Options{CNAB: exporters.CNABOptions{CompanyDocument: "12.345.678/0001-95"}})
+	result, err = ImportCNAB240(data, CNAB240Options{})
+	if assert.NoError(t, err) {
+		assert.NotEmpty(t, result.Errors)
+	}
+	result, err = ImportCNAB240(data, CNAB240Options{CompanyIdentification: "1234567800"})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Equal(t, "1234567800", result.File.Batches[0].Header.CompanyIdentification)
+	}
+
+	// Test case 4: Content that is not CNAB 240
+	_, err = ImportCNAB240(source.ToBytes(), CNAB240Options{})
+	assert.Error(t, err)
+}
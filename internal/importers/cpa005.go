@@ -0,0 +1,217 @@
+package importers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/cpa005"
+	"github.com/nacha-service/pkg/models"
+)
+
+// Transaction codes of imported entries, counted as a credit and a debit in
+// the batch control totals
+const (
+	cpaCreditTransactionCode = "32"
+	cpaDebitTransactionCode  = "27"
+)
+
+// CPA005Options holds the NACHA file header values of a CPA 005 import that
+// the file does not carry
+type CPA005Options struct {
+	// ImmediateDestination defaults to the institution for returns of the
+	// first transaction, with its ABA check digit
+	ImmediateDestination     string
+	ImmediateDestinationName string
+}
+
+// CPA005Result is the outcome of a CPA 005 import. File is only complete
+// when Errors is empty.
+type CPA005Result struct {
+	File   *models.NachaFile
+	Errors []ImportError
+}
+
+// cpaBatch is a run of segments of the same record type, originator and due
+// date, which become a NACHA batch
+type cpaBatch struct {
+	recordType string
+	segments   []*cpa005.Record
+}
+
+func (b *cpaBatch) accepts(recordType string, s *cpa005.Record) bool {
+	first := b.segments[0]
+	return b.recordType == recordType &&
+		first.Raw("originator_short_name") == s.Raw("originator_short_name") &&
+		first.Raw("due_date") == s.Raw("due_date") &&
+		first.Raw("return_institution_id") == s.Raw("return_institution_id")
+}
+
+// ImportCPA005 builds a NACHA file from a CPA Standard 005 file. Consecutive
+// segments of the same record type, originator short name, due date and
+// institution for returns become a batch: service class 220 for C records
+// and 225 for D records. The institution and transit numbers become the
+// eight digit DFI identification, with the ABA check digit, the reverse of
+// the CPA005 export. Structural problems of the file are reported as import
+// errors; only content that is not CPA 005 at all fails the import.
+func ImportCPA005(data []byte, opts CPA005Options) (*CPA005Result, error) {
+	file, issues, err := cpa005.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs errorList
+	for _, issue := range append(issues, file.Check()...) {
+		code := ErrorInvalidFile
+		if issue.Code == cpa005.IssueControlMismatch {
+			code = ErrorControlMismatch
+		}
+		errs.add(code, issue.Location(), "", "%s", issue.Message)
+	}
+	if len(errs) > 0 {
+		return &CPA005Result{Errors: errs}, nil
+	}
+
+	result := &CPA005Result{File: cpaFile(file, opts, &errs)}
+	result.Errors = errs
+	return result, nil
+}
+
+// cpaFile maps a parsed CPA 005 file to a NACHA file with the controls
+// computed by the creator
+func cpaFile(file *cpa005.File, opts CPA005Options, errs *errorList) *models.NachaFile {
+	var batches []*cpaBatch
+	for _, r := range file.Records {
+		for _, s := range r.Segments() {
+			if n := len(batches); n > 0 && batches[n-1].accepts(r.Type(), s) {
+				batches[n-1].segments = append(batches[n-1].segments, s)
+				continue
+			}
+			batches = append(batches, &cpaBatch{recordType: r.Type(), segments: []*cpa005.Record{s}})
+		}
+	}
+
+	h := file.Header
+	created, _ := h.Date("creation_date")
+	fileHeader := models.FileHeader{
+		PriorityCode:         "01",
+		ImmediateDestination: strings.TrimSpace(opts.ImmediateDestination),
+		ImmediateOrigin:      h.Get("originator_id"),
+		FileCreationDate:     created,
+		FileCreationTime:     "0000",
+		FileIDModifier:       "A",
+		RecordSize:           "094",
+		BlockingFactor:       "10",
+		FormatCode:           "1",
+		DestinationName:      cut(opts.ImmediateDestinationName, 23),
+	}
+	if len(batches) > 0 {
+		first := batches[0].segments[0]
+		fileHeader.OriginName = cut(first.Get("originator_long_name"), 23)
+		if fileHeader.ImmediateDestination == "" {
+			if returns := cpaDFI(first.Raw("return_institution_id")); returns != "" {
+				check, _ := models.CheckDigit(returns)
+				fileHeader.ImmediateDestination = returns + check
+			}
+		}
+	}
+	if fileHeader.ImmediateDestination == "" {
+		errs.add(ErrorMissingField, "", "", "immediate destination is required when the file has no institution for returns")
+	}
+
+	c := creator.NewCreator()
+	nacha := c.CreateFile(fileHeader)
+	for _, b := range batches {
+		batchHeader := cpaBatchHeader(b, h.Get("originator_id"), fileHeader.ImmediateDestination)
+		c.AddBatch(nacha, batchHeader)
+		batch := &nacha.Batches[len(nacha.Batches)-1]
+
+		for _, s := range b.segments {
+			entry, ok := cpaEntry(s, b.recordType, batchHeader, len(batch.Entries), errs)
+			if !ok {
+				continue
+			}
+			if err := addEntry(c, batch, entry, nil); err != nil {
+				errs.add(ErrorInvalidValue, cpaLocation(s, ""), "", "%v", err)
+			}
+		}
+	}
+
+	if err := c.FinalizeFile(nacha); err != nil {
+		errs.add(ErrorInvalidFile, "", "", "%v", err)
+	}
+	return nacha
+}
+
+// cpaBatchHeader maps the first segment of a run to a batch header. Payroll
+// transaction types (2xx) are PPD and the others CCD.
+func cpaBatchHeader(b *cpaBatch, originator, destination string) models.BatchHeader {
+	s := b.segments[0]
+	header := models.BatchHeader{
+		ServiceClassCode:        "220",
+		CompanyName:             cut(s.Get("originator_short_name"), 16),
+		CompanyIdentification:   originator,
+		StandardEntryClass:      "CCD",
+		CompanyEntryDescription: DefaultCreditEntryDescription,
+		OriginatorStatusCode:    "1",
+		OriginatingDFI:          cpaDFI(s.Raw("return_institution_id")),
+	}
+	if b.recordType == cpa005.TypeDebit {
+		header.ServiceClassCode = "225"
+		header.CompanyEntryDescription = DefaultDebitEntryDescription
+	}
+	if strings.HasPrefix(s.Get("transaction_type"), "2") {
+		header.StandardEntryClass = "PPD"
+	}
+	if header.OriginatingDFI == "" && len(destination) >= 8 {
+		header.OriginatingDFI = destination[:8]
+	}
+	due, _ := s.Date("due_date")
+	header.EffectiveEntryDate = due.Format("060102")
+	return header
+}
+
+// cpaEntry maps a transaction segment to an entry
+func cpaEntry(s *cpa005.Record, recordType string, batchHeader models.BatchHeader, index int, errs *errorList) (models.EntryDetail, bool) {
+	before := len(*errs)
+
+	entry := models.EntryDetail{
+		RecordType:             "6",
+		TransactionCode:        cpaCreditTransactionCode,
+		DFIAccountNumber:       s.Get("account_number"),
+		Amount:                 s.Int("amount"),
+		IndividualIDNumber:     cut(s.Get("sundry_information"), 15),
+		IndividualName:         cut(s.Get("payee_name"), 22),
+		AddendaRecordIndicator: "0",
+	}
+	if recordType == cpa005.TypeDebit {
+		entry.TransactionCode = cpaDebitTransactionCode
+	}
+
+	entry.ReceivingDFI = cpaDFI(s.Raw("institution_id"))
+	if entry.ReceivingDFI == "" {
+		errs.add(ErrorInvalidRouting, cpaLocation(s, "institution_id"), s.Get("institution_id"), "institution ID must be 0 followed by the institution and transit numbers")
+	} else {
+		entry.CheckDigit, _ = models.CheckDigit(entry.ReceivingDFI)
+	}
+
+	// The trace number written by the CPA005 export is kept
+	entry.TraceNumber = s.Get("cross_reference_number")
+	if len(entry.TraceNumber) != 15 || !models.IsDigits(entry.TraceNumber) {
+		entry.TraceNumber = fmt.Sprintf("%s%07d", batchHeader.OriginatingDFI, index+1)
+	}
+	return entry, len(*errs) == before
+}
+
+// cpaDFI returns the eight digit DFI identification of a nine digit
+// institution ID, or an empty string when it is zero or not one
+func cpaDFI(id string) string {
+	if len(id) != 9 || id[0] != '0' || !models.IsDigits(id) || strings.Trim(id, "0") == "" {
+		return ""
+	}
+	return id[1:]
+}
+
+func cpaLocation(s *cpa005.Record, field string) string {
+	return cpa005.Issue{Record: s.Number, Segment: s.Segment, Field: field}.Location()
+}
@@ -0,0 +1,85 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/internal/exporters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCPA005(t *testing.T) {
+	source := testFile(t)
+	data := exportFile(t, source, "CPA005", exporters.Options{CPA: exporters.CPAOptions{
+		DestinationDataCentre: "86900",
+		FileCreationNumber:    12,
+		ReturnAccount:         "9876543",
+	}})
+
+	// Test case 1: The file imports back with a batch per record type and originator
+	result, err := ImportCPA005(data, CPA005Options{ImmediateDestinationName: "BANCO DO BRASIL"})
+	if !assert.NoError(t, err) || !assert.Empty(t, result.Errors) {
+		return
+	}
+	file := result.File
+	assert.Equal(t, "076401251", strings.TrimSpace(file.Header.ImmediateDestination))
+	assert.Equal(t, "BANCO DO BRASIL", strings.TrimSpace(file.Header.DestinationName))
+	assert.Equal(t, "0764012512", strings.TrimSpace(file.Header.ImmediateOrigin))
+	assert.Equal(t, "EMPRESA EXEMPLO", strings.TrimSpace(file.Header.OriginName))
+	if assert.Len(t, file.Batches, 3) {
+		assert.Equal(t, "225", file.Batches[0].Header.ServiceClassCode)
+		assert.Len(t, file.Batches[0].Entries, 2)
+		assert.Equal(t, "PPD", file.Batches[1].Header.StandardEntryClass)
+
+		batch := file.Batches[2]
+		assert.Equal(t, "220", batch.Header.ServiceClassCode)
+		assert.Equal(t, "CCD", batch.Header.StandardEntryClass)
+		assert.Equal(t, "OUTRA EMPRESA", strings.TrimSpace(batch.Header.CompanyName))
+		assert.Equal(t, "261020", batch.Header.EffectiveEntryDate)
+		assert.Equal(t, "07640125", batch.Header.OriginatingDFI)
+		if assert.Len(t, batch.Entries, 1) {
+			entry := batch.Entries[0]
+			assert.Equal(t, "32", entry.TransactionCode)
+			assert.Equal(t, "02100002", entry.ReceivingDFI)
+			assert.Equal(t, "1", entry.CheckDigit)
+			assert.Equal(t, "444444", strings.TrimSpace(entry.DFIAccountNumber))
+			assert.Equal(t, int64(510000), entry.Amount)
+			assert.Equal(t, "076401250000004", entry.TraceNumber)
+		}
+	}
+	assert.Equal(t, int64(870000), file.Control.TotalDebitAmount)
+	assert.Equal(t, int64(1410000), file.Control.TotalCreditAmount)
+
+	// Test case 2: Records without line breaks
+	records := strings.Split(strings.TrimSuffix(string(data), "\r\n"), "\r\n")
+	result, err = ImportCPA005([]byte(strings.Join(records, "")), CPA005Options{})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Len(t, result.File.Batches, 3)
+	}
+
+	// Test case 3: A trailer that disagrees with the segments is located
+	tampered := append([]string(nil), records...)
+	tampered[4] = tampered[4][:46] + "00000001410001" + tampered[4][60:]
+	result, err = ImportCPA005([]byte(strings.Join(tampered, "\n")), CPA005Options{})
+	if assert.NoError(t, err) {
+		assert.Nil(t, result.File)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, ErrorControlMismatch, result.Errors[0].Code)
+			assert.Equal(t, "record[5]/total_credit_value", result.Errors[0].Location)
+		}
+	}
+
+	// Test case 4: Records of six segments
+	acme := source.Batches[1].Entries[0]
+	for i := 0; i < 6; i++ {
+		source.Batches[1].Entries = append(source.Batches[1].Entries, acme)
+	}
+	result, err = ImportCPA005(exportFile(t, source, "CPA005", exporters.Options{}), CPA005Options{})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) && assert.Len(t, result.File.Batches, 3) {
+		assert.Len(t, result.File.Batches[2].Entries, 7)
+	}
+
+	// Test case 5: Content that is not CPA 005
+	_, err = ImportCPA005(testFile(t).ToBytes(), CPA005Options{})
+	assert.Error(t, err)
+}
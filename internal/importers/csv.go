@@ -0,0 +1,775 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/models"
+)
+
+// CSV layouts recognised by ImportCSV
+const (
+	// CSVLayoutExport is the layout written by the CSV exporter, with a row
+	// per NACHA record
+	CSVLayoutExport = "EXPORT"
+	// CSVLayoutColumns is a spreadsheet with a row per payee and the columns
+	// named in CSVColumns
+	CSVLayoutColumns = "COLUMNS"
+)
+
+// CSVAmountFormat selects how payee amounts are written
+type CSVAmountFormat int
+
+const (
+	// CSVAmountDecimal reads amounts in dollars, such as 1,234.56
+	CSVAmountDecimal CSVAmountFormat = iota
+	// CSVAmountCents reads amounts in cents, such as 123456
+	CSVAmountCents
+)
+
+// CSVColumns names the columns of a payee spreadsheet. Names are matched
+// against the header row without regard to case, and empty names use the
+// names of DefaultCSVColumns. Account type, addenda, ID number and
+// transaction code columns are optional.
+type CSVColumns struct {
+	Name            string
+	Routing         string
+	Account         string
+	AccountType     string
+	Amount          string
+	Addenda         string
+	IDNumber        string
+	TransactionCode string
+}
+
+// DefaultCSVColumns are the column names used when none are given
+var DefaultCSVColumns = CSVColumns{
+	Name:            "name",
+	Routing:         "routing",
+	Account:         "account",
+	AccountType:     "account_type",
+	Amount:          "amount",
+	Addenda:         "addenda",
+	IDNumber:        "id_number",
+	TransactionCode: "transaction_code",
+}
+
+// CSVOptions configures a CSV import. The headers hold the fields of the
+// file and of its single batch for payee spreadsheets; the export layout
+// carries its own headers and ignores them.
+type CSVOptions struct {
+	FileHeader   models.FileHeader
+	BatchHeader  models.BatchHeader
+	Columns      CSVColumns
+	AmountFormat CSVAmountFormat
+}
+
+// CSVResult is the outcome of a CSV import. File is nil when there are errors.
+type CSVResult struct {
+	Layout string
+	File   *models.NachaFile
+	Errors []ImportError
+}
+
+// csvRow is a CSV record with the line it starts on
+type csvRow struct {
+	line   int
+	fields []string
+}
+
+// value returns the trimmed field at index, or an empty string
+func (r csvRow) value(index int) string {
+	if index < 0 || index >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[index])
+}
+
+func (r csvRow) location(column string) string {
+	if column == "" {
+		return fmt.Sprintf("row[%d]", r.line)
+	}
+	return fmt.Sprintf("row[%d]/%s", r.line, column)
+}
+
+// Transaction codes accepted in a transaction code column
+var transactionCodes = map[string]bool{
+	"22": true, "23": true, "24": true, "27": true, "28": true, "29": true,
+	"32": true, "33": true, "34": true, "37": true, "38": true, "39": true,
+}
+
+// ImportCSV builds a NACHA file from a CSV. A CSV written by the CSV exporter
+// is read back record by record; any other CSV is read as a spreadsheet with
+// a row per payee, which becomes a single batch. Rows that cannot be mapped
+// are returned as import errors; a malformed CSV is returned as an error.
+func ImportCSV(data []byte, opts CSVOptions) (*CSVResult, error) {
+	rows, err := readCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV has no rows")
+	}
+
+	var errs errorList
+	result := &CSVResult{}
+	var file *models.NachaFile
+	if isExportLayout(rows[0]) {
+		result.Layout = CSVLayoutExport
+		file = exportedCSVFile(rows, &errs)
+	} else {
+		result.Layout = CSVLayoutColumns
+		file = payeeCSVFile(rows, opts, &errs)
+	}
+
+	if len(errs) == 0 {
+		if err := file.Validate(); err != nil {
+			errs.add(ErrorInvalidFile, "", "", "%v", err)
+		}
+	}
+	if len(errs) > 0 {
+		result.Errors = errs
+		return result, nil
+	}
+
+	result.File = file
+	return result, nil
+}
+
+// readCSV reads the non-blank records of a CSV
+func readCSV(data []byte) ([]csvRow, error) {
+	// Spreadsheets often save CSV with a byte order mark
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []csvRow
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if strings.TrimSpace(strings.Join(fields, "")) == "" {
+			continue
+		}
+		rows = append(rows, csvRow{line: line, fields: fields})
+	}
+}
+
+func isExportLayout(first csvRow) bool {
+	return first.value(0) == "Record Type" && first.value(1) == "Priority Code"
+}
+
+// payeeCSVFile maps a spreadsheet with a row per payee to a file with a
+// single batch, with the controls computed by the creator
+func payeeCSVFile(rows []csvRow, opts CSVOptions, errs *errorList) *models.NachaFile {
+	header := csvHeaderRow(rows[0])
+	columns := csvColumns(header, rows[0], opts.Columns, errs)
+
+	fileHeader := csvFileHeader(opts.FileHeader, opts.BatchHeader, errs)
+	batchHeader := csvBatchHeader(opts.BatchHeader, fileHeader.FileCreationDate, errs)
+
+	c := creator.NewCreator()
+	file := c.CreateFile(fileHeader)
+	c.AddBatch(file, batchHeader)
+	batch := &file.Batches[0]
+
+	if len(rows) == 1 {
+		errs.add(ErrorMissingField, "", "", "CSV has no payee rows")
+	}
+	for i, row := range rows[1:] {
+		entry, addenda, ok := payeeEntry(row, columns, batchHeader, opts.AmountFormat, i, errs)
+		if !ok {
+			continue
+		}
+		if err := addEntry(c, batch, entry, addenda); err != nil {
+			errs.add(ErrorInvalidValue, row.location(""), "", "%v", err)
+		}
+	}
+
+	if err := c.FinalizeFile(file); err != nil {
+		errs.add(ErrorInvalidFile, "", "", "%v", err)
+	}
+	return file
+}
+
+// csvHeaderRow indexes the columns of a header row by lowercase name
+func csvHeaderRow(row csvRow) map[string]int {
+	index := make(map[string]int, len(row.fields))
+	for i := range row.fields {
+		name := strings.ToLower(row.value(i))
+		if _, ok := index[name]; !ok && name != "" {
+			index[name] = i
+		}
+	}
+	return index
+}
+
+// payeeColumns holds the index of each mapped column, -1 when absent
+type payeeColumns struct {
+	names CSVColumns
+
+	name, routing, account, amount                  int
+	accountType, addenda, idNumber, transactionCode int
+}
+
+// csvColumns resolves the mapped columns against the header row. Required
+// columns and columns named explicitly must be present.
+func csvColumns(header map[string]int, row csvRow, mapping CSVColumns, errs *errorList) payeeColumns {
+	names := mapping
+	resolve := func(name *string, fallback string, required bool) int {
+		explicit := strings.TrimSpace(*name) != ""
+		if !explicit {
+			*name = fallback
+		}
+		*name = strings.TrimSpace(*name)
+		if index, ok := header[strings.ToLower(*name)]; ok {
+			return index
+		}
+		if required || explicit {
+			errs.add(ErrorMissingField, row.location(""), *name, "column %s not found in the header row", *name)
+		}
+		return -1
+	}
+
+	columns := payeeColumns{}
+	columns.name = resolve(&names.Name, DefaultCSVColumns.Name, true)
+	columns.routing = resolve(&names.Routing, DefaultCSVColumns.Routing, true)
+	columns.account = resolve(&names.Account, DefaultCSVColumns.Account, true)
+	columns.accountType = resolve(&names.AccountType, DefaultCSVColumns.AccountType, false)
+	columns.amount = resolve(&names.Amount, DefaultCSVColumns.Amount, true)
+	columns.addenda = resolve(&names.Addenda, DefaultCSVColumns.Addenda, false)
+	columns.idNumber = resolve(&names.IDNumber, DefaultCSVColumns.IDNumber, false)
+	columns.transactionCode = resolve(&names.TransactionCode, DefaultCSVColumns.TransactionCode, false)
+	columns.names = names
+	return columns
+}
+
+// csvFileHeader completes the file header given in the request
+func csvFileHeader(header models.FileHeader, batch models.BatchHeader, errs *errorList) models.FileHeader {
+	now := time.Now().UTC()
+	if header.PriorityCode == "" {
+		header.PriorityCode = "01"
+	}
+	if header.FileCreationDate.IsZero() {
+		header.FileCreationDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	if header.FileCreationTime == "" {
+		header.FileCreationTime = now.Format("1504")
+	}
+	if header.FileIDModifier == "" {
+		header.FileIDModifier = "A"
+	}
+	if header.RecordSize == "" {
+		header.RecordSize = "094"
+	}
+	if header.BlockingFactor == "" {
+		header.BlockingFactor = "10"
+	}
+	if header.FormatCode == "" {
+		header.FormatCode = "1"
+	}
+	if header.ImmediateOrigin == "" {
+		header.ImmediateOrigin = batch.CompanyIdentification
+	}
+	if header.ImmediateDestination == "" {
+		errs.add(ErrorMissingField, "file_header/immediate_destination", "", "immediate destination is required")
+	}
+	return header
+}
+
+// csvBatchHeader completes the batch header given in the request
+func csvBatchHeader(header models.BatchHeader, created time.Time, errs *errorList) models.BatchHeader {
+	if header.ServiceClassCode == "" {
+		header.ServiceClassCode = "220"
+	}
+	if header.StandardEntryClass == "" {
+		header.StandardEntryClass = "PPD"
+	}
+	header.StandardEntryClass = strings.ToUpper(header.StandardEntryClass)
+	if header.CompanyEntryDescription == "" {
+		header.CompanyEntryDescription = DefaultCreditEntryDescription
+		if header.ServiceClassCode == "225" {
+			header.CompanyEntryDescription = DefaultDebitEntryDescription
+		}
+	}
+	if header.EffectiveEntryDate == "" {
+		header.EffectiveEntryDate = created.Format("060102")
+	}
+	if header.OriginatorStatusCode == "" {
+		header.OriginatorStatusCode = "1"
+	}
+
+	switch header.ServiceClassCode {
+	case "200", "220", "225":
+	default:
+		errs.add(ErrorInvalidValue, "batch_header/service_class_code", header.ServiceClassCode, "service class code must be 200, 220 or 225")
+	}
+	if _, err := time.Parse("060102", header.EffectiveEntryDate); err != nil {
+		errs.add(ErrorInvalidValue, "batch_header/effective_entry_date", header.EffectiveEntryDate, "effective entry date must be YYMMDD")
+	}
+	if header.CompanyName == "" {
+		errs.add(ErrorMissingField, "batch_header/company_name", "", "company name is required")
+	}
+	if header.CompanyIdentification == "" {
+		errs.add(ErrorMissingField, "batch_header/company_identification", "", "company identification is required")
+	}
+	if len(header.OriginatingDFI) != 8 || !models.IsDigits(header.OriginatingDFI) {
+		errs.add(ErrorInvalidRouting, "batch_header/originating_dfi_identification", header.OriginatingDFI, "originating DFI must be the first eight digits of a routing number")
+	}
+	return header
+}
+
+// payeeEntry maps a payee row to an entry and its addenda
+func payeeEntry(row csvRow, columns payeeColumns, batchHeader models.BatchHeader, format CSVAmountFormat, index int, errs *errorList) (models.EntryDetail, []models.AddendaRecord, bool) {
+	before := len(*errs)
+	names := columns.names
+
+	entry := models.EntryDetail{
+		RecordType:             "6",
+		IndividualName:         cut(row.value(columns.name), 22),
+		IndividualIDNumber:     cut(row.value(columns.idNumber), 15),
+		AddendaRecordIndicator: "0",
+	}
+	if entry.IndividualName == "" {
+		errs.add(ErrorMissingField, row.location(names.Name), "", "name is required")
+	}
+
+	routing := row.value(columns.routing)
+	if dfi, check, ok := routingNumber(routing); ok {
+		entry.ReceivingDFI = dfi
+		entry.CheckDigit = check
+	} else {
+		errs.add(ErrorInvalidRouting, row.location(names.Routing), routing, "invalid ABA routing number")
+	}
+
+	account := row.value(columns.account)
+	switch {
+	case account == "":
+		errs.add(ErrorMissingField, row.location(names.Account), "", "account number is required")
+	case len(account) > 17:
+		errs.add(ErrorInvalidValue, row.location(names.Account), account, "account number is longer than 17 characters")
+	case strings.Contains(account, "*"):
+		errs.add(ErrorInvalidValue, row.location(names.Account), account, "account number is masked")
+	default:
+		entry.DFIAccountNumber = account
+	}
+
+	amount := row.value(columns.amount)
+	if cents, ok := csvAmount(amount, format); ok && cents > 0 {
+		entry.Amount = cents
+	} else {
+		unit := "dollars with at most two decimal places"
+		if format == CSVAmountCents {
+			unit = "cents"
+		}
+		errs.add(ErrorInvalidAmount, row.location(names.Amount), amount, "amount must be a positive number of %s", unit)
+	}
+
+	accountType, transactionCode := row.value(columns.accountType), row.value(columns.transactionCode)
+	code, err := payeeTransactionCode(accountType, transactionCode, batchHeader.ServiceClassCode)
+	if err != nil {
+		column, value := names.AccountType, accountType
+		if transactionCode != "" || batchHeader.ServiceClassCode == "200" {
+			column, value = names.TransactionCode, transactionCode
+		}
+		errs.add(ErrorInvalidValue, row.location(column), value, "%v", err)
+	}
+	entry.TransactionCode = code
+
+	entry.TraceNumber = fmt.Sprintf("%s%07d", batchHeader.OriginatingDFI, index+1)
+
+	addenda := paymentAddenda(row.value(columns.addenda))
+	if len(addenda) > 1 && batchHeader.StandardEntryClass != "CTX" {
+		errs.add(ErrorTooManyAddenda, row.location(names.Addenda), "", "%s entries carry one addenda of 80 characters, text needs %d", batchHeader.StandardEntryClass, len(addenda))
+	}
+
+	return entry, addenda, len(*errs) == before
+}
+
+// payeeTransactionCode returns the transaction code of a payee. The code
+// column takes precedence, and must have the direction of the batch service
+// class; otherwise the code follows the account type and the direction of
+// the service class.
+func payeeTransactionCode(accountType, code, serviceClass string) (string, error) {
+	if code != "" {
+		if !transactionCodes[code] {
+			return "", fmt.Errorf("invalid transaction code: %s", code)
+		}
+		if serviceClass == "220" && !models.IsCredit(code) {
+			return "", fmt.Errorf("transaction code %s is a debit in a batch of credits", code)
+		}
+		if serviceClass == "225" && !models.IsDebit(code) {
+			return "", fmt.Errorf("transaction code %s is a credit in a batch of debits", code)
+		}
+		return code, nil
+	}
+
+	var checking, savings string
+	switch serviceClass {
+	case "220":
+		checking, savings = "22", "32"
+	case "225":
+		checking, savings = "27", "37"
+	default:
+		return "", fmt.Errorf("a transaction code is required in batches of credits and debits")
+	}
+
+	switch strings.ToLower(accountType) {
+	case "", "checking", "c", "dda", "demand":
+		return checking, nil
+	case "savings", "s", "sav", "sa":
+		return savings, nil
+	default:
+		return "", fmt.Errorf("account type must be checking or savings: %s", accountType)
+	}
+}
+
+// csvAmount parses an amount in dollars or cents. Dollar amounts may have a
+// currency sign and thousands separators.
+func csvAmount(value string, format CSVAmountFormat) (int64, bool) {
+	if format == CSVAmountCents {
+		return parseInteger(value)
+	}
+	value = strings.TrimPrefix(value, "$")
+	return parseCents(strings.ReplaceAll(value, ",", ""))
+}
+
+// exportedCSVFile reads back the records written by the CSV exporter. Header
+// rows name the columns of the file header, batch header and entry rows that
+// follow, so exports with selected entry fields are read as well.
+func exportedCSVFile(rows []csvRow, errs *errorList) *models.NachaFile {
+	file := &models.NachaFile{}
+	labels := make(map[string][]string)
+	var pending []string
+	var batch *models.Batch
+	var entry *models.EntryDetail
+	var controlRows []csvRow
+	var fileControlRow csvRow
+
+	for _, row := range rows {
+		recordType := row.value(0)
+		if recordType == "Record Type" {
+			pending = row.fields
+			continue
+		}
+		if pending != nil {
+			labels[recordType] = pending
+			pending = nil
+		}
+
+		switch recordType {
+		case "1":
+			file.Header.RecordType = "1"
+			forEachLabel(row, labels["1"], func(label, value string) {
+				setFileHeaderField(&file.Header, label, value, row.location(label), errs)
+			})
+		case "5":
+			file.Batches = append(file.Batches, models.Batch{Header: models.BatchHeader{RecordType: "5"}})
+			batch = &file.Batches[len(file.Batches)-1]
+			entry = nil
+			forEachLabel(row, labels["5"], func(label, value string) {
+				setBatchHeaderField(&batch.Header, label, value)
+			})
+		case "6":
+			if batch == nil {
+				errs.add(ErrorInvalidValue, row.location(""), recordType, "entry row before any batch row")
+				continue
+			}
+			batch.Entries = append(batch.Entries, models.EntryDetail{RecordType: "6"})
+			entry = &batch.Entries[len(batch.Entries)-1]
+			forEachLabel(row, labels["6"], func(label, value string) {
+				setEntryField(entry, label, value, row.location(label), errs)
+			})
+		case "7":
+			if entry == nil {
+				errs.add(ErrorInvalidValue, row.location(""), recordType, "addenda row before any entry row")
+				continue
+			}
+			entry.AddendaRecords = append(entry.AddendaRecords, models.AddendaRecord{
+				AddendaTypeCode:           row.value(1),
+				PaymentRelatedInformation: row.value(2),
+				AddendaSequenceNumber:     row.value(3),
+				EntryDetailSequenceNumber: row.value(4),
+			})
+		case "8":
+			if batch == nil {
+				errs.add(ErrorInvalidValue, row.location(""), recordType, "batch control row before any batch row")
+				continue
+			}
+			batch.Control = models.BatchControl{
+				RecordType:            "8",
+				ServiceClassCode:      row.value(1),
+				EntryAddendaCount:     csvInt(row, 2, "Entry Addenda Count", errs),
+				EntryHash:             row.value(3),
+				TotalDebitAmount:      csvControlAmount(row, 4, "Total Debit Amount", errs),
+				TotalCreditAmount:     csvControlAmount(row, 5, "Total Credit Amount", errs),
+				CompanyIdentification: row.value(6),
+				OriginatingDFI:        row.value(7),
+				BatchNumber:           row.value(8),
+			}
+			// The header and control rows repeat these, but either may
+			// leave them empty
+			if batch.Header.BatchNumber == "" {
+				batch.Header.BatchNumber = batch.Control.BatchNumber
+			}
+			if batch.Control.OriginatingDFI == "" {
+				batch.Control.OriginatingDFI = batch.Header.OriginatingDFI
+			}
+			if batch.Control.BatchNumber == "" {
+				batch.Control.BatchNumber = batch.Header.BatchNumber
+			}
+			controlRows = append(controlRows, row)
+			batch, entry = nil, nil
+		case "9":
+			file.Control = models.FileControl{
+				RecordType:        "9",
+				BatchCount:        csvInt(row, 1, "Batch Count", errs),
+				BlockCount:        csvInt(row, 2, "Block Count", errs),
+				EntryAddendaCount: csvInt(row, 3, "Entry Addenda Count", errs),
+				EntryHash:         row.value(4),
+				TotalDebitAmount:  csvControlAmount(row, 5, "Total Debit Amount", errs),
+				TotalCreditAmount: csvControlAmount(row, 6, "Total Credit Amount", errs),
+			}
+			fileControlRow = row
+		default:
+			errs.add(ErrorInvalidValue, row.location("Record Type"), recordType, "unknown record type")
+		}
+	}
+
+	if len(*errs) == 0 {
+		checkExportedControls(file, controlRows, fileControlRow, errs)
+	}
+	return file
+}
+
+// forEachLabel calls set with every labelled value of a row
+func forEachLabel(row csvRow, labels []string, set func(label, value string)) {
+	for i := 1; i < len(labels) && i < len(row.fields); i++ {
+		set(strings.TrimSpace(labels[i]), row.fields[i])
+	}
+}
+
+// setFileHeaderField sets the file header field of an exported column label.
+// location is where the value is reported if it is invalid.
+func setFileHeaderField(h *models.FileHeader, label, value, location string, errs *errorList) {
+	switch label {
+	case "Priority Code":
+		h.PriorityCode = value
+	case "Immediate Destination":
+		h.ImmediateDestination = value
+	case "Immediate Origin":
+		h.ImmediateOrigin = value
+	case "File Creation Date":
+		date, err := parseCSVDate(value)
+		if err != nil {
+			errs.add(ErrorInvalidValue, location, value, "file creation date must be YYYY-MM-DD or YYMMDD")
+		}
+		h.FileCreationDate = date
+	case "File Creation Time":
+		h.FileCreationTime = value
+	case "File ID Modifier":
+		h.FileIDModifier = value
+	case "Record Size":
+		h.RecordSize = value
+	case "Blocking Factor":
+		h.BlockingFactor = value
+	case "Format Code":
+		h.FormatCode = value
+	case "Destination Name":
+		h.DestinationName = value
+	case "Origin Name":
+		h.OriginName = value
+	case "Reference Code":
+		h.ReferenceCode = value
+	}
+}
+
+// setBatchHeaderField sets the batch header field of an exported column label
+func setBatchHeaderField(h *models.BatchHeader, label, value string) {
+	switch label {
+	case "Service Class Code":
+		h.ServiceClassCode = value
+	case "Company Name":
+		h.CompanyName = value
+	case "Company Discretionary Data":
+		h.CompanyDiscretionaryData = value
+	case "Company Identification":
+		h.CompanyIdentification = value
+	case "Standard Entry Class":
+		h.StandardEntryClass = value
+	case "Company Entry Description":
+		h.CompanyEntryDescription = value
+	case "Company Descriptive Date":
+		h.CompanyDescriptiveDate = value
+	case "Effective Entry Date":
+		h.EffectiveEntryDate = value
+	case "Settlement Date":
+		h.SettlementDate = value
+	case "Originator Status Code":
+		h.OriginatorStatusCode = value
+	case "Originating DFI":
+		h.OriginatingDFI = value
+	case "Batch Number":
+		h.BatchNumber = value
+	}
+}
+
+// setEntryField sets the entry field of an exported column label. location
+// is where the value is reported if it is invalid.
+func setEntryField(e *models.EntryDetail, label, value, location string, errs *errorList) {
+	switch label {
+	case "Transaction Code":
+		e.TransactionCode = value
+	case "Receiving DFI":
+		e.ReceivingDFI = value
+	case "Check Digit":
+		e.CheckDigit = value
+	case "DFI Account Number":
+		if strings.Contains(value, "*") {
+			errs.add(ErrorInvalidValue, location, value, "account number is masked")
+		}
+		e.DFIAccountNumber = value
+	case "Amount":
+		cents, ok := exportedAmount(value)
+		if !ok {
+			errs.add(ErrorInvalidAmount, location, value, "amount must be cents or dollars with two decimal places")
+		}
+		e.Amount = cents
+	case "Individual ID Number":
+		e.IndividualIDNumber = value
+	case "Individual Name":
+		e.IndividualName = value
+	case "Discretionary Data":
+		e.DiscretionaryData = value
+	case "Addenda Record Indicator":
+		e.AddendaRecordIndicator = value
+	case "Trace Number":
+		e.TraceNumber = value
+	}
+}
+
+// exportedAmount parses an exported amount, written in cents by default and
+// in dollars with the decimal amount format
+func exportedAmount(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, ".") {
+		return parseCents(strings.ReplaceAll(value, ",", ""))
+	}
+	return parseInteger(value)
+}
+
+func csvControlAmount(row csvRow, index int, label string, errs *errorList) int64 {
+	cents, ok := exportedAmount(row.value(index))
+	if !ok {
+		errs.add(ErrorInvalidAmount, row.location(label), row.value(index), "amount must be cents or dollars with two decimal places")
+	}
+	return cents
+}
+
+func csvInt(row csvRow, index int, label string, errs *errorList) int {
+	n, err := strconv.Atoi(row.value(index))
+	if err != nil {
+		errs.add(ErrorInvalidValue, row.location(label), row.value(index), "must be a number")
+	}
+	return n
+}
+
+// parseInteger parses an amount in cents
+func parseInteger(value string) (int64, bool) {
+	if !models.IsDigits(value) || len(value) > 10 {
+		return 0, false
+	}
+	cents, err := strconv.ParseInt(value, 10, 64)
+	return cents, err == nil
+}
+
+// parseCSVDate parses an exported date, written as YYYY-MM-DD by default
+func parseCSVDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date: %s", value)
+}
+
+// checkExportedControls compares the control rows with the controls the
+// creator computes from the records, so a mismatch is reported on its row
+func checkExportedControls(file *models.NachaFile, controlRows []csvRow, fileControlRow csvRow, errs *errorList) {
+	checkDeclaredControls(file, func(batch int, label string) (string, bool) {
+		switch {
+		case batch < 0 && fileControlRow.fields == nil:
+			return "", false
+		case batch < 0:
+			return fileControlRow.location(label), true
+		case batch >= len(controlRows):
+			return "", false
+		default:
+			return controlRows[batch].location(label), true
+		}
+	}, errs)
+}
+
+// checkDeclaredControls compares the controls declared by an export with the
+// controls the creator computes from the records. locate returns where a
+// control of a batch, or of the file for batch -1, is declared, and false
+// when the export has none.
+func checkDeclaredControls(file *models.NachaFile, locate func(batch int, label string) (string, bool), errs *errorList) {
+	computed := *file
+	computed.Batches = append([]models.Batch(nil), file.Batches...)
+	if err := creator.NewCreator().FinalizeFile(&computed); err != nil {
+		errs.add(ErrorInvalidFile, "", "", "%v", err)
+		return
+	}
+
+	for i, batch := range file.Batches {
+		if _, ok := locate(i, ""); !ok {
+			errs.add(ErrorMissingField, "", "", "batch %d has no control row", i+1)
+			continue
+		}
+		at := func(label string) string {
+			location, _ := locate(i, label)
+			return location
+		}
+		control := computed.Batches[i].Control
+		checkControl(at("Entry Addenda Count"), batch.Control.EntryAddendaCount, control.EntryAddendaCount, errs)
+		checkControl(at("Entry Hash"), batch.Control.EntryHash, control.EntryHash, errs)
+		checkControl(at("Total Debit Amount"), batch.Control.TotalDebitAmount, control.TotalDebitAmount, errs)
+		checkControl(at("Total Credit Amount"), batch.Control.TotalCreditAmount, control.TotalCreditAmount, errs)
+	}
+
+	if _, ok := locate(-1, ""); !ok {
+		errs.add(ErrorMissingField, "", "", "file has no control row")
+		return
+	}
+	at := func(label string) string {
+		location, _ := locate(-1, label)
+		return location
+	}
+	checkControl(at("Batch Count"), file.Control.BatchCount, computed.Control.BatchCount, errs)
+	checkControl(at("Entry Addenda Count"), file.Control.EntryAddendaCount, computed.Control.EntryAddendaCount, errs)
+	checkControl(at("Entry Hash"), file.Control.EntryHash, computed.Control.EntryHash, errs)
+	checkControl(at("Total Debit Amount"), file.Control.TotalDebitAmount, computed.Control.TotalDebitAmount, errs)
+	checkControl(at("Total Credit Amount"), file.Control.TotalCreditAmount, computed.Control.TotalCreditAmount, errs)
+}
+
+func checkControl[T comparable](location string, declared, actual T, errs *errorList) {
+	if declared != actual {
+		errs.add(ErrorControlMismatch, location, fmt.Sprint(declared), "control row declares %v, records give %v", declared, actual)
+	}
+}
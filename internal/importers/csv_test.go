@@ -0,0 +1,192 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/internal/exporters"
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testCSVOptions are the headers of a payee spreadsheet import
+func testCSVOptions(serviceClass string) CSVOptions {
+	return CSVOptions{
+		FileHeader: models.FileHeader{ImmediateDestination: "021000021"},
+		BatchHeader: models.BatchHeader{
+			ServiceClassCode:      serviceClass,
+			CompanyName:           "EMPRESA EXEMPLO",
+			CompanyIdentification: "0764012512",
+			OriginatingDFI:        "02100002",
+			EffectiveEntryDate:    "261019",
+		},
+	}
+}
+
+func TestImportCSV(t *testing.T) {
+	// Test case 1: A payee spreadsheet becomes a single batch
+	result, err := ImportCSV([]byte("\xef\xbb\xbfName,Routing,Account,Account_Type,Amount\nJOAO DA SILVA,021000021,111111,checking,\"$1,200.00\"\n\n,,,,\nMARIA,021000021,222222,savings,50\n"), testCSVOptions(""))
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Equal(t, CSVLayoutColumns, result.Layout)
+		batch := result.File.Batches[0]
+		assert.Equal(t, "220", batch.Header.ServiceClassCode)
+		if assert.Len(t, batch.Entries, 2) {
+			assert.Equal(t, "22", batch.Entries[0].TransactionCode)
+			assert.Equal(t, int64(120000), batch.Entries[0].Amount)
+			assert.Equal(t, "32", batch.Entries[1].TransactionCode)
+			assert.Equal(t, "021000020000002", batch.Entries[1].TraceNumber)
+		}
+	}
+
+	// Test case 2: Malformed and empty CSVs
+	for _, malformed := range []string{"", "\n\n", ",,,\n", "name,routing\n\"JOAO,021000021\n", "name,\"routing\"x\n"} {
+		_, err := ImportCSV([]byte(malformed), testCSVOptions(""))
+		assert.Error(t, err, malformed)
+	}
+
+	// Test case 3: Spreadsheets without payees or required columns
+	result, err = ImportCSV([]byte("name,routing,account,amount\n"), testCSVOptions(""))
+	if assert.NoError(t, err) {
+		assert.Nil(t, result.File)
+		assert.Contains(t, result.Errors, ImportError{Code: ErrorMissingField, Message: "CSV has no payee rows"})
+	}
+	result, err = ImportCSV([]byte("name,routing,amount\nJOAO,021000021,1.00\n"), testCSVOptions(""))
+	if assert.NoError(t, err) && assert.NotEmpty(t, result.Errors) {
+		assert.Equal(t, ImportError{Code: ErrorMissingField, Location: "row[1]", Message: "column account not found in the header row", Value: "account"}, result.Errors[0])
+	}
+
+	// Test case 4: Invalid payee values are reported by row and column
+	for _, tc := range []struct {
+		row, code, location string
+	}{
+		{",021000021,111,1.00", ErrorMissingField, "row[2]/name"},
+		{"JOAO,021000022,111,1.00", ErrorInvalidRouting, "row[2]/routing"},
+		{"JOAO,021000021,,1.00", ErrorMissingField, "row[2]/account"},
+		{"JOAO,021000021,******1111,1.00", ErrorInvalidValue, "row[2]/account"},
+		{"JOAO,021000021,111,-1.00", ErrorInvalidAmount, "row[2]/amount"},
+		{"JOAO,021000021,111,1.001", ErrorInvalidAmount, "row[2]/amount"},
+		{"JOAO,021000021,111,0", ErrorInvalidAmount, "row[2]/amount"},
+	} {
+		result, err := ImportCSV([]byte("name,routing,account,amount\n"+tc.row+"\n"), testCSVOptions(""))
+		if assert.NoError(t, err) && assert.NotEmpty(t, result.Errors, tc.row) {
+			assert.Equal(t, tc.code, result.Errors[0].Code, tc.row)
+			assert.Equal(t, tc.location, result.Errors[0].Location, tc.row)
+		}
+	}
+
+	// Test case 5: Prenote codes follow the direction of the batch and, like
+	// every entry, need an amount
+	result, err = ImportCSV([]byte("name,routing,account,amount,transaction_code\nJOAO,021000021,111,1.00,23\nMARIA,021000021,222,1.00,38\n"), testCSVOptions("200"))
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Equal(t, "23", result.File.Batches[0].Entries[0].TransactionCode)
+		assert.Equal(t, "38", result.File.Batches[0].Entries[1].TransactionCode)
+		assert.Equal(t, int64(100), result.File.Batches[0].Control.TotalCreditAmount)
+		assert.Equal(t, int64(100), result.File.Batches[0].Control.TotalDebitAmount)
+	}
+	result, err = ImportCSV([]byte("name,routing,account,amount,transaction_code\nJOAO,021000021,111,1.00,28\n"), testCSVOptions("220"))
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, ImportError{Code: ErrorInvalidValue, Location: "row[2]/transaction_code", Message: "transaction code 28 is a debit in a batch of credits", Value: "28"}, result.Errors[0])
+	}
+	result, err = ImportCSV([]byte("name,routing,account,amount,transaction_code\nJOAO,021000021,111,0,23\n"), testCSVOptions("220"))
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, ErrorInvalidAmount, result.Errors[0].Code)
+	}
+
+	// Test case 6: Amounts in cents
+	opts := testCSVOptions("225")
+	opts.AmountFormat = CSVAmountCents
+	result, err = ImportCSV([]byte("name,routing,account,amount\nJOAO,021000021,111,750000\n"), opts)
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Equal(t, int64(750000), result.File.Batches[0].Entries[0].Amount)
+		assert.Equal(t, "27", result.File.Batches[0].Entries[0].TransactionCode)
+		assert.Equal(t, DefaultDebitEntryDescription, result.File.Batches[0].Header.CompanyEntryDescription)
+	}
+	result, err = ImportCSV([]byte("name,routing,account,amount\nJOAO,021000021,111,7500.00\n"), opts)
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, ErrorInvalidAmount, result.Errors[0].Code)
+	}
+}
+
+func TestImportCSVExport(t *testing.T) {
+	data := string(exportFile(t, testFile(t), "CSV", exporters.Options{}))
+
+	// Test case 1: A CSV export imports back to the same file
+	result, err := ImportCSV([]byte(data), CSVOptions{})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Equal(t, CSVLayoutExport, result.Layout)
+		assert.Equal(t, string(testContent(t)), string(result.File.ToBytes()))
+	}
+
+	// Test case 2: A tampered control row is reported on its row
+	tampered := strings.Replace(data, "\n8,200,3,", "\n8,200,4,", 1)
+	result, err = ImportCSV([]byte(tampered), CSVOptions{})
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Nil(t, result.File)
+		assert.Equal(t, ErrorControlMismatch, result.Errors[0].Code)
+		assert.Contains(t, result.Errors[0].Location, "/Entry Addenda Count")
+	}
+
+	// Test case 3: Mapped payee columns, with addenda and formatted amounts
+	opts := testCSVOptions("")
+	opts.Columns = CSVColumns{Name: "Payee", Routing: "ABA", Account: "Acct", AccountType: "Type", Amount: "Value", Addenda: "Memo"}
+	result, err = ImportCSV([]byte("Payee,ABA,Acct,Type,Value,Memo\n"+
+		"JOAO DA SILVA,021000021,111111,checking,\"7,500.00\",Invoice 1001\n"+
+		"MARIA SOUZA,021000021,222222,savings,$1200.5,\n"), opts)
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) && assert.Len(t, result.File.Batches, 1) {
+		assert.Equal(t, CSVLayoutColumns, result.Layout)
+		entries := result.File.Batches[0].Entries
+		if assert.Len(t, entries, 2) {
+			assert.Equal(t, "22", entries[0].TransactionCode)
+			assert.Equal(t, int64(750000), entries[0].Amount)
+			if assert.Len(t, entries[0].AddendaRecords, 1) {
+				assert.Equal(t, "Invoice 1001", entries[0].AddendaRecords[0].PaymentRelatedInformation)
+			}
+			assert.Equal(t, "32", entries[1].TransactionCode)
+			assert.Equal(t, int64(120050), entries[1].Amount)
+			assert.Empty(t, entries[1].AddendaRecords)
+		}
+	}
+}
+
+func TestPayeeTransactionCode(t *testing.T) {
+	// Test case 1: Account types give the code in the direction of the batch
+	for _, tc := range []struct {
+		accountType, code, serviceClass, expected string
+	}{
+		{"", "", "220", "22"},
+		{"Checking", "", "220", "22"},
+		{"dda", "", "225", "27"},
+		{"SAVINGS", "", "220", "32"},
+		{"s", "", "225", "37"},
+		{"savings", "22", "220", "22"},
+		{"", "23", "220", "23"},
+		{"", "24", "220", "24"},
+		{"", "38", "225", "38"},
+		{"", "27", "200", "27"},
+		{"", "33", "200", "33"},
+	} {
+		code, err := payeeTransactionCode(tc.accountType, tc.code, tc.serviceClass)
+		if assert.NoError(t, err, tc) {
+			assert.Equal(t, tc.expected, code, tc)
+		}
+	}
+
+	// Test case 2: Codes against the direction of the batch, unknown codes
+	// and account types, and mixed batches without a code
+	for _, tc := range []struct {
+		accountType, code, serviceClass string
+	}{
+		{"", "27", "220"},
+		{"", "28", "220"},
+		{"", "22", "225"},
+		{"", "33", "225"},
+		{"", "21", "220"},
+		{"", "2", "220"},
+		{"", "X2", "200"},
+		{"loan", "", "220"},
+		{"checking", "", "200"},
+	} {
+		_, err := payeeTransactionCode(tc.accountType, tc.code, tc.serviceClass)
+		assert.Error(t, err, tc)
+	}
+}
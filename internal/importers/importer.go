@@ -0,0 +1,129 @@
+// Package importers builds NACHA files from other payment formats
+package importers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/models"
+)
+
+// Import error codes
+const (
+	ErrorMissingField        = "MISSING_FIELD"
+	ErrorInvalidValue        = "INVALID_VALUE"
+	ErrorInvalidAmount       = "INVALID_AMOUNT"
+	ErrorInvalidRouting      = "INVALID_ROUTING"
+	ErrorUnsupportedCurrency = "UNSUPPORTED_CURRENCY"
+	ErrorUnsupportedAgent    = "UNSUPPORTED_AGENT"
+	ErrorUnsupportedAccount  = "UNSUPPORTED_ACCOUNT"
+	ErrorTooManyAddenda      = "TOO_MANY_ADDENDA"
+	ErrorControlMismatch     = "CONTROL_MISMATCH"
+	ErrorInvalidFile         = "INVALID_FILE"
+)
+
+// ImportError reports a value of the source that cannot be mapped to NACHA
+type ImportError struct {
+	Code string
+	// Location is the path of the value in the source, such as
+	// PmtInf[1]/CdtTrfTxInf[2]/Amt/InstdAmt
+	Location string
+	Message  string
+	Value    string
+}
+
+func (e ImportError) Error() string {
+	if e.Location == "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("%s at %s: %s", e.Code, e.Location, e.Message)
+}
+
+// errorList collects import errors
+type errorList []ImportError
+
+func (l *errorList) add(code, location, value, format string, args ...interface{}) {
+	*l = append(*l, ImportError{
+		Code:     code,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+		Value:    value,
+	})
+}
+
+// addEntry adds an entry with its addenda to a batch, returning the error of
+// the creator when it rejects either
+func addEntry(c *creator.Creator, batch *models.Batch, entry models.EntryDetail, addenda []models.AddendaRecord) error {
+	for _, a := range addenda {
+		if err := c.AddAddenda(&entry, a); err != nil {
+			return err
+		}
+	}
+	return c.AddEntry(batch, entry)
+}
+
+// cut trims a value and cuts it to the length of a NACHA field
+func cut(s string, length int) string {
+	s = strings.TrimSpace(s)
+	if len(s) > length {
+		s = strings.TrimSpace(s[:length])
+	}
+	return s
+}
+
+// routingNumber splits a nine digit ABA routing number into the eight digit
+// institution identification and its check digit
+func routingNumber(routing string) (string, string, bool) {
+	routing = strings.TrimSpace(routing)
+	if len(routing) != 9 {
+		return "", "", false
+	}
+	if check, ok := models.CheckDigit(routing[:8]); !ok || check != routing[8:] {
+		return "", "", false
+	}
+	return routing[:8], routing[8:], true
+}
+
+// parseCents parses a decimal amount with at most two decimal places
+func parseCents(value string) (int64, bool) {
+	value = strings.TrimSpace(value)
+	units, fraction, hasPoint := strings.Cut(value, ".")
+	if units == "" || len(fraction) > 2 || (hasPoint && fraction == "") {
+		return 0, false
+	}
+	for len(fraction) < 2 {
+		fraction += "0"
+	}
+
+	var cents int64
+	for _, c := range units + fraction {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		cents = cents*10 + int64(c-'0')
+		if cents > 9999999999 {
+			// Larger than the ten digit NACHA amount field
+			return 0, false
+		}
+	}
+	return cents, true
+}
+
+// paymentAddenda turns remittance text into 05 addenda, cut into records of
+// 80 characters
+func paymentAddenda(lines ...string) []models.AddendaRecord {
+	var addenda []models.AddendaRecord
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		for len(line) > 0 {
+			n := min(len(line), 80)
+			addenda = append(addenda, models.AddendaRecord{
+				AddendaTypeCode:           "05",
+				PaymentRelatedInformation: strings.TrimSpace(line[:n]),
+			})
+			line = strings.TrimSpace(line[n:])
+		}
+	}
+	return addenda
+}
@@ -0,0 +1,111 @@
+package importers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/internal/exporters"
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testContent creates a NACHA file with two batches and returns its content
+func testContent(t *testing.T) []byte {
+	t.Helper()
+
+	c := creator.NewCreator()
+	file := c.CreateFile(models.FileHeader{
+		PriorityCode:         "01",
+		ImmediateDestination: "076401251",
+		ImmediateOrigin:      "0764012512",
+		FileCreationDate:     time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		FileCreationTime:     "1200",
+		FileIDModifier:       "A",
+		RecordSize:           "094",
+		BlockingFactor:       "10",
+		FormatCode:           "1",
+		DestinationName:      "BANCO DO BRASIL",
+		OriginName:           "EMPRESA EXEMPLO",
+	})
+
+	batches := []struct {
+		header  models.BatchHeader
+		entries []models.EntryDetail
+	}{
+		{
+			header: models.BatchHeader{
+				ServiceClassCode:        "200",
+				CompanyName:             "EMPRESA EXEMPLO",
+				CompanyIdentification:   "0764012512",
+				StandardEntryClass:      "PPD",
+				CompanyEntryDescription: "COBRANCA",
+				CompanyDescriptiveDate:  "261017",
+				EffectiveEntryDate:      "261019",
+				OriginatorStatusCode:    "1",
+				OriginatingDFI:          "07640125",
+			},
+			entries: []models.EntryDetail{
+				{TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "111111", Amount: 750000, IndividualName: "JOAO DA SILVA", TraceNumber: "076401250000001"},
+				{TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "222222", Amount: 120000, IndividualName: "MARIA SOUZA", TraceNumber: "076401250000002"},
+				{TransactionCode: "22", ReceivingDFI: "07640125", CheckDigit: "1", DFIAccountNumber: "333333", Amount: 900000, IndividualName: "PEDRO ALVARES", TraceNumber: "076401250000003"},
+			},
+		},
+		{
+			header: models.BatchHeader{
+				ServiceClassCode:        "220",
+				CompanyName:             "OUTRA EMPRESA",
+				CompanyIdentification:   "1234567890",
+				StandardEntryClass:      "CCD",
+				CompanyEntryDescription: "FORNECEDOR",
+				CompanyDescriptiveDate:  "261017",
+				EffectiveEntryDate:      "261020",
+				OriginatorStatusCode:    "1",
+				OriginatingDFI:          "07640125",
+			},
+			entries: []models.EntryDetail{
+				{TransactionCode: "32", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "444444", Amount: 510000, IndividualName: "ACME SUPPLIES", TraceNumber: "076401250000004",
+					AddendaRecords: []models.AddendaRecord{{AddendaTypeCode: "05", PaymentRelatedInformation: "INV-1001 INV-1002"}}},
+			},
+		},
+	}
+
+	for _, b := range batches {
+		c.AddBatch(file, b.header)
+		batch := &file.Batches[len(file.Batches)-1]
+		for _, entry := range b.entries {
+			entry.RecordType = "6"
+			entry.AddendaRecordIndicator = "0"
+			addenda := entry.AddendaRecords
+			entry.AddendaRecords = nil
+			for _, a := range addenda {
+				assert.NoError(t, c.AddAddenda(&entry, a))
+			}
+			assert.NoError(t, c.AddEntry(batch, entry))
+		}
+	}
+	assert.NoError(t, c.FinalizeFile(file))
+
+	return file.ToBytes()
+}
+
+// testFile reads the content of testContent back the way the service does,
+// so exporters see the parsed records
+func testFile(t *testing.T) *models.NachaFile {
+	return models.FromBytes(testContent(t))
+}
+
+// exportFile writes a file in a registered export format, the input of the
+// round trip imports
+func exportFile(t *testing.T, file *models.NachaFile, format string, options exporters.Options) []byte {
+	t.Helper()
+
+	exporter, err := exporters.CreateExporter(format)
+	if !assert.NoError(t, err, format) {
+		return nil
+	}
+	exporter.SetOptions(options)
+	data, err := exporter.Export(file)
+	assert.NoError(t, err, format)
+	return data
+}
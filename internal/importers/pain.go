@@ -0,0 +1,563 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/pkg/iso20022"
+	"github.com/nacha-service/pkg/models"
+)
+
+// Supported pain message types
+const (
+	MessagePain001 = "pain.001.001.03"
+	MessagePain008 = "pain.008.001.02"
+)
+
+// Default values of the NACHA fields that pain messages do not carry
+const (
+	DefaultCreditEntryDescription = "PAYMENT"
+	DefaultDebitEntryDescription  = "COLLECTION"
+)
+
+// PainOptions sets the NACHA file header fields that pain messages do not
+// carry. Empty fields are derived from the message.
+type PainOptions struct {
+	// ImmediateDestination defaults to the routing number of the first
+	// originating agent
+	ImmediateDestination     string
+	ImmediateDestinationName string
+	// ImmediateOrigin defaults to the initiating party identification
+	ImmediateOrigin string
+	// FileIDModifier defaults to A
+	FileIDModifier string
+	// CompanyEntryDescription defaults to PAYMENT for pain.001 and
+	// COLLECTION for pain.008
+	CompanyEntryDescription string
+}
+
+// PainResult is the outcome of a pain import. File is nil when there are errors.
+type PainResult struct {
+	MessageType string
+	File        *models.NachaFile
+	Errors      []ImportError
+}
+
+// painBatch is a payment information block of either message type, seen
+// from the company that originates the entries
+type painBatch struct {
+	path         string
+	company      iso20022.Party
+	agent        iso20022.Agent
+	agentPath    string
+	date         string
+	datePath     string
+	paymentType  *iso20022.PaymentTypeInformation
+	count, sum   string
+	transactions []painTransaction
+}
+
+// painTransaction is a transaction of either message type, seen from the
+// company's counterparty
+type painTransaction struct {
+	path       string
+	amountPath string
+	id         iso20022.PaymentID
+	amount     iso20022.Amount
+	agent      *iso20022.Agent
+	party      *iso20022.Party
+	account    *iso20022.Account
+	remittance *iso20022.RemittanceInformation
+	partyName  string // element names used in error locations
+	agentName  string
+	accountNm  string
+}
+
+// ImportPain builds a NACHA file from a pain.001 or pain.008 message. Credit
+// transfers become credit entries and direct debits become debit entries.
+// Values that cannot be mapped are returned as import errors; a malformed or
+// unsupported message is returned as an error.
+func ImportPain(data []byte, opts PainOptions) (*PainResult, error) {
+	namespace, err := rootNamespace(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var header iso20022.GroupHeader
+	var batches []painBatch
+	result := &PainResult{}
+
+	switch namespace {
+	case iso20022.Pain001Namespace:
+		var doc iso20022.Pain001Document
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse pain.001: %v", err)
+		}
+		result.MessageType = MessagePain001
+		header = doc.Initiation.GroupHeader
+		batches = creditBatches(&doc.Initiation)
+		if opts.CompanyEntryDescription == "" {
+			opts.CompanyEntryDescription = DefaultCreditEntryDescription
+		}
+	case iso20022.Pain008Namespace:
+		var doc iso20022.Pain008Document
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse pain.008: %v", err)
+		}
+		result.MessageType = MessagePain008
+		header = doc.Initiation.GroupHeader
+		batches = debitBatches(&doc.Initiation)
+		if opts.CompanyEntryDescription == "" {
+			opts.CompanyEntryDescription = DefaultDebitEntryDescription
+		}
+	default:
+		return nil, fmt.Errorf("unsupported message: %q (supported: %s, %s)", namespace, MessagePain001, MessagePain008)
+	}
+
+	credit := result.MessageType == MessagePain001
+	var errs errorList
+	file := painFile(&header, batches, opts, credit, &errs)
+
+	if len(errs) == 0 {
+		if err := file.Validate(); err != nil {
+			errs.add(ErrorInvalidFile, "", "", "%v", err)
+		}
+	}
+	if len(errs) > 0 {
+		result.Errors = errs
+		return result, nil
+	}
+
+	result.File = file
+	return result, nil
+}
+
+// rootNamespace returns the namespace of the root element
+func rootNamespace(data []byte) (string, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", fmt.Errorf("empty XML document")
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse XML: %v", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if start.Name.Local != "Document" {
+				return "", fmt.Errorf("unexpected root element: %s", start.Name.Local)
+			}
+			return start.Name.Space, nil
+		}
+	}
+}
+
+func creditBatches(initiation *iso20022.CustomerCreditTransferInitiation) []painBatch {
+	batches := make([]painBatch, len(initiation.PaymentInformation))
+	for i, info := range initiation.PaymentInformation {
+		path := fmt.Sprintf("PmtInf[%d]", i+1)
+		batch := painBatch{
+			path:        path,
+			company:     info.Debtor,
+			agent:       info.DebtorAgent,
+			agentPath:   path + "/DbtrAgt",
+			date:        info.RequestedExecutionDate,
+			datePath:    path + "/ReqdExctnDt",
+			paymentType: info.PaymentTypeInformation,
+			count:       info.NumberOfTransactions,
+			sum:         info.ControlSum,
+		}
+		for j, tx := range info.Transactions {
+			txPath := fmt.Sprintf("%s/CdtTrfTxInf[%d]", path, j+1)
+			batch.transactions = append(batch.transactions, painTransaction{
+				path:       txPath,
+				amountPath: txPath + "/Amt/InstdAmt",
+				id:         tx.PaymentID,
+				amount:     tx.Amount.InstructedAmount,
+				agent:      tx.CreditorAgent,
+				party:      tx.Creditor,
+				account:    tx.CreditorAccount,
+				remittance: tx.RemittanceInformation,
+				partyName:  "Cdtr",
+				agentName:  "CdtrAgt",
+				accountNm:  "CdtrAcct",
+			})
+		}
+		batches[i] = batch
+	}
+	return batches
+}
+
+func debitBatches(initiation *iso20022.CustomerDirectDebitInitiation) []painBatch {
+	batches := make([]painBatch, len(initiation.PaymentInformation))
+	for i, info := range initiation.PaymentInformation {
+		path := fmt.Sprintf("PmtInf[%d]", i+1)
+		batch := painBatch{
+			path:        path,
+			company:     info.Creditor,
+			agent:       info.CreditorAgent,
+			agentPath:   path + "/CdtrAgt",
+			date:        info.RequestedCollectionDate,
+			datePath:    path + "/ReqdColltnDt",
+			paymentType: info.PaymentTypeInformation,
+			count:       info.NumberOfTransactions,
+			sum:         info.ControlSum,
+		}
+		for j := range info.Transactions {
+			tx := &info.Transactions[j]
+			txPath := fmt.Sprintf("%s/DrctDbtTxInf[%d]", path, j+1)
+			batch.transactions = append(batch.transactions, painTransaction{
+				path:       txPath,
+				amountPath: txPath + "/InstdAmt",
+				id:         tx.PaymentID,
+				amount:     tx.InstructedAmount,
+				agent:      &tx.DebtorAgent,
+				party:      &tx.Debtor,
+				account:    &tx.DebtorAccount,
+				remittance: tx.RemittanceInformation,
+				partyName:  "Dbtr",
+				agentName:  "DbtrAgt",
+				accountNm:  "DbtrAcct",
+			})
+		}
+		batches[i] = batch
+	}
+	return batches
+}
+
+// painFile maps the group header and payment information blocks to a NACHA
+// file, with the controls computed by the creator
+func painFile(header *iso20022.GroupHeader, batches []painBatch, opts PainOptions, credit bool, errs *errorList) *models.NachaFile {
+	created, err := parseDateTime(header.CreationDateTime)
+	if err != nil {
+		errs.add(ErrorInvalidValue, "GrpHdr/CreDtTm", header.CreationDateTime, "invalid creation date and time")
+	}
+	if len(batches) == 0 {
+		errs.add(ErrorMissingField, "PmtInf", "", "message has no payment information")
+	}
+
+	fileHeader := models.FileHeader{
+		PriorityCode:         "01",
+		ImmediateDestination: strings.TrimSpace(opts.ImmediateDestination),
+		ImmediateOrigin:      strings.TrimSpace(opts.ImmediateOrigin),
+		FileCreationDate:     time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, time.UTC),
+		FileCreationTime:     created.Format("1504"),
+		FileIDModifier:       strings.TrimSpace(opts.FileIDModifier),
+		RecordSize:           "094",
+		BlockingFactor:       "10",
+		FormatCode:           "1",
+		DestinationName:      cut(opts.ImmediateDestinationName, 23),
+		OriginName:           cut(header.InitiatingParty.Name, 23),
+	}
+	if fileHeader.FileIDModifier == "" {
+		fileHeader.FileIDModifier = "A"
+	}
+	if fileHeader.ImmediateOrigin == "" {
+		fileHeader.ImmediateOrigin = cut(partyID(&header.InitiatingParty), 10)
+	}
+
+	c := creator.NewCreator()
+	file := c.CreateFile(fileHeader)
+
+	var count int
+	var total int64
+	for i := range batches {
+		b := &batches[i]
+		before := len(*errs)
+		batchHeader, routing := painBatchHeader(b, opts, credit, errs)
+		headerOK := len(*errs) == before
+		if file.Header.ImmediateDestination == "" {
+			file.Header.ImmediateDestination = routing
+		}
+		if file.Header.ImmediateOrigin == "" {
+			file.Header.ImmediateOrigin = batchHeader.CompanyIdentification
+		}
+
+		c.AddBatch(file, batchHeader)
+		batch := &file.Batches[len(file.Batches)-1]
+
+		var batchTotal int64
+		for j := range b.transactions {
+			entry, addenda, ok := painEntry(&b.transactions[j], batchHeader, credit, j, errs)
+			if !ok {
+				continue
+			}
+			batchTotal += entry.Amount
+			// The transactions of a block whose header failed are still
+			// checked, but have no batch to be added to
+			if !headerOK {
+				continue
+			}
+			if err := addEntry(c, batch, entry, addenda); err != nil {
+				errs.add(ErrorInvalidValue, b.transactions[j].path, "", "%v", err)
+			}
+		}
+
+		checkControls(b.path, b.count, b.sum, len(b.transactions), batchTotal, errs)
+		count += len(b.transactions)
+		total += batchTotal
+	}
+
+	checkControls("GrpHdr", header.NumberOfTransactions, header.ControlSum, count, total, errs)
+
+	if err := c.FinalizeFile(file); err != nil {
+		errs.add(ErrorInvalidFile, "", "", "%v", err)
+	}
+	return file
+}
+
+// painBatchHeader maps a payment information block to a batch header and
+// returns the nine digit routing number of the originating agent
+func painBatchHeader(b *painBatch, opts PainOptions, credit bool, errs *errorList) (models.BatchHeader, string) {
+	header := models.BatchHeader{
+		ServiceClassCode:        "225",
+		CompanyName:             cut(b.company.Name, 16),
+		CompanyIdentification:   cut(partyID(&b.company), 10),
+		StandardEntryClass:      painSECCode(b),
+		CompanyEntryDescription: cut(opts.CompanyEntryDescription, 10),
+		OriginatorStatusCode:    "1",
+	}
+	if credit {
+		header.ServiceClassCode = "220"
+	}
+
+	companyElement := "Cdtr"
+	if credit {
+		companyElement = "Dbtr"
+	}
+	if header.CompanyName == "" {
+		errs.add(ErrorMissingField, b.path+"/"+companyElement+"/Nm", "", "company name is required")
+	}
+	if header.CompanyIdentification == "" {
+		errs.add(ErrorMissingField, b.path+"/"+companyElement+"/Id", "", "company identification is required")
+	}
+
+	if date, err := time.Parse("2006-01-02", strings.TrimSpace(b.date)); err == nil {
+		header.EffectiveEntryDate = date.Format("060102")
+		header.CompanyDescriptiveDate = header.EffectiveEntryDate
+	} else {
+		errs.add(ErrorInvalidValue, b.datePath, b.date, "invalid date")
+	}
+
+	routing, check, ok := painRouting(&b.agent, b.agentPath, errs)
+	if !ok {
+		return header, ""
+	}
+	header.OriginatingDFI = routing
+	return header, routing + check
+}
+
+// painSECCode takes the SEC code from the local instrument. Without one,
+// payments to organisations are CCD and payments to individuals PPD.
+func painSECCode(b *painBatch) string {
+	if b.paymentType != nil && b.paymentType.LocalInstrument != nil {
+		code := b.paymentType.LocalInstrument.Proprietary
+		if code == "" {
+			code = b.paymentType.LocalInstrument.Code
+		}
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if len(code) == 3 {
+			return code
+		}
+	}
+
+	for _, tx := range b.transactions {
+		if tx.party == nil || tx.party.ID == nil || tx.party.ID.Organisation == nil {
+			return "PPD"
+		}
+	}
+	return "CCD"
+}
+
+// painEntry maps a transaction to an entry and its addenda
+func painEntry(tx *painTransaction, batchHeader models.BatchHeader, credit bool, index int, errs *errorList) (models.EntryDetail, []models.AddendaRecord, bool) {
+	before := len(*errs)
+
+	entry := models.EntryDetail{
+		RecordType:             "6",
+		AddendaRecordIndicator: "0",
+	}
+
+	// Amount
+	if currency := strings.TrimSpace(tx.amount.Currency); currency != iso20022.CurrencyUSD {
+		errs.add(ErrorUnsupportedCurrency, tx.amountPath+"/@Ccy", currency, "only USD amounts can be sent through ACH")
+	}
+	if cents, ok := parseCents(tx.amount.Value); ok && cents > 0 {
+		entry.Amount = cents
+	} else {
+		errs.add(ErrorInvalidAmount, tx.amountPath, tx.amount.Value, "amount must be a positive number with at most two decimal places")
+	}
+
+	// Receiving institution
+	if tx.agent == nil {
+		errs.add(ErrorMissingField, tx.path+"/"+tx.agentName, "", "agent is required")
+	} else if routing, check, ok := painRouting(tx.agent, tx.path+"/"+tx.agentName, errs); ok {
+		entry.ReceivingDFI = routing
+		entry.CheckDigit = check
+	}
+
+	// Account
+	switch {
+	case tx.account == nil:
+		errs.add(ErrorMissingField, tx.path+"/"+tx.accountNm, "", "account is required")
+	case tx.account.ID.IBAN != "":
+		errs.add(ErrorUnsupportedAccount, tx.path+"/"+tx.accountNm+"/Id/IBAN", tx.account.ID.IBAN, "IBAN accounts cannot be reached through ACH")
+	case tx.account.ID.Other == nil || strings.TrimSpace(tx.account.ID.Other.ID) == "":
+		errs.add(ErrorMissingField, tx.path+"/"+tx.accountNm+"/Id/Othr/Id", "", "account number is required")
+	case len(strings.TrimSpace(tx.account.ID.Other.ID)) > 17:
+		errs.add(ErrorInvalidValue, tx.path+"/"+tx.accountNm+"/Id/Othr/Id", tx.account.ID.Other.ID, "account number is longer than 17 characters")
+	default:
+		entry.DFIAccountNumber = strings.TrimSpace(tx.account.ID.Other.ID)
+	}
+	if tx.account != nil {
+		code, err := painTransactionCode(tx.account, credit)
+		if err != nil {
+			errs.add(ErrorUnsupportedAccount, tx.path+"/"+tx.accountNm+"/Tp", painAccountType(tx.account), "%v", err)
+		}
+		entry.TransactionCode = code
+	}
+
+	// Receiver
+	if tx.party != nil {
+		entry.IndividualName = cut(tx.party.Name, 22)
+		entry.IndividualIDNumber = cut(partyID(tx.party), 15)
+	}
+	if entry.IndividualName == "" {
+		errs.add(ErrorMissingField, tx.path+"/"+tx.partyName+"/Nm", "", "name is required")
+	}
+
+	// A numeric end to end ID of fifteen digits, as written by the pain
+	// exporters, is kept as the trace number
+	if trace := strings.TrimSpace(tx.id.EndToEndID); len(trace) == 15 && models.IsDigits(trace) {
+		entry.TraceNumber = trace
+	} else if len(batchHeader.OriginatingDFI) == 8 {
+		entry.TraceNumber = fmt.Sprintf("%s%07d", batchHeader.OriginatingDFI, index+1)
+	}
+
+	var addenda []models.AddendaRecord
+	if tx.remittance != nil {
+		addenda = paymentAddenda(tx.remittance.Unstructured...)
+	}
+	if len(addenda) > 1 && batchHeader.StandardEntryClass != "CTX" {
+		errs.add(ErrorTooManyAddenda, tx.path+"/RmtInf", "", "%s entries carry one addenda of 80 characters, remittance needs %d", batchHeader.StandardEntryClass, len(addenda))
+	}
+
+	return entry, addenda, len(*errs) == before
+}
+
+// painTransactionCode returns the transaction code of an entry from the cash
+// account type, the way payee spreadsheets map their account type: current
+// accounts, the default, are checking and SVGS accounts are savings
+func painTransactionCode(account *iso20022.Account, credit bool) (string, error) {
+	serviceClass := "225"
+	if credit {
+		serviceClass = "220"
+	}
+
+	accountType := painAccountType(account)
+	switch accountType {
+	case "", iso20022.AccountTypeCurrent:
+		accountType = "checking"
+	case iso20022.AccountTypeSavings:
+		accountType = "savings"
+	}
+	code, err := payeeTransactionCode(accountType, "", serviceClass)
+	if err != nil {
+		return "", fmt.Errorf("account type must be %s or %s: %s", iso20022.AccountTypeCurrent, iso20022.AccountTypeSavings, painAccountType(account))
+	}
+	return code, nil
+}
+
+// painAccountType returns the code or, without one, the proprietary type of
+// an account
+func painAccountType(account *iso20022.Account) string {
+	if account.Type == nil {
+		return ""
+	}
+	if code := strings.TrimSpace(account.Type.Code); code != "" {
+		return strings.ToUpper(code)
+	}
+	return strings.TrimSpace(account.Type.Proprietary)
+}
+
+// painRouting reads the ABA routing number of an agent, reporting agents
+// outside the US clearing system
+func painRouting(agent *iso20022.Agent, path string, errs *errorList) (string, string, bool) {
+	institution := agent.FinancialInstitution
+	member := institution.ClearingSystemMemberID
+
+	if institution.PostalAddress != nil {
+		if country := strings.ToUpper(strings.TrimSpace(institution.PostalAddress.Country)); country != "" && country != "US" {
+			errs.add(ErrorUnsupportedAgent, path+"/FinInstnId/PstlAdr/Ctry", country, "only US financial institutions can be reached through ACH")
+			return "", "", false
+		}
+	}
+	if member == nil {
+		value := institution.BIC
+		errs.add(ErrorUnsupportedAgent, path+"/FinInstnId", value, "agent must be identified by a USABA routing number")
+		return "", "", false
+	}
+
+	memberID := strings.TrimSpace(member.MemberID)
+	if member.ClearingSystemID != nil {
+		if code := strings.TrimSpace(member.ClearingSystemID.Code); code != "" && code != iso20022.ClearingSystemUSABA {
+			errs.add(ErrorUnsupportedAgent, path+"/FinInstnId/ClrSysMmbId/ClrSysId/Cd", code, "only the USABA clearing system is supported")
+			return "", "", false
+		}
+	} else {
+		// The clearing system may be given as a prefix of the member ID
+		memberID = strings.TrimPrefix(memberID, iso20022.ClearingSystemUSABA)
+	}
+
+	routing, check, ok := routingNumber(memberID)
+	if !ok {
+		errs.add(ErrorInvalidRouting, path+"/FinInstnId/ClrSysMmbId/MmbId", member.MemberID, "invalid ABA routing number")
+		return "", "", false
+	}
+	return routing, check, true
+}
+
+// checkControls compares the declared transaction count and control sum with
+// the transactions
+func checkControls(path, count, sum string, actualCount int, actualTotal int64, errs *errorList) {
+	if count != "" && strings.TrimSpace(count) != strconv.Itoa(actualCount) {
+		errs.add(ErrorControlMismatch, path+"/NbOfTxs", count, "message declares %s transactions, found %d", count, actualCount)
+	}
+	if sum == "" {
+		return
+	}
+	if cents, ok := parseCents(sum); !ok || cents != actualTotal {
+		errs.add(ErrorControlMismatch, path+"/CtrlSum", sum, "control sum does not match the transactions total of %d.%02d", actualTotal/100, actualTotal%100)
+	}
+}
+
+// partyID returns the first other identification of a party
+func partyID(party *iso20022.Party) string {
+	if party.ID == nil {
+		return ""
+	}
+	for _, ids := range []*iso20022.GenericIdentifications{party.ID.Organisation, party.ID.Private} {
+		if ids != nil && len(ids.Other) > 0 {
+			return ids.Other[0].ID
+		}
+	}
+	return ""
+}
+
+// parseDateTime parses an ISO date and time, with or without fractional
+// seconds and timezone
+func parseDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date and time: %s", value)
+}
@@ -0,0 +1,269 @@
+package importers
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/internal/exporters"
+	"github.com/nacha-service/pkg/iso20022"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPain001 is a credit transfer of one payment to a checking account
+const testPain001 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03">
+  <CstmrCdtTrfInitn>
+    <GrpHdr>
+      <MsgId>MSG1</MsgId>
+      <CreDtTm>2026-10-17T12:20:00</CreDtTm>
+      <NbOfTxs>1</NbOfTxs>
+      <CtrlSum>9000.00</CtrlSum>
+      <InitgPty><Nm>EMPRESA EXEMPLO</Nm></InitgPty>
+    </GrpHdr>
+    <PmtInf>
+      <PmtInfId>1</PmtInfId>
+      <PmtMtd>TRF</PmtMtd>
+      <ReqdExctnDt>2026-10-19</ReqdExctnDt>
+      <Dbtr><Nm>EMPRESA EXEMPLO</Nm><Id><OrgId><Othr><Id>0764012512</Id></Othr></OrgId></Id></Dbtr>
+      <DbtrAcct><Id><Othr><Id>9876543210</Id></Othr></Id></DbtrAcct>
+      <DbtrAgt><FinInstnId><ClrSysMmbId><MmbId>021000021</MmbId></ClrSysMmbId></FinInstnId></DbtrAgt>
+      <CdtTrfTxInf>
+        <PmtId><EndToEndId>E2E1</EndToEndId></PmtId>
+        <Amt><InstdAmt Ccy="USD">9000.00</InstdAmt></Amt>
+        <CdtrAgt><FinInstnId><ClrSysMmbId><MmbId>021000021</MmbId></ClrSysMmbId></FinInstnId></CdtrAgt>
+        <Cdtr><Nm>PEDRO ALVARES</Nm></Cdtr>
+        <CdtrAcct><Id><Othr><Id>333333</Id></Othr></Id></CdtrAcct>
+      </CdtTrfTxInf>
+    </PmtInf>
+  </CstmrCdtTrfInitn>
+</Document>`
+
+func TestImportPain(t *testing.T) {
+	// Test case 1: A credit transfer becomes a credit entry to checking
+	result, err := ImportPain([]byte(testPain001), PainOptions{})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) {
+		assert.Equal(t, MessagePain001, result.MessageType)
+		batch := result.File.Batches[0]
+		assert.Equal(t, "220", batch.Header.ServiceClassCode)
+		assert.Equal(t, "PPD", batch.Header.StandardEntryClass)
+		assert.Equal(t, DefaultCreditEntryDescription, batch.Header.CompanyEntryDescription)
+		assert.Equal(t, "02100002", batch.Header.OriginatingDFI)
+		assert.Equal(t, "22", batch.Entries[0].TransactionCode)
+		assert.Equal(t, int64(900000), batch.Entries[0].Amount)
+		assert.Equal(t, "021000020000001", batch.Entries[0].TraceNumber)
+		assert.Equal(t, "021000021", result.File.Header.ImmediateDestination)
+	}
+
+	// Test case 2: Malformed and unsupported messages
+	for _, malformed := range []string{
+		"",
+		"not xml",
+		"<Document",
+		`<Root xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"/>`,
+		`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.09"/>`,
+		`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.002.001.03"/>`,
+		strings.Replace(testPain001, "</Document>", "", 1),
+	} {
+		_, err := ImportPain([]byte(malformed), PainOptions{})
+		assert.Error(t, err, malformed)
+	}
+
+	// Test case 3: Messages without payment information or transactions
+	result, err = ImportPain([]byte(`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"><CstmrCdtTrfInitn><GrpHdr><CreDtTm>2026-10-17T12:20:00</CreDtTm></GrpHdr></CstmrCdtTrfInitn></Document>`), PainOptions{})
+	if assert.NoError(t, err) && assert.NotEmpty(t, result.Errors) {
+		assert.Nil(t, result.File)
+		assert.Equal(t, ImportError{Code: ErrorMissingField, Location: "PmtInf", Message: "message has no payment information"}, result.Errors[0])
+	}
+	empty := testPain001[:strings.Index(testPain001, "<CdtTrfTxInf>")] + testPain001[strings.Index(testPain001, "</PmtInf>"):]
+	empty = strings.Replace(empty, "<NbOfTxs>1</NbOfTxs>", "<NbOfTxs>0</NbOfTxs>", 1)
+	empty = strings.Replace(empty, "<CtrlSum>9000.00</CtrlSum>", "", 1)
+	result, err = ImportPain([]byte(empty), PainOptions{})
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Nil(t, result.File)
+		assert.Equal(t, ErrorInvalidFile, result.Errors[0].Code)
+		assert.Contains(t, result.Errors[0].Message, "at least one entry")
+	}
+
+	// Test case 4: Declared controls that do not match the transactions
+	result, err = ImportPain([]byte(strings.Replace(testPain001, "<CtrlSum>9000.00</CtrlSum>", "<CtrlSum>9000.01</CtrlSum>", 1)), PainOptions{})
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, ErrorControlMismatch, result.Errors[0].Code)
+		assert.Equal(t, "GrpHdr/CtrlSum", result.Errors[0].Location)
+	}
+
+	// Test case 5: Values that cannot be sent through ACH
+	for _, tc := range []struct {
+		from, to, code, location string
+	}{
+		{`Ccy="USD"`, `Ccy="EUR"`, ErrorUnsupportedCurrency, "PmtInf[1]/CdtTrfTxInf[1]/Amt/InstdAmt/@Ccy"},
+		{">9000.00</InstdAmt>", ">9000.001</InstdAmt>", ErrorInvalidAmount, "PmtInf[1]/CdtTrfTxInf[1]/Amt/InstdAmt"},
+		{"<Id><Othr><Id>333333</Id></Othr></Id>", "<Id><IBAN>DE89370400440532013000</IBAN></Id>", ErrorUnsupportedAccount, "PmtInf[1]/CdtTrfTxInf[1]/CdtrAcct/Id/IBAN"},
+		{"<Id>333333</Id></Othr></Id>", "<Id>333333</Id></Othr></Id><Tp><Cd>LOAN</Cd></Tp>", ErrorUnsupportedAccount, "PmtInf[1]/CdtTrfTxInf[1]/CdtrAcct/Tp"},
+		{"<CdtrAgt><FinInstnId><ClrSysMmbId><MmbId>021000021", "<CdtrAgt><FinInstnId><ClrSysMmbId><MmbId>021000022", ErrorInvalidRouting, "PmtInf[1]/CdtTrfTxInf[1]/CdtrAgt/FinInstnId/ClrSysMmbId/MmbId"},
+		{"<ReqdExctnDt>2026-10-19", "<ReqdExctnDt>19/10/2026", ErrorInvalidValue, "PmtInf[1]/ReqdExctnDt"},
+	} {
+		result, err := ImportPain([]byte(strings.Replace(testPain001, tc.from, tc.to, 1)), PainOptions{})
+		if assert.NoError(t, err) && assert.NotEmpty(t, result.Errors, tc.to) {
+			assert.Equal(t, tc.code, result.Errors[0].Code, tc.to)
+			assert.Equal(t, tc.location, result.Errors[0].Location, tc.to)
+		}
+	}
+
+	// Test case 6: Remittance in a block whose agent has no valid routing
+	// number is reported, not added without a trace number
+	invalid := strings.Replace(testPain001, "<DbtrAgt><FinInstnId><ClrSysMmbId><MmbId>021000021", "<DbtrAgt><FinInstnId><ClrSysMmbId><MmbId>123", 1)
+	invalid = strings.Replace(invalid, "</CdtrAcct>", "</CdtrAcct><RmtInf><Ustrd>INV-1001</Ustrd></RmtInf>", 1)
+	result, err = ImportPain([]byte(invalid), PainOptions{})
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 1) {
+		assert.Nil(t, result.File)
+		assert.Equal(t, ImportError{Code: ErrorInvalidRouting, Location: "PmtInf[1]/DbtrAgt/FinInstnId/ClrSysMmbId/MmbId", Message: "invalid ABA routing number", Value: "123"}, result.Errors[0])
+	}
+}
+
+func TestPainTransactionCode(t *testing.T) {
+	account := func(code, proprietary string) *iso20022.Account {
+		if code == "" && proprietary == "" {
+			return &iso20022.Account{}
+		}
+		return &iso20022.Account{Type: &iso20022.Code{Code: code, Proprietary: proprietary}}
+	}
+
+	// Test case 1: Current accounts, the default, and savings accounts, also
+	// given by their proprietary payee spreadsheet names
+	for _, tc := range []struct {
+		account *iso20022.Account
+		credit  bool
+		code    string
+	}{
+		{account("", ""), true, "22"},
+		{account("", ""), false, "27"},
+		{account(iso20022.AccountTypeCurrent, ""), true, "22"},
+		{account("cacc", ""), false, "27"},
+		{account(iso20022.AccountTypeSavings, ""), true, "32"},
+		{account(iso20022.AccountTypeSavings, ""), false, "37"},
+		{account("", "Savings"), true, "32"},
+	} {
+		code, err := painTransactionCode(tc.account, tc.credit)
+		assert.NoError(t, err)
+		assert.Equal(t, tc.code, code)
+	}
+
+	// Test case 2: Other account types
+	for _, a := range []*iso20022.Account{account("LOAN", ""), account("TRAN", "CHECKING"), account("", "prenote")} {
+		_, err := painTransactionCode(a, true)
+		assert.Error(t, err)
+	}
+}
+
+// testPainOptions holds the accounts of the companies of testFile
+var testPainOptions = exporters.PainOptions{
+	CompanyAccount:  "9876543210",
+	CompanyAccounts: map[string]string{"1234567890": "5555500001"},
+}
+
+func TestImportPainRoundTrip(t *testing.T) {
+	source := testFile(t)
+
+	// Test case 1: A pain.001 export imports back as credit batches
+	data := exportFile(t, source, "PAIN001", exporters.Options{Pain: testPainOptions})
+	result, err := ImportPain(data, PainOptions{})
+	if !assert.NoError(t, err) || !assert.Empty(t, result.Errors) {
+		return
+	}
+	assert.Equal(t, MessagePain001, result.MessageType)
+	file := result.File
+	assert.Equal(t, "076401251", file.Header.ImmediateDestination)
+	assert.Equal(t, "1200", file.Header.FileCreationTime)
+	if assert.Len(t, file.Batches, 2) {
+		batch := file.Batches[1]
+		assert.Equal(t, "220", batch.Header.ServiceClassCode)
+		assert.Equal(t, "CCD", batch.Header.StandardEntryClass)
+		assert.Equal(t, "OUTRA EMPRESA", batch.Header.CompanyName)
+		assert.Equal(t, "1234567890", batch.Header.CompanyIdentification)
+		assert.Equal(t, "261020", batch.Header.EffectiveEntryDate)
+		assert.Equal(t, "07640125", batch.Header.OriginatingDFI)
+		if assert.Len(t, batch.Entries, 1) {
+			entry := batch.Entries[0]
+			assert.Equal(t, "32", entry.TransactionCode)
+			assert.Equal(t, int64(510000), entry.Amount)
+			assert.Equal(t, "02100002", entry.ReceivingDFI)
+			assert.Equal(t, "1", entry.CheckDigit)
+			assert.Equal(t, "444444", entry.DFIAccountNumber)
+			assert.Equal(t, "076401250000004", entry.TraceNumber)
+			if assert.Len(t, entry.AddendaRecords, 1) {
+				assert.Equal(t, "INV-1001 INV-1002", entry.AddendaRecords[0].PaymentRelatedInformation)
+			}
+		}
+		assert.Equal(t, int64(510000), batch.Control.TotalCreditAmount)
+
+		// A credit to a checking account keeps its code
+		if assert.Len(t, file.Batches[0].Entries, 1) {
+			assert.Equal(t, "22", file.Batches[0].Entries[0].TransactionCode)
+			assert.Equal(t, int64(900000), file.Batches[0].Control.TotalCreditAmount)
+		}
+	}
+
+	// Test case 2: A pain.008 export imports back as a debit batch
+	debits := string(exportFile(t, source, "PAIN008", exporters.Options{Pain: testPainOptions}))
+	result, err = ImportPain([]byte(debits), PainOptions{ImmediateDestination: "021000021"})
+	if !assert.NoError(t, err) || !assert.Empty(t, result.Errors) {
+		return
+	}
+	assert.Equal(t, MessagePain008, result.MessageType)
+	file = result.File
+	assert.Equal(t, "021000021", file.Header.ImmediateDestination)
+	if assert.Len(t, file.Batches, 1) {
+		batch := file.Batches[0]
+		assert.Equal(t, "225", batch.Header.ServiceClassCode)
+		assert.Equal(t, "PPD", batch.Header.StandardEntryClass)
+		assert.Equal(t, DefaultDebitEntryDescription, batch.Header.CompanyEntryDescription)
+		if assert.Len(t, batch.Entries, 2) {
+			assert.Equal(t, "27", batch.Entries[0].TransactionCode)
+			assert.Equal(t, "JOAO DA SILVA", batch.Entries[0].IndividualName)
+			assert.Equal(t, int64(120000), batch.Entries[1].Amount)
+		}
+		assert.Equal(t, int64(870000), batch.Control.TotalDebitAmount)
+	}
+
+	// Test case 3: Unmappable values are reported instead of dropped
+	foreign := strings.Replace(debits, `Ccy="USD"`, `Ccy="EUR"`, 1)
+	agent := regexp.MustCompile(`(?s)<DbtrAgt>.*?</DbtrAgt>`)
+	// Every debtor agent becomes a BIC
+	foreign = agent.ReplaceAllLiteralString(foreign, "<DbtrAgt><FinInstnId><BIC>DEUTDEFF</BIC></FinInstnId></DbtrAgt>")
+	result, err = ImportPain([]byte(foreign), PainOptions{})
+	if assert.NoError(t, err) {
+		assert.Nil(t, result.File)
+		var found []string
+		for _, e := range result.Errors {
+			found = append(found, e.Code+" "+e.Location)
+		}
+		assert.Contains(t, found, ErrorUnsupportedCurrency+" PmtInf[1]/DrctDbtTxInf[1]/InstdAmt/@Ccy")
+		assert.Contains(t, found, ErrorUnsupportedAgent+" PmtInf[1]/DrctDbtTxInf[2]/DbtrAgt/FinInstnId")
+	}
+
+	// Test case 4: The account type selects checking or savings codes
+	accountType := regexp.MustCompile(`<Tp>\s*<Cd>CACC</Cd>\s*</Tp>`)
+	savings := accountType.ReplaceAllLiteralString(debits, "<Tp><Cd>SVGS</Cd></Tp>")
+	result, err = ImportPain([]byte(savings), PainOptions{})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) && assert.Len(t, result.File.Batches, 1) {
+		for _, entry := range result.File.Batches[0].Entries {
+			assert.Equal(t, "37", entry.TransactionCode)
+		}
+		assert.Equal(t, int64(870000), result.File.Batches[0].Control.TotalDebitAmount)
+		assert.Zero(t, result.File.Batches[0].Control.TotalCreditAmount)
+	}
+	untyped := accountType.ReplaceAllLiteralString(debits, "")
+	result, err = ImportPain([]byte(untyped), PainOptions{})
+	if assert.NoError(t, err) && assert.Empty(t, result.Errors) && assert.Len(t, result.File.Batches, 1) {
+		assert.Equal(t, "27", result.File.Batches[0].Entries[0].TransactionCode)
+	}
+	loan := accountType.ReplaceAllLiteralString(debits, "<Tp><Cd>LOAN</Cd></Tp>")
+	result, err = ImportPain([]byte(loan), PainOptions{})
+	if assert.NoError(t, err) && assert.NotEmpty(t, result.Errors) {
+		assert.Nil(t, result.File)
+		assert.Equal(t, ErrorUnsupportedAccount, result.Errors[0].Code)
+		assert.Equal(t, "PmtInf[1]/DrctDbtTxInf[1]/DbtrAcct/Tp", result.Errors[0].Location)
+		assert.Equal(t, "LOAN", result.Errors[0].Value)
+	}
+}
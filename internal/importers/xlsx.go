@@ -0,0 +1,278 @@
+package importers
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/xuri/excelize/v2"
+)
+
+// Sheets of the workbooks written by the XLSX exporter
+const (
+	xlsxFileHeaderSheet = "File Header"
+	xlsxBatchesSheet    = "Batches"
+	xlsxEntriesSheet    = "Entries"
+	xlsxAddendaSheet    = "Addenda"
+)
+
+// XLSXResult is the outcome of an XLSX import. File is nil when there are errors.
+type XLSXResult struct {
+	File   *models.NachaFile
+	Errors []ImportError
+}
+
+// xlsxSheet holds the rows of a sheet below its header row, indexed by label
+type xlsxSheet struct {
+	name    string
+	columns map[string]int
+	labels  []string
+	rows    [][]string
+}
+
+// readXLSXSheet returns the rows of a sheet below its header row, without
+// the totals rows
+func readXLSXSheet(workbook *excelize.File, name string) (*xlsxSheet, error) {
+	rows, err := workbook.GetRows(name, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s sheet: %v", name, err)
+	}
+	sheet := &xlsxSheet{name: name, columns: make(map[string]int)}
+	if len(rows) == 0 {
+		return sheet, nil
+	}
+	sheet.labels = rows[0]
+	for i, label := range rows[0] {
+		sheet.columns[strings.TrimSpace(label)] = i
+	}
+	for _, row := range rows[1:] {
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+		// Totals rows hold formulas over the rows above, and their label
+		// takes the first column
+		if len(row) > 0 && strings.HasPrefix(row[0], "Total") {
+			continue
+		}
+		sheet.rows = append(sheet.rows, row)
+	}
+	return sheet, nil
+}
+
+// value returns the trimmed cell of a row under a label
+func (s *xlsxSheet) value(row []string, label string) string {
+	i, ok := s.columns[label]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+// location returns the cell of a row index and label, such as Entries!F3.
+// An empty label gives the row.
+func (s *xlsxSheet) location(index int, label string) string {
+	i, ok := s.columns[label]
+	if !ok {
+		return fmt.Sprintf("%s!%d", s.name, index+2)
+	}
+	cell, _ := excelize.CoordinatesToCellName(i+1, index+2)
+	return s.name + "!" + cell
+}
+
+// each calls set with every labelled cell of a row
+func (s *xlsxSheet) each(row []string, set func(label, value string)) {
+	for i, label := range s.labels {
+		if i < len(row) {
+			set(strings.TrimSpace(label), strings.TrimSpace(row[i]))
+		}
+	}
+}
+
+// ImportXLSX builds a NACHA file from a workbook in the layout of the XLSX
+// exporter. Columns are matched by their header labels, so exports with
+// selected entry fields are read as well, and the totals rows are skipped.
+// The declared controls are checked against the records. Values that cannot
+// be mapped are returned as import errors; a workbook without the sheets of
+// the layout is returned as an error.
+func ImportXLSX(data []byte) (*XLSXResult, error) {
+	workbook, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open workbook: %v", err)
+	}
+	defer workbook.Close()
+
+	sheets := make(map[string]*xlsxSheet)
+	for _, name := range []string{xlsxFileHeaderSheet, xlsxBatchesSheet, xlsxEntriesSheet, xlsxAddendaSheet} {
+		if index, _ := workbook.GetSheetIndex(name); index < 0 {
+			if name == xlsxAddendaSheet {
+				sheets[name] = &xlsxSheet{name: name, columns: make(map[string]int)}
+				continue
+			}
+			return nil, fmt.Errorf("workbook has no %s sheet", name)
+		}
+		if sheets[name], err = readXLSXSheet(workbook, name); err != nil {
+			return nil, err
+		}
+	}
+
+	var errs errorList
+	file := xlsxFile(sheets, &errs)
+	if len(errs) == 0 {
+		checkDeclaredControls(file, func(batch int, label string) (string, bool) {
+			if batch < 0 {
+				return sheets[xlsxFileHeaderSheet].location(0, label), true
+			}
+			return sheets[xlsxBatchesSheet].location(batch, label), true
+		}, &errs)
+	}
+	if len(errs) == 0 {
+		if err := file.Validate(); err != nil {
+			errs.add(ErrorInvalidFile, "", "", "%v", err)
+		}
+	}
+	if len(errs) > 0 {
+		return &XLSXResult{Errors: errs}, nil
+	}
+	return &XLSXResult{File: file}, nil
+}
+
+// xlsxFile reads the records of the sheets. Entries and addenda are placed
+// in their batch by batch number, and addenda on their entry by trace number.
+func xlsxFile(sheets map[string]*xlsxSheet, errs *errorList) *models.NachaFile {
+	file := &models.NachaFile{}
+
+	header := sheets[xlsxFileHeaderSheet]
+	if len(header.rows) != 1 {
+		errs.add(ErrorInvalidFile, header.location(0, ""), "", "%s sheet must have one row below its header row, found %d", header.name, len(header.rows))
+		return file
+	}
+	row := header.rows[0]
+	file.Header.RecordType = "1"
+	file.Control = models.FileControl{
+		RecordType:        "9",
+		BatchCount:        xlsxInt(header, 0, "Batch Count", errs),
+		BlockCount:        xlsxInt(header, 0, "Block Count", errs),
+		EntryAddendaCount: xlsxInt(header, 0, "Entry Addenda Count", errs),
+		EntryHash:         header.value(row, "Entry Hash"),
+		TotalDebitAmount:  xlsxAmount(header, 0, "Total Debit Amount", errs),
+		TotalCreditAmount: xlsxAmount(header, 0, "Total Credit Amount", errs),
+	}
+	header.each(row, func(label, value string) {
+		setFileHeaderField(&file.Header, label, value, header.location(0, label), errs)
+	})
+
+	batches := sheets[xlsxBatchesSheet]
+	byNumber := make(map[string]int)
+	for i, row := range batches.rows {
+		batch := models.Batch{Header: models.BatchHeader{RecordType: "5"}}
+		batches.each(row, func(label, value string) {
+			setBatchHeaderField(&batch.Header, label, value)
+		})
+		batch.Control = models.BatchControl{
+			RecordType:            "8",
+			ServiceClassCode:      batch.Header.ServiceClassCode,
+			EntryAddendaCount:     xlsxInt(batches, i, "Entry Addenda Count", errs),
+			EntryHash:             batches.value(row, "Entry Hash"),
+			TotalDebitAmount:      xlsxAmount(batches, i, "Total Debit Amount", errs),
+			TotalCreditAmount:     xlsxAmount(batches, i, "Total Credit Amount", errs),
+			CompanyIdentification: batch.Header.CompanyIdentification,
+			OriginatingDFI:        batch.Header.OriginatingDFI,
+			BatchNumber:           batch.Header.BatchNumber,
+		}
+
+		number := batchKey(batch.Header.BatchNumber)
+		if _, ok := byNumber[number]; ok || number == "" {
+			errs.add(ErrorInvalidValue, batches.location(i, "Batch Number"), batch.Header.BatchNumber, "batch number must be present and unique")
+		}
+		byNumber[number] = len(file.Batches)
+		file.Batches = append(file.Batches, batch)
+	}
+
+	entries := sheets[xlsxEntriesSheet]
+	for i, row := range entries.rows {
+		number := entries.value(row, "Batch Number")
+		index, ok := byNumber[batchKey(number)]
+		if !ok {
+			errs.add(ErrorInvalidValue, entries.location(i, "Batch Number"), number, "no batch has this number")
+			continue
+		}
+		entry := models.EntryDetail{RecordType: "6"}
+		entries.each(row, func(label, value string) {
+			if label == "Amount" {
+				entry.Amount = xlsxAmount(entries, i, label, errs)
+				return
+			}
+			setEntryField(&entry, label, value, entries.location(i, label), errs)
+		})
+		file.Batches[index].Entries = append(file.Batches[index].Entries, entry)
+	}
+
+	addenda := sheets[xlsxAddendaSheet]
+	for i, row := range addenda.rows {
+		number, trace := addenda.value(row, "Batch Number"), addenda.value(row, "Trace Number")
+		entry := xlsxEntry(file, byNumber, number, trace)
+		if entry == nil {
+			errs.add(ErrorInvalidValue, addenda.location(i, "Trace Number"), trace, "batch %s has no entry with this trace number", number)
+			continue
+		}
+		entry.AddendaRecords = append(entry.AddendaRecords, models.AddendaRecord{
+			AddendaTypeCode:           addenda.value(row, "Addenda Type Code"),
+			PaymentRelatedInformation: addenda.value(row, "Payment Related Information"),
+			AddendaSequenceNumber:     addenda.value(row, "Addenda Sequence Number"),
+			EntryDetailSequenceNumber: addenda.value(row, "Entry Detail Sequence Number"),
+		})
+	}
+	return file
+}
+
+// xlsxEntry finds the entry of a batch with a trace number
+func xlsxEntry(file *models.NachaFile, byNumber map[string]int, number, trace string) *models.EntryDetail {
+	index, ok := byNumber[batchKey(number)]
+	if !ok {
+		return nil
+	}
+	entries := file.Batches[index].Entries
+	for i := range entries {
+		if entries[i].TraceNumber == trace {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// batchKey returns a batch number without leading zeros
+func batchKey(number string) string {
+	number = strings.TrimSpace(number)
+	if trimmed := strings.TrimLeft(number, "0"); trimmed != "" {
+		return trimmed
+	}
+	return number
+}
+
+// xlsxAmount reads an amount in dollars, from a numeric cell or from text
+// such as 1,234.56
+func xlsxAmount(sheet *xlsxSheet, index int, label string, errs *errorList) int64 {
+	value := sheet.value(sheet.rows[index], label)
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		cents := math.Round(n * 100)
+		if math.Abs(n*100-cents) < 1e-6 && cents >= 0 && cents <= 9999999999 {
+			return int64(cents)
+		}
+	} else if cents, ok := parseCents(strings.ReplaceAll(strings.TrimPrefix(value, "$"), ",", "")); ok {
+		return cents
+	}
+	errs.add(ErrorInvalidAmount, sheet.location(index, label), value, "amount must be dollars with at most two decimal places")
+	return 0
+}
+
+func xlsxInt(sheet *xlsxSheet, index int, label string, errs *errorList) int {
+	value := sheet.value(sheet.rows[index], label)
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		errs.add(ErrorInvalidValue, sheet.location(index, label), value, "must be a number")
+	}
+	return n
+}
@@ -0,0 +1,66 @@
+package importers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nacha-service/internal/exporters"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestImportXLSX(t *testing.T) {
+	source := testFile(t)
+	data := exportFile(t, source, "XLSX", exporters.Options{})
+
+	// Test case 1: The workbook imports back to the same file
+	result, err := ImportXLSX(data)
+	if !assert.NoError(t, err) || !assert.Empty(t, result.Errors) {
+		return
+	}
+	assert.Equal(t, string(testContent(t)), string(result.File.ToBytes()))
+
+	// Test case 2: A total that disagrees with the entries is reported on its cell
+	workbook, err := excelize.OpenReader(bytes.NewReader(data))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer workbook.Close()
+	assert.NoError(t, workbook.SetCellFloat("Batches", "P3", 5100.01, 2, 64))
+	var tampered bytes.Buffer
+	_, err = workbook.WriteTo(&tampered)
+	assert.NoError(t, err)
+	result, err = ImportXLSX(tampered.Bytes())
+	if assert.NoError(t, err) {
+		assert.Nil(t, result.File)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, ErrorControlMismatch, result.Errors[0].Code)
+			assert.Equal(t, "Batches!P3", result.Errors[0].Location)
+		}
+	}
+
+	// Test case 3: Values that cannot be mapped are located by cell
+	assert.NoError(t, workbook.SetCellFloat("Batches", "P3", 5100, 2, 64))
+	assert.NoError(t, workbook.SetCellStr("Entries", "F3", "12.345"))
+	assert.NoError(t, workbook.SetCellStr("Addenda", "B2", "076401250000009"))
+	tampered.Reset()
+	_, err = workbook.WriteTo(&tampered)
+	assert.NoError(t, err)
+	result, err = ImportXLSX(tampered.Bytes())
+	if assert.NoError(t, err) && assert.Len(t, result.Errors, 2) {
+		assert.Equal(t, ErrorInvalidAmount, result.Errors[0].Code)
+		assert.Equal(t, "Entries!F3", result.Errors[0].Location)
+		assert.Equal(t, "Addenda!B2", result.Errors[1].Location)
+	}
+
+	// Test case 4: Content that is not a workbook of this layout
+	_, err = ImportXLSX(source.ToBytes())
+	assert.Error(t, err)
+	empty := excelize.NewFile()
+	defer empty.Close()
+	var other bytes.Buffer
+	_, err = empty.WriteTo(&other)
+	assert.NoError(t, err)
+	_, err = ImportXLSX(other.Bytes())
+	assert.Error(t, err)
+}
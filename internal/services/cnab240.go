@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/importers"
+	"github.com/nacha-service/pkg/cnab240"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportFromCNAB240 builds a NACHA file from a FEBRABAN CNAB 240 payment
+// remittance. Structural problems and values that cannot be mapped are
+// reported in the response errors, and no file is returned.
+func (s *NachaService) ImportFromCNAB240(ctx context.Context, req *pb.CnabImportRequest) (*pb.CnabImportResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.CnabContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "CNAB 240 content cannot be empty")
+	}
+
+	result, err := importers.ImportCNAB240(req.CnabContent, importers.CNAB240Options{
+		CompanyIdentification: req.CompanyIdentification,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to import CNAB 240 file: %v", err)
+	}
+
+	resp := &pb.CnabImportResponse{Errors: convertImportErrors(result.Errors)}
+	if len(result.Errors) > 0 {
+		resp.Message = fmt.Sprintf("CNAB 240 file has %d problems that prevent the conversion to NACHA", len(result.Errors))
+		return resp, nil
+	}
+
+	resp.FileContent = result.File.ToBytes()
+	resp.Message = "CNAB 240 file successfully converted to NACHA format"
+	return resp, nil
+}
+
+// ViewCNAB240 returns the records of a CNAB 240 file split into their fields,
+// along with the issues of the structural validation
+func (s *NachaService) ViewCNAB240(ctx context.Context, req *pb.CnabRequest) (*pb.CnabViewResponse, error) {
+	file, issues, err := parseCNAB240(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.CnabViewResponse{
+		Header:  convertCNABRecord(file.Header),
+		Trailer: convertCNABRecord(file.Trailer),
+		IsValid: len(issues) == 0,
+		Errors:  convertCNABIssues(issues),
+	}
+	for _, lote := range file.Lotes {
+		out := &pb.CnabLote{
+			Header:  convertCNABRecord(lote.Header),
+			Trailer: convertCNABRecord(lote.Trailer),
+		}
+		for _, detail := range lote.Details {
+			out.Details = append(out.Details, convertCNABRecord(detail))
+		}
+		resp.Lotes = append(resp.Lotes, out)
+	}
+	return resp, nil
+}
+
+// ValidateCNAB240 checks the structure of a CNAB 240 file: record lengths and
+// order, field contents, numbering, and the counts and totals of the trailers
+func (s *NachaService) ValidateCNAB240(ctx context.Context, req *pb.CnabRequest) (*pb.ValidationResponse, error) {
+	_, issues, err := parseCNAB240(req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ValidationResponse{
+		IsValid: len(issues) == 0,
+		Errors:  convertCNABIssues(issues),
+	}, nil
+}
+
+// parseCNAB240 parses the content of a request and checks the parsed file
+func parseCNAB240(req *pb.CnabRequest) (*cnab240.File, []cnab240.Issue, error) {
+	if req == nil {
+		return nil, nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.CnabContent) == 0 {
+		return nil, nil, status.Error(codes.InvalidArgument, "CNAB 240 content cannot be empty")
+	}
+
+	file, issues, err := cnab240.Parse(req.CnabContent)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "failed to parse CNAB 240 file: %v", err)
+	}
+	return file, append(issues, file.Check()...), nil
+}
+
+func convertCNABRecord(r *cnab240.Record) *pb.CnabRecord {
+	if r == nil {
+		return nil
+	}
+	out := &pb.CnabRecord{
+		Line:       int32(r.Line),
+		RecordType: r.Get("tipo_registro"),
+		Segment:    r.Get("segmento"),
+		Layout:     r.Layout.Name,
+	}
+	for _, v := range r.Values() {
+		out.Fields = append(out.Fields, &pb.CnabField{
+			Name:  v.Name,
+			Start: int32(v.Start),
+			End:   int32(v.End),
+			Value: v.Value,
+		})
+	}
+	return out
+}
+
+func convertCNABIssues(issues []cnab240.Issue) []*pb.ValidationError {
+	out := make([]*pb.ValidationError, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, &pb.ValidationError{
+			ErrorCode: issue.Code,
+			Message:   issue.Message,
+			Location:  issue.Location(),
+		})
+	}
+	return out
+}
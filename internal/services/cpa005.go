@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/importers"
+	"github.com/nacha-service/pkg/cpa005"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportFromCPA005 builds a NACHA file from a CPA Standard 005 file.
+// Structural problems and values that cannot be mapped are reported in the
+// response errors, and no file is returned.
+func (s *NachaService) ImportFromCPA005(ctx context.Context, req *pb.CpaImportRequest) (*pb.CpaImportResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.CpaContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "CPA 005 content cannot be empty")
+	}
+
+	result, err := importers.ImportCPA005(req.CpaContent, importers.CPA005Options{
+		ImmediateDestination:     req.ImmediateDestination,
+		ImmediateDestinationName: req.ImmediateDestinationName,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to import CPA 005 file: %v", err)
+	}
+
+	resp := &pb.CpaImportResponse{Errors: convertImportErrors(result.Errors)}
+	if len(result.Errors) > 0 {
+		resp.Message = fmt.Sprintf("CPA 005 file has %d problems that prevent the conversion to NACHA", len(result.Errors))
+		return resp, nil
+	}
+
+	resp.FileContent = result.File.ToBytes()
+	resp.Message = "CPA 005 file successfully converted to NACHA format"
+	return resp, nil
+}
+
+// ValidateCPA005 checks the structure of a CPA 005 file: record lengths and
+// order, field contents, record numbering, transaction segments and the
+// totals of the trailer
+func (s *NachaService) ValidateCPA005(ctx context.Context, req *pb.CpaRequest) (*pb.ValidationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.CpaContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "CPA 005 content cannot be empty")
+	}
+
+	issues, err := cpa005.Validate(req.CpaContent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse CPA 005 file: %v", err)
+	}
+
+	resp := &pb.ValidationResponse{
+		IsValid: len(issues) == 0,
+		Errors:  make([]*pb.ValidationError, 0, len(issues)),
+	}
+	for _, issue := range issues {
+		resp.Errors = append(resp.Errors, &pb.ValidationError{
+			ErrorCode: issue.Code,
+			Message:   issue.Message,
+			Location:  issue.Location(),
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/importers"
+	"github.com/nacha-service/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ImportFromPain builds a NACHA file from an ISO 20022 pain.001 or pain.008
+// message. Values that cannot be mapped are reported in the response errors
+// instead of being dropped, and no file is returned.
+func (s *NachaService) ImportFromPain(ctx context.Context, req *pb.PainImportRequest) (*pb.PainImportResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.XmlContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "XML content cannot be empty")
+	}
+
+	result, err := importers.ImportPain(req.XmlContent, importers.PainOptions{
+		ImmediateDestination:     req.ImmediateDestination,
+		ImmediateDestinationName: req.ImmediateDestinationName,
+		ImmediateOrigin:          req.ImmediateOrigin,
+		FileIDModifier:           req.FileIdModifier,
+		CompanyEntryDescription:  req.CompanyEntryDescription,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to import pain message: %v", err)
+	}
+
+	resp := &pb.PainImportResponse{
+		MessageType: result.MessageType,
+		Errors:      convertImportErrors(result.Errors),
+	}
+	if len(result.Errors) > 0 {
+		resp.Message = fmt.Sprintf("%s message has %d values that cannot be mapped to NACHA", result.MessageType, len(result.Errors))
+		return resp, nil
+	}
+
+	resp.FileContent = result.File.ToBytes()
+	resp.Message = fmt.Sprintf("%s message successfully converted to NACHA format", result.MessageType)
+	return resp, nil
+}
+
+// ImportFromCSV builds a NACHA file from a CSV written by ExportFile or from a
+// spreadsheet with a row per payee. Rows that cannot be mapped are reported
+// in the response errors, and no file is returned.
+func (s *NachaService) ImportFromCSV(ctx context.Context, req *pb.CsvImportRequest) (*pb.CsvImportResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.CsvContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "CSV content cannot be empty")
+	}
+
+	opts := importers.CSVOptions{
+		AmountFormat: importers.CSVAmountFormat(req.AmountFormat),
+	}
+	switch req.AmountFormat {
+	case pb.CsvAmountFormat_CSV_AMOUNT_DECIMAL, pb.CsvAmountFormat_CSV_AMOUNT_CENTS:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount format: %v", req.AmountFormat)
+	}
+
+	if h := req.FileHeader; h != nil {
+		opts.FileHeader = models.FileHeader{
+			PriorityCode:         h.PriorityCode,
+			ImmediateDestination: h.ImmediateDestination,
+			ImmediateOrigin:      h.ImmediateOrigin,
+			FileCreationTime:     h.FileCreationTime,
+			FileIDModifier:       h.FileIdModifier,
+			RecordSize:           h.RecordSize,
+			BlockingFactor:       h.BlockingFactor,
+			FormatCode:           h.FormatCode,
+			DestinationName:      h.ImmediateDestinationName,
+			OriginName:           h.ImmediateOriginName,
+			ReferenceCode:        h.ReferenceCode,
+		}
+		if h.FileCreationDate != "" {
+			date, err := time.Parse("060102", h.FileCreationDate)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid file creation date: %v", err)
+			}
+			opts.FileHeader.FileCreationDate = date
+		}
+	}
+	if h := req.BatchHeader; h != nil {
+		opts.BatchHeader = models.BatchHeader{
+			ServiceClassCode:         h.ServiceClassCode,
+			CompanyName:              h.CompanyName,
+			CompanyDiscretionaryData: h.CompanyDiscretionaryData,
+			CompanyIdentification:    h.CompanyIdentification,
+			StandardEntryClass:       h.StandardEntryClass,
+			CompanyEntryDescription:  h.CompanyEntryDescription,
+			CompanyDescriptiveDate:   h.CompanyDescriptiveDate,
+			EffectiveEntryDate:       h.EffectiveEntryDate,
+			SettlementDate:           h.SettlementDate,
+			OriginatorStatusCode:     h.OriginatorStatusCode,
+			OriginatingDFI:           h.OriginatingDfiIdentification,
+		}
+	}
+	if c := req.Columns; c != nil {
+		opts.Columns = importers.CSVColumns{
+			Name:            c.Name,
+			Routing:         c.Routing,
+			Account:         c.Account,
+			AccountType:     c.AccountType,
+			Amount:          c.Amount,
+			Addenda:         c.Addenda,
+			IDNumber:        c.IdNumber,
+			TransactionCode: c.TransactionCode,
+		}
+	}
+
+	result, err := importers.ImportCSV(req.CsvContent, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to import CSV: %v", err)
+	}
+
+	resp := &pb.CsvImportResponse{
+		Layout: result.Layout,
+		Errors: convertImportErrors(result.Errors),
+	}
+	if len(result.Errors) > 0 {
+		resp.Message = fmt.Sprintf("CSV has %d values that cannot be mapped to NACHA", len(result.Errors))
+		return resp, nil
+	}
+
+	resp.FileContent = result.File.ToBytes()
+	resp.Message = "CSV successfully converted to NACHA format"
+	return resp, nil
+}
+
+// ImportFromXLSX builds a NACHA file from a workbook written by the XLSX
+// exporter. Values that cannot be mapped and controls that do not match the
+// records are reported in the response errors, and no file is returned.
+func (s *NachaService) ImportFromXLSX(ctx context.Context, req *pb.XlsxImportRequest) (*pb.XlsxImportResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.XlsxContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "XLSX content cannot be empty")
+	}
+
+	result, err := importers.ImportXLSX(req.XlsxContent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to import XLSX: %v", err)
+	}
+
+	resp := &pb.XlsxImportResponse{Errors: convertImportErrors(result.Errors)}
+	if len(result.Errors) > 0 {
+		resp.Message = fmt.Sprintf("XLSX has %d values that cannot be mapped to NACHA", len(result.Errors))
+		return resp, nil
+	}
+
+	resp.FileContent = result.File.ToBytes()
+	resp.Message = "XLSX successfully converted to NACHA format"
+	return resp, nil
+}
+
+func convertImportErrors(errs []importers.ImportError) []*pb.ImportError {
+	if len(errs) == 0 {
+		return nil
+	}
+	out := make([]*pb.ImportError, len(errs))
+	for i, e := range errs {
+		out[i] = &pb.ImportError{
+			ErrorCode: e.Code,
+			Message:   e.Message,
+			Location:  e.Location,
+			Value:     e.Value,
+		}
+	}
+	return out
+}
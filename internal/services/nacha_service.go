@@ -3,17 +3,20 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 	"strings"
 	"time"
 
 	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/cache"
 	"github.com/nacha-service/internal/creator"
 	"github.com/nacha-service/internal/exporters"
 	"github.com/nacha-service/internal/validator"
 	"github.com/nacha-service/pkg/models"
+	"github.com/nacha-service/pkg/moovjson"
+	"github.com/nacha-service/pkg/nachajson"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -23,13 +26,21 @@ type NachaService struct {
 	pb.UnimplementedNachaServiceServer
 	validator *validator.Validator
 	creator   *creator.Creator
+	cache     *cache.FileCache
 }
 
 // NewNachaService creates a new NACHA service instance
 func NewNachaService() *NachaService {
+	return NewNachaServiceWithCache(cache.DefaultConfig())
+}
+
+// NewNachaServiceWithCache creates a new NACHA service instance with the given
+// limits for uploaded files
+func NewNachaServiceWithCache(config cache.Config) *NachaService {
 	return &NachaService{
 		validator: validator.NewValidator(),
 		creator:   creator.NewCreator(),
+		cache:     cache.NewFileCache(config),
 	}
 }
 
@@ -38,24 +49,10 @@ func (s *NachaService) ValidateFile(ctx context.Context, req *pb.FileRequest) (*
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
-	if req.FileContent == nil && req.FilePath == "" {
-		return nil, status.Error(codes.InvalidArgument, "either file_content or file_path must be provided")
-	}
-
-	var file *models.NachaFile
-
-	if req.FileContent != nil {
-		file = models.FromBytes(req.FileContent)
-	} else {
-		content, err := ioutil.ReadFile(req.FilePath)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "failed to read file: %v", err)
-		}
-		file = models.FromBytes(content)
-	}
 
-	if file == nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse NACHA file")
+	file, err := s.loadFile(req.FileId, req.FileContent, req.FilePath)
+	if err != nil {
+		return nil, err
 	}
 
 	errors := s.validator.ValidateFile(file)
@@ -123,6 +120,7 @@ func (s *NachaService) CreateFile(ctx context.Context, req *pb.NachaFileRequest)
 			StandardEntryClass:       batchReq.Header.StandardEntryClass,
 			CompanyEntryDescription:  batchReq.Header.CompanyEntryDescription,
 			CompanyDescriptiveDate:   batchReq.Header.CompanyDescriptiveDate,
+			EffectiveEntryDate:       batchReq.Header.EffectiveEntryDate,
 			SettlementDate:           batchReq.Header.SettlementDate,
 			OriginatorStatusCode:     batchReq.Header.OriginatorStatusCode,
 			OriginatingDFI:           batchReq.Header.OriginatingDfiIdentification,
@@ -178,9 +176,9 @@ func (s *NachaService) CreateFile(ctx context.Context, req *pb.NachaFileRequest)
 			val, _ := strconv.ParseInt(routing, 10, 64)
 			entryHash += val
 
-			if strings.HasPrefix(entry.TransactionCode, "2") {
+			if models.IsDebit(entry.TransactionCode) {
 				totalDebit += entry.Amount
-			} else if strings.HasPrefix(entry.TransactionCode, "3") {
+			} else if models.IsCredit(entry.TransactionCode) {
 				totalCredit += entry.Amount
 			}
 
@@ -261,40 +259,25 @@ func (s *NachaService) ExportFile(ctx context.Context, req *pb.ExportRequest) (*
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
-	if req.FileContent == nil {
-		return nil, status.Error(codes.InvalidArgument, "file content cannot be nil")
-	}
 
 	// Parse NACHA file
-	file := models.FromBytes(req.FileContent)
-	if file == nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse NACHA file")
+	file, err := s.loadFile(req.FileId, req.FileContent, "")
+	if err != nil {
+		return nil, err
 	}
 
 	// Skip validation for export - we'll export even with validation errors
 
-	// Validate format
-	if req.Format < pb.ExportFormat_JSON || req.Format > pb.ExportFormat_PARQUET {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid format: %v (must be between %v and %v)",
-			req.Format, pb.ExportFormat_JSON, pb.ExportFormat_PARQUET)
-	}
-
-	// Get format name
-	formatName := req.Format.String()
-	if formatName == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "invalid format: %v", req.Format)
-	}
-
 	// Get exporter for the requested format
-	exporter, err := exporters.CreateExporter(formatName)
+	exporter, formatName, err := exporterFor(req)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get exporter: %v", err)
+		return nil, err
 	}
 
 	// Export file
 	content, err := exporter.Export(file)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to export file: %v", err)
+		return nil, exportError(err)
 	}
 
 	// Validate content type
@@ -310,124 +293,252 @@ func (s *NachaService) ExportFile(ctx context.Context, req *pb.ExportRequest) (*
 	}, nil
 }
 
+// exportError converts an exporter failure to a gRPC error
+func exportError(err error) error {
+	if errors.Is(err, exporters.ErrNoEntries) || errors.Is(err, exporters.ErrUnsupportedValue) || errors.Is(err, exporters.ErrControlMismatch) {
+		return status.Errorf(codes.FailedPrecondition, "failed to export file: %v", err)
+	}
+	if errors.Is(err, exporters.ErrInvalidTemplate) {
+		return status.Errorf(codes.InvalidArgument, "failed to export file: %v", err)
+	}
+	return status.Errorf(codes.Internal, "failed to export file: %v", err)
+}
+
+// exporterFor returns the exporter and format name for an export request.
+// A format name takes precedence over the enum.
+func exporterFor(req *pb.ExportRequest) (exporters.NachaExporter, string, error) {
+	formatName := strings.ToUpper(strings.TrimSpace(req.FormatName))
+	if formatName == "" {
+		// Validate format
+		name, ok := pb.ExportFormat_name[int32(req.Format)]
+		if !ok {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid format: %v", req.Format)
+		}
+		formatName = name
+	}
+
+	exporter, err := exporters.CreateExporter(formatName)
+	if err != nil {
+		return nil, "", status.Errorf(codes.InvalidArgument, "failed to get exporter: %v", err)
+	}
+
+	options, err := convertExportOptions(req.Options)
+	if err != nil {
+		return nil, "", err
+	}
+	exporter.SetOptions(options)
+
+	return exporter, formatName, nil
+}
+
+// convertExportOptions converts the export options of a request
+func convertExportOptions(opts *pb.ExportOptions) (exporters.Options, error) {
+	if opts == nil {
+		return exporters.Options{}, nil
+	}
+
+	options := exporters.Options{
+		Fields:             opts.Fields,
+		MaskAccountNumbers: opts.MaskAccountNumbers,
+		MaskVisibleDigits:  int(opts.MaskVisibleDigits),
+		BatchNumbers:       opts.BatchNumbers,
+		SECCodes:           opts.SecCodes,
+		Locale:             opts.Locale,
+		DateFormat:         opts.DateFormat,
+		SkipSQLSchema:      opts.SkipSqlSchema,
+	}
+	if cnab := opts.Cnab; cnab != nil {
+		if cnab.FileSequence < 0 {
+			return options, status.Errorf(codes.InvalidArgument, "invalid CNAB file sequence: %d", cnab.FileSequence)
+		}
+		options.CNAB = exporters.CNABOptions{
+			CompanyDocument: cnab.CompanyDocument,
+			Agreement:       cnab.Agreement,
+			CompanyAccount:  cnab.CompanyAccount,
+			FileSequence:    int(cnab.FileSequence),
+		}
+	}
+	if cpa := opts.Cpa; cpa != nil {
+		options.CPA = exporters.CPAOptions{
+			OriginatorID:          cpa.OriginatorId,
+			FileCreationNumber:    int(cpa.FileCreationNumber),
+			DestinationDataCentre: cpa.DestinationDataCentre,
+			Currency:              cpa.Currency,
+			CreditTransactionType: cpa.CreditTransactionType,
+			DebitTransactionType:  cpa.DebitTransactionType,
+			ReturnAccount:         cpa.ReturnAccount,
+		}
+	}
+	if template := opts.Template; template != nil {
+		options.Template = exporters.TemplateOptions{
+			Source:      template.Source,
+			Name:        template.Name,
+			HTML:        template.Html,
+			ContentType: template.ContentType,
+		}
+	}
+	if gl := opts.Gl; gl != nil {
+		options.GL = exporters.GLOptions{
+			DebitAccount:  gl.DebitAccount,
+			CreditAccount: gl.CreditAccount,
+			OffsetAccount: gl.OffsetAccount,
+		}
+		for _, rule := range gl.Rules {
+			options.GL.Rules = append(options.GL.Rules, exporters.GLAccountRule{
+				CompanyID:       rule.CompanyId,
+				SECCode:         rule.SecCode,
+				TransactionCode: rule.TransactionCode,
+				Account:         rule.Account,
+				OffsetAccount:   rule.OffsetAccount,
+			})
+		}
+	}
+	if pain := opts.Pain; pain != nil {
+		options.Pain = exporters.PainOptions{
+			CompanyAccount:  pain.CompanyAccount,
+			CompanyAccounts: pain.CompanyAccounts,
+		}
+	}
+
+	switch opts.AmountFormat {
+	case pb.AmountFormat_AMOUNT_DEFAULT:
+		options.AmountFormat = exporters.AmountDefault
+	case pb.AmountFormat_AMOUNT_CENTS:
+		options.AmountFormat = exporters.AmountCents
+	case pb.AmountFormat_AMOUNT_DECIMAL:
+		options.AmountFormat = exporters.AmountDecimal
+	default:
+		return options, status.Errorf(codes.InvalidArgument, "invalid amount format: %v", opts.AmountFormat)
+	}
+
+	switch opts.SqlDialect {
+	case pb.SqlDialect_SQL_DIALECT_POSTGRESQL:
+		options.SQLDialect = exporters.SQLDialectPostgreSQL
+	case pb.SqlDialect_SQL_DIALECT_MYSQL:
+		options.SQLDialect = exporters.SQLDialectMySQL
+	case pb.SqlDialect_SQL_DIALECT_SQLITE:
+		options.SQLDialect = exporters.SQLDialectSQLite
+	case pb.SqlDialect_SQL_DIALECT_SQLSERVER:
+		options.SQLDialect = exporters.SQLDialectSQLServer
+	default:
+		return options, status.Errorf(codes.InvalidArgument, "invalid SQL dialect: %v", opts.SqlDialect)
+	}
+
+	if opts.Timezone != "" {
+		location, err := time.LoadLocation(opts.Timezone)
+		if err != nil {
+			return options, status.Errorf(codes.InvalidArgument, "invalid timezone: %s", opts.Timezone)
+		}
+		options.Location = location
+	}
+
+	if err := options.Validate(); err != nil {
+		return options, status.Errorf(codes.InvalidArgument, "invalid export options: %v", err)
+	}
+	return options, nil
+}
+
+// ListExportFormats returns the registered export formats
+func (s *NachaService) ListExportFormats(ctx context.Context, req *pb.ListExportFormatsRequest) (*pb.ListExportFormatsResponse, error) {
+	response := &pb.ListExportFormatsResponse{}
+	for _, format := range exporters.Formats() {
+		value, hasEnum := pb.ExportFormat_value[format.Name]
+		response.Formats = append(response.Formats, &pb.ExportFormatInfo{
+			Name:        format.Name,
+			ContentType: format.ContentType,
+			Extension:   format.Extension,
+			Description: format.Description,
+			Binary:      format.Capabilities.Binary,
+			Aggregated:  format.Capabilities.Aggregated,
+			Importable:  format.Capabilities.Importable,
+			HasEnum:     hasEnum,
+			Format:      pb.ExportFormat(value),
+		})
+	}
+	return response, nil
+}
+
 // ViewFile returns the complete details of a NACHA file
 func (s *NachaService) ViewFile(ctx context.Context, req *pb.FileRequest) (*pb.FileDetailsResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
-	if req.FileContent == nil && req.FilePath == "" {
-		return nil, status.Error(codes.InvalidArgument, "either file_content or file_path must be provided")
+
+	file, err := s.loadFile(req.FileId, req.FileContent, req.FilePath)
+	if err != nil {
+		return nil, err
 	}
 
-	var file *models.NachaFile
-	if req.FileContent != nil {
-		file = models.FromBytes(req.FileContent)
-	} else {
-		content, err := ioutil.ReadFile(req.FilePath)
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "failed to read file: %v", err)
-		}
-		file = models.FromBytes(content)
+	mask, err := newViewMask(req.ViewMask)
+	if err != nil {
+		return nil, err
 	}
 
-	if file == nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse NACHA file")
+	batchOffset, err := parsePageToken(req.BatchPageToken)
+	if err != nil {
+		return nil, err
+	}
+	entryBatch, entryOffset, err := parseEntryPageToken(req.EntryPageToken)
+	if err != nil {
+		return nil, err
+	}
+	if req.BatchPageSize < 0 || req.EntryPageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size cannot be negative")
 	}
 
 	// Convert to response
 	response := &pb.FileDetailsResponse{
-		FileHeader: &pb.FileHeader{
-			PriorityCode:             file.Header.PriorityCode,
-			ImmediateDestination:     file.Header.ImmediateDestination,
-			ImmediateOrigin:          file.Header.ImmediateOrigin,
-			FileCreationTime:         file.Header.FileCreationTime,
-			FileIdModifier:           file.Header.FileIDModifier,
-			RecordSize:               file.Header.RecordSize,
-			BlockingFactor:           file.Header.BlockingFactor,
-			FormatCode:               file.Header.FormatCode,
-			ImmediateDestinationName: file.Header.DestinationName,
-			ImmediateOriginName:      file.Header.OriginName,
-			ReferenceCode:            file.Header.ReferenceCode,
-		},
-		FileControl: &pb.FileControl{
-			BatchCount:        int32(file.Control.BatchCount),
-			BlockCount:        int32(file.Control.BlockCount),
-			EntryAddendaCount: int32(file.Control.EntryAddendaCount),
-			EntryHash:         file.Control.EntryHash,
-			TotalDebitAmount:  file.Control.TotalDebitAmount,
-			TotalCreditAmount: file.Control.TotalCreditAmount,
-			Reserved:          file.Control.Reserved,
-		},
-		Summary: make(map[string]string),
+		TotalBatches: int32(len(file.Batches)),
+	}
+	if mask.has("file_header") {
+		response.FileHeader = convertFileHeader(&file.Header)
+	}
+	if mask.has("file_control") {
+		response.FileControl = convertFileControl(&file.Control)
 	}
 
 	// Add batches
-	for _, batch := range file.Batches {
+	batchStart, batchEnd, nextBatchToken := pageBounds(len(file.Batches), batchOffset, int(req.BatchPageSize))
+	response.NextBatchPageToken = nextBatchToken
+	if entryBatch >= 0 && (entryBatch < batchStart || entryBatch >= batchEnd) {
+		return nil, status.Errorf(codes.InvalidArgument, "entry page token is for batch %d, which is not on this batch page", entryBatch+1)
+	}
+	for i := batchStart; i < batchEnd && mask.hasBatches(); i++ {
+		batch := &file.Batches[i]
 		batchDetails := &pb.BatchDetails{
-			Header: &pb.BatchHeader{
-				ServiceClassCode:             batch.Header.ServiceClassCode,
-				CompanyName:                  batch.Header.CompanyName,
-				CompanyDiscretionaryData:     batch.Header.CompanyDiscretionaryData,
-				CompanyIdentification:        batch.Header.CompanyIdentification,
-				StandardEntryClass:           batch.Header.StandardEntryClass,
-				CompanyEntryDescription:      batch.Header.CompanyEntryDescription,
-				CompanyDescriptiveDate:       batch.Header.CompanyDescriptiveDate,
-				SettlementDate:               batch.Header.SettlementDate,
-				OriginatorStatusCode:         batch.Header.OriginatorStatusCode,
-				OriginatingDfiIdentification: batch.Header.OriginatingDFI,
-				BatchNumber:                  batch.Header.BatchNumber,
-			},
-			Control: &pb.BatchControl{
-				ServiceClassCode:             batch.Control.ServiceClassCode,
-				EntryAddendaCount:            int32(batch.Control.EntryAddendaCount),
-				EntryHash:                    batch.Control.EntryHash,
-				TotalDebitAmount:             batch.Control.TotalDebitAmount,
-				TotalCreditAmount:            batch.Control.TotalCreditAmount,
-				CompanyIdentification:        batch.Control.CompanyIdentification,
-				MessageAuthenticationCode:    batch.Control.MessageAuthenticationCode,
-				Reserved:                     batch.Control.Reserved,
-				OriginatingDfiIdentification: batch.Control.OriginatingDFI,
-				BatchNumber:                  batch.Control.BatchNumber,
-			},
+			TotalEntries: int32(len(batch.Entries)),
+		}
+		if mask.has("batches.header") {
+			batchDetails.Header = convertBatchHeader(&batch.Header)
+		}
+		if mask.has("batches.control") {
+			batchDetails.Control = convertBatchControl(&batch.Control)
 		}
 
-		// Add entries
-		for _, entry := range batch.Entries {
-			entryDetail := &pb.EntryDetail{
-				TransactionCode:                entry.TransactionCode,
-				ReceivingDfiIdentification:     entry.ReceivingDFI,
-				CheckDigit:                     entry.CheckDigit,
-				DfiAccountNumber:               entry.DFIAccountNumber,
-				Amount:                         entry.Amount,
-				IndividualIdentificationNumber: entry.IndividualIDNumber,
-				IndividualName:                 entry.IndividualName,
-				DiscretionaryData:              entry.DiscretionaryData,
-				AddendaRecordIndicator:         entry.AddendaRecordIndicator,
-				TraceNumber:                    entry.TraceNumber,
-			}
-
-			// Add addenda records
-			for _, addenda := range entry.AddendaRecords {
-				addendaRecord := &pb.AddendaRecord{
-					AddendaTypeCode:           addenda.AddendaTypeCode,
-					PaymentRelatedInformation: addenda.PaymentRelatedInformation,
-					AddendaSequenceNumber:     addenda.AddendaSequenceNumber,
-					EntryDetailSequenceNumber: addenda.EntryDetailSequenceNumber,
-				}
-				entryDetail.AddendaRecords = append(entryDetail.AddendaRecords, addendaRecord)
+		// Add entries. The entry page token only moves the entries of the
+		// batch it was returned for; the other batches start at their first.
+		if mask.has("batches.entries") {
+			offset := 0
+			if i == entryBatch {
+				offset = entryOffset
 			}
-
-			batchDetails.Entries = append(batchDetails.Entries, entryDetail)
+			entryStart, entryEnd, nextEntryToken := pageBounds(len(batch.Entries), offset, int(req.EntryPageSize))
+			batchDetails.Entries = convertEntries(batch.Entries[entryStart:entryEnd])
+			batchDetails.NextEntryPageToken = entryPageToken(i, nextEntryToken)
 		}
 
 		response.Batches = append(response.Batches, batchDetails)
 	}
 
 	// Add summary information
-	response.Summary["Total Batches"] = fmt.Sprintf("%d", len(file.Batches))
-	response.Summary["Total Entries"] = fmt.Sprintf("%d", file.Control.EntryAddendaCount)
-	response.Summary["Total Debit Amount"] = fmt.Sprintf("$%.2f", float64(file.Control.TotalDebitAmount)/100.0)
-	response.Summary["Total Credit Amount"] = fmt.Sprintf("$%.2f", float64(file.Control.TotalCreditAmount)/100.0)
+	if mask.has("summary") {
+		response.Summary = map[string]string{
+			"Total Batches":       fmt.Sprintf("%d", len(file.Batches)),
+			"Total Entries":       fmt.Sprintf("%d", file.Control.EntryAddendaCount),
+			"Total Debit Amount":  fmt.Sprintf("$%.2f", float64(file.Control.TotalDebitAmount)/100.0),
+			"Total Credit Amount": fmt.Sprintf("$%.2f", float64(file.Control.TotalCreditAmount)/100.0),
+		}
+	}
 
 	return response, nil
 }
@@ -437,14 +548,11 @@ func (s *NachaService) ViewDetails(ctx context.Context, req *pb.DetailRequest) (
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
-	if req.FileContent == nil {
-		return nil, status.Error(codes.InvalidArgument, "file content cannot be nil")
-	}
 
 	// Parse NACHA file
-	file := models.FromBytes(req.FileContent)
-	if file == nil {
-		return nil, status.Error(codes.InvalidArgument, "failed to parse NACHA file")
+	file, err := s.loadFile(req.FileId, req.FileContent, "")
+	if err != nil {
+		return nil, err
 	}
 
 	response := &pb.DetailResponse{}
@@ -486,6 +594,40 @@ func (s *NachaService) ViewDetails(ctx context.Context, req *pb.DetailRequest) (
 }
 
 // Helper functions for converting between models and protobuf messages
+func convertFileHeader(header *models.FileHeader) *pb.FileHeader {
+	result := &pb.FileHeader{
+		RecordType:               header.RecordType,
+		PriorityCode:             header.PriorityCode,
+		ImmediateDestination:     header.ImmediateDestination,
+		ImmediateOrigin:          header.ImmediateOrigin,
+		FileCreationTime:         header.FileCreationTime,
+		FileIdModifier:           header.FileIDModifier,
+		RecordSize:               header.RecordSize,
+		BlockingFactor:           header.BlockingFactor,
+		FormatCode:               header.FormatCode,
+		ImmediateDestinationName: header.DestinationName,
+		ImmediateOriginName:      header.OriginName,
+		ReferenceCode:            header.ReferenceCode,
+	}
+	if !header.FileCreationDate.IsZero() {
+		result.FileCreationDate = header.FileCreationDate.Format("060102")
+	}
+	return result
+}
+
+func convertFileControl(control *models.FileControl) *pb.FileControl {
+	return &pb.FileControl{
+		RecordType:        control.RecordType,
+		BatchCount:        int32(control.BatchCount),
+		BlockCount:        int32(control.BlockCount),
+		EntryAddendaCount: int32(control.EntryAddendaCount),
+		EntryHash:         control.EntryHash,
+		TotalDebitAmount:  control.TotalDebitAmount,
+		TotalCreditAmount: control.TotalCreditAmount,
+		Reserved:          control.Reserved,
+	}
+}
+
 func convertBatchHeader(header *models.BatchHeader) *pb.BatchHeader {
 	return &pb.BatchHeader{
 		ServiceClassCode:             header.ServiceClassCode,
@@ -495,6 +637,7 @@ func convertBatchHeader(header *models.BatchHeader) *pb.BatchHeader {
 		StandardEntryClass:           header.StandardEntryClass,
 		CompanyEntryDescription:      header.CompanyEntryDescription,
 		CompanyDescriptiveDate:       header.CompanyDescriptiveDate,
+		EffectiveEntryDate:           header.EffectiveEntryDate,
 		SettlementDate:               header.SettlementDate,
 		OriginatorStatusCode:         header.OriginatorStatusCode,
 		OriginatingDfiIdentification: header.OriginatingDFI,
@@ -551,19 +694,60 @@ func convertBatchControl(control *models.BatchControl) *pb.BatchControl {
 	}
 }
 
-// ImportFromJson converts a JSON representation of a NACHA file to NACHA format
-func (s *NachaService) ImportFromJson(ctx context.Context, req *pb.FileRequest) (*pb.FileResponse, error) {
+// ImportFromJson converts a JSON document in the NACHA JSON schema or in the
+// moov-io/ach layout to NACHA format. Controls are computed when the request
+// asks for it or when the document leaves them out; otherwise they are
+// checked. Other documents are read with the field names of models.NachaFile,
+// as written by earlier exports.
+func (s *NachaService) ImportFromJson(ctx context.Context, req *pb.ImportRequest) (*pb.FileResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
 	}
-	if req.FileContent == nil {
+	if req.JsonContent == nil {
 		return nil, status.Error(codes.InvalidArgument, "JSON content cannot be nil")
 	}
 
 	// Parse the JSON content into a NachaFile struct
-	var nachaFile models.NachaFile
-	if err := json.Unmarshal(req.FileContent, &nachaFile); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "failed to parse JSON: %v", err)
+	var nachaFile *models.NachaFile
+	recompute := req.RecomputeControls
+	if nachajson.IsDocument(req.JsonContent) {
+		var doc nachajson.File
+		if err := json.Unmarshal(req.JsonContent, &doc); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse JSON: %v", err)
+		}
+		file, err := doc.ToFile()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid JSON document: %v", err)
+		}
+		nachaFile = file
+		recompute = recompute || !doc.HasControls()
+	} else if moovjson.IsDocument(req.JsonContent) {
+		var doc moovjson.File
+		if err := json.Unmarshal(req.JsonContent, &doc); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse moov-io/ach JSON: %v", err)
+		}
+		file, err := doc.ToFile()
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid moov-io/ach JSON document: %v", err)
+		}
+		nachaFile = file
+		recompute = recompute || !doc.HasControls()
+	} else {
+		nachaFile = &models.NachaFile{}
+		if err := json.Unmarshal(req.JsonContent, nachaFile); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse JSON: %v", err)
+		}
+	}
+
+	if recompute {
+		for i := range nachaFile.Batches {
+			if nachaFile.Batches[i].Header.BatchNumber == "" {
+				nachaFile.Batches[i].Header.BatchNumber = fmt.Sprintf("%07d", i+1)
+			}
+		}
+		if err := s.creator.FinalizeFile(nachaFile); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to compute controls: %v", err)
+		}
 	}
 
 	// Validate the parsed file structure
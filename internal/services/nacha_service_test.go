@@ -1,12 +1,27 @@
 package services
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
 	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/creator"
+	"github.com/nacha-service/internal/exporters"
+	"github.com/nacha-service/pkg/models"
+	"github.com/nacha-service/pkg/nachajson"
 	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestCreateFile(t *testing.T) {
@@ -51,7 +66,7 @@ func TestCreateFile(t *testing.T) {
 				Entries: []*pb.EntryDetailRequest{
 					{
 						RecordType:                     "6",
-						TransactionCode:                "22",
+						TransactionCode:                "27",
 						ReceivingDfiIdentification:     "07640125",
 						CheckDigit:                     "1",
 						DfiAccountNumber:               "123456789",
@@ -249,7 +264,7 @@ func TestViewDetails(t *testing.T) {
 				Entries: []*pb.EntryDetailRequest{
 					{
 						RecordType:                     "6",
-						TransactionCode:                "22",
+						TransactionCode:                "27",
 						ReceivingDfiIdentification:     "07640125",
 						CheckDigit:                     "1",
 						DfiAccountNumber:               "123456789",
@@ -353,3 +368,1293 @@ func TestViewDetails(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, viewResp)
 }
+
+// buildTestFile creates a NACHA file with two batches and returns its content
+func buildTestFile(t *testing.T) []byte {
+	t.Helper()
+
+	c := creator.NewCreator()
+	file := c.CreateFile(models.FileHeader{
+		PriorityCode:         "01",
+		ImmediateDestination: "076401251",
+		ImmediateOrigin:      "0764012512",
+		FileCreationDate:     time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+		FileCreationTime:     "1200",
+		FileIDModifier:       "A",
+		RecordSize:           "094",
+		BlockingFactor:       "10",
+		FormatCode:           "1",
+		DestinationName:      "BANCO DO BRASIL",
+		OriginName:           "EMPRESA EXEMPLO",
+	})
+
+	batches := []struct {
+		header  models.BatchHeader
+		entries []models.EntryDetail
+	}{
+		{
+			header: models.BatchHeader{
+				ServiceClassCode:        "200",
+				CompanyName:             "EMPRESA EXEMPLO",
+				CompanyIdentification:   "0764012512",
+				StandardEntryClass:      "PPD",
+				CompanyEntryDescription: "COBRANCA",
+				CompanyDescriptiveDate:  "261017",
+				EffectiveEntryDate:      "261019",
+				OriginatorStatusCode:    "1",
+				OriginatingDFI:          "07640125",
+			},
+			entries: []models.EntryDetail{
+				{TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "111111", Amount: 750000, IndividualName: "JOAO DA SILVA", TraceNumber: "076401250000001"},
+				{TransactionCode: "27", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "222222", Amount: 120000, IndividualName: "MARIA SOUZA", TraceNumber: "076401250000002"},
+				{TransactionCode: "22", ReceivingDFI: "07640125", CheckDigit: "1", DFIAccountNumber: "333333", Amount: 900000, IndividualName: "PEDRO ALVARES", TraceNumber: "076401250000003"},
+			},
+		},
+		{
+			header: models.BatchHeader{
+				ServiceClassCode:        "220",
+				CompanyName:             "OUTRA EMPRESA",
+				CompanyIdentification:   "1234567890",
+				StandardEntryClass:      "CCD",
+				CompanyEntryDescription: "FORNECEDOR",
+				CompanyDescriptiveDate:  "261017",
+				EffectiveEntryDate:      "261020",
+				OriginatorStatusCode:    "1",
+				OriginatingDFI:          "07640125",
+			},
+			entries: []models.EntryDetail{
+				{TransactionCode: "32", ReceivingDFI: "02100002", CheckDigit: "1", DFIAccountNumber: "444444", Amount: 510000, IndividualName: "ACME SUPPLIES", TraceNumber: "076401250000004",
+					AddendaRecords: []models.AddendaRecord{{AddendaTypeCode: "05", PaymentRelatedInformation: "INV-1001 INV-1002"}}},
+			},
+		},
+	}
+
+	for _, b := range batches {
+		c.AddBatch(file, b.header)
+		batch := &file.Batches[len(file.Batches)-1]
+		for _, entry := range b.entries {
+			entry.RecordType = "6"
+			entry.AddendaRecordIndicator = "0"
+			addenda := entry.AddendaRecords
+			entry.AddendaRecords = nil
+			for _, a := range addenda {
+				assert.NoError(t, c.AddAddenda(&entry, a))
+			}
+			assert.NoError(t, c.AddEntry(batch, entry))
+		}
+	}
+	assert.NoError(t, c.FinalizeFile(file))
+
+	return file.ToBytes()
+}
+
+func TestQueryEntries(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Debits over $5,000 to a routing prefix
+	resp, err := service.QueryEntries(ctx, &pb.QueryRequest{
+		FileContent: content,
+		Filter: &pb.EntryFilter{
+			MinAmount:    500001,
+			Direction:    pb.EntryDirection_DIRECTION_DEBIT,
+			ReceivingDfi: "0210000",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.TotalMatches)
+	assert.Equal(t, "JOAO DA SILVA", resp.Matches[0].Entry.IndividualName)
+	assert.Equal(t, "EMPRESA EXEMPLO", resp.Matches[0].BatchHeader.CompanyName)
+
+	// Test case 2: SEC code and company filters
+	resp, err = service.QueryEntries(ctx, &pb.QueryRequest{
+		FileContent: content,
+		Filter:      &pb.EntryFilter{StandardEntryClasses: []string{"ccd"}, CompanyName: "outra"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.TotalMatches)
+	assert.Equal(t, int32(1), resp.Matches[0].BatchIndex)
+	assert.Len(t, resp.Matches[0].Entry.AddendaRecords, 1)
+
+	// Test case 3: Fuzzy name match
+	resp, err = service.QueryEntries(ctx, &pb.QueryRequest{
+		FileContent: content,
+		Filter:      &pb.EntryFilter{IndividualName: "MARIA SOUSA"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resp.TotalMatches)
+
+	resp, err = service.QueryEntries(ctx, &pb.QueryRequest{
+		FileContent: content,
+		Filter:      &pb.EntryFilter{IndividualName: "MARIA SOUSA", FuzzyName: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.TotalMatches)
+
+	// Test case 4: Sorting and pagination
+	req := &pb.QueryRequest{
+		FileContent: content,
+		SortBy:      "amount",
+		Descending:  true,
+		PageSize:    3,
+	}
+	resp, err = service.QueryEntries(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), resp.TotalMatches)
+	assert.Len(t, resp.Matches, 3)
+	assert.Equal(t, int64(900000), resp.Matches[0].Entry.Amount)
+	assert.Equal(t, "3", resp.NextPageToken)
+
+	req.PageToken = resp.NextPageToken
+	resp, err = service.QueryEntries(ctx, req)
+	assert.NoError(t, err)
+	assert.Len(t, resp.Matches, 1)
+	assert.Equal(t, int64(120000), resp.Matches[0].Entry.Amount)
+	assert.Empty(t, resp.NextPageToken)
+
+	// Test case 5: Invalid requests
+	_, err = service.QueryEntries(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = service.QueryEntries(ctx, &pb.QueryRequest{FileContent: content, SortBy: "unknown"})
+	assert.Error(t, err)
+
+	_, err = service.QueryEntries(ctx, &pb.QueryRequest{FileContent: content, PageToken: "abc"})
+	assert.Error(t, err)
+
+	_, err = service.QueryEntries(ctx, &pb.QueryRequest{
+		FileContent: content,
+		Filter:      &pb.EntryFilter{MinAmount: 100, MaxAmount: 50},
+	})
+	assert.Error(t, err)
+}
+
+func TestSummarizeFile(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Aggregates by dimension
+	resp, err := service.SummarizeFile(ctx, &pb.SummaryRequest{FileContent: content, TopEntries: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), resp.Totals.EntryCount)
+	assert.Equal(t, int64(870000), resp.Totals.DebitAmount)
+	assert.Equal(t, int64(1410000), resp.Totals.CreditAmount)
+	assert.Equal(t, int32(1), resp.Totals.EntriesWithAddenda)
+	assert.InDelta(t, 0.25, resp.Totals.AddendaUsageRate, 0.0001)
+
+	assert.Len(t, resp.BySecCode, 2)
+	assert.Equal(t, "CCD", resp.BySecCode[0].Key)
+	assert.Equal(t, int64(510000), resp.BySecCode[0].CreditAmount)
+
+	assert.Len(t, resp.ByCompany, 2)
+	assert.Equal(t, "0764012512", resp.ByCompany[0].Key)
+	assert.Equal(t, "EMPRESA EXEMPLO", resp.ByCompany[0].Label)
+	assert.Equal(t, int32(3), resp.ByCompany[0].EntryCount)
+
+	assert.Len(t, resp.ByReceivingDfi, 2)
+	assert.Equal(t, "02100002", resp.ByReceivingDfi[0].Key)
+	assert.Equal(t, int32(3), resp.ByReceivingDfi[0].EntryCount)
+
+	assert.Len(t, resp.ByEffectiveDate, 2)
+	assert.Equal(t, "261019", resp.ByEffectiveDate[0].Key)
+
+	assert.Len(t, resp.ByDirection, 2)
+	assert.Equal(t, "CREDIT", resp.ByDirection[0].Key)
+	assert.Equal(t, int32(2), resp.ByDirection[0].CreditCount)
+
+	// Test case 2: Largest entries
+	assert.Len(t, resp.LargestEntries, 2)
+	assert.Equal(t, int64(900000), resp.LargestEntries[0].Entry.Amount)
+	assert.Equal(t, int64(750000), resp.LargestEntries[1].Entry.Amount)
+
+	// Test case 3: Export of the same aggregates
+	exportResp, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: pb.ExportFormat_SUMMARY_CSV})
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv", exportResp.FileType)
+	assert.Contains(t, string(exportResp.ExportedContent), "sec_code,CCD,,1,1,1,1.0000,0,0,1,510000")
+
+	exportResp, err = service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: pb.ExportFormat_SUMMARY_JSON})
+	assert.NoError(t, err)
+	assert.Contains(t, string(exportResp.ExportedContent), `"by_sec_code"`)
+
+	// Test case 4: Invalid requests
+	_, err = service.SummarizeFile(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = service.SummarizeFile(ctx, &pb.SummaryRequest{})
+	assert.Error(t, err)
+}
+
+func TestViewFilePaging(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Batch and entry pages
+	resp, err := service.ViewFile(ctx, &pb.FileRequest{
+		FileContent:   content,
+		BatchPageSize: 1,
+		EntryPageSize: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), resp.TotalBatches)
+	assert.Len(t, resp.Batches, 1)
+	assert.Equal(t, "1", resp.NextBatchPageToken)
+	assert.Equal(t, int32(3), resp.Batches[0].TotalEntries)
+	assert.Len(t, resp.Batches[0].Entries, 2)
+	assert.Equal(t, "0:2", resp.Batches[0].NextEntryPageToken)
+
+	resp, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent:    content,
+		BatchPageSize:  1,
+		EntryPageSize:  2,
+		EntryPageToken: "0:2",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Batches[0].Entries, 1)
+	assert.Equal(t, "PEDRO ALVARES", resp.Batches[0].Entries[0].IndividualName)
+	assert.Empty(t, resp.Batches[0].NextEntryPageToken)
+
+	resp, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent:    content,
+		BatchPageSize:  1,
+		BatchPageToken: "1",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Batches, 1)
+	assert.Equal(t, "OUTRA EMPRESA", resp.Batches[0].Header.CompanyName)
+	assert.Empty(t, resp.NextBatchPageToken)
+
+	// Test case 2: An entry page token only pages the batch it was returned for
+	resp, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent:   content,
+		EntryPageSize: 1,
+	})
+	if assert.NoError(t, err) && assert.Len(t, resp.Batches, 2) {
+		assert.Equal(t, "0:1", resp.Batches[0].NextEntryPageToken)
+		assert.Empty(t, resp.Batches[1].NextEntryPageToken)
+	}
+	resp, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent:    content,
+		EntryPageSize:  1,
+		EntryPageToken: "0:1",
+	})
+	if assert.NoError(t, err) && assert.Len(t, resp.Batches, 2) {
+		assert.Equal(t, "MARIA SOUZA", resp.Batches[0].Entries[0].IndividualName)
+		assert.Equal(t, "0:2", resp.Batches[0].NextEntryPageToken)
+		assert.Equal(t, "ACME SUPPLIES", resp.Batches[1].Entries[0].IndividualName)
+	}
+	_, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent:    content,
+		BatchPageSize:  1,
+		EntryPageToken: "1:0",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Test case 3: Headers and controls only
+	resp, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent: content,
+		ViewMask: &fieldmaskpb.FieldMask{
+			Paths: []string{"file_header", "file_control", "batches.header", "batches.control"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp.FileHeader)
+	assert.Equal(t, "261017", resp.FileHeader.FileCreationDate)
+	assert.NotNil(t, resp.FileControl)
+	assert.Nil(t, resp.Summary)
+	assert.Len(t, resp.Batches, 2)
+	assert.NotNil(t, resp.Batches[0].Header)
+	assert.NotNil(t, resp.Batches[0].Control)
+	assert.Empty(t, resp.Batches[0].Entries)
+	assert.Equal(t, int32(3), resp.Batches[0].TotalEntries)
+
+	// Test case 4: Invalid paging and mask
+	_, err = service.ViewFile(ctx, &pb.FileRequest{FileContent: content, BatchPageToken: "x"})
+	assert.Error(t, err)
+
+	for _, token := range []string{"2", "0:", ":1", "0:-1", "-1:0", "a:b"} {
+		_, err = service.ViewFile(ctx, &pb.FileRequest{FileContent: content, EntryPageToken: token})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), token)
+	}
+
+	_, err = service.ViewFile(ctx, &pb.FileRequest{FileContent: content, EntryPageSize: -1})
+	assert.Error(t, err)
+
+	_, err = service.ViewFile(ctx, &pb.FileRequest{
+		FileContent: content,
+		ViewMask:    &fieldmaskpb.FieldMask{Paths: []string{"batches.unknown"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestUploadFile(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Upload returns a stable file ID
+	resp, err := service.UploadFile(ctx, &pb.UploadRequest{FileContent: content})
+	assert.NoError(t, err)
+	assert.Len(t, resp.FileId, 64)
+	assert.Equal(t, int64(len(content)), resp.Size)
+	assert.Equal(t, int32(2), resp.BatchCount)
+	assert.Equal(t, int32(4), resp.EntryCount)
+	assert.Greater(t, resp.ExpiresAt, time.Now().Unix())
+
+	again, err := service.UploadFile(ctx, &pb.UploadRequest{FileContent: content})
+	assert.NoError(t, err)
+	assert.Equal(t, resp.FileId, again.FileId)
+
+	// Test case 2: Other RPCs accept the file ID
+	viewResp, err := service.ViewFile(ctx, &pb.FileRequest{FileId: resp.FileId})
+	assert.NoError(t, err)
+	assert.Len(t, viewResp.Batches, 2)
+
+	exportResp, err := service.ExportFile(ctx, &pb.ExportRequest{FileId: resp.FileId, Format: pb.ExportFormat_CSV})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, exportResp.ExportedContent)
+
+	detailResp, err := service.ViewDetails(ctx, &pb.DetailRequest{FileId: resp.FileId, DetailType: "entry", Identifier: "076401250000003"})
+	assert.NoError(t, err)
+	assert.Equal(t, "PEDRO ALVARES", detailResp.GetEntry().IndividualName)
+
+	queryResp, err := service.QueryEntries(ctx, &pb.QueryRequest{FileId: resp.FileId})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), queryResp.TotalMatches)
+
+	_, err = service.ValidateFile(ctx, &pb.FileRequest{FileId: resp.FileId})
+	assert.NoError(t, err)
+
+	// Test case 3: Unknown file ID
+	_, err = service.ViewFile(ctx, &pb.FileRequest{FileId: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Test case 4: Invalid requests
+	_, err = service.UploadFile(ctx, nil)
+	assert.Error(t, err)
+
+	_, err = service.UploadFile(ctx, &pb.UploadRequest{})
+	assert.Error(t, err)
+}
+
+// uploadStream is an in-memory UploadStream server stream
+type uploadStream struct {
+	grpc.ServerStream
+	chunks   [][]byte
+	response *pb.UploadResponse
+}
+
+func (s *uploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *uploadStream) Recv() (*pb.UploadChunk, error) {
+	if len(s.chunks) == 0 {
+		return nil, io.EOF
+	}
+	chunk := &pb.UploadChunk{Data: s.chunks[0]}
+	s.chunks = s.chunks[1:]
+	return chunk, nil
+}
+
+func (s *uploadStream) SendAndClose(resp *pb.UploadResponse) error {
+	s.response = resp
+	return nil
+}
+
+// exportStream is an in-memory ExportStream server stream
+type exportStream struct {
+	grpc.ServerStream
+	chunks []*pb.ExportChunk
+}
+
+func (s *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *exportStream) Send(chunk *pb.ExportChunk) error {
+	// Chunk data may share the exporter's buffer, so keep a copy
+	chunk.Data = append([]byte(nil), chunk.Data...)
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func TestStreaming(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Chunked upload matches a single upload
+	upload := &uploadStream{}
+	for start := 0; start < len(content); start += 100 {
+		end := min(start+100, len(content))
+		upload.chunks = append(upload.chunks, content[start:end])
+	}
+	assert.NoError(t, service.UploadStream(upload))
+	assert.NotNil(t, upload.response)
+
+	single, err := service.UploadFile(ctx, &pb.UploadRequest{FileContent: content})
+	assert.NoError(t, err)
+	assert.Equal(t, single.FileId, upload.response.FileId)
+	assert.Equal(t, int64(len(content)), upload.response.Size)
+	assert.Equal(t, int32(4), upload.response.EntryCount)
+
+	// Test case 2: Empty upload
+	assert.Error(t, service.UploadStream(&uploadStream{}))
+
+	// Test case 3: Chunked export matches a single export
+	export := &exportStream{}
+	err = service.ExportStream(&pb.ExportRequest{
+		FileId:    upload.response.FileId,
+		Format:    pb.ExportFormat_CSV,
+		ChunkSize: 50,
+	}, export)
+	assert.NoError(t, err)
+
+	expected, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: pb.ExportFormat_CSV})
+	assert.NoError(t, err)
+
+	var data bytes.Buffer
+	for i, chunk := range export.chunks {
+		assert.Equal(t, int32(i), chunk.Sequence)
+		assert.Equal(t, int64(data.Len()), chunk.Offset)
+		assert.Equal(t, i == len(export.chunks)-1, chunk.Last)
+		data.Write(chunk.Data)
+	}
+	assert.Equal(t, expected.ExportedContent, data.Bytes())
+	assert.Greater(t, len(export.chunks), 1)
+
+	first, last := export.chunks[0], export.chunks[len(export.chunks)-1]
+	assert.Equal(t, "text/csv", first.FileType)
+	assert.Equal(t, int32(2), first.TotalBatches)
+	assert.Equal(t, int32(4), first.TotalEntries)
+	assert.Equal(t, int64(data.Len()), last.TotalSize)
+
+	// Test case 4: Invalid requests
+	assert.Error(t, service.ExportStream(&pb.ExportRequest{FileContent: content, ChunkSize: -1}, &exportStream{}))
+	assert.Error(t, service.ExportStream(&pb.ExportRequest{FileContent: content, Format: pb.ExportFormat(99)}, &exportStream{}))
+	assert.Error(t, service.ExportStream(nil, &exportStream{}))
+}
+
+// traceExporter is a custom export format registered from outside the exporters package
+type traceExporter struct {
+	*exporters.BaseExporter
+}
+
+func (e *traceExporter) Export(file *models.NachaFile) ([]byte, error) {
+	var buf bytes.Buffer
+	err := e.ExportTo(&buf, file)
+	return buf.Bytes(), err
+}
+
+func (e *traceExporter) ExportTo(w io.Writer, file *models.NachaFile) error {
+	for _, batch := range file.Batches {
+		for _, entry := range batch.Entries {
+			if _, err := io.WriteString(w, entry.TraceNumber+"\n"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestListExportFormats(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Built-in formats are listed with their enum values
+	resp, err := service.ListExportFormats(ctx, &pb.ListExportFormatsRequest{})
+	assert.NoError(t, err)
+
+	formats := make(map[string]*pb.ExportFormatInfo)
+	for _, f := range resp.Formats {
+		formats[f.Name] = f
+	}
+	for name := range pb.ExportFormat_value {
+		assert.Contains(t, formats, name)
+	}
+	assert.Equal(t, "text/csv", formats["CSV"].ContentType)
+	assert.Equal(t, ".csv", formats["CSV"].Extension)
+	assert.True(t, formats["PDF"].Binary)
+	assert.True(t, formats["SUMMARY_JSON"].Aggregated)
+	assert.True(t, formats["JSON"].Importable)
+	assert.True(t, formats["PARQUET"].HasEnum)
+	assert.Equal(t, pb.ExportFormat_PARQUET, formats["PARQUET"].Format)
+
+	// Test case 2: A format registered from another package is listed and exportable by name
+	// The registry is global, so only register once when the test is repeated
+	if _, ok := exporters.Lookup("TRACE_LIST"); !ok {
+		err = exporters.Register(exporters.Format{
+			Name:        "trace_list",
+			Extension:   "txt",
+			Description: "One trace number per line",
+			New: func() exporters.NachaExporter {
+				return &traceExporter{BaseExporter: exporters.NewBaseExporter("text/plain")}
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	resp, err = service.ListExportFormats(ctx, &pb.ListExportFormatsRequest{})
+	assert.NoError(t, err)
+	var custom *pb.ExportFormatInfo
+	for _, f := range resp.Formats {
+		if f.Name == "TRACE_LIST" {
+			custom = f
+		}
+	}
+	if assert.NotNil(t, custom) {
+		assert.Equal(t, "text/plain", custom.ContentType)
+		assert.Equal(t, ".txt", custom.Extension)
+		assert.False(t, custom.HasEnum)
+	}
+
+	exportResp, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "trace_list"})
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain", exportResp.FileType)
+	assert.Equal(t, 4, bytes.Count(exportResp.ExportedContent, []byte("\n")))
+
+	// Test case 3: Format name takes precedence over the enum
+	exportResp, err = service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: pb.ExportFormat_PDF, FormatName: "csv"})
+	assert.NoError(t, err)
+	assert.Equal(t, "text/csv", exportResp.FileType)
+
+	// Test case 4: Unknown formats
+	_, err = service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "unknown"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestExportOptions(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	export := func(format pb.ExportFormat, options *pb.ExportOptions) string {
+		resp, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: format, Options: options})
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		return string(resp.ExportedContent)
+	}
+
+	// Test case 1: Request options reach the exporter
+	csv := export(pb.ExportFormat_CSV, &pb.ExportOptions{
+		Fields:             []string{"trace_number", "amount", "dfi_account_number"},
+		MaskAccountNumbers: true,
+		MaskVisibleDigits:  2,
+		SecCodes:           []string{"PPD"},
+		AmountFormat:       pb.AmountFormat_AMOUNT_DECIMAL,
+		Locale:             "pt-BR",
+	})
+	assert.Contains(t, csv, "Record Type,Trace Number,Amount,DFI Account Number\n")
+	assert.Contains(t, csv, "6,076401250000001,\"7.500,00\",****11\n")
+	assert.NotContains(t, csv, "076401250000004")
+
+	// Test case 2: The timezone is loaded by name
+	txt := export(pb.ExportFormat_TXT, &pb.ExportOptions{DateFormat: "DD/MM/YYYY", Timezone: "America/Sao_Paulo"})
+	assert.Contains(t, txt, "File Creation Date: 17/10/2026\n")
+	assert.Contains(t, txt, "File Creation Time: 0900\n")
+
+	// Test case 3: Format options are converted
+	sql := export(pb.ExportFormat_SQL, &pb.ExportOptions{SqlDialect: pb.SqlDialect_SQL_DIALECT_MYSQL, SkipSqlSchema: true})
+	assert.Contains(t, sql, "SET @entry_detail_id = LAST_INSERT_ID();")
+	assert.NotContains(t, sql, "CREATE TABLE")
+
+	resp, err := service.ExportFile(ctx, &pb.ExportRequest{
+		FileContent: content,
+		FormatName:  "CNAB240",
+		Options:     &pb.ExportOptions{Cnab: &pb.CnabOptions{Agreement: "123456", CompanyAccount: "98765-4", FileSequence: 7}},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "000007", string(resp.ExportedContent[157:163]))
+	}
+
+	resp, err = service.ExportFile(ctx, &pb.ExportRequest{
+		FileContent: content,
+		FormatName:  "GL_CSV",
+		Options: &pb.ExportOptions{Gl: &pb.GlOptions{
+			OffsetAccount: "1010 Operating",
+			Rules:         []*pb.GlAccountRule{{CompanyId: "1234567890", SecCode: "CCD", TransactionCode: "32", Account: "2000 Vendors", OffsetAccount: "1020 Vendor Clearing"}},
+		}},
+	})
+	if assert.NoError(t, err) {
+		journal := string(resp.ExportedContent)
+		assert.Contains(t, journal, ",1010 Operating,")
+		assert.Contains(t, journal, ",1020 Vendor Clearing,")
+		assert.Contains(t, journal, ",2000 Vendors,")
+	}
+
+	resp, err = service.ExportFile(ctx, &pb.ExportRequest{
+		FileContent: content,
+		FormatName:  "TEMPLATE",
+		Options:     &pb.ExportOptions{Template: &pb.TemplateOptions{Source: `<p>{{"R&D"}}</p>`, Html: true, ContentType: "text/x-test"}},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "text/x-test", resp.FileType)
+		assert.Equal(t, "<p>R&amp;D</p>", string(resp.ExportedContent))
+	}
+
+	// Test case 4: Every format accepts the options
+	options := &pb.ExportOptions{
+		Fields:             []string{"amount", "individual_name"},
+		MaskAccountNumbers: true,
+		SecCodes:           []string{"PPD"},
+		AmountFormat:       pb.AmountFormat_AMOUNT_CENTS,
+		DateFormat:         "YYYY/MM/DD",
+		Timezone:           "UTC",
+	}
+	for value := range pb.ExportFormat_name {
+		assert.NotEmpty(t, export(pb.ExportFormat(value), options), pb.ExportFormat(value).String())
+	}
+
+	// Test case 5: Invalid options
+	for _, invalid := range []*pb.ExportOptions{
+		{Fields: []string{"unknown"}},
+		{Locale: "fr-FR"},
+		{Timezone: "Mars/Olympus"},
+		{MaskVisibleDigits: -1},
+		{AmountFormat: 99},
+		{SqlDialect: 99},
+		{Cnab: &pb.CnabOptions{FileSequence: -1}},
+		{Cpa: &pb.CpaOptions{Currency: "EUR"}},
+		{Gl: &pb.GlOptions{Rules: []*pb.GlAccountRule{{SecCode: "PPD"}}}},
+		{Pain: &pb.PainOptions{CompanyAccount: strings.Repeat("1", 35)}},
+	} {
+		_, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Options: invalid})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), invalid.String())
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Files the format cannot hold fail the precondition
+	for _, req := range []*pb.ExportRequest{
+		{FormatName: "PAIN008", Options: &pb.ExportOptions{SecCodes: []string{"CCD"}, Pain: &pb.PainOptions{CompanyAccount: "9876543210"}}},
+		{FormatName: "PAIN001"},
+		{FormatName: "CNAB240", Options: &pb.ExportOptions{MaskAccountNumbers: true}},
+		{FormatName: "OFX", Options: &pb.ExportOptions{SecCodes: []string{"WEB"}}},
+	} {
+		req.FileContent = content
+		_, err := service.ExportFile(ctx, req)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), req.FormatName)
+	}
+	assert.Equal(t, codes.FailedPrecondition, status.Code(exportError(fmt.Errorf("batch 1: %w", exporters.ErrControlMismatch))))
+
+	// Test case 2: Templates that fail are invalid arguments
+	for _, template := range []*pb.TemplateOptions{nil, {Source: "{{.Unknown}}"}, {Name: "unknown"}} {
+		_, err := service.ExportFile(ctx, &pb.ExportRequest{
+			FileContent: content,
+			FormatName:  "TEMPLATE",
+			Options:     &pb.ExportOptions{Template: template},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", template)
+	}
+
+	// Test case 3: Anything else is internal
+	assert.Equal(t, codes.Internal, status.Code(exportError(io.ErrShortWrite)))
+}
+
+// testPainOptions holds the accounts of the companies of buildTestFile
+var testPainOptions = &pb.PainOptions{
+	CompanyAccount:  "9876543210",
+	CompanyAccounts: map[string]string{"1234567890": "5555500001"},
+}
+
+func TestImportFromPain(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: A pain.001 export imports back as credit batches
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "PAIN001", Options: &pb.ExportOptions{Pain: testPainOptions}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := service.ImportFromPain(ctx, &pb.PainImportRequest{XmlContent: exported.ExportedContent})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "pain.001.001.03", resp.MessageType)
+	assert.Empty(t, resp.Errors)
+
+	file, err := service.loadFile("", resp.FileContent, "")
+	if assert.NoError(t, err) && assert.Len(t, file.Batches, 2) {
+		assert.Equal(t, "076401251", file.Header.ImmediateDestination)
+		assert.Equal(t, int64(510000), file.Batches[1].Control.TotalCreditAmount)
+	}
+
+	// Test case 2: Request headers override the message
+	exported, err = service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "PAIN008", Options: &pb.ExportOptions{Pain: testPainOptions}})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err = service.ImportFromPain(ctx, &pb.PainImportRequest{
+		XmlContent:           exported.ExportedContent,
+		ImmediateDestination: "021000021",
+	})
+	if assert.NoError(t, err) && assert.Empty(t, resp.Errors) {
+		assert.Equal(t, "pain.008.001.02", resp.MessageType)
+		file, err = service.loadFile("", resp.FileContent, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "021000021", file.Header.ImmediateDestination)
+		}
+	}
+
+	// Test case 3: Import errors are returned with their location and value
+	foreign := strings.Replace(string(exported.ExportedContent), `Ccy="USD"`, `Ccy="EUR"`, 1)
+	resp, err = service.ImportFromPain(ctx, &pb.PainImportRequest{XmlContent: []byte(foreign)})
+	if assert.NoError(t, err) && assert.NotEmpty(t, resp.Errors) {
+		assert.Empty(t, resp.FileContent)
+		assert.Equal(t, "UNSUPPORTED_CURRENCY", resp.Errors[0].ErrorCode)
+		assert.Equal(t, "PmtInf[1]/DrctDbtTxInf[1]/InstdAmt/@Ccy", resp.Errors[0].Location)
+		assert.Equal(t, "EUR", resp.Errors[0].Value)
+	}
+
+	// Test case 4: Malformed XML
+	_, err = service.ImportFromPain(ctx, &pb.PainImportRequest{XmlContent: []byte("<Document>")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestImportFromCSV(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: A CSV export imports back to the same file
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: pb.ExportFormat_CSV})
+	if !assert.NoError(t, err) {
+		return
+	}
+	resp, err := service.ImportFromCSV(ctx, &pb.CsvImportRequest{CsvContent: exported.ExportedContent})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "EXPORT", resp.Layout)
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, string(content), string(resp.FileContent))
+
+	// Test case 2: Request headers and column mapping reach the importer
+	payees := "Payee,ABA,Acct,Type,Value,Memo\n" +
+		"JOAO DA SILVA,021000021,111111,checking,\"7,500.00\",Invoice 1001\n" +
+		"MARIA SOUZA,021000021,222222,savings,$1200.5,\n"
+	resp, err = service.ImportFromCSV(ctx, &pb.CsvImportRequest{
+		CsvContent: []byte(payees),
+		FileHeader: &pb.FileHeader{ImmediateDestination: "076401251", FileCreationDate: "261017", FileCreationTime: "0900"},
+		BatchHeader: &pb.BatchHeader{
+			CompanyName:                  "EMPRESA EXEMPLO",
+			CompanyIdentification:        "0764012512",
+			OriginatingDfiIdentification: "07640125",
+			EffectiveEntryDate:           "261019",
+		},
+		Columns: &pb.CsvColumnMapping{Name: "Payee", Routing: "ABA", Account: "Acct", AccountType: "Type", Amount: "Value", Addenda: "Memo"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "COLUMNS", resp.Layout)
+	assert.Empty(t, resp.Errors)
+	file, err := service.loadFile("", resp.FileContent, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "076401251", file.Header.ImmediateDestination)
+	assert.Equal(t, "0764012512", file.Header.ImmediateOrigin)
+	assert.Equal(t, "0900", file.Header.FileCreationTime)
+	if assert.Len(t, file.Batches, 1) {
+		batch := file.Batches[0]
+		assert.Equal(t, "EMPRESA EXEMPLO", batch.Header.CompanyName)
+		assert.Equal(t, "07640125", batch.Header.OriginatingDFI)
+		assert.Equal(t, "261019", batch.Header.EffectiveEntryDate)
+		assert.Len(t, batch.Entries, 2)
+	}
+
+	// Test case 3: Row-level errors, with amounts in cents
+	payees = "name,routing,account,amount\n" +
+		"JOAO DA SILVA,021000022,111111,750000\n" +
+		",021000021,222222,12.50\n"
+	resp, err = service.ImportFromCSV(ctx, &pb.CsvImportRequest{
+		CsvContent:   []byte(payees),
+		FileHeader:   &pb.FileHeader{ImmediateDestination: "076401251"},
+		BatchHeader:  &pb.BatchHeader{CompanyName: "EMPRESA EXEMPLO", CompanyIdentification: "0764012512", OriginatingDfiIdentification: "07640125"},
+		AmountFormat: pb.CsvAmountFormat_CSV_AMOUNT_CENTS,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, resp.FileContent)
+	var found []string
+	for _, e := range resp.Errors {
+		found = append(found, e.ErrorCode+" "+e.Location)
+	}
+	assert.ElementsMatch(t, []string{
+		"INVALID_ROUTING row[2]/routing",
+		"MISSING_FIELD row[3]/name",
+		"INVALID_AMOUNT row[3]/amount",
+	}, found)
+
+	// Test case 4: Malformed CSV
+	_, err = service.ImportFromCSV(ctx, &pb.CsvImportRequest{CsvContent: []byte("name,routing\n\"JOAO,021000021\n")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestImportFromCSVRoundTrip(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+
+	importPayees := func(serviceClass string) *pb.CsvImportResponse {
+		resp, err := service.ImportFromCSV(ctx, &pb.CsvImportRequest{
+			CsvContent: []byte("name,routing,account,account_type,amount\n" +
+				"JOAO DA SILVA,021000021,111111,checking,7500.00\n" +
+				"MARIA SOUZA,021000021,222222,savings,1200.00\n"),
+			FileHeader: &pb.FileHeader{ImmediateDestination: "076401251", FileCreationDate: "261017", FileCreationTime: "0900"},
+			BatchHeader: &pb.BatchHeader{
+				ServiceClassCode:             serviceClass,
+				CompanyName:                  "EMPRESA EXEMPLO",
+				CompanyIdentification:        "0764012512",
+				OriginatingDfiIdentification: "07640125",
+				EffectiveEntryDate:           "261019",
+			},
+		})
+		assert.NoError(t, err)
+		return resp
+	}
+	options := &pb.ExportOptions{Pain: &pb.PainOptions{CompanyAccount: "9876543210"}}
+
+	// Test case 1: Payee credits validate and export as pain.001 credit transfers
+	// Test case 2: Payee debits validate and export as pain.008 direct debits
+	for serviceClass, formats := range map[string][2]string{
+		"":    {"PAIN001", "PAIN008"},
+		"225": {"PAIN008", "PAIN001"},
+	} {
+		resp := importPayees(serviceClass)
+		if !assert.NotNil(t, resp) || !assert.Empty(t, resp.Errors) {
+			continue
+		}
+		validation, err := service.ValidateFile(ctx, &pb.FileRequest{FileContent: resp.FileContent})
+		if assert.NoError(t, err) {
+			assert.True(t, validation.IsValid, "%v", validation.Errors)
+		}
+		exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: resp.FileContent, FormatName: formats[0], Options: options})
+		if assert.NoError(t, err, formats[0]) {
+			assert.Contains(t, string(exported.ExportedContent), "<CtrlSum>8700.00</CtrlSum>")
+		}
+		_, err = service.ExportFile(ctx, &pb.ExportRequest{FileContent: resp.FileContent, FormatName: formats[1], Options: options})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err), formats[1])
+	}
+}
+
+func TestImportFromJson(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, Format: pb.ExportFormat_JSON})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Test case 1: A JSON export imports back to the same file
+	resp, err := service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: exported.ExportedContent})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, string(content), string(resp.FileContent))
+
+	// Test case 2: Controls left out are computed, amounts may be cents
+	var doc nachajson.File
+	assert.NoError(t, json.Unmarshal(exported.ExportedContent, &doc))
+	doc.Control = nil
+	for i := range doc.Batches {
+		doc.Batches[i].Control = nil
+	}
+	doc.Batches[0].Entries[0].Amount = 800000
+	stripped, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	stripped = bytes.Replace(stripped, []byte(`"amount":"8000.00"`), []byte(`"amount":800000`), 1)
+
+	resp, err = service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: stripped})
+	if assert.NoError(t, err) {
+		file, err := service.loadFile("", resp.FileContent, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(800000), file.Batches[0].Entries[0].Amount)
+			assert.Equal(t, int64(920000), file.Batches[0].Control.TotalDebitAmount)
+			assert.Equal(t, 2, file.Control.BatchCount)
+		}
+	}
+
+	// Test case 3: Stale controls are rejected unless recomputed
+	doc = nachajson.File{}
+	assert.NoError(t, json.Unmarshal(exported.ExportedContent, &doc))
+	doc.Batches[0].Entries[0].Amount = 800000
+	tampered, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	_, err = service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: tampered})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: tampered, RecomputeControls: true})
+	assert.NoError(t, err)
+
+	// Test case 4: Unsupported schema versions and malformed dates
+	for _, invalid := range []string{
+		strings.Replace(string(exported.ExportedContent), `"schema_version": "1.0"`, `"schema_version": "2.0"`, 1),
+		strings.Replace(string(exported.ExportedContent), `"effective_entry_date": "2026-10-19"`, `"effective_entry_date": "261019"`, 1),
+	} {
+		_, err = service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: []byte(invalid)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestMoovJSON(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "MOOV_JSON"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Test case 1: A moov-io/ach export imports back to the same file
+	resp, err := service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: exported.ExportedContent})
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(content), string(resp.FileContent))
+	}
+
+	// Test case 2: A file written by moov-io/ach, without controls
+	moov := `{
+  "id": "3f2d23ee214",
+  "fileHeader": {
+    "id": "",
+    "priorityCode": "01",
+    "immediateDestination": "231380104",
+    "immediateOrigin": "121042882",
+    "fileCreationDate": "2026-10-17T00:00:00Z",
+    "fileCreationTime": "0930",
+    "fileIDModifier": "A",
+    "immediateDestinationName": "Federal Reserve Bank",
+    "immediateOriginName": "My Bank Name"
+  },
+  "batches": [{
+    "batchHeader": {
+      "serviceClassCode": 220,
+      "companyName": "Name on Account",
+      "companyIdentification": "121042882",
+      "standardEntryClassCode": "PPD",
+      "companyEntryDescription": "REG.SALARY",
+      "effectiveEntryDate": "261019",
+      "originatorStatusCode": 1,
+      "ODFIIdentification": "12104288",
+      "batchNumber": 1
+    },
+    "entryDetails": [{
+      "transactionCode": 22,
+      "RDFIIdentification": "23138010",
+      "checkDigit": "4",
+      "DFIAccountNumber": "123456789",
+      "amount": 100000000,
+      "individualName": "Receiver Account Name",
+      "traceNumber": "121042880000001",
+      "addenda05": [{"typeCode": "05", "paymentRelatedInformation": "Salary", "sequenceNumber": 1, "entryDetailSequenceNumber": 1}],
+      "category": "Forward"
+    }]
+  }]
+}`
+	resp, err = service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: []byte(moov)})
+	if assert.NoError(t, err) {
+		file, err := service.loadFile("", resp.FileContent, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "2026-10-17", file.Header.FileCreationDate.Format("2006-01-02"))
+			assert.Equal(t, "094", file.Header.RecordSize)
+			assert.Equal(t, "220", file.Batches[0].Header.ServiceClassCode)
+			assert.Equal(t, "0000001", file.Batches[0].Header.BatchNumber)
+			entry := file.Batches[0].Entries[0]
+			assert.Equal(t, "22", entry.TransactionCode)
+			assert.Equal(t, "1", entry.AddendaRecordIndicator)
+			assert.Equal(t, "Salary", entry.AddendaRecords[0].PaymentRelatedInformation)
+			assert.Equal(t, "0023138010", file.Batches[0].Control.EntryHash)
+			assert.Equal(t, 2, file.Control.EntryAddendaCount)
+		}
+	}
+
+	// Test case 3: IAT batches and malformed dates are rejected
+	for _, invalid := range []string{
+		strings.Replace(moov, `"batches": [`, `"IATBatches": [{}], "batches": [`, 1),
+		strings.Replace(moov, `"2026-10-17T00:00:00Z"`, `"17/10/2026"`, 1),
+	} {
+		_, err = service.ImportFromJson(ctx, &pb.ImportRequest{JsonContent: []byte(invalid)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+// cnabFields returns the field values of a CNAB 240 record by name
+func cnabFields(r *pb.CnabRecord) map[string]string {
+	values := make(map[string]string)
+	for _, f := range r.Fields {
+		values[f.Name] = f.Value
+	}
+	return values
+}
+
+func TestCNAB240(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "CNAB240"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	lines := strings.Split(strings.TrimSuffix(string(exported.ExportedContent), "\r\n"), "\r\n")
+
+	// Test case 1: Records, lotes and segments are returned with their fields
+	view, err := service.ViewCNAB240(ctx, &pb.CnabRequest{CnabContent: exported.ExportedContent})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, view.IsValid, "%v", view.Errors)
+	assert.Equal(t, "076", cnabFields(view.Header)["banco"])
+	if assert.Len(t, view.Lotes, 2) {
+		lote := view.Lotes[1]
+		assert.Equal(t, "41", cnabFields(lote.Header)["forma_lancamento"])
+		if assert.Len(t, lote.Details, 2) {
+			assert.Equal(t, "A", lote.Details[0].Segment)
+			assert.Equal(t, "segmento_a", lote.Details[0].Layout)
+			assert.Equal(t, "ACME SUPPLIES", cnabFields(lote.Details[0])["nome_favorecido"])
+			assert.Equal(t, "B", lote.Details[1].Segment)
+		}
+		assert.Equal(t, "510000", strings.TrimLeft(cnabFields(lote.Trailer)["somatoria_valores"], "0"))
+	}
+	assert.Equal(t, "000010", cnabFields(view.Trailer)["quantidade_registros"])
+
+	validation, err := service.ValidateCNAB240(ctx, &pb.CnabRequest{CnabContent: exported.ExportedContent})
+	if assert.NoError(t, err) {
+		assert.True(t, validation.IsValid)
+	}
+
+	// Test case 2: The remittance imports back to the credit entries
+	imported, err := service.ImportFromCNAB240(ctx, &pb.CnabImportRequest{CnabContent: exported.ExportedContent})
+	if assert.NoError(t, err) && assert.Empty(t, imported.Errors) {
+		file, err := service.loadFile("", imported.FileContent, "")
+		if assert.NoError(t, err) && assert.Len(t, file.Batches, 2) {
+			assert.Equal(t, int64(510000), file.Batches[1].Control.TotalCreditAmount)
+		}
+	}
+
+	// Test case 3: Issues are returned with their code and location
+	tampered := strings.Join(append(append([]string(nil), lines[:8]...), lines[8][:23]+"000000000000999999"+lines[8][41:], lines[9]), "\r\n")
+	validation, err = service.ValidateCNAB240(ctx, &pb.CnabRequest{CnabContent: []byte(tampered)})
+	if assert.NoError(t, err) {
+		assert.False(t, validation.IsValid)
+		if assert.Len(t, validation.Errors, 1) {
+			assert.Equal(t, "CONTROL_MISMATCH", validation.Errors[0].ErrorCode)
+			assert.Equal(t, "line[9]/somatoria_valores", validation.Errors[0].Location)
+		}
+	}
+	imported, err = service.ImportFromCNAB240(ctx, &pb.CnabImportRequest{CnabContent: []byte(tampered)})
+	if assert.NoError(t, err) {
+		assert.Empty(t, imported.FileContent)
+		if assert.Len(t, imported.Errors, 1) {
+			assert.Equal(t, "CONTROL_MISMATCH", imported.Errors[0].ErrorCode)
+			assert.Equal(t, "line[9]/somatoria_valores", imported.Errors[0].Location)
+		}
+	}
+
+	// Test case 4: Short records and missing trailers
+	validation, err = service.ValidateCNAB240(ctx, &pb.CnabRequest{CnabContent: []byte(lines[0][:200] + "\r\n" + lines[1])})
+	if assert.NoError(t, err) {
+		found := make([]string, 0, len(validation.Errors))
+		for _, e := range validation.Errors {
+			found = append(found, e.ErrorCode)
+		}
+		assert.Contains(t, found, "INVALID_LENGTH")
+		assert.Contains(t, found, "MISSING_RECORD")
+	}
+
+	// Test case 5: The company identification of the request reaches the importer
+	imported, err = service.ImportFromCNAB240(ctx, &pb.CnabImportRequest{CnabContent: exported.ExportedContent, CompanyIdentification: "1234567800"})
+	if assert.NoError(t, err) && assert.Empty(t, imported.Errors) {
+		file, err := service.loadFile("", imported.FileContent, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "1234567800", file.Batches[0].Header.CompanyIdentification)
+		}
+	}
+
+	// Test case 6: Content that is not CNAB 240
+	_, err = service.ValidateCNAB240(ctx, &pb.CnabRequest{CnabContent: content})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.ImportFromCNAB240(ctx, &pb.CnabImportRequest{CnabContent: content})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.ViewCNAB240(ctx, &pb.CnabRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCPA005(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: Request options reach the exporter
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{
+		FileContent: content,
+		FormatName:  "CPA005",
+		Options: &pb.ExportOptions{Cpa: &pb.CpaOptions{
+			DestinationDataCentre: "86900",
+			FileCreationNumber:    12,
+			ReturnAccount:         "9876543",
+		}},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	records := strings.Split(strings.TrimSuffix(string(exported.ExportedContent), "\r\n"), "\r\n")
+	if !assert.Len(t, records, 5) {
+		return
+	}
+	assert.Equal(t, "0012", records[0][20:24])
+	assert.Equal(t, "86900", records[0][30:35])
+	assert.Equal(t, "9876543", strings.TrimSpace(records[3][24+178:24+190]))
+
+	validation, err := service.ValidateCPA005(ctx, &pb.CpaRequest{CpaContent: exported.ExportedContent})
+	if assert.NoError(t, err) {
+		assert.True(t, validation.IsValid, "%v", validation.Errors)
+	}
+
+	// Test case 2: The file imports back with the request headers
+	imported, err := service.ImportFromCPA005(ctx, &pb.CpaImportRequest{
+		CpaContent:               exported.ExportedContent,
+		ImmediateDestination:     "021000021",
+		ImmediateDestinationName: "BANCO DO BRASIL",
+	})
+	if assert.NoError(t, err) && assert.Empty(t, imported.Errors) {
+		file, err := service.loadFile("", imported.FileContent, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "021000021", strings.TrimSpace(file.Header.ImmediateDestination))
+			assert.Equal(t, "BANCO DO BRASIL", strings.TrimSpace(file.Header.DestinationName))
+			assert.Len(t, file.Batches, 3)
+		}
+	}
+
+	// Test case 3: Issues are returned with their code and location
+	tampered := append([]string(nil), records...)
+	tampered[4] = tampered[4][:46] + "00000001410001" + tampered[4][60:]
+	validation, err = service.ValidateCPA005(ctx, &pb.CpaRequest{CpaContent: []byte(strings.Join(tampered, "\n"))})
+	if assert.NoError(t, err) {
+		assert.False(t, validation.IsValid)
+		if assert.Len(t, validation.Errors, 1) {
+			assert.Equal(t, "CONTROL_MISMATCH", validation.Errors[0].ErrorCode)
+			assert.Equal(t, "record[5]/total_credit_value", validation.Errors[0].Location)
+		}
+	}
+	imported, err = service.ImportFromCPA005(ctx, &pb.CpaImportRequest{CpaContent: []byte(strings.Join(tampered, "\n"))})
+	if assert.NoError(t, err) {
+		assert.Empty(t, imported.FileContent)
+		assert.Len(t, imported.Errors, 1)
+	}
+
+	tampered = append([]string(nil), records...)
+	tampered[3] = tampered[3][:24+19] + "102100002" + tampered[3][24+28:]
+	validation, err = service.ValidateCPA005(ctx, &pb.CpaRequest{CpaContent: []byte(strings.Join(tampered, "\n"))})
+	if assert.NoError(t, err) && assert.Len(t, validation.Errors, 1) {
+		assert.Equal(t, "INVALID_FIELD", validation.Errors[0].ErrorCode)
+		assert.Equal(t, "record[4]/segment[1]/institution_id", validation.Errors[0].Location)
+	}
+
+	// Test case 4: Content that is not CPA 005
+	_, err = service.ImportFromCPA005(ctx, &pb.CpaImportRequest{CpaContent: content})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.ValidateCPA005(ctx, &pb.CpaRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestXLSX(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	// Test case 1: The workbook imports back to the same file
+	exported, err := service.ExportFile(ctx, &pb.ExportRequest{FileContent: content, FormatName: "XLSX"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", exported.FileType)
+	imported, err := service.ImportFromXLSX(ctx, &pb.XlsxImportRequest{XlsxContent: exported.ExportedContent})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Empty(t, imported.Errors)
+	assert.Equal(t, string(content), string(imported.FileContent))
+
+	// Test case 2: Import errors are returned with their cell
+	workbook, err := excelize.OpenReader(bytes.NewReader(exported.ExportedContent))
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer workbook.Close()
+	assert.NoError(t, workbook.SetCellStr("Entries", "F3", "12.345"))
+	var tampered bytes.Buffer
+	_, err = workbook.WriteTo(&tampered)
+	assert.NoError(t, err)
+	imported, err = service.ImportFromXLSX(ctx, &pb.XlsxImportRequest{XlsxContent: tampered.Bytes()})
+	if assert.NoError(t, err) && assert.NotEmpty(t, imported.Errors) {
+		assert.Empty(t, imported.FileContent)
+		assert.Equal(t, "INVALID_AMOUNT", imported.Errors[0].ErrorCode)
+		assert.Equal(t, "Entries!F3", imported.Errors[0].Location)
+	}
+
+	// Test case 3: Content that is not a workbook of this layout
+	_, err = service.ImportFromXLSX(ctx, &pb.XlsxImportRequest{XlsxContent: content})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.ImportFromXLSX(ctx, &pb.XlsxImportRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRemittanceAdvice(t *testing.T) {
+	service := NewNachaService()
+	ctx := context.Background()
+	content := buildTestFile(t)
+
+	names := func(data []byte) []string {
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		var names []string
+		for _, f := range archive.File {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+
+	// Test case 1: A PDF advice for each payment by default
+	resp, err := service.GenerateRemittanceAdvice(ctx, &pb.RemittanceAdviceRequest{FileContent: content})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, int32(4), resp.AdviceCount)
+	assert.Contains(t, names(resp.ZipContent), "076401250000004.pdf")
+
+	// Test case 2: The format and options of the request
+	resp, err = service.GenerateRemittanceAdvice(ctx, &pb.RemittanceAdviceRequest{
+		FileContent: content,
+		Format:      pb.RemittanceFormat_REMITTANCE_HTML,
+		Options:     &pb.ExportOptions{BatchNumbers: []string{"1"}, AmountFormat: pb.AmountFormat_AMOUNT_DECIMAL},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int32(3), resp.AdviceCount)
+		assert.ElementsMatch(t, []string{"076401250000001.html", "076401250000002.html", "076401250000003.html"}, names(resp.ZipContent))
+	}
+
+	// Test case 3: Invalid requests
+	_, err = service.GenerateRemittanceAdvice(ctx, &pb.RemittanceAdviceRequest{FileContent: content, Format: pb.RemittanceFormat(9)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.GenerateRemittanceAdvice(ctx, &pb.RemittanceAdviceRequest{FileContent: content, Options: &pb.ExportOptions{Locale: "xx"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = service.GenerateRemittanceAdvice(ctx, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
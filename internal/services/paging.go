@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nacha-service/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// viewFields lists the paths accepted in a ViewFile field mask
+var viewFields = []string{
+	"file_header",
+	"file_control",
+	"summary",
+	"batches.header",
+	"batches.control",
+	"batches.entries",
+}
+
+// viewMask tracks which parts of a FileDetailsResponse were requested
+type viewMask map[string]bool
+
+// newViewMask builds a view mask from a field mask. An empty mask selects
+// every field and "batches" selects every batch field.
+func newViewMask(fm *fieldmaskpb.FieldMask) (viewMask, error) {
+	mask := make(viewMask)
+	if fm == nil || len(fm.GetPaths()) == 0 {
+		for _, field := range viewFields {
+			mask[field] = true
+		}
+		return mask, nil
+	}
+
+	for _, path := range fm.GetPaths() {
+		path = strings.TrimSpace(path)
+		if path == "batches" {
+			mask["batches.header"] = true
+			mask["batches.control"] = true
+			mask["batches.entries"] = true
+			continue
+		}
+		if !models.ContainsFold(viewFields, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid view mask path: %s", path)
+		}
+		mask[strings.ToLower(path)] = true
+	}
+	return mask, nil
+}
+
+func (m viewMask) has(field string) bool {
+	return m[field]
+}
+
+// hasBatches reports whether any batch level field was requested
+func (m viewMask) hasBatches() bool {
+	return m["batches.header"] || m["batches.control"] || m["batches.entries"]
+}
+
+// parsePageToken decodes a page token into an offset
+func parsePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid page token: %s", token)
+	}
+	return offset, nil
+}
+
+// parseEntryPageToken decodes an entry page token into the index of the batch
+// it pages and the offset in that batch. The batch is -1 without a token.
+func parseEntryPageToken(token string) (batch, offset int, err error) {
+	if token == "" {
+		return -1, 0, nil
+	}
+	b, o, ok := strings.Cut(token, ":")
+	if ok {
+		batch, err = strconv.Atoi(b)
+	}
+	if ok && err == nil {
+		offset, err = strconv.Atoi(o)
+	}
+	if !ok || err != nil || batch < 0 || offset < 0 {
+		return 0, 0, status.Errorf(codes.InvalidArgument, "invalid entry page token: %s", token)
+	}
+	return batch, offset, nil
+}
+
+// entryPageToken encodes the offset of the next entry page of a batch. The
+// token carries the batch index so that it only moves that batch's entries.
+func entryPageToken(batch int, offset string) string {
+	if offset == "" {
+		return ""
+	}
+	return fmt.Sprintf("%d:%s", batch, offset)
+}
+
+// pageBounds returns the slice bounds of a page and the token of the next one.
+// A page size of zero selects everything from the offset on.
+func pageBounds(total, offset, pageSize int) (start, end int, nextToken string) {
+	start = offset
+	if start > total {
+		start = total
+	}
+	end = total
+	if pageSize > 0 && start+pageSize < total {
+		end = start + pageSize
+		nextToken = strconv.Itoa(end)
+	}
+	return start, end, nextToken
+}
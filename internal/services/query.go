@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/summary"
+	"github.com/nacha-service/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// entryRef points at an entry together with the batch it belongs to
+type entryRef struct {
+	batch      *models.Batch
+	entry      *models.EntryDetail
+	batchIndex int
+	entryIndex int
+}
+
+// QueryEntries returns the entries of a NACHA file that match the given filter
+func (s *NachaService) QueryEntries(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.PageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page size: %d", req.PageSize)
+	}
+
+	offset, err := parsePageToken(req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := req.Filter
+	if filter == nil {
+		filter = &pb.EntryFilter{}
+	}
+	if filter.MinAmount < 0 || filter.MaxAmount < 0 ||
+		(filter.MaxAmount > 0 && filter.MinAmount > filter.MaxAmount) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount range: %d to %d", filter.MinAmount, filter.MaxAmount)
+	}
+
+	// Parse NACHA file
+	file, err := s.loadFile(req.FileId, req.FileContent, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect matching entries
+	var matches []entryRef
+	for i := range file.Batches {
+		batch := &file.Batches[i]
+		if !matchBatch(&batch.Header, filter) {
+			continue
+		}
+		for j := range batch.Entries {
+			if matchEntry(&batch.Entries[j], filter) {
+				matches = append(matches, entryRef{
+					batch:      batch,
+					entry:      &batch.Entries[j],
+					batchIndex: i,
+					entryIndex: j,
+				})
+			}
+		}
+	}
+
+	if err := sortEntries(matches, req.SortBy, req.Descending); err != nil {
+		return nil, err
+	}
+
+	// Apply pagination
+	start, end, nextToken := pageBounds(len(matches), offset, int(req.PageSize))
+	response := &pb.QueryResponse{
+		TotalMatches:  int32(len(matches)),
+		NextPageToken: nextToken,
+	}
+
+	for _, m := range matches[start:end] {
+		response.Matches = append(response.Matches, &pb.EntryMatch{
+			Entry:       convertEntry(m.entry),
+			BatchHeader: convertBatchHeader(&m.batch.Header),
+			BatchIndex:  int32(m.batchIndex),
+			EntryIndex:  int32(m.entryIndex),
+		})
+	}
+
+	return response, nil
+}
+
+// matchBatch checks the batch level criteria of a filter
+func matchBatch(header *models.BatchHeader, filter *pb.EntryFilter) bool {
+	if len(filter.StandardEntryClasses) > 0 && !models.ContainsFold(filter.StandardEntryClasses, header.StandardEntryClass) {
+		return false
+	}
+	if filter.CompanyName != "" &&
+		!strings.Contains(strings.ToUpper(header.CompanyName), strings.ToUpper(strings.TrimSpace(filter.CompanyName))) {
+		return false
+	}
+	if filter.CompanyIdentification != "" && header.CompanyIdentification != strings.TrimSpace(filter.CompanyIdentification) {
+		return false
+	}
+	return true
+}
+
+// matchEntry checks the entry level criteria of a filter
+func matchEntry(entry *models.EntryDetail, filter *pb.EntryFilter) bool {
+	if filter.MinAmount > 0 && entry.Amount < filter.MinAmount {
+		return false
+	}
+	if filter.MaxAmount > 0 && entry.Amount > filter.MaxAmount {
+		return false
+	}
+	if len(filter.TransactionCodes) > 0 && !models.ContainsFold(filter.TransactionCodes, entry.TransactionCode) {
+		return false
+	}
+	switch filter.Direction {
+	case pb.EntryDirection_DIRECTION_DEBIT:
+		if summary.Direction(entry.TransactionCode) != summary.DirectionDebit {
+			return false
+		}
+	case pb.EntryDirection_DIRECTION_CREDIT:
+		if summary.Direction(entry.TransactionCode) != summary.DirectionCredit {
+			return false
+		}
+	}
+	if filter.ReceivingDfi != "" && !strings.HasPrefix(entry.ReceivingDFI, strings.TrimSpace(filter.ReceivingDfi)) {
+		return false
+	}
+	if filter.DfiAccountNumber != "" && entry.DFIAccountNumber != strings.TrimSpace(filter.DfiAccountNumber) {
+		return false
+	}
+	if filter.IndividualName != "" && !matchName(entry.IndividualName, filter.IndividualName, filter.FuzzyName) {
+		return false
+	}
+	return true
+}
+
+// sortEntries orders matches by the requested field, keeping file order for ties
+func sortEntries(matches []entryRef, sortBy string, descending bool) error {
+	var less func(a, b entryRef) bool
+
+	switch strings.ToLower(sortBy) {
+	case "":
+		// No field given: keep file order
+		if descending {
+			for i, j := 0, len(matches)-1; i < j; i, j = i+1, j-1 {
+				matches[i], matches[j] = matches[j], matches[i]
+			}
+		}
+		return nil
+	case "amount":
+		less = func(a, b entryRef) bool { return a.entry.Amount < b.entry.Amount }
+	case "trace_number":
+		less = func(a, b entryRef) bool { return a.entry.TraceNumber < b.entry.TraceNumber }
+	case "individual_name":
+		less = func(a, b entryRef) bool { return a.entry.IndividualName < b.entry.IndividualName }
+	case "receiving_dfi":
+		less = func(a, b entryRef) bool { return a.entry.ReceivingDFI < b.entry.ReceivingDFI }
+	case "batch_number":
+		less = func(a, b entryRef) bool { return a.batch.Header.BatchNumber < b.batch.Header.BatchNumber }
+	default:
+		return status.Errorf(codes.InvalidArgument, "invalid sort field: %s", sortBy)
+	}
+
+	if descending {
+		asc := less
+		less = func(a, b entryRef) bool { return asc(b, a) }
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return less(matches[i], matches[j])
+	})
+	return nil
+}
+
+// matchName matches an individual name against a query, optionally allowing typos
+func matchName(name, query string, fuzzy bool) bool {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	query = strings.ToUpper(strings.TrimSpace(query))
+
+	if strings.Contains(name, query) {
+		return true
+	}
+	if !fuzzy {
+		return false
+	}
+
+	// Allow roughly one edit per four characters of the query
+	maxDistance := len(query) / 4
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+
+	if levenshtein(name, query) <= maxDistance {
+		return true
+	}
+
+	// Compare against each run of words with the same word count as the query
+	words := strings.Fields(name)
+	size := len(strings.Fields(query))
+	for i := 0; i+size <= len(words); i++ {
+		if levenshtein(strings.Join(words[i:i+size], " "), query) <= maxDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
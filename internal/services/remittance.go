@@ -0,0 +1,61 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/exporters"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GenerateRemittanceAdvice returns a zip with a PDF or HTML remittance advice
+// for each payment of a NACHA file, named after its trace number
+func (s *NachaService) GenerateRemittanceAdvice(ctx context.Context, req *pb.RemittanceAdviceRequest) (*pb.RemittanceAdviceResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+
+	var format string
+	switch req.Format {
+	case pb.RemittanceFormat_REMITTANCE_PDF:
+		format = exporters.RemittancePDF
+	case pb.RemittanceFormat_REMITTANCE_HTML:
+		format = exporters.RemittanceHTML
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid remittance format: %v", req.Format)
+	}
+	exporter, err := exporters.NewRemittanceExporter(format)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to get exporter: %v", err)
+	}
+	options, err := convertExportOptions(req.Options)
+	if err != nil {
+		return nil, err
+	}
+	exporter.SetOptions(options)
+
+	// Parse NACHA file
+	file, err := s.loadFile(req.FileId, req.FileContent, "")
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := exporter.Export(file)
+	if err != nil {
+		return nil, exportError(err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read remittance advices: %v", err)
+	}
+
+	return &pb.RemittanceAdviceResponse{
+		ZipContent:  content,
+		AdviceCount: int32(len(archive.File)),
+		Message:     fmt.Sprintf("Generated %d remittance advices", len(archive.File)),
+	}, nil
+}
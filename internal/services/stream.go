@@ -0,0 +1,131 @@
+package services
+
+import (
+	pb "github.com/nacha-service/api/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Export chunk sizes in bytes
+const (
+	defaultChunkSize = 64 << 10 // 64KB
+	maxChunkSize     = 1 << 20  // 1MB, well below the default gRPC message limit
+)
+
+// ExportStream exports a NACHA file and sends the output in chunks as the
+// exporter writes it
+func (s *NachaService) ExportStream(req *pb.ExportRequest, stream pb.NachaService_ExportStreamServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.ChunkSize < 0 || req.ChunkSize > maxChunkSize {
+		return status.Errorf(codes.InvalidArgument, "invalid chunk size: %d (must be between 0 and %d)", req.ChunkSize, maxChunkSize)
+	}
+
+	// Parse NACHA file
+	file, err := s.loadFile(req.FileId, req.FileContent, "")
+	if err != nil {
+		return err
+	}
+
+	// Get exporter for the requested format
+	exporter, _, err := exporterFor(req)
+	if err != nil {
+		return err
+	}
+
+	chunkSize := int(req.ChunkSize)
+	if chunkSize == 0 {
+		chunkSize = defaultChunkSize
+	}
+
+	var entryCount int
+	for _, batch := range file.Batches {
+		entryCount += len(batch.Entries)
+	}
+
+	writer := &chunkWriter{
+		stream: stream,
+		size:   chunkSize,
+		first: &pb.ExportChunk{
+			FileType:     exporter.GetContentType(),
+			TotalBatches: int32(len(file.Batches)),
+			TotalEntries: int32(entryCount),
+		},
+	}
+
+	if err := exporter.ExportTo(writer, file); err != nil {
+		if writer.err != nil {
+			return writer.err
+		}
+		return exportError(err)
+	}
+
+	return writer.Close()
+}
+
+// chunkWriter sends everything written to it as ExportChunk messages of a
+// fixed size
+type chunkWriter struct {
+	stream   pb.NachaService_ExportStreamServer
+	size     int
+	buf      []byte
+	offset   int64
+	sequence int32
+	first    *pb.ExportChunk
+	err      error
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= w.size {
+		if err := w.send(w.buf[:w.size], false); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[w.size:]
+	}
+	return len(p), nil
+}
+
+// Close sends the remaining output as the final chunk
+func (w *chunkWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.send(w.buf, true)
+}
+
+// send writes a single chunk to the stream
+func (w *chunkWriter) send(data []byte, last bool) error {
+	if err := w.stream.Context().Err(); err != nil {
+		w.err = status.FromContextError(err).Err()
+		return w.err
+	}
+
+	chunk := w.first
+	if chunk == nil {
+		chunk = &pb.ExportChunk{}
+	}
+	w.first = nil
+
+	chunk.Data = data
+	chunk.Offset = w.offset
+	chunk.Sequence = w.sequence
+	chunk.Last = last
+	if last {
+		chunk.TotalSize = w.offset + int64(len(data))
+	}
+
+	if err := w.stream.Send(chunk); err != nil {
+		w.err = err
+		return err
+	}
+
+	w.offset += int64(len(data))
+	w.sequence++
+	return nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/summary"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SummarizeFile returns typed aggregates of a NACHA file
+func (s *NachaService) SummarizeFile(ctx context.Context, req *pb.SummaryRequest) (*pb.SummaryResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if req.TopEntries < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid top entries: %d", req.TopEntries)
+	}
+
+	// Parse NACHA file
+	file, err := s.loadFile(req.FileId, req.FileContent, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sum := summary.Summarize(file, int(req.TopEntries))
+
+	response := &pb.SummaryResponse{
+		Totals:            convertAggregate(&sum.Totals),
+		BySecCode:         convertAggregates(sum.BySECCode),
+		ByCompany:         convertAggregates(sum.ByCompany),
+		ByReceivingDfi:    convertAggregates(sum.ByReceivingDFI),
+		ByTransactionCode: convertAggregates(sum.ByTransactionCode),
+		ByEffectiveDate:   convertAggregates(sum.ByEffectiveDate),
+		ByDirection:       convertAggregates(sum.ByDirection),
+	}
+
+	for _, largest := range sum.LargestEntries {
+		batch := &file.Batches[largest.BatchIndex]
+		response.LargestEntries = append(response.LargestEntries, &pb.EntryMatch{
+			Entry:       convertEntry(&batch.Entries[largest.EntryIndex]),
+			BatchHeader: convertBatchHeader(&batch.Header),
+			BatchIndex:  int32(largest.BatchIndex),
+			EntryIndex:  int32(largest.EntryIndex),
+		})
+	}
+
+	return response, nil
+}
+
+func convertAggregates(aggregates []summary.Aggregate) []*pb.Aggregate {
+	result := make([]*pb.Aggregate, len(aggregates))
+	for i := range aggregates {
+		result[i] = convertAggregate(&aggregates[i])
+	}
+	return result
+}
+
+func convertAggregate(a *summary.Aggregate) *pb.Aggregate {
+	return &pb.Aggregate{
+		Key:                a.Key,
+		Label:              a.Label,
+		EntryCount:         int32(a.EntryCount),
+		AddendaCount:       int32(a.AddendaCount),
+		EntriesWithAddenda: int32(a.EntriesWithAddenda),
+		AddendaUsageRate:   a.AddendaUsageRate,
+		DebitCount:         int32(a.DebitCount),
+		DebitAmount:        a.DebitAmount,
+		CreditCount:        int32(a.CreditCount),
+		CreditAmount:       a.CreditAmount,
+	}
+}
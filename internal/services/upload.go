@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"io"
+	"os"
+
+	pb "github.com/nacha-service/api/proto"
+	"github.com/nacha-service/internal/cache"
+	"github.com/nacha-service/pkg/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UploadFile parses a NACHA file once and stores it in the file cache
+func (s *NachaService) UploadFile(ctx context.Context, req *pb.UploadRequest) (*pb.UploadResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "request cannot be nil")
+	}
+	if len(req.FileContent) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "file content cannot be empty")
+	}
+
+	entry, err := s.cache.Put(req.FileContent)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to store file: %v", err)
+	}
+
+	return convertUpload(entry), nil
+}
+
+// UploadStream receives a NACHA file in chunks, parsing it as it arrives, and
+// stores it in the file cache
+func (s *NachaService) UploadStream(stream pb.NachaService_UploadStreamServer) error {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(receiveChunks(stream, pw))
+	}()
+
+	entry, err := s.cache.PutReader(pr)
+	// Unblock the receiver if parsing stopped before the end of the stream
+	pr.Close()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to store file: %v", err)
+	}
+
+	return stream.SendAndClose(convertUpload(entry))
+}
+
+// receiveChunks copies the uploaded chunks to w until the client closes the stream
+func receiveChunks(stream pb.NachaService_UploadStreamServer, w io.Writer) error {
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// convertUpload describes a cached file
+func convertUpload(entry *cache.Entry) *pb.UploadResponse {
+	var entryCount int
+	for _, batch := range entry.File.Batches {
+		entryCount += len(batch.Entries)
+	}
+
+	return &pb.UploadResponse{
+		FileId:     entry.ID,
+		Size:       entry.Size,
+		ExpiresAt:  entry.ExpiresAt.Unix(),
+		BatchCount: int32(len(entry.File.Batches)),
+		EntryCount: int32(entryCount),
+	}
+}
+
+// loadFile resolves the NACHA file a request refers to, either by uploaded
+// file ID, inline content or a path on the server. Files from the cache are
+// shared and must not be modified.
+func (s *NachaService) loadFile(fileID string, content []byte, path string) (*models.NachaFile, error) {
+	var file *models.NachaFile
+
+	switch {
+	case fileID != "":
+		entry, ok := s.cache.Get(fileID)
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "file not found or expired: %s", fileID)
+		}
+		file = entry.File
+	case content != nil:
+		file = models.FromBytes(content)
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to read file: %v", err)
+		}
+		file = models.FromBytes(data)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "either file_id, file_content or file_path must be provided")
+	}
+
+	if file == nil {
+		return nil, status.Error(codes.InvalidArgument, "failed to parse NACHA file")
+	}
+	return file, nil
+}
@@ -0,0 +1,190 @@
+// Package summary computes aggregate totals over the entries of a NACHA file
+package summary
+
+import (
+	"sort"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// DefaultTopEntries is the number of largest entries reported when none is requested
+const DefaultTopEntries = 10
+
+// Direction keys used in the ByDirection aggregates
+const (
+	DirectionDebit  = "DEBIT"
+	DirectionCredit = "CREDIT"
+	DirectionOther  = "OTHER"
+)
+
+// Aggregate holds counts and amounts for a group of entries
+type Aggregate struct {
+	Key                string  `json:"key"`
+	Label              string  `json:"label,omitempty"`
+	EntryCount         int     `json:"entry_count"`
+	AddendaCount       int     `json:"addenda_count"`
+	EntriesWithAddenda int     `json:"entries_with_addenda"`
+	AddendaUsageRate   float64 `json:"addenda_usage_rate"`
+	DebitCount         int     `json:"debit_count"`
+	DebitAmount        int64   `json:"debit_amount"`
+	CreditCount        int     `json:"credit_count"`
+	CreditAmount       int64   `json:"credit_amount"`
+}
+
+// EntrySummary identifies a single entry in the largest entries list
+type EntrySummary struct {
+	BatchIndex         int    `json:"batch_index"`
+	EntryIndex         int    `json:"entry_index"`
+	BatchNumber        string `json:"batch_number"`
+	CompanyName        string `json:"company_name"`
+	StandardEntryClass string `json:"standard_entry_class"`
+	TransactionCode    string `json:"transaction_code"`
+	ReceivingDFI       string `json:"receiving_dfi"`
+	IndividualName     string `json:"individual_name"`
+	TraceNumber        string `json:"trace_number"`
+	Amount             int64  `json:"amount"`
+}
+
+// Summary is the complete set of aggregates for a file
+type Summary struct {
+	Totals            Aggregate      `json:"totals"`
+	BySECCode         []Aggregate    `json:"by_sec_code"`
+	ByCompany         []Aggregate    `json:"by_company"`
+	ByReceivingDFI    []Aggregate    `json:"by_receiving_dfi"`
+	ByTransactionCode []Aggregate    `json:"by_transaction_code"`
+	ByEffectiveDate   []Aggregate    `json:"by_effective_date"`
+	ByDirection       []Aggregate    `json:"by_direction"`
+	LargestEntries    []EntrySummary `json:"largest_entries"`
+}
+
+// Direction classifies a transaction code the same way batch control totals do
+func Direction(transactionCode string) string {
+	switch {
+	case models.IsDebit(transactionCode):
+		return DirectionDebit
+	case models.IsCredit(transactionCode):
+		return DirectionCredit
+	default:
+		return DirectionOther
+	}
+}
+
+// Summarize computes the aggregates of a file. topEntries limits the largest
+// entries list; zero or less uses DefaultTopEntries.
+func Summarize(file *models.NachaFile, topEntries int) *Summary {
+	if topEntries <= 0 {
+		topEntries = DefaultTopEntries
+	}
+
+	s := &Summary{Totals: Aggregate{Key: "TOTAL"}}
+	if file == nil {
+		return s
+	}
+
+	bySEC := newGroup()
+	byCompany := newGroup()
+	byRDFI := newGroup()
+	byCode := newGroup()
+	byDate := newGroup()
+	byDirection := newGroup()
+	var entries []EntrySummary
+
+	for i, batch := range file.Batches {
+		for j, entry := range batch.Entries {
+			s.Totals.add(&entry)
+			bySEC.get(batch.Header.StandardEntryClass, "").add(&entry)
+			byCompany.get(batch.Header.CompanyIdentification, batch.Header.CompanyName).add(&entry)
+			byRDFI.get(entry.ReceivingDFI, "").add(&entry)
+			byCode.get(entry.TransactionCode, "").add(&entry)
+			byDate.get(batch.Header.EffectiveEntryDate, "").add(&entry)
+			byDirection.get(Direction(entry.TransactionCode), "").add(&entry)
+
+			entries = append(entries, EntrySummary{
+				BatchIndex:         i,
+				EntryIndex:         j,
+				BatchNumber:        batch.Header.BatchNumber,
+				CompanyName:        batch.Header.CompanyName,
+				StandardEntryClass: batch.Header.StandardEntryClass,
+				TransactionCode:    entry.TransactionCode,
+				ReceivingDFI:       entry.ReceivingDFI,
+				IndividualName:     entry.IndividualName,
+				TraceNumber:        entry.TraceNumber,
+				Amount:             entry.Amount,
+			})
+		}
+	}
+	s.Totals.finish()
+
+	s.BySECCode = bySEC.list()
+	s.ByCompany = byCompany.list()
+	s.ByReceivingDFI = byRDFI.list()
+	s.ByTransactionCode = byCode.list()
+	s.ByEffectiveDate = byDate.list()
+	s.ByDirection = byDirection.list()
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Amount > entries[j].Amount
+	})
+	if len(entries) > topEntries {
+		entries = entries[:topEntries]
+	}
+	s.LargestEntries = entries
+
+	return s
+}
+
+// add accumulates an entry into the aggregate
+func (a *Aggregate) add(entry *models.EntryDetail) {
+	a.EntryCount++
+	a.AddendaCount += len(entry.AddendaRecords)
+	if len(entry.AddendaRecords) > 0 {
+		a.EntriesWithAddenda++
+	}
+
+	switch Direction(entry.TransactionCode) {
+	case DirectionDebit:
+		a.DebitCount++
+		a.DebitAmount += entry.Amount
+	case DirectionCredit:
+		a.CreditCount++
+		a.CreditAmount += entry.Amount
+	}
+}
+
+// finish computes the derived fields of the aggregate
+func (a *Aggregate) finish() {
+	if a.EntryCount > 0 {
+		a.AddendaUsageRate = float64(a.EntriesWithAddenda) / float64(a.EntryCount)
+	}
+}
+
+// group collects aggregates by key
+type group struct {
+	items map[string]*Aggregate
+}
+
+func newGroup() *group {
+	return &group{items: make(map[string]*Aggregate)}
+}
+
+func (g *group) get(key, label string) *Aggregate {
+	a, ok := g.items[key]
+	if !ok {
+		a = &Aggregate{Key: key, Label: label}
+		g.items[key] = a
+	}
+	return a
+}
+
+// list returns the aggregates sorted by key
+func (g *group) list() []Aggregate {
+	result := make([]Aggregate, 0, len(g.items))
+	for _, a := range g.items {
+		a.finish()
+		result = append(result, *a)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,111 @@
+package summary
+
+import (
+	"testing"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirection(t *testing.T) {
+	// Test case 1: The second digit gives the direction
+	for code, direction := range map[string]string{
+		"22": DirectionCredit, "23": DirectionCredit, "24": DirectionCredit,
+		"27": DirectionDebit, "28": DirectionDebit, "29": DirectionDebit,
+		"32": DirectionCredit, "33": DirectionCredit, "37": DirectionDebit, "38": DirectionDebit,
+		"42": DirectionCredit, "47": DirectionDebit,
+		"52": DirectionCredit, "55": DirectionDebit,
+	} {
+		assert.Equal(t, direction, Direction(code), code)
+	}
+
+	// Test case 2: Malformed codes have no direction
+	for _, code := range []string{"", "2", "222", "X7", "2X", "20", " 7"} {
+		assert.Equal(t, DirectionOther, Direction(code), code)
+	}
+}
+
+// testFile returns a file with a mixed batch, an empty batch and a batch
+// holding a prenote
+func testFile() *models.NachaFile {
+	return &models.NachaFile{
+		Batches: []models.Batch{
+			{
+				Header: models.BatchHeader{CompanyName: "EMPRESA EXEMPLO", CompanyIdentification: "0764012512", StandardEntryClass: "PPD", EffectiveEntryDate: "261019", BatchNumber: "0000001"},
+				Entries: []models.EntryDetail{
+					{TransactionCode: "27", ReceivingDFI: "02100002", Amount: 750000, IndividualName: "JOAO DA SILVA", TraceNumber: "076401250000001"},
+					{TransactionCode: "22", ReceivingDFI: "07640125", Amount: 900000, IndividualName: "PEDRO ALVARES", TraceNumber: "076401250000002",
+						AddendaRecords: []models.AddendaRecord{{AddendaTypeCode: "05"}, {AddendaTypeCode: "05"}}},
+				},
+			},
+			{
+				Header: models.BatchHeader{CompanyName: "VAZIA", CompanyIdentification: "9999999999", StandardEntryClass: "CCD", EffectiveEntryDate: "261020", BatchNumber: "0000002"},
+			},
+			{
+				Header: models.BatchHeader{CompanyName: "OUTRA EMPRESA", CompanyIdentification: "1234567890", StandardEntryClass: "CCD", EffectiveEntryDate: "261020", BatchNumber: "0000003"},
+				Entries: []models.EntryDetail{
+					{TransactionCode: "33", ReceivingDFI: "02100002", IndividualName: "ACME SUPPLIES", TraceNumber: "076401250000003"},
+				},
+			},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	s := Summarize(testFile(), 0)
+
+	// Test case 1: Totals by direction
+	assert.Equal(t, 3, s.Totals.EntryCount)
+	assert.Equal(t, 1, s.Totals.DebitCount)
+	assert.Equal(t, int64(750000), s.Totals.DebitAmount)
+	assert.Equal(t, 2, s.Totals.CreditCount)
+	assert.Equal(t, int64(900000), s.Totals.CreditAmount)
+	assert.Equal(t, 2, s.Totals.AddendaCount)
+	assert.Equal(t, 1, s.Totals.EntriesWithAddenda)
+	assert.InDelta(t, 1.0/3, s.Totals.AddendaUsageRate, 0.0001)
+
+	// Test case 2: Empty batches add no groups
+	companies := make([]string, len(s.ByCompany))
+	for i, a := range s.ByCompany {
+		companies[i] = a.Key
+	}
+	assert.Equal(t, []string{"0764012512", "1234567890"}, companies)
+	assert.Len(t, s.ByEffectiveDate, 2)
+
+	// Test case 3: Prenotes count with their zero amount
+	if assert.Len(t, s.ByTransactionCode, 3) {
+		prenote := s.ByTransactionCode[2]
+		assert.Equal(t, "33", prenote.Key)
+		assert.Equal(t, 1, prenote.CreditCount)
+		assert.Zero(t, prenote.CreditAmount)
+	}
+	if assert.Len(t, s.ByDirection, 2) {
+		assert.Equal(t, Aggregate{Key: DirectionCredit, EntryCount: 2, AddendaCount: 2, EntriesWithAddenda: 1, AddendaUsageRate: 0.5, CreditCount: 2, CreditAmount: 900000}, s.ByDirection[0])
+		assert.Equal(t, DirectionDebit, s.ByDirection[1].Key)
+	}
+
+	// Test case 4: Largest entries, limited when requested
+	if assert.Len(t, s.LargestEntries, 3) {
+		assert.Equal(t, "PEDRO ALVARES", s.LargestEntries[0].IndividualName)
+		assert.Equal(t, 0, s.LargestEntries[0].BatchIndex)
+		assert.Equal(t, 1, s.LargestEntries[0].EntryIndex)
+		assert.Equal(t, 2, s.LargestEntries[2].BatchIndex)
+	}
+	assert.Len(t, Summarize(testFile(), 1).LargestEntries, 1)
+
+	// Test case 5: Malformed transaction codes are counted in neither direction
+	file := testFile()
+	file.Batches[0].Entries[0].TransactionCode = "7"
+	s = Summarize(file, 0)
+	assert.Equal(t, 3, s.Totals.EntryCount)
+	assert.Zero(t, s.Totals.DebitCount)
+	assert.Equal(t, DirectionOther, s.ByDirection[1].Key)
+
+	// Test case 6: Files without entries
+	s = Summarize(&models.NachaFile{Batches: []models.Batch{{}}}, 0)
+	assert.Equal(t, Aggregate{Key: "TOTAL"}, s.Totals)
+	assert.Empty(t, s.BySECCode)
+	assert.Empty(t, s.LargestEntries)
+	s = Summarize(nil, 0)
+	assert.Equal(t, Aggregate{Key: "TOTAL"}, s.Totals)
+}
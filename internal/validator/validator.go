@@ -3,7 +3,6 @@ package validator
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/nacha-service/pkg/models"
 )
@@ -29,43 +28,61 @@ func NewValidator() *Validator {
 	return v
 }
 
+// Finding is a validation error with the records it was found in
+type Finding struct {
+	// Batch is the one-based number of the batch in the file, zero for
+	// errors in the file header and control
+	Batch int
+	// Entry is the one-based number of the entry in its batch, zero for
+	// errors in the batch header and control
+	Entry int
+	Err   error
+}
+
 // ValidateFile performs comprehensive validation of a NACHA file
 func (v *Validator) ValidateFile(file *models.NachaFile) []error {
 	var errors []error
+	for _, finding := range v.Findings(file) {
+		errors = append(errors, finding.Err)
+	}
+	return errors
+}
+
+// Findings validates a NACHA file like ValidateFile and returns the errors
+// with the batch and entry they were found in
+func (v *Validator) Findings(file *models.NachaFile) []Finding {
+	var findings []Finding
+	fileFindings := func(errors []error) {
+		for _, err := range errors {
+			findings = append(findings, Finding{Err: err})
+		}
+	}
 
 	// Check for nil file
 	if file == nil {
-		return []error{fmt.Errorf("file is nil")}
+		return []Finding{{Err: fmt.Errorf("file is nil")}}
 	}
 
 	// Basic structure validation
 	if err := file.Validate(); err != nil {
-		errors = append(errors, err)
+		fileFindings([]error{err})
 	}
 
 	// Validate file header
-	if headerErrors := v.validateFileHeader(&file.Header); len(headerErrors) > 0 {
-		errors = append(errors, headerErrors...)
-	}
+	fileFindings(v.validateFileHeader(&file.Header))
 
 	// Validate batches
 	for i, batch := range file.Batches {
-		if batchErrors := v.validateBatch(&batch, i+1); len(batchErrors) > 0 {
-			errors = append(errors, batchErrors...)
-		}
+		findings = append(findings, v.validateBatch(&batch, i+1)...)
 	}
 
 	// Validate file control
-	if controlErrors := v.validateFileControl(&file.Control, file); len(controlErrors) > 0 {
-		errors = append(errors, controlErrors...)
-	}
+	fileFindings(v.validateFileControl(&file.Control, file))
 
 	// Validate file-level totals and counts
-	if balanceErrors := v.validateFileBalances(file); len(balanceErrors) > 0 {
-		errors = append(errors, balanceErrors...)
-	}
+	fileFindings(v.validateFileBalances(file))
 
-	return errors
+	return findings
 }
 
 func (v *Validator) initializeRules() {
@@ -193,27 +210,26 @@ func (v *Validator) validateFileHeader(header *models.FileHeader) []error {
 	return errors
 }
 
-func (v *Validator) validateBatch(batch *models.NachaBatch, batchNum int) []error {
-	var errors []error
+func (v *Validator) validateBatch(batch *models.NachaBatch, batchNum int) []Finding {
+	var findings []Finding
+	add := func(entryNum int, errors []error) {
+		for _, err := range errors {
+			findings = append(findings, Finding{Batch: batchNum, Entry: entryNum, Err: err})
+		}
+	}
 
 	// Validate batch header
-	if headerErrors := v.validateBatchHeader(&batch.Header); len(headerErrors) > 0 {
-		errors = append(errors, headerErrors...)
-	}
+	add(0, v.validateBatchHeader(&batch.Header))
 
 	// Validate entries
 	for i, entry := range batch.Entries {
-		if entryErrors := v.validateEntryDetail(&entry, i+1); len(entryErrors) > 0 {
-			errors = append(errors, entryErrors...)
-		}
+		add(i+1, v.validateEntryDetail(&entry, i+1))
 	}
 
 	// Validate batch control
-	if controlErrors := v.validateBatchControl(&batch.Control, batch); len(controlErrors) > 0 {
-		errors = append(errors, controlErrors...)
-	}
+	add(0, v.validateBatchControl(&batch.Control, batch))
 
-	return errors
+	return findings
 }
 
 func (v *Validator) validateBatchHeader(header *models.BatchHeader) []error {
@@ -374,9 +390,9 @@ func (v *Validator) validateFileBalances(file *models.NachaFile) []error {
 	var totalEntryAddenda int
 	for _, batch := range file.Batches {
 		for _, entry := range batch.Entries {
-			if strings.HasPrefix(entry.TransactionCode, "2") {
+			if models.IsDebit(entry.TransactionCode) {
 				totalDebit += entry.Amount
-			} else {
+			} else if models.IsCredit(entry.TransactionCode) {
 				totalCredit += entry.Amount
 			}
 			totalEntryAddenda++
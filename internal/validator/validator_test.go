@@ -48,7 +48,7 @@ func TestValidator_ValidateFile(t *testing.T) {
 				Entries: []models.EntryDetail{
 					{
 						RecordType:             "6",
-						TransactionCode:        "22",
+						TransactionCode:        "27",
 						ReceivingDFI:           "07640125",
 						CheckDigit:             "1",
 						DFIAccountNumber:       "123456789",
@@ -195,6 +195,99 @@ func TestValidator_ValidateFile(t *testing.T) {
 	assert.NotEmpty(t, errors)
 }
 
+func TestValidator_Findings(t *testing.T) {
+	validator := NewValidator()
+	now := time.Now()
+
+	entry := models.EntryDetail{
+		RecordType:             "6",
+		TransactionCode:        "27",
+		ReceivingDFI:           "07640125",
+		CheckDigit:             "1",
+		DFIAccountNumber:       "123456789",
+		Amount:                 100000,
+		IndividualIDNumber:     "0",
+		IndividualName:         "JOAO DA SILVA",
+		DiscretionaryData:      "0",
+		AddendaRecordIndicator: "0",
+		TraceNumber:            "0764012500000001",
+	}
+	file := &models.NachaFile{
+		Header: models.FileHeader{
+			RecordType:           "1",
+			PriorityCode:         "01",
+			ImmediateDestination: "076401251",
+			ImmediateOrigin:      "0764012512",
+			FileCreationDate:     now,
+			FileCreationTime:     now.Format("1504"),
+			FileIDModifier:       "A",
+			RecordSize:           "094",
+			BlockingFactor:       "10",
+			FormatCode:           "1",
+			DestinationName:      "BANCO DO BRASIL",
+			OriginName:           "EMPRESA EXEMPLO",
+		},
+		Batches: []models.Batch{
+			{
+				Header: models.BatchHeader{
+					RecordType:            "5",
+					ServiceClassCode:      "225",
+					CompanyName:           "EMPRESA EXEMPLO",
+					CompanyIdentification: "0764012512",
+					StandardEntryClass:    "PPD",
+					OriginatorStatusCode:  "1",
+					OriginatingDFI:        "07640125",
+					BatchNumber:           "0000001",
+				},
+				Entries: []models.EntryDetail{entry, entry},
+				Control: models.BatchControl{
+					RecordType:        "8",
+					ServiceClassCode:  "225",
+					EntryAddendaCount: 2,
+					EntryHash:         "0015280250",
+					TotalDebitAmount:  200000,
+				},
+			},
+		},
+		Control: models.FileControl{
+			RecordType:        "9",
+			BatchCount:        1,
+			BlockCount:        1,
+			EntryAddendaCount: 2,
+			EntryHash:         "0015280250",
+			TotalDebitAmount:  200000,
+		},
+	}
+
+	// Test case 1: Valid file
+	assert.Empty(t, validator.Findings(file))
+
+	// Test case 2: Errors are located in the entry, the batch or the file
+	file.Batches[0].Entries[1].IndividualName = ""
+	file.Batches[0].Control.EntryAddendaCount = 3
+	file.Control.BatchCount = 2
+	findings := validator.Findings(file)
+	if assert.Len(t, findings, 4) {
+		assert.Equal(t, 0, findings[0].Batch)
+		assert.EqualError(t, findings[0].Err, "invalid batch 1: invalid entry 2: individual name is required")
+		assert.Equal(t, Finding{Batch: 1, Entry: 2, Err: findings[1].Err}, findings[1])
+		assert.EqualError(t, findings[1].Err, "individual name is required")
+		assert.Equal(t, 1, findings[2].Batch)
+		assert.Equal(t, 0, findings[2].Entry)
+		assert.Contains(t, findings[2].Err.Error(), "batch control entry count mismatch")
+		assert.Equal(t, 0, findings[3].Batch)
+		assert.Contains(t, findings[3].Err.Error(), "file control batch count mismatch")
+	}
+
+	// Test case 3: ValidateFile returns the same errors
+	errors := validator.ValidateFile(file)
+	if assert.Len(t, errors, len(findings)) {
+		for i, finding := range findings {
+			assert.Equal(t, finding.Err, errors[i])
+		}
+	}
+}
+
 func TestValidator_ValidateFileHeader(t *testing.T) {
 	validator := NewValidator()
 	now := time.Now()
@@ -298,7 +391,7 @@ func TestValidator_ValidateEntryDetail(t *testing.T) {
 	// Test case 1: Valid entry detail
 	entry := models.EntryDetail{
 		RecordType:             "6",
-		TransactionCode:        "22",
+		TransactionCode:        "27",
 		ReceivingDFI:           "07640125",
 		CheckDigit:             "1",
 		DFIAccountNumber:       "123456789",
@@ -362,7 +455,7 @@ func TestValidator_ValidateBatchControl(t *testing.T) {
 		},
 		Entries: []models.EntryDetail{
 			{
-				TransactionCode: "22",
+				TransactionCode: "27",
 				ReceivingDFI:    "07640125",
 				Amount:          123400,
 				IndividualName:  "JOAO DA SILVA",
@@ -450,7 +543,7 @@ func TestValidator_ValidateFileControl(t *testing.T) {
 				Entries: []models.EntryDetail{
 					{
 						RecordType:             "6",
-						TransactionCode:        "22",
+						TransactionCode:        "27",
 						ReceivingDFI:           "07640125",
 						CheckDigit:             "1",
 						DFIAccountNumber:       "123456789",
@@ -522,3 +615,29 @@ func TestValidator_ValidateFileControl(t *testing.T) {
 	errors = validator.validateFileControl(&invalidControl, file)
 	assert.NotEmpty(t, errors)
 }
+
+func TestValidator_TransactionCodeDirection(t *testing.T) {
+	validator := NewValidator()
+
+	entry := func(code string, amount int64) models.EntryDetail {
+		return models.EntryDetail{TransactionCode: code, ReceivingDFI: "07640125", Amount: amount}
+	}
+	file := &models.NachaFile{
+		Batches: []models.Batch{
+			{Entries: []models.EntryDetail{entry("22", 100), entry("32", 200), entry("27", 400), entry("37", 800)}},
+		},
+		Control: models.FileControl{EntryAddendaCount: 4, TotalDebitAmount: 1200, TotalCreditAmount: 300},
+	}
+
+	// Test case 1: The second digit gives the direction for checking and savings
+	assert.Empty(t, validator.validateFileBalances(file))
+
+	// Test case 2: Totals that count 22 as a debit and 37 as a credit no longer balance
+	file.Control.TotalDebitAmount, file.Control.TotalCreditAmount = 500, 1000
+	assert.Len(t, validator.validateFileBalances(file), 2)
+
+	// Test case 3: Codes without a direction count in neither total
+	file.Batches[0].Entries = append(file.Batches[0].Entries, entry("2", 1600))
+	file.Control = models.FileControl{EntryAddendaCount: 5, TotalDebitAmount: 1200, TotalCreditAmount: 300}
+	assert.Empty(t, validator.validateFileBalances(file))
+}
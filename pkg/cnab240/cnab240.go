@@ -0,0 +1,458 @@
+// Package cnab240 reads and writes FEBRABAN CNAB 240 remittance files for
+// payments (pagamentos). A file holds a file header and trailer around lotes,
+// and each lote a lote header and trailer around segment A and B records.
+// Records are 240 characters; numeric fields are zero padded and
+// alphanumeric fields are upper case without accents.
+package cnab240
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecordLength is the length of every record
+const RecordLength = 240
+
+// Layout versions written in the file and lote headers
+const (
+	FileLayoutVersion = "089"
+	LoteLayoutVersion = "045"
+)
+
+// Record types (tipo de registro)
+const (
+	RecordFileHeader  = "0"
+	RecordLoteHeader  = "1"
+	RecordDetail      = "3"
+	RecordLoteTrailer = "5"
+	RecordFileTrailer = "9"
+)
+
+// Detail segments
+const (
+	SegmentA = "A"
+	SegmentB = "B"
+)
+
+// Kind is the content type of a field
+type Kind int
+
+const (
+	// Alpha fields are left aligned and padded with spaces
+	Alpha Kind = iota
+	// Numeric fields are right aligned and padded with zeros
+	Numeric
+	// Date fields are DDMMAAAA, or zeros when empty
+	Date
+	// Time fields are HHMMSS
+	Time
+)
+
+// Field is a field of a record layout, at the one-based positions Start to
+// End of the FEBRABAN manual. Fields without a name are reserved and written
+// as spaces.
+type Field struct {
+	Name    string
+	Start   int
+	End     int
+	Kind    Kind
+	Default string
+}
+
+// Width returns the number of characters of the field
+func (f Field) Width() int {
+	return f.End - f.Start + 1
+}
+
+// Layout is the list of fields of a record type
+type Layout struct {
+	Name   string
+	Fields []Field
+	index  map[string]int
+}
+
+func newLayout(name string, fields ...Field) *Layout {
+	l := &Layout{Name: name, Fields: fields, index: make(map[string]int)}
+	for i, f := range fields {
+		if f.Name != "" {
+			l.index[f.Name] = i
+		}
+	}
+	return l
+}
+
+// Field returns the field with the given name
+func (l *Layout) Field(name string) (Field, bool) {
+	i, ok := l.index[name]
+	if !ok {
+		return Field{}, false
+	}
+	return l.Fields[i], true
+}
+
+// Record layouts of the FEBRABAN manual for payments
+var (
+	FileHeaderLayout = newLayout("header_arquivo",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, "0000"},
+		Field{"tipo_registro", 8, 8, Numeric, RecordFileHeader},
+		Field{"", 9, 17, Alpha, ""},
+		Field{"tipo_inscricao", 18, 18, Numeric, ""},
+		Field{"numero_inscricao", 19, 32, Numeric, ""},
+		Field{"convenio", 33, 52, Alpha, ""},
+		Field{"agencia", 53, 57, Numeric, ""},
+		Field{"agencia_dv", 58, 58, Alpha, ""},
+		Field{"conta", 59, 70, Numeric, ""},
+		Field{"conta_dv", 71, 71, Alpha, ""},
+		Field{"agencia_conta_dv", 72, 72, Alpha, ""},
+		Field{"nome_empresa", 73, 102, Alpha, ""},
+		Field{"nome_banco", 103, 132, Alpha, ""},
+		Field{"", 133, 142, Alpha, ""},
+		Field{"codigo_remessa", 143, 143, Numeric, "1"},
+		Field{"data_geracao", 144, 151, Date, ""},
+		Field{"hora_geracao", 152, 157, Time, ""},
+		Field{"sequencial_arquivo", 158, 163, Numeric, ""},
+		Field{"versao_layout", 164, 166, Numeric, FileLayoutVersion},
+		Field{"densidade", 167, 171, Numeric, "01600"},
+		Field{"reservado_banco", 172, 191, Alpha, ""},
+		Field{"reservado_empresa", 192, 211, Alpha, ""},
+		Field{"", 212, 240, Alpha, ""},
+	)
+
+	LoteHeaderLayout = newLayout("header_lote",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, ""},
+		Field{"tipo_registro", 8, 8, Numeric, RecordLoteHeader},
+		Field{"tipo_operacao", 9, 9, Alpha, "C"},
+		Field{"tipo_servico", 10, 11, Numeric, ""},
+		Field{"forma_lancamento", 12, 13, Numeric, ""},
+		Field{"versao_layout", 14, 16, Numeric, LoteLayoutVersion},
+		Field{"", 17, 17, Alpha, ""},
+		Field{"tipo_inscricao", 18, 18, Numeric, ""},
+		Field{"numero_inscricao", 19, 32, Numeric, ""},
+		Field{"convenio", 33, 52, Alpha, ""},
+		Field{"agencia", 53, 57, Numeric, ""},
+		Field{"agencia_dv", 58, 58, Alpha, ""},
+		Field{"conta", 59, 70, Numeric, ""},
+		Field{"conta_dv", 71, 71, Alpha, ""},
+		Field{"agencia_conta_dv", 72, 72, Alpha, ""},
+		Field{"nome_empresa", 73, 102, Alpha, ""},
+		Field{"mensagem", 103, 142, Alpha, ""},
+		Field{"logradouro", 143, 172, Alpha, ""},
+		Field{"numero", 173, 177, Alpha, ""},
+		Field{"complemento", 178, 192, Alpha, ""},
+		Field{"cidade", 193, 212, Alpha, ""},
+		Field{"cep", 213, 217, Alpha, ""},
+		Field{"cep_complemento", 218, 220, Alpha, ""},
+		Field{"uf", 221, 222, Alpha, ""},
+		Field{"forma_pagamento", 223, 224, Alpha, ""},
+		Field{"", 225, 230, Alpha, ""},
+		Field{"ocorrencias", 231, 240, Alpha, ""},
+	)
+
+	SegmentALayout = newLayout("segmento_a",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, ""},
+		Field{"tipo_registro", 8, 8, Numeric, RecordDetail},
+		Field{"sequencial", 9, 13, Numeric, ""},
+		Field{"segmento", 14, 14, Alpha, SegmentA},
+		Field{"tipo_movimento", 15, 15, Numeric, "0"},
+		Field{"codigo_instrucao", 16, 17, Numeric, "00"},
+		Field{"camara", 18, 20, Numeric, ""},
+		Field{"banco_favorecido", 21, 23, Numeric, ""},
+		Field{"agencia_favorecido", 24, 28, Numeric, ""},
+		Field{"agencia_favorecido_dv", 29, 29, Alpha, ""},
+		Field{"conta_favorecido", 30, 41, Numeric, ""},
+		Field{"conta_favorecido_dv", 42, 42, Alpha, ""},
+		Field{"agencia_conta_favorecido_dv", 43, 43, Alpha, ""},
+		Field{"nome_favorecido", 44, 73, Alpha, ""},
+		Field{"seu_numero", 74, 93, Alpha, ""},
+		Field{"data_pagamento", 94, 101, Date, ""},
+		Field{"tipo_moeda", 102, 104, Alpha, "BRL"},
+		Field{"quantidade_moeda", 105, 119, Numeric, ""},
+		Field{"valor_pagamento", 120, 134, Numeric, ""},
+		Field{"nosso_numero", 135, 154, Alpha, ""},
+		Field{"data_real", 155, 162, Date, ""},
+		Field{"valor_real", 163, 177, Numeric, ""},
+		Field{"outras_informacoes", 178, 217, Alpha, ""},
+		Field{"complemento_servico", 218, 219, Alpha, ""},
+		Field{"finalidade_ted", 220, 224, Alpha, ""},
+		Field{"finalidade_complemento", 225, 226, Alpha, ""},
+		Field{"", 227, 229, Alpha, ""},
+		Field{"aviso", 230, 230, Numeric, "0"},
+		Field{"ocorrencias", 231, 240, Alpha, ""},
+	)
+
+	SegmentBLayout = newLayout("segmento_b",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, ""},
+		Field{"tipo_registro", 8, 8, Numeric, RecordDetail},
+		Field{"sequencial", 9, 13, Numeric, ""},
+		Field{"segmento", 14, 14, Alpha, SegmentB},
+		Field{"", 15, 17, Alpha, ""},
+		Field{"tipo_inscricao_favorecido", 18, 18, Numeric, ""},
+		Field{"numero_inscricao_favorecido", 19, 32, Numeric, ""},
+		Field{"logradouro", 33, 62, Alpha, ""},
+		Field{"numero", 63, 67, Alpha, ""},
+		Field{"complemento", 68, 82, Alpha, ""},
+		Field{"bairro", 83, 97, Alpha, ""},
+		Field{"cidade", 98, 117, Alpha, ""},
+		Field{"cep", 118, 122, Alpha, ""},
+		Field{"cep_complemento", 123, 125, Alpha, ""},
+		Field{"uf", 126, 127, Alpha, ""},
+		Field{"data_vencimento", 128, 135, Date, ""},
+		Field{"valor_documento", 136, 150, Numeric, ""},
+		Field{"valor_abatimento", 151, 165, Numeric, ""},
+		Field{"valor_desconto", 166, 180, Numeric, ""},
+		Field{"valor_mora", 181, 195, Numeric, ""},
+		Field{"valor_multa", 196, 210, Numeric, ""},
+		Field{"codigo_documento_favorecido", 211, 225, Alpha, ""},
+		Field{"aviso", 226, 226, Numeric, "0"},
+		Field{"codigo_ug", 227, 232, Alpha, ""},
+		Field{"codigo_ispb", 233, 240, Alpha, ""},
+	)
+
+	// SegmentLayout covers the common fields of segments other than A and B
+	SegmentLayout = newLayout("segmento",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, ""},
+		Field{"tipo_registro", 8, 8, Numeric, RecordDetail},
+		Field{"sequencial", 9, 13, Numeric, ""},
+		Field{"segmento", 14, 14, Alpha, ""},
+		Field{"", 15, 240, Alpha, ""},
+	)
+
+	LoteTrailerLayout = newLayout("trailer_lote",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, ""},
+		Field{"tipo_registro", 8, 8, Numeric, RecordLoteTrailer},
+		Field{"", 9, 17, Alpha, ""},
+		Field{"quantidade_registros", 18, 23, Numeric, ""},
+		Field{"somatoria_valores", 24, 41, Numeric, ""},
+		Field{"somatoria_moedas", 42, 59, Numeric, ""},
+		Field{"numero_aviso_debito", 60, 65, Numeric, ""},
+		Field{"", 66, 230, Alpha, ""},
+		Field{"ocorrencias", 231, 240, Alpha, ""},
+	)
+
+	FileTrailerLayout = newLayout("trailer_arquivo",
+		Field{"banco", 1, 3, Numeric, ""},
+		Field{"lote", 4, 7, Numeric, "9999"},
+		Field{"tipo_registro", 8, 8, Numeric, RecordFileTrailer},
+		Field{"", 9, 17, Alpha, ""},
+		Field{"quantidade_lotes", 18, 23, Numeric, ""},
+		Field{"quantidade_registros", 24, 29, Numeric, ""},
+		Field{"quantidade_contas", 30, 35, Numeric, ""},
+		Field{"", 36, 240, Alpha, ""},
+	)
+)
+
+// Record is a record of a CNAB 240 file
+type Record struct {
+	Layout *Layout
+	// Line is the one-based line of the record in the parsed file, zero
+	// for records built in memory
+	Line int
+	raw  []byte
+}
+
+// NewRecord creates a record with the defaults of a layout. Numeric fields
+// without a default are zero.
+func NewRecord(layout *Layout) *Record {
+	r := &Record{Layout: layout, raw: bytes.Repeat([]byte{' '}, RecordLength)}
+	for _, f := range layout.Fields {
+		switch {
+		case f.Default != "":
+			r.put(f, f.Default)
+		case f.Name != "" && f.Kind != Alpha:
+			r.put(f, "")
+		}
+	}
+	return r
+}
+
+// Get returns the value of a field without padding spaces
+func (r *Record) Get(name string) string {
+	return strings.TrimSpace(r.Raw(name))
+}
+
+// Raw returns the value of a field as written
+func (r *Record) Raw(name string) string {
+	f, ok := r.Layout.Field(name)
+	if !ok {
+		return ""
+	}
+	return string(r.raw[f.Start-1 : f.End])
+}
+
+// Int returns the value of a numeric field, zero when it is blank or not a number
+func (r *Record) Int(name string) int64 {
+	v, _ := strconv.ParseInt(r.Get(name), 10, 64)
+	return v
+}
+
+// Set writes a field. Numeric values are zero padded on the left and
+// alphanumeric values are upper cased, stripped of accents and cut to the
+// width of the field.
+func (r *Record) Set(name, value string) {
+	f, ok := r.Layout.Field(name)
+	if !ok {
+		panic(fmt.Sprintf("cnab240: %s has no field %s", r.Layout.Name, name))
+	}
+	r.put(f, value)
+}
+
+// SetInt writes a numeric field
+func (r *Record) SetInt(name string, value int64) {
+	r.Set(name, strconv.FormatInt(value, 10))
+}
+
+// SetDate writes a date field, or zeros for the zero time
+func (r *Record) SetDate(name string, date time.Time) {
+	if date.IsZero() {
+		r.Set(name, "")
+		return
+	}
+	r.Set(name, date.Format("02012006"))
+}
+
+// Date parses a date field. Zeros are the zero time.
+func (r *Record) Date(name string) (time.Time, error) {
+	value := r.Get(name)
+	if strings.Trim(value, "0") == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("02012006", value)
+}
+
+func (r *Record) put(f Field, value string) {
+	width := f.Width()
+	if f.Kind == Alpha {
+		value = strings.ToUpper(stripAccents(value))
+		if len(value) > width {
+			value = value[:width]
+		}
+		value += strings.Repeat(" ", width-len(value))
+	} else {
+		value = strings.TrimSpace(value)
+		if len(value) > width {
+			value = value[len(value)-width:]
+		}
+		value = strings.Repeat("0", width-len(value)) + value
+	}
+	copy(r.raw[f.Start-1:f.End], value)
+}
+
+// String returns the record as written in the file
+func (r *Record) String() string {
+	return string(r.raw)
+}
+
+// FieldValue is the value of a named field of a record
+type FieldValue struct {
+	Field
+	Value string
+}
+
+// Values returns the named fields of a record with their values
+func (r *Record) Values() []FieldValue {
+	values := make([]FieldValue, 0, len(r.Layout.Fields))
+	for _, f := range r.Layout.Fields {
+		if f.Name != "" {
+			values = append(values, FieldValue{Field: f, Value: r.Get(f.Name)})
+		}
+	}
+	return values
+}
+
+// Lote is a lote with its detail records
+type Lote struct {
+	Header  *Record
+	Details []*Record
+	Trailer *Record
+}
+
+// File is a CNAB 240 file
+type File struct {
+	Header  *Record
+	Lotes   []*Lote
+	Trailer *Record
+}
+
+// Finalize numbers the lotes and detail records, writes the bank code of
+// the file header in every record and computes the lote and file trailers
+func (f *File) Finalize() {
+	bank := f.Header.Get("banco")
+	records := 2
+	for i, lote := range f.Lotes {
+		number := int64(i + 1)
+		var total int64
+		lote.Header.Set("banco", bank)
+		lote.Header.SetInt("lote", number)
+		for j, detail := range lote.Details {
+			detail.Set("banco", bank)
+			detail.SetInt("lote", number)
+			detail.SetInt("sequencial", int64(j+1))
+			if detail.Layout == SegmentALayout {
+				total += detail.Int("valor_pagamento")
+			}
+		}
+		if lote.Trailer == nil {
+			lote.Trailer = NewRecord(LoteTrailerLayout)
+		}
+		lote.Trailer.Set("banco", bank)
+		lote.Trailer.SetInt("lote", number)
+		lote.Trailer.SetInt("quantidade_registros", int64(len(lote.Details)+2))
+		lote.Trailer.SetInt("somatoria_valores", total)
+		records += len(lote.Details) + 2
+	}
+
+	if f.Trailer == nil {
+		f.Trailer = NewRecord(FileTrailerLayout)
+	}
+	f.Trailer.Set("banco", bank)
+	f.Trailer.SetInt("quantidade_lotes", int64(len(f.Lotes)))
+	f.Trailer.SetInt("quantidade_registros", int64(records))
+}
+
+// Bytes writes the file with CRLF line endings
+func (f *File) Bytes() []byte {
+	var buf bytes.Buffer
+	write := func(r *Record) {
+		if r != nil {
+			buf.Write(r.raw)
+			buf.WriteString("\r\n")
+		}
+	}
+	write(f.Header)
+	for _, lote := range f.Lotes {
+		write(lote.Header)
+		for _, detail := range lote.Details {
+			write(detail)
+		}
+		write(lote.Trailer)
+	}
+	write(f.Trailer)
+	return buf.Bytes()
+}
+
+// stripAccents replaces the accented letters of Portuguese with plain ones
+// and any other character outside ASCII with a space
+func stripAccents(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 127 {
+			return ' '
+		}
+		return r
+	}, accents.Replace(s))
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "ê", "e", "è", "e", "í", "i", "ó", "o", "ô", "o", "õ", "o", "ú", "u", "ü", "u", "ç", "c",
+	"Á", "A", "À", "A", "Â", "A", "Ã", "A", "Ä", "A",
+	"É", "E", "Ê", "E", "È", "E", "Í", "I", "Ó", "O", "Ô", "O", "Õ", "O", "Ú", "U", "Ü", "U", "Ç", "C",
+)
@@ -0,0 +1,158 @@
+package cnab240
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// Issue codes of the structural validator
+const (
+	IssueInvalidLength   = "INVALID_LENGTH"
+	IssueInvalidRecord   = "INVALID_RECORD"
+	IssueInvalidSequence = "INVALID_SEQUENCE"
+	IssueMissingRecord   = "MISSING_RECORD"
+	IssueInvalidField    = "INVALID_FIELD"
+	IssueControlMismatch = "CONTROL_MISMATCH"
+)
+
+// Issue is a structural problem of a CNAB 240 file
+type Issue struct {
+	Code string
+	// Line is the one-based line of the record, zero for the whole file
+	Line    int
+	Field   string
+	Message string
+}
+
+// Location returns the line and field of the issue, such as line[3]/valor_pagamento
+func (i Issue) Location() string {
+	switch {
+	case i.Line == 0:
+		return ""
+	case i.Field == "":
+		return fmt.Sprintf("line[%d]", i.Line)
+	default:
+		return fmt.Sprintf("line[%d]/%s", i.Line, i.Field)
+	}
+}
+
+func (i Issue) Error() string {
+	if location := i.Location(); location != "" {
+		return fmt.Sprintf("%s at %s: %s", i.Code, location, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.Code, i.Message)
+}
+
+// ErrEmpty is returned by Parse for content without records
+var ErrEmpty = errors.New("CNAB 240 file has no records")
+
+// Parse reads a CNAB 240 file. Records are grouped into lotes as far as the
+// structure allows, and problems with the record order or lengths are
+// returned as issues instead of stopping the parse. Parse only fails on
+// content without records, or whose first record is not a CNAB 240 file
+// header of lote 0000.
+func Parse(data []byte) (*File, []Issue, error) {
+	var issues []Issue
+	add := func(code string, line int, field, format string, args ...interface{}) {
+		issues = append(issues, Issue{Code: code, Line: line, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	file := &File{}
+	var lote *Lote
+	var lastA bool
+	closeLote := func(line int) {
+		if lote != nil && lote.Trailer == nil {
+			add(IssueMissingRecord, line, "", "lote %s has no trailer", lote.Header.Get("lote"))
+		}
+		lote = nil
+	}
+
+	lines := bytes.Split(data, []byte("\n"))
+	for i, raw := range lines {
+		number := i + 1
+		raw = bytes.TrimSuffix(raw, []byte("\r"))
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+		// Records are edited in place, so they must not share data
+		raw = append([]byte(nil), raw...)
+		if len(raw) != RecordLength {
+			add(IssueInvalidLength, number, "", "record has %d characters instead of %d", len(raw), RecordLength)
+			if len(raw) > RecordLength {
+				raw = raw[:RecordLength]
+			} else {
+				raw = append(raw, bytes.Repeat([]byte{' '}, RecordLength-len(raw))...)
+			}
+		}
+
+		recordType := string(raw[7:8])
+		if file.Header == nil && (recordType != RecordFileHeader || string(raw[3:7]) != "0000") {
+			return nil, nil, fmt.Errorf("record at line %d is not a CNAB 240 file header", number)
+		}
+		if file.Trailer != nil {
+			add(IssueInvalidSequence, number, "", "record follows the file trailer")
+			continue
+		}
+
+		switch recordType {
+		case RecordFileHeader:
+			if file.Header != nil {
+				add(IssueInvalidSequence, number, "", "file has more than one file header")
+				continue
+			}
+			file.Header = &Record{Layout: FileHeaderLayout, Line: number, raw: raw}
+
+		case RecordLoteHeader:
+			closeLote(number)
+			lote = &Lote{Header: &Record{Layout: LoteHeaderLayout, Line: number, raw: raw}}
+			file.Lotes = append(file.Lotes, lote)
+			lastA = false
+
+		case RecordDetail:
+			if lote == nil || lote.Trailer != nil {
+				add(IssueInvalidSequence, number, "", "detail record outside a lote")
+				continue
+			}
+			record := &Record{Layout: SegmentLayout, Line: number, raw: raw}
+			switch string(raw[13:14]) {
+			case SegmentA:
+				record.Layout = SegmentALayout
+				lastA = true
+			case SegmentB:
+				record.Layout = SegmentBLayout
+				if !lastA {
+					add(IssueInvalidSequence, number, "segmento", "segment B does not follow a segment A")
+				}
+				lastA = false
+			default:
+				lastA = false
+			}
+			lote.Details = append(lote.Details, record)
+
+		case RecordLoteTrailer:
+			if lote == nil || lote.Trailer != nil {
+				add(IssueInvalidSequence, number, "", "lote trailer outside a lote")
+				continue
+			}
+			lote.Trailer = &Record{Layout: LoteTrailerLayout, Line: number, raw: raw}
+			lote = nil
+
+		case RecordFileTrailer:
+			closeLote(number)
+			file.Trailer = &Record{Layout: FileTrailerLayout, Line: number, raw: raw}
+
+		default:
+			add(IssueInvalidRecord, number, "tipo_registro", "unknown record type %q", recordType)
+		}
+	}
+
+	if file.Header == nil {
+		return nil, nil, ErrEmpty
+	}
+	if file.Trailer == nil {
+		closeLote(0)
+		add(IssueMissingRecord, 0, "", "file has no file trailer")
+	}
+	return file, issues, nil
+}
@@ -0,0 +1,125 @@
+package cnab240
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// Validate parses a CNAB 240 file and checks its structure: record lengths
+// and order, field contents, the numbering of lotes and records, and the
+// counts and totals of the trailers. It returns every issue found.
+func Validate(data []byte) ([]Issue, error) {
+	file, issues, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(issues, file.Check()...), nil
+}
+
+// Check checks the field contents, numbering, counts and totals of a parsed file
+func (f *File) Check() []Issue {
+	var issues []Issue
+	add := func(r *Record, code, field, format string, args ...interface{}) {
+		issues = append(issues, Issue{Code: code, Line: r.Line, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	bank := f.Header.Get("banco")
+	records := 0
+	check := func(r *Record, lote string) {
+		records++
+		issues = append(issues, checkFields(r)...)
+		if got := r.Get("banco"); got != bank {
+			add(r, IssueInvalidField, "banco", "bank code %s differs from %s of the file header", got, bank)
+		}
+		if got := r.Raw("lote"); got != lote {
+			add(r, IssueInvalidSequence, "lote", "lote number is %s instead of %s", got, lote)
+		}
+	}
+
+	check(f.Header, "0000")
+	switch f.Header.Get("codigo_remessa") {
+	case "1", "2":
+	default:
+		add(f.Header, IssueInvalidField, "codigo_remessa", "codigo_remessa must be 1 (remessa) or 2 (retorno)")
+	}
+
+	for i, lote := range f.Lotes {
+		number := fmt.Sprintf("%04d", i+1)
+		check(lote.Header, number)
+
+		var total int64
+		for j, detail := range lote.Details {
+			check(detail, number)
+			if got, want := detail.Int("sequencial"), int64(j+1); got != want {
+				add(detail, IssueInvalidSequence, "sequencial", "record number is %d instead of %d", got, want)
+			}
+			if detail.Layout == SegmentALayout {
+				total += detail.Int("valor_pagamento")
+			}
+		}
+
+		if lote.Trailer == nil {
+			continue
+		}
+		check(lote.Trailer, number)
+		if got, want := lote.Trailer.Int("quantidade_registros"), int64(len(lote.Details)+2); got != want {
+			add(lote.Trailer, IssueControlMismatch, "quantidade_registros", "lote has %d records, trailer says %d", want, got)
+		}
+		if got := lote.Trailer.Int("somatoria_valores"); got != total {
+			add(lote.Trailer, IssueControlMismatch, "somatoria_valores", "segment A payments total %d, trailer says %d", total, got)
+		}
+	}
+
+	if f.Trailer != nil {
+		check(f.Trailer, "9999")
+		if got, want := f.Trailer.Int("quantidade_lotes"), int64(len(f.Lotes)); got != want {
+			add(f.Trailer, IssueControlMismatch, "quantidade_lotes", "file has %d lotes, trailer says %d", want, got)
+		}
+		if got := f.Trailer.Int("quantidade_registros"); got != int64(records) {
+			add(f.Trailer, IssueControlMismatch, "quantidade_registros", "file has %d records, trailer says %d", records, got)
+		}
+	}
+	return issues
+}
+
+// checkFields checks numeric, date and time fields and the record type
+func checkFields(r *Record) []Issue {
+	var issues []Issue
+	for _, f := range r.Layout.Fields {
+		if f.Name == "" {
+			continue
+		}
+		raw := r.Raw(f.Name)
+		var problem string
+		switch f.Kind {
+		case Numeric:
+			if !models.IsDigits(raw) {
+				problem = "must be numeric"
+			}
+		case Date:
+			if !models.IsDigits(raw) {
+				problem = "must be a DDMMAAAA date"
+			} else if strings.Trim(raw, "0") != "" {
+				if _, err := time.Parse("02012006", raw); err != nil {
+					problem = "must be a DDMMAAAA date"
+				}
+			}
+		case Time:
+			if _, err := time.Parse("150405", raw); err != nil {
+				problem = "must be an HHMMSS time"
+			}
+		}
+		if problem != "" {
+			issues = append(issues, Issue{
+				Code:    IssueInvalidField,
+				Line:    r.Line,
+				Field:   f.Name,
+				Message: fmt.Sprintf("%s %s: %q", f.Name, problem, raw),
+			})
+		}
+	}
+	return issues
+}
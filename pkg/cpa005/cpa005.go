@@ -0,0 +1,408 @@
+// Package cpa005 reads and writes Payments Canada CPA Standard 005 files for
+// automated funds transfers. A file holds a header (A) and a trailer (Z)
+// record around logical records of credits (C) and debits (D). Logical
+// records are 1464 characters, and each C or D record carries up to six
+// transaction segments of 240 characters. Numeric fields are zero padded and
+// alphanumeric fields are upper case without accents.
+package cpa005
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record and segment lengths
+const (
+	RecordLength      = 1464
+	SegmentLength     = 240
+	SegmentsPerRecord = 6
+	// segmentsStart is the offset of the first segment of C and D records
+	segmentsStart = 24
+)
+
+// Logical record types
+const (
+	TypeHeader  = "A"
+	TypeCredit  = "C"
+	TypeDebit   = "D"
+	TypeTrailer = "Z"
+)
+
+// Currencies of a file
+const (
+	CurrencyCAD = "CAD"
+	CurrencyUSD = "USD"
+)
+
+// Kind is the content type of a field
+type Kind int
+
+const (
+	// Alpha fields are left aligned and padded with spaces
+	Alpha Kind = iota
+	// Numeric fields are right aligned and padded with zeros
+	Numeric
+	// Date fields are 0YYDDD, the day of the year, or zeros when empty
+	Date
+)
+
+// Field is a field of a layout, at the one-based positions Start to End of
+// the standard. Positions of segment fields are relative to the segment.
+// Fields without a name are filler and written as spaces.
+type Field struct {
+	Name    string
+	Start   int
+	End     int
+	Kind    Kind
+	Default string
+}
+
+// Width returns the number of characters of the field
+func (f Field) Width() int {
+	return f.End - f.Start + 1
+}
+
+// Layout is the list of fields of a record type or of a segment
+type Layout struct {
+	Name   string
+	Length int
+	Fields []Field
+	index  map[string]int
+}
+
+func newLayout(name string, length int, fields ...Field) *Layout {
+	l := &Layout{Name: name, Length: length, Fields: fields, index: make(map[string]int)}
+	for i, f := range fields {
+		if f.Name != "" {
+			l.index[f.Name] = i
+		}
+	}
+	return l
+}
+
+// Field returns the field with the given name
+func (l *Layout) Field(name string) (Field, bool) {
+	i, ok := l.index[name]
+	if !ok {
+		return Field{}, false
+	}
+	return l.Fields[i], true
+}
+
+// Layouts of the logical records and of the transaction segment
+var (
+	HeaderLayout = newLayout("header", RecordLength,
+		Field{"record_type", 1, 1, Alpha, TypeHeader},
+		Field{"record_count", 2, 10, Numeric, ""},
+		Field{"originator_id", 11, 20, Alpha, ""},
+		Field{"file_creation_number", 21, 24, Numeric, ""},
+		Field{"creation_date", 25, 30, Date, ""},
+		Field{"destination_data_centre", 31, 35, Numeric, ""},
+		Field{"communication_area", 36, 55, Alpha, ""},
+		Field{"currency", 56, 58, Alpha, CurrencyCAD},
+		Field{"", 59, 1464, Alpha, ""},
+	)
+
+	// DetailLayout holds the fields of C and D records before their segments
+	DetailLayout = newLayout("detail", RecordLength,
+		Field{"record_type", 1, 1, Alpha, ""},
+		Field{"record_count", 2, 10, Numeric, ""},
+		Field{"originator_id", 11, 20, Alpha, ""},
+		Field{"file_creation_number", 21, 24, Numeric, ""},
+	)
+
+	SegmentLayout = newLayout("segment", SegmentLength,
+		Field{"transaction_type", 1, 3, Numeric, ""},
+		Field{"amount", 4, 13, Numeric, ""},
+		Field{"due_date", 14, 19, Date, ""},
+		Field{"institution_id", 20, 28, Numeric, ""},
+		Field{"account_number", 29, 40, Alpha, ""},
+		Field{"item_trace_number", 41, 62, Numeric, ""},
+		Field{"stored_transaction_type", 63, 65, Numeric, ""},
+		Field{"originator_short_name", 66, 80, Alpha, ""},
+		Field{"payee_name", 81, 110, Alpha, ""},
+		Field{"originator_long_name", 111, 140, Alpha, ""},
+		Field{"originator_user_id", 141, 150, Alpha, ""},
+		Field{"cross_reference_number", 151, 169, Alpha, ""},
+		Field{"return_institution_id", 170, 178, Numeric, ""},
+		Field{"return_account_number", 179, 190, Alpha, ""},
+		Field{"sundry_information", 191, 205, Alpha, ""},
+		Field{"", 206, 227, Alpha, ""},
+		Field{"settlement_code", 228, 229, Alpha, ""},
+		Field{"invalid_data_element", 230, 240, Numeric, ""},
+	)
+
+	TrailerLayout = newLayout("trailer", RecordLength,
+		Field{"record_type", 1, 1, Alpha, TypeTrailer},
+		Field{"record_count", 2, 10, Numeric, ""},
+		Field{"originator_id", 11, 20, Alpha, ""},
+		Field{"file_creation_number", 21, 24, Numeric, ""},
+		Field{"total_debit_value", 25, 38, Numeric, ""},
+		Field{"total_debit_count", 39, 46, Numeric, ""},
+		Field{"total_credit_value", 47, 60, Numeric, ""},
+		Field{"total_credit_count", 61, 68, Numeric, ""},
+		Field{"total_e_value", 69, 82, Numeric, ""},
+		Field{"total_e_count", 83, 90, Numeric, ""},
+		Field{"total_f_value", 91, 104, Numeric, ""},
+		Field{"total_f_count", 105, 112, Numeric, ""},
+		Field{"", 113, 1464, Alpha, ""},
+	)
+)
+
+// Record is a logical record, or a segment of a C or D record
+type Record struct {
+	Layout *Layout
+	// Number is the one-based position of the record in the parsed file,
+	// zero for records built in memory
+	Number int
+	// Segment is the one-based position of a segment in its record
+	Segment int
+	raw     []byte
+}
+
+// NewRecord creates a record with the defaults of a layout. Numeric fields
+// without a default are zero.
+func NewRecord(layout *Layout) *Record {
+	r := &Record{Layout: layout, raw: bytes.Repeat([]byte{' '}, layout.Length)}
+	r.reset()
+	return r
+}
+
+// NewDetail creates a C or D record without segments
+func NewDetail(recordType string) *Record {
+	r := NewRecord(DetailLayout)
+	r.Set("record_type", recordType)
+	return r
+}
+
+func (r *Record) reset() {
+	for _, f := range r.Layout.Fields {
+		switch {
+		case f.Default != "":
+			r.put(f, f.Default)
+		case f.Name != "" && f.Kind != Alpha:
+			r.put(f, "")
+		}
+	}
+}
+
+// Type returns the logical record type
+func (r *Record) Type() string {
+	return string(r.raw[0:1])
+}
+
+// Segments returns the transaction segments of a C or D record that are in
+// use. Segments share the data of the record, so setting their fields
+// changes the record.
+func (r *Record) Segments() []*Record {
+	var segments []*Record
+	for i := 0; i < SegmentsPerRecord; i++ {
+		s := r.segment(i)
+		if s.used() {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// AddSegment returns the first unused segment of a C or D record with the
+// segment defaults, or nil when the record is full
+func (r *Record) AddSegment() *Record {
+	for i := 0; i < SegmentsPerRecord; i++ {
+		if s := r.segment(i); !s.used() {
+			s.reset()
+			return s
+		}
+	}
+	return nil
+}
+
+func (r *Record) segment(i int) *Record {
+	if r.Layout != DetailLayout {
+		panic(fmt.Sprintf("cpa005: %s record has no segments", r.Layout.Name))
+	}
+	start := segmentsStart + i*SegmentLength
+	return &Record{Layout: SegmentLayout, Number: r.Number, Segment: i + 1, raw: r.raw[start : start+SegmentLength]}
+}
+
+// used reports whether a segment holds a transaction. Unused segments are
+// blank, or zero filled by some originators.
+func (r *Record) used() bool {
+	return strings.Trim(string(r.raw), " 0") != ""
+}
+
+// Get returns the value of a field without padding spaces
+func (r *Record) Get(name string) string {
+	return strings.TrimSpace(r.Raw(name))
+}
+
+// Raw returns the value of a field as written
+func (r *Record) Raw(name string) string {
+	f, ok := r.Layout.Field(name)
+	if !ok {
+		return ""
+	}
+	return string(r.raw[f.Start-1 : f.End])
+}
+
+// Int returns the value of a numeric field, zero when it is blank or not a number
+func (r *Record) Int(name string) int64 {
+	v, _ := strconv.ParseInt(r.Get(name), 10, 64)
+	return v
+}
+
+// Set writes a field. Numeric values are zero padded on the left and
+// alphanumeric values are upper cased, stripped of accents and cut to the
+// width of the field.
+func (r *Record) Set(name, value string) {
+	f, ok := r.Layout.Field(name)
+	if !ok {
+		panic(fmt.Sprintf("cpa005: %s has no field %s", r.Layout.Name, name))
+	}
+	r.put(f, value)
+}
+
+// SetInt writes a numeric field
+func (r *Record) SetInt(name string, value int64) {
+	r.Set(name, strconv.FormatInt(value, 10))
+}
+
+// SetDate writes a date field as 0YYDDD, or zeros for the zero time
+func (r *Record) SetDate(name string, date time.Time) {
+	if date.IsZero() {
+		r.Set(name, "")
+		return
+	}
+	r.Set(name, fmt.Sprintf("0%02d%03d", date.Year()%100, date.YearDay()))
+}
+
+// Date parses a 0YYDDD date field of the years 2000 to 2099. Zeros are the
+// zero time.
+func (r *Record) Date(name string) (time.Time, error) {
+	return ParseDate(r.Get(name))
+}
+
+// ParseDate parses a 0YYDDD date of the years 2000 to 2099. Zeros are the
+// zero time.
+func ParseDate(value string) (time.Time, error) {
+	if strings.Trim(value, "0") == "" {
+		return time.Time{}, nil
+	}
+	if len(value) != 6 || value[0] != '0' {
+		return time.Time{}, fmt.Errorf("date %q is not 0YYDDD", value)
+	}
+	year, err1 := strconv.Atoi(value[1:3])
+	day, err2 := strconv.Atoi(value[3:])
+	if err1 != nil || err2 != nil || day < 1 {
+		return time.Time{}, fmt.Errorf("date %q is not 0YYDDD", value)
+	}
+	date := time.Date(2000+year, time.January, day, 0, 0, 0, 0, time.UTC)
+	if date.Year() != 2000+year {
+		return time.Time{}, fmt.Errorf("date %q has no day %d", value, day)
+	}
+	return date, nil
+}
+
+func (r *Record) put(f Field, value string) {
+	width := f.Width()
+	if f.Kind == Alpha {
+		value = strings.ToUpper(stripAccents(value))
+		if len(value) > width {
+			value = value[:width]
+		}
+		value += strings.Repeat(" ", width-len(value))
+	} else {
+		value = strings.TrimSpace(value)
+		if len(value) > width {
+			value = value[len(value)-width:]
+		}
+		value = strings.Repeat("0", width-len(value)) + value
+	}
+	copy(r.raw[f.Start-1:f.End], value)
+}
+
+// String returns the record as written in the file
+func (r *Record) String() string {
+	return string(r.raw)
+}
+
+// File is a CPA 005 file
+type File struct {
+	Header *Record
+	// Records are the C and D records, in file order
+	Records []*Record
+	Trailer *Record
+}
+
+// Finalize numbers the records, writes the originator and file creation
+// number of the header in every record and computes the trailer
+func (f *File) Finalize() {
+	if f.Trailer == nil {
+		f.Trailer = NewRecord(TrailerLayout)
+	}
+
+	var debits, credits, debitCount, creditCount int64
+	records := append(append([]*Record{f.Header}, f.Records...), f.Trailer)
+	for i, r := range records {
+		r.SetInt("record_count", int64(i+1))
+		r.Set("originator_id", f.Header.Get("originator_id"))
+		r.Set("file_creation_number", f.Header.Raw("file_creation_number"))
+		if r.Layout != DetailLayout {
+			continue
+		}
+		for _, s := range r.Segments() {
+			switch r.Type() {
+			case TypeCredit:
+				credits += s.Int("amount")
+				creditCount++
+			case TypeDebit:
+				debits += s.Int("amount")
+				debitCount++
+			}
+		}
+	}
+
+	f.Trailer.SetInt("total_debit_value", debits)
+	f.Trailer.SetInt("total_debit_count", debitCount)
+	f.Trailer.SetInt("total_credit_value", credits)
+	f.Trailer.SetInt("total_credit_count", creditCount)
+}
+
+// Bytes writes the file with a logical record per line, ended by CRLF
+func (f *File) Bytes() []byte {
+	var buf bytes.Buffer
+	write := func(r *Record) {
+		if r != nil {
+			buf.Write(r.raw)
+			buf.WriteString("\r\n")
+		}
+	}
+	write(f.Header)
+	for _, r := range f.Records {
+		write(r)
+	}
+	write(f.Trailer)
+	return buf.Bytes()
+}
+
+// stripAccents replaces the accented letters of French with plain ones and
+// any other character outside ASCII with a space
+func stripAccents(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 127 {
+			return ' '
+		}
+		return r
+	}, accents.Replace(s))
+}
+
+var accents = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "ÿ", "y", "ç", "c",
+	"À", "A", "Â", "A", "Ä", "A", "É", "E", "È", "E", "Ê", "E", "Ë", "E",
+	"Î", "I", "Ï", "I", "Ô", "O", "Ö", "O", "Ù", "U", "Û", "U", "Ü", "U", "Ÿ", "Y", "Ç", "C",
+	"œ", "oe", "Œ", "OE", "æ", "ae", "Æ", "AE",
+)
@@ -0,0 +1,135 @@
+package cpa005
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// Issue codes of the structural validator
+const (
+	IssueInvalidLength   = "INVALID_LENGTH"
+	IssueInvalidRecord   = "INVALID_RECORD"
+	IssueInvalidSequence = "INVALID_SEQUENCE"
+	IssueMissingRecord   = "MISSING_RECORD"
+	IssueInvalidField    = "INVALID_FIELD"
+	IssueControlMismatch = "CONTROL_MISMATCH"
+)
+
+// Issue is a structural problem of a CPA 005 file
+type Issue struct {
+	Code string
+	// Record is the one-based logical record, zero for the whole file
+	Record int
+	// Segment is the one-based segment of a C or D record, zero for the
+	// record itself
+	Segment int
+	Field   string
+	Message string
+}
+
+// Location returns the record, segment and field of the issue, such as
+// record[2]/segment[3]/amount
+func (i Issue) Location() string {
+	if i.Record == 0 {
+		return ""
+	}
+	location := fmt.Sprintf("record[%d]", i.Record)
+	if i.Segment > 0 {
+		location += fmt.Sprintf("/segment[%d]", i.Segment)
+	}
+	if i.Field != "" {
+		location += "/" + i.Field
+	}
+	return location
+}
+
+func (i Issue) Error() string {
+	if location := i.Location(); location != "" {
+		return fmt.Sprintf("%s at %s: %s", i.Code, location, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.Code, i.Message)
+}
+
+// ErrEmpty is returned by Parse for content without records
+var ErrEmpty = errors.New("CPA 005 file has no records")
+
+// Parse reads a CPA 005 file, with a logical record per line or as one
+// stream of 1464 character records. Problems with the record order or
+// lengths are returned as issues instead of stopping the parse. Parse only
+// fails on content without records, or whose first record is not a CPA 005
+// header.
+func Parse(data []byte) (*File, []Issue, error) {
+	var issues []Issue
+	add := func(code string, record int, field, format string, args ...interface{}) {
+		issues = append(issues, Issue{Code: code, Record: record, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	file := &File{}
+	number := 0
+	for _, raw := range splitRecords(data) {
+		raw = bytes.TrimSuffix(raw, []byte("\r"))
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+		number++
+		// Records are edited in place, so they must not share data
+		raw = append([]byte(nil), raw...)
+		if len(raw) != RecordLength {
+			add(IssueInvalidLength, number, "", "record has %d characters instead of %d", len(raw), RecordLength)
+			if len(raw) > RecordLength {
+				raw = raw[:RecordLength]
+			} else {
+				raw = append(raw, bytes.Repeat([]byte{' '}, RecordLength-len(raw))...)
+			}
+		}
+
+		recordType := string(raw[0:1])
+		if file.Header == nil && (recordType != TypeHeader || !models.IsDigits(string(raw[1:10]))) {
+			return nil, nil, fmt.Errorf("record %d is not a CPA 005 header", number)
+		}
+		if file.Trailer != nil {
+			add(IssueInvalidSequence, number, "", "record follows the trailer")
+			continue
+		}
+
+		switch recordType {
+		case TypeHeader:
+			if file.Header != nil {
+				add(IssueInvalidSequence, number, "", "file has more than one header")
+				continue
+			}
+			file.Header = &Record{Layout: HeaderLayout, Number: number, raw: raw}
+		case TypeCredit, TypeDebit:
+			file.Records = append(file.Records, &Record{Layout: DetailLayout, Number: number, raw: raw})
+		case TypeTrailer:
+			file.Trailer = &Record{Layout: TrailerLayout, Number: number, raw: raw}
+		default:
+			add(IssueInvalidRecord, number, "record_type", "record type %q is not supported, only A, C, D and Z", recordType)
+		}
+	}
+
+	if file.Header == nil {
+		return nil, nil, ErrEmpty
+	}
+	if file.Trailer == nil {
+		add(IssueMissingRecord, 0, "", "file has no trailer")
+	}
+	return file, issues, nil
+}
+
+// splitRecords splits the content into lines, or into logical records when
+// it has no line breaks
+func splitRecords(data []byte) [][]byte {
+	if bytes.IndexByte(data, '\n') >= 0 {
+		return bytes.Split(data, []byte("\n"))
+	}
+	var records [][]byte
+	for len(data) > RecordLength {
+		records = append(records, data[:RecordLength])
+		data = data[RecordLength:]
+	}
+	return append(records, data)
+}
@@ -0,0 +1,162 @@
+package cpa005
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// Validate parses a CPA 005 file and checks its structure: record lengths
+// and order, field contents, the numbering of records, the transaction
+// segments and the totals of the trailer. It returns every issue found.
+func Validate(data []byte) ([]Issue, error) {
+	file, issues, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(issues, file.Check()...), nil
+}
+
+// Check checks the field contents, numbering, segments and totals of a parsed file
+func (f *File) Check() []Issue {
+	var issues []Issue
+	add := func(r *Record, code, field, format string, args ...interface{}) {
+		issues = append(issues, Issue{Code: code, Record: r.Number, Segment: r.Segment, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	originator := f.Header.Get("originator_id")
+	creation := f.Header.Raw("file_creation_number")
+	count := 0
+	check := func(r *Record) {
+		count++
+		issues = append(issues, checkFields(r)...)
+		if got := r.Int("record_count"); got != int64(count) {
+			add(r, IssueInvalidSequence, "record_count", "record count is %d instead of %d", got, count)
+		}
+		if got := r.Get("originator_id"); got != originator {
+			add(r, IssueInvalidField, "originator_id", "originator %s differs from %s of the header", got, originator)
+		}
+		if got := r.Raw("file_creation_number"); got != creation {
+			add(r, IssueInvalidField, "file_creation_number", "file creation number %s differs from %s of the header", got, creation)
+		}
+	}
+
+	check(f.Header)
+	if originator == "" {
+		add(f.Header, IssueInvalidField, "originator_id", "originator ID is required")
+	}
+	if f.Header.Int("file_creation_number") == 0 {
+		add(f.Header, IssueInvalidField, "file_creation_number", "file creation number must be 0001 to 9999")
+	}
+	switch f.Header.Get("currency") {
+	case CurrencyCAD, CurrencyUSD:
+	default:
+		add(f.Header, IssueInvalidField, "currency", "currency must be CAD or USD")
+	}
+
+	var debits, credits, debitCount, creditCount int64
+	for _, r := range f.Records {
+		check(r)
+		segments := r.Segments()
+		if len(segments) == 0 {
+			add(r, IssueInvalidRecord, "", "%s record has no transaction segments", r.Type())
+		}
+		for i, s := range segments {
+			if s.Segment != i+1 {
+				add(s, IssueInvalidSequence, "", "segment follows an unused segment")
+			}
+			issues = append(issues, checkSegment(s)...)
+			if r.Type() == TypeCredit {
+				credits += s.Int("amount")
+				creditCount++
+			} else {
+				debits += s.Int("amount")
+				debitCount++
+			}
+		}
+	}
+
+	if f.Trailer == nil {
+		return issues
+	}
+	check(f.Trailer)
+	totals := []struct {
+		field string
+		want  int64
+	}{
+		{"total_debit_value", debits},
+		{"total_debit_count", debitCount},
+		{"total_credit_value", credits},
+		{"total_credit_count", creditCount},
+	}
+	for _, total := range totals {
+		if got := f.Trailer.Int(total.field); got != total.want {
+			add(f.Trailer, IssueControlMismatch, total.field, "records give %d, trailer says %d", total.want, got)
+		}
+	}
+	return issues
+}
+
+// checkSegment checks the fields a transaction segment cannot do without
+func checkSegment(s *Record) []Issue {
+	issues := checkFields(s)
+	add := func(field, format string, args ...interface{}) {
+		issues = append(issues, Issue{Code: IssueInvalidField, Record: s.Number, Segment: s.Segment, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Int("amount") <= 0 {
+		add("amount", "amount must be positive")
+	}
+	if date, _ := s.Date("due_date"); date.IsZero() {
+		add("due_date", "due date is required")
+	}
+	for _, field := range []string{"institution_id", "return_institution_id"} {
+		if id := s.Raw(field); id[0] != '0' && strings.Trim(id, "0") != "" {
+			add(field, "institution ID must be 0 followed by the institution and transit numbers")
+		}
+	}
+	if s.Int("institution_id") == 0 {
+		add("institution_id", "institution ID is required")
+	}
+	for _, field := range []string{"account_number", "payee_name", "originator_short_name"} {
+		if s.Get(field) == "" {
+			add(field, "%s is required", field)
+		}
+	}
+	return issues
+}
+
+// checkFields checks numeric and date fields and the record type
+func checkFields(r *Record) []Issue {
+	var issues []Issue
+	for _, f := range r.Layout.Fields {
+		if f.Name == "" {
+			continue
+		}
+		raw := r.Raw(f.Name)
+		var problem string
+		switch f.Kind {
+		case Numeric:
+			if !models.IsDigits(raw) {
+				problem = "must be numeric"
+			}
+		case Date:
+			if !models.IsDigits(raw) {
+				problem = "must be a 0YYDDD date"
+			} else if _, err := ParseDate(raw); err != nil {
+				problem = "must be a 0YYDDD date"
+			}
+		}
+		if problem != "" {
+			issues = append(issues, Issue{
+				Code:    IssueInvalidField,
+				Record:  r.Number,
+				Segment: r.Segment,
+				Field:   f.Name,
+				Message: fmt.Sprintf("%s %s: %q", f.Name, problem, raw),
+			})
+		}
+	}
+	return issues
+}
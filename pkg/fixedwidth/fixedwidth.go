@@ -0,0 +1,266 @@
+// Package fixedwidth renders NACHA files into fixed-width flat files whose
+// layout is declared in YAML, for banks that want proprietary layouts of the
+// same payments. A layout lists record types and, for each, the fields with
+// their positions, padding, justification and the value they hold: a literal
+// or a source expression such as entry.amount.
+//
+//	name: BANK_X
+//	extension: .txt
+//	record_length: 80
+//	records:
+//	  - type: entry
+//	    fields:
+//	      - {name: record_type, start: 1, length: 1, value: "D"}
+//	      - {name: routing, start: 2, length: 9, source: entry.routing_number}
+//	      - {name: amount, start: 11, length: 12, source: entry.amount}
+//	      - {name: payee, start: 23, length: 22, source: entry.individual_name}
+package fixedwidth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Record types, written in the order of the NACHA file: the file header, then
+// for each batch its header, its entries each followed by their addenda and
+// its control, and last the file control
+const (
+	FileHeader   = "file_header"
+	BatchHeader  = "batch_header"
+	Entry        = "entry"
+	Addenda      = "addenda"
+	BatchControl = "batch_control"
+	FileControl  = "file_control"
+)
+
+// recordTypes lists the record types with the scopes of the sources their
+// fields may use
+var recordTypes = map[string][]string{
+	FileHeader:   {"file", "record"},
+	BatchHeader:  {"file", "batch", "record"},
+	Entry:        {"file", "batch", "entry", "record"},
+	Addenda:      {"file", "batch", "entry", "addenda", "record"},
+	BatchControl: {"file", "batch", "record"},
+	FileControl:  {"file", "record"},
+}
+
+// Justification of a value in its field
+const (
+	Left  = "left"
+	Right = "right"
+)
+
+// Line endings
+const (
+	LF   = "lf"
+	CRLF = "crlf"
+)
+
+// Amount formats
+const (
+	// Cents writes amounts as integer cents, the default
+	Cents = "cents"
+	// Decimal writes amounts as dollars with a decimal point
+	Decimal = "decimal"
+)
+
+// ErrValueTooLong is returned by Render when a number does not fit its field.
+// Text longer than its field is truncated.
+var ErrValueTooLong = errors.New("value does not fit its field")
+
+// Layout is a fixed-width file layout
+type Layout struct {
+	// Name is the export format name of the layout
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// Extension is the file extension of exports, .txt by default
+	Extension string `yaml:"extension"`
+	// RecordLength pads every record with spaces to this length. Zero leaves
+	// each record as long as its last field.
+	RecordLength int `yaml:"record_length"`
+	// LineEnding separates the records: lf, the default, or crlf
+	LineEnding string   `yaml:"line_ending"`
+	Records    []Record `yaml:"records"`
+}
+
+// Record is a record type of a layout. A NACHA record produces one record of
+// each layout record with its type, in the order listed; NACHA records
+// without a layout record are left out.
+type Record struct {
+	Type   string  `yaml:"type"`
+	Fields []Field `yaml:"fields"`
+}
+
+// Field is a field of a record, at the one-based position Start. Positions
+// not covered by a field are written as spaces.
+type Field struct {
+	Name   string `yaml:"name"`
+	Start  int    `yaml:"start"`
+	Length int    `yaml:"length"`
+	// Source is the expression the value is taken from, such as
+	// batch.company_name. Fields with neither a source nor a value are
+	// written as padding.
+	Source string `yaml:"source"`
+	// Value is a literal written in place of a source
+	Value string `yaml:"value"`
+	// Format is the amount format (cents or decimal) of amount sources or
+	// the pattern of date sources, using YYYY, YY, MM, DD and DDD (day of
+	// the year)
+	Format string `yaml:"format"`
+	// Justify is left or right. Numbers are right justified and other
+	// values left justified by default.
+	Justify string `yaml:"justify"`
+	// Pad is the padding character. Right justified numbers are padded
+	// with zeros and other values with spaces by default.
+	Pad string `yaml:"pad"`
+}
+
+// End returns the one-based position of the last character of the field
+func (f Field) End() int {
+	return f.Start + f.Length - 1
+}
+
+// label names a field in errors
+func (f Field) label(i int) string {
+	if f.Name != "" {
+		return fmt.Sprintf("field %q", f.Name)
+	}
+	return fmt.Sprintf("field %d", i+1)
+}
+
+// Parse reads a layout from its YAML definition and checks it
+func Parse(data []byte) (*Layout, error) {
+	var layout Layout
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&layout); err != nil {
+		return nil, fmt.Errorf("failed to parse layout: %v", err)
+	}
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// Validate checks that the layout can be rendered: known record types and
+// sources, fields that do not overlap and fit the record length, and formats
+// that suit their sources
+func (l *Layout) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return fmt.Errorf("layout has no name")
+	}
+	if l.RecordLength < 0 {
+		return fmt.Errorf("layout %s: record length %d is negative", l.Name, l.RecordLength)
+	}
+	switch l.LineEnding {
+	case "", LF, CRLF:
+	default:
+		return fmt.Errorf("layout %s: line ending %q is not lf or crlf", l.Name, l.LineEnding)
+	}
+	if len(l.Records) == 0 {
+		return fmt.Errorf("layout %s has no records", l.Name)
+	}
+
+	for i, record := range l.Records {
+		if err := l.validateRecord(record); err != nil {
+			return fmt.Errorf("layout %s: record %d (%s): %v", l.Name, i+1, record.Type, err)
+		}
+	}
+	return nil
+}
+
+func (l *Layout) validateRecord(record Record) error {
+	scopes, ok := recordTypes[record.Type]
+	if !ok {
+		return fmt.Errorf("unknown record type, use file_header, batch_header, entry, addenda, batch_control or file_control")
+	}
+	if len(record.Fields) == 0 {
+		return fmt.Errorf("record has no fields")
+	}
+
+	for i, f := range record.Fields {
+		if err := validateField(f, scopes); err != nil {
+			return fmt.Errorf("%s: %v", f.label(i), err)
+		}
+		if l.RecordLength > 0 && f.End() > l.RecordLength {
+			return fmt.Errorf("%s: ends at %d, after the record length %d", f.label(i), f.End(), l.RecordLength)
+		}
+	}
+
+	order := make([]int, len(record.Fields))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return record.Fields[order[a]].Start < record.Fields[order[b]].Start
+	})
+	for k := 1; k < len(order); k++ {
+		prev, f := record.Fields[order[k-1]], record.Fields[order[k]]
+		if f.Start <= prev.End() {
+			return fmt.Errorf("%s overlaps %s", f.label(order[k]), prev.label(order[k-1]))
+		}
+	}
+	return nil
+}
+
+func validateField(f Field, scopes []string) error {
+	if f.Start < 1 || f.Length < 1 {
+		return fmt.Errorf("start and length must be positive")
+	}
+	if f.Source != "" && f.Value != "" {
+		return fmt.Errorf("has both a source and a value")
+	}
+	if len(f.Pad) > 1 {
+		return fmt.Errorf("pad %q is not a single character", f.Pad)
+	}
+	switch f.Justify {
+	case "", Left, Right:
+	default:
+		return fmt.Errorf("justify %q is not left or right", f.Justify)
+	}
+
+	kind := kindText
+	if f.Source != "" {
+		src, ok := sources[f.Source]
+		if !ok {
+			return fmt.Errorf("unknown source %q", f.Source)
+		}
+		scope, _, _ := strings.Cut(f.Source, ".")
+		allowed := false
+		for _, s := range scopes {
+			allowed = allowed || s == scope
+		}
+		if !allowed {
+			return fmt.Errorf("source %q is not available in this record type", f.Source)
+		}
+		kind = src.kind
+	}
+
+	switch {
+	case f.Format == "":
+	case kind == kindAmount && (f.Format == Cents || f.Format == Decimal):
+	case kind == kindAmount:
+		return fmt.Errorf("amount format %q is not cents or decimal", f.Format)
+	case kind == kindDate && dateLayout(f.Format) == f.Format:
+		return fmt.Errorf("date format %q has none of YYYY, YY, MM, DD and DDD", f.Format)
+	case kind != kindDate:
+		return fmt.Errorf("format only applies to amount and date sources")
+	}
+	return nil
+}
+
+// dateLayout converts a pattern such as DDMMYYYY to a Go time layout
+func dateLayout(pattern string) string {
+	return strings.NewReplacer(
+		"YYYY", "2006",
+		"YY", "06",
+		"MM", "01",
+		"DDD", "002",
+		"DD", "02",
+	).Replace(pattern)
+}
@@ -0,0 +1,130 @@
+package fixedwidth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nacha-service/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// testLayout writes a line per batch and per entry
+const testLayout = `
+name: test
+records:
+  - type: batch_header
+    fields:
+      - {start: 1, length: 1, value: B}
+      - {start: 2, length: 3, source: batch.number}
+      - {start: 5, length: 6, source: batch.effective_entry_date, format: DDMMYY}
+  - type: entry
+    fields:
+      - {start: 1, length: 1, value: E}
+      - {start: 2, length: 2, source: entry.transaction_code}
+      - {start: 4, length: 1, source: entry.direction}
+      - {start: 5, length: 10, source: entry.amount, format: decimal}
+      - {start: 15, length: 5, source: entry.individual_name}
+  - type: batch_control
+    fields:
+      - {start: 1, length: 1, value: C}
+      - {start: 2, length: 3, source: batch.entry_count}
+`
+
+func TestParse(t *testing.T) {
+	// Test case 1: A valid layout
+	layout, err := Parse([]byte(testLayout))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "test", layout.Name)
+		assert.Len(t, layout.Records, 3)
+		assert.Equal(t, 14, layout.Records[1].Fields[3].End())
+	}
+
+	// Test case 2: Malformed YAML
+	for _, malformed := range []string{
+		"",
+		"name: [",
+		"name: x\nrecords: {type: entry}",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: one, length: 5}]",
+	} {
+		_, err := Parse([]byte(malformed))
+		assert.Error(t, err, malformed)
+	}
+
+	// Test case 3: Layouts that cannot be rendered
+	for _, invalid := range []string{
+		"records: []",
+		"name: x\nrecords: []",
+		"name: x\nrecord_length: -1\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 1}]",
+		"name: x\nline_ending: cr\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 1}]",
+		"name: x\nrecords:\n  - type: entry",
+		"name: x\nrecords:\n  - type: trailer\n    fields: [{start: 1, length: 1}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 0, length: 5}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5}, {start: 5, length: 2}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, source: entry.amont}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, source: entry.amount, value: '1'}]",
+		"name: x\nrecords:\n  - type: file_header\n    fields: [{start: 1, length: 5, source: entry.amount}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, source: entry.amount, format: dollars}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, source: batch.effective_entry_date, format: XX}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, source: entry.individual_name, format: upper}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, justify: center}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, pad: '00'}]",
+		"name: x\nrecord_length: 4\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5}]",
+		"name: x\nrecords:\n  - type: entry\n    fields: [{start: 1, length: 5, width: 3}]",
+	} {
+		_, err := Parse([]byte(invalid))
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRender(t *testing.T) {
+	layout, err := Parse([]byte(testLayout))
+	if !assert.NoError(t, err) {
+		return
+	}
+	file := &models.NachaFile{
+		Batches: []models.Batch{
+			{
+				Header: models.BatchHeader{BatchNumber: "0000001", EffectiveEntryDate: "261019"},
+				Entries: []models.EntryDetail{
+					{TransactionCode: "27", Amount: 750000, IndividualName: "JOAO DA SILVA"},
+					{TransactionCode: "22", Amount: 900000, IndividualName: "PEDRO"},
+					{TransactionCode: "23", IndividualName: "PRENOTE"},
+					{TransactionCode: "38", IndividualName: "PRENOTE"},
+					{TransactionCode: "2", Amount: 1, IndividualName: "BAD"},
+				},
+			},
+			{
+				Header: models.BatchHeader{BatchNumber: "0000002"},
+			},
+		},
+	}
+
+	// Test case 1: Entries with their direction, prenotes included
+	rendered, err := layout.Render(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{
+		"B001191026",
+		"E27D0007500.00JOAO ",
+		"E22C0009000.00PEDRO",
+		"E23C0000000.00PRENO",
+		"E38D0000000.00PRENO",
+		"E2  0000000.01BAD  ",
+		"C005",
+		"B002      ",
+		"C000",
+	}, strings.Split(strings.TrimSuffix(string(rendered), "\n"), "\n"))
+
+	// Test case 2: Files without batches only write file records
+	rendered, err = layout.Render(&models.NachaFile{})
+	assert.NoError(t, err)
+	assert.Empty(t, rendered)
+
+	// Test case 3: Numbers that do not fit their field
+	file.Batches[0].Entries[0].Amount = 100000000000
+	_, err = layout.Render(file)
+	assert.True(t, errors.Is(err, ErrValueTooLong))
+	assert.Contains(t, err.Error(), "record 2 (entry)")
+}
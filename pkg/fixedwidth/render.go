@@ -0,0 +1,156 @@
+package fixedwidth
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// Render writes a NACHA file in the layout. Numbers that do not fit their
+// field fail the rendering with ErrValueTooLong.
+func (l *Layout) Render(file *models.NachaFile) ([]byte, error) {
+	byType := make(map[string][]Record)
+	for _, record := range l.Records {
+		byType[record.Type] = append(byType[record.Type], record)
+	}
+
+	var buf bytes.Buffer
+	s := &scope{file: file, records: l.count(file, byType)}
+	write := func(recordType string) error {
+		for _, record := range byType[recordType] {
+			s.record++
+			line, err := l.renderRecord(record, s)
+			if err != nil {
+				return fmt.Errorf("record %d (%s): %w", s.record, recordType, err)
+			}
+			buf.WriteString(line)
+			if l.LineEnding == CRLF {
+				buf.WriteString("\r\n")
+			} else {
+				buf.WriteString("\n")
+			}
+		}
+		return nil
+	}
+
+	if err := write(FileHeader); err != nil {
+		return nil, err
+	}
+	for i := range file.Batches {
+		s.batch = &file.Batches[i]
+		if err := write(BatchHeader); err != nil {
+			return nil, err
+		}
+		for j := range s.batch.Entries {
+			s.entry = &s.batch.Entries[j]
+			s.entryNumber++
+			if err := write(Entry); err != nil {
+				return nil, err
+			}
+			for k := range s.entry.AddendaRecords {
+				s.addenda = &s.entry.AddendaRecords[k]
+				if err := write(Addenda); err != nil {
+					return nil, err
+				}
+			}
+			s.addenda = nil
+		}
+		s.entry = nil
+		if err := write(BatchControl); err != nil {
+			return nil, err
+		}
+	}
+	s.batch = nil
+	if err := write(FileControl); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// count returns the number of records the layout writes for a file
+func (l *Layout) count(file *models.NachaFile, byType map[string][]Record) int {
+	n := len(byType[FileHeader]) + len(byType[FileControl])
+	for _, batch := range file.Batches {
+		n += len(byType[BatchHeader]) + len(byType[BatchControl])
+		for _, entry := range batch.Entries {
+			n += len(byType[Entry]) + len(entry.AddendaRecords)*len(byType[Addenda])
+		}
+	}
+	return n
+}
+
+func (l *Layout) renderRecord(record Record, s *scope) (string, error) {
+	line := []byte(strings.Repeat(" ", l.RecordLength))
+	for i, f := range record.Fields {
+		text, err := f.render(s)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", f.label(i), err)
+		}
+		if end := f.End(); end > len(line) {
+			line = append(line, strings.Repeat(" ", end-len(line))...)
+		}
+		copy(line[f.Start-1:], text)
+	}
+	return string(line), nil
+}
+
+// render returns the value of a field justified and padded to its length
+func (f Field) render(s *scope) (string, error) {
+	k, v := kindText, value{text: f.Value}
+	if f.Source != "" {
+		src := sources[f.Source]
+		k, v = src.kind, src.value(s)
+	}
+
+	var text string
+	numeric := k == kindNumber || k == kindAmount
+	switch {
+	case k == kindAmount && f.Format == Decimal:
+		text = fmt.Sprintf("%d.%02d", v.number/100, v.number%100)
+	case numeric:
+		text = strconv.FormatInt(v.number, 10)
+	case k == kindDate && !v.date.IsZero():
+		format := f.Format
+		if format == "" {
+			format = "YYMMDD"
+		}
+		text = v.date.Format(dateLayout(format))
+	default:
+		text = v.text
+	}
+
+	if len(text) > f.Length {
+		if numeric {
+			return "", fmt.Errorf("%s has more than %d characters: %w", text, f.Length, ErrValueTooLong)
+		}
+		text = text[:f.Length]
+	}
+
+	justify, pad := f.Justify, f.Pad
+	if justify == "" {
+		justify = Left
+		if numeric {
+			justify = Right
+		}
+	}
+	if pad == "" {
+		pad = " "
+		if numeric && justify == Right {
+			pad = "0"
+		}
+	}
+
+	switch {
+	case numeric && justify == Right && pad == "0" && f.Format != Decimal:
+		return models.FormatNumber(v.number, f.Length), nil
+	case justify == Left && pad == " ":
+		return models.PadRight(text, f.Length), nil
+	case justify == Left:
+		return text + strings.Repeat(pad, f.Length-len(text)), nil
+	default:
+		return strings.Repeat(pad, f.Length-len(text)) + text, nil
+	}
+}
@@ -0,0 +1,155 @@
+package fixedwidth
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// kind is the type of the value of a source
+type kind int
+
+const (
+	kindText kind = iota
+	kindNumber
+	kindAmount
+	kindDate
+)
+
+// value is the value of a source: text, a number or amount in cents, or a
+// date that is zero when empty
+type value struct {
+	text   string
+	number int64
+	date   time.Time
+}
+
+// scope holds the records a source expression reads from
+type scope struct {
+	file    *models.NachaFile
+	batch   *models.Batch
+	entry   *models.EntryDetail
+	addenda *models.AddendaRecord
+	// record is the number of the record being written, records the number
+	// of records of the file and entry the number of the entry in the file
+	record, records, entryNumber int
+}
+
+type source struct {
+	kind  kind
+	value func(s *scope) value
+}
+
+func text(get func(s *scope) string) source {
+	return source{kind: kindText, value: func(s *scope) value {
+		return value{text: strings.TrimSpace(get(s))}
+	}}
+}
+
+func number(get func(s *scope) int64) source {
+	return source{kind: kindNumber, value: func(s *scope) value {
+		return value{number: get(s)}
+	}}
+}
+
+func amount(get func(s *scope) int64) source {
+	return source{kind: kindAmount, value: func(s *scope) value {
+		return value{number: get(s)}
+	}}
+}
+
+// yymmdd is a date source of a YYMMDD field; other content is empty
+func yymmdd(get func(s *scope) string) source {
+	return source{kind: kindDate, value: func(s *scope) value {
+		date, _ := time.Parse("060102", strings.TrimSpace(get(s)))
+		return value{date: date}
+	}}
+}
+
+// sources are the expressions fields can take their value from, by scope:
+// file, batch, entry, addenda and record
+var sources = map[string]source{
+	"file.priority_code":         text(func(s *scope) string { return s.file.Header.PriorityCode }),
+	"file.immediate_destination": text(func(s *scope) string { return s.file.Header.ImmediateDestination }),
+	"file.immediate_origin":      text(func(s *scope) string { return s.file.Header.ImmediateOrigin }),
+	"file.creation_date": {kind: kindDate, value: func(s *scope) value {
+		return value{date: s.file.Header.FileCreationDate}
+	}},
+	"file.creation_time":       text(func(s *scope) string { return s.file.Header.FileCreationTime }),
+	"file.id_modifier":         text(func(s *scope) string { return s.file.Header.FileIDModifier }),
+	"file.destination_name":    text(func(s *scope) string { return s.file.Header.DestinationName }),
+	"file.origin_name":         text(func(s *scope) string { return s.file.Header.OriginName }),
+	"file.reference_code":      text(func(s *scope) string { return s.file.Header.ReferenceCode }),
+	"file.batch_count":         number(func(s *scope) int64 { return int64(s.file.Control.BatchCount) }),
+	"file.block_count":         number(func(s *scope) int64 { return int64(s.file.Control.BlockCount) }),
+	"file.entry_addenda_count": number(func(s *scope) int64 { return int64(s.file.Control.EntryAddendaCount) }),
+	"file.entry_count": number(func(s *scope) int64 {
+		var n int
+		for _, batch := range s.file.Batches {
+			n += len(batch.Entries)
+		}
+		return int64(n)
+	}),
+	"file.entry_hash":          text(func(s *scope) string { return s.file.Control.EntryHash }),
+	"file.total_debit_amount":  amount(func(s *scope) int64 { return s.file.Control.TotalDebitAmount }),
+	"file.total_credit_amount": amount(func(s *scope) int64 { return s.file.Control.TotalCreditAmount }),
+	"file.record_count":        number(func(s *scope) int64 { return int64(s.records) }),
+
+	"batch.number": number(func(s *scope) int64 {
+		n, _ := strconv.ParseInt(strings.TrimSpace(s.batch.Header.BatchNumber), 10, 64)
+		return n
+	}),
+	"batch.service_class_code":         text(func(s *scope) string { return s.batch.Header.ServiceClassCode }),
+	"batch.company_name":               text(func(s *scope) string { return s.batch.Header.CompanyName }),
+	"batch.company_discretionary_data": text(func(s *scope) string { return s.batch.Header.CompanyDiscretionaryData }),
+	"batch.company_identification":     text(func(s *scope) string { return s.batch.Header.CompanyIdentification }),
+	"batch.standard_entry_class":       text(func(s *scope) string { return s.batch.Header.StandardEntryClass }),
+	"batch.company_entry_description":  text(func(s *scope) string { return s.batch.Header.CompanyEntryDescription }),
+	"batch.company_descriptive_date":   text(func(s *scope) string { return s.batch.Header.CompanyDescriptiveDate }),
+	"batch.effective_entry_date":       yymmdd(func(s *scope) string { return s.batch.Header.EffectiveEntryDate }),
+	"batch.settlement_date":            text(func(s *scope) string { return s.batch.Header.SettlementDate }),
+	"batch.originator_status_code":     text(func(s *scope) string { return s.batch.Header.OriginatorStatusCode }),
+	"batch.originating_dfi":            text(func(s *scope) string { return s.batch.Header.OriginatingDFI }),
+	"batch.entry_addenda_count":        number(func(s *scope) int64 { return int64(s.batch.Control.EntryAddendaCount) }),
+	"batch.entry_count":                number(func(s *scope) int64 { return int64(len(s.batch.Entries)) }),
+	"batch.entry_hash":                 text(func(s *scope) string { return s.batch.Control.EntryHash }),
+	"batch.total_debit_amount":         amount(func(s *scope) int64 { return s.batch.Control.TotalDebitAmount }),
+	"batch.total_credit_amount":        amount(func(s *scope) int64 { return s.batch.Control.TotalCreditAmount }),
+
+	"entry.number":           number(func(s *scope) int64 { return int64(s.entryNumber) }),
+	"entry.transaction_code": text(func(s *scope) string { return s.entry.TransactionCode }),
+	// D for debits and C for credits, told apart by the transaction code as
+	// in the batch controls
+	"entry.direction": text(func(s *scope) string {
+		switch {
+		case models.IsDebit(s.entry.TransactionCode):
+			return "D"
+		case models.IsCredit(s.entry.TransactionCode):
+			return "C"
+		default:
+			return ""
+		}
+	}),
+	"entry.receiving_dfi": text(func(s *scope) string { return s.entry.ReceivingDFI }),
+	"entry.check_digit":   text(func(s *scope) string { return s.entry.CheckDigit }),
+	"entry.routing_number": text(func(s *scope) string {
+		return strings.TrimSpace(s.entry.ReceivingDFI) + strings.TrimSpace(s.entry.CheckDigit)
+	}),
+	"entry.dfi_account_number":       text(func(s *scope) string { return s.entry.DFIAccountNumber }),
+	"entry.amount":                   amount(func(s *scope) int64 { return s.entry.Amount }),
+	"entry.individual_id_number":     text(func(s *scope) string { return s.entry.IndividualIDNumber }),
+	"entry.individual_name":          text(func(s *scope) string { return s.entry.IndividualName }),
+	"entry.discretionary_data":       text(func(s *scope) string { return s.entry.DiscretionaryData }),
+	"entry.addenda_record_indicator": text(func(s *scope) string { return s.entry.AddendaRecordIndicator }),
+	"entry.trace_number":             text(func(s *scope) string { return s.entry.TraceNumber }),
+	"entry.addenda_count":            number(func(s *scope) int64 { return int64(len(s.entry.AddendaRecords)) }),
+
+	"addenda.type_code":                    text(func(s *scope) string { return s.addenda.AddendaTypeCode }),
+	"addenda.payment_related_information":  text(func(s *scope) string { return s.addenda.PaymentRelatedInformation }),
+	"addenda.sequence_number":              text(func(s *scope) string { return s.addenda.AddendaSequenceNumber }),
+	"addenda.entry_detail_sequence_number": text(func(s *scope) string { return s.addenda.EntryDetailSequenceNumber }),
+
+	"record.number": number(func(s *scope) int64 { return int64(s.record) }),
+}
@@ -0,0 +1,209 @@
+// Package iso20022 holds the ISO 20022 payment initiation messages exchanged
+// with treasury platforms: pain.001.001.03 customer credit transfers and
+// pain.008.001.02 customer direct debits. Only the elements mapped to and
+// from NACHA are modelled, in schema order.
+package iso20022
+
+import "encoding/xml"
+
+// Message namespaces
+const (
+	Pain001Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+	Pain008Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.008.001.02"
+)
+
+// Code values used by the NACHA mapping
+const (
+	// ClearingSystemUSABA identifies ABA routing numbers
+	ClearingSystemUSABA = "USABA"
+	// PaymentMethodTransfer is the payment method of credit transfers
+	PaymentMethodTransfer = "TRF"
+	// PaymentMethodDirectDebit is the payment method of direct debits
+	PaymentMethodDirectDebit = "DD"
+	// ServiceLevelNonUrgent is the service level of ACH payments
+	ServiceLevelNonUrgent = "NURG"
+	// CurrencyUSD is the only currency carried by ACH
+	CurrencyUSD = "USD"
+	// AccountTypeCurrent is the cash account type of checking accounts
+	AccountTypeCurrent = "CACC"
+	// AccountTypeSavings is the cash account type of savings accounts
+	AccountTypeSavings = "SVGS"
+)
+
+// Pain001Document is a pain.001.001.03 message
+type Pain001Document struct {
+	XMLName    xml.Name                         `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 Document"`
+	Initiation CustomerCreditTransferInitiation `xml:"CstmrCdtTrfInitn"`
+}
+
+// CustomerCreditTransferInitiation is the body of a pain.001 message
+type CustomerCreditTransferInitiation struct {
+	GroupHeader        GroupHeader                        `xml:"GrpHdr"`
+	PaymentInformation []CreditTransferPaymentInformation `xml:"PmtInf"`
+}
+
+// CreditTransferPaymentInformation groups the credit transfers of one debtor
+type CreditTransferPaymentInformation struct {
+	PaymentInformationID   string                      `xml:"PmtInfId"`
+	PaymentMethod          string                      `xml:"PmtMtd"`
+	NumberOfTransactions   string                      `xml:"NbOfTxs,omitempty"`
+	ControlSum             string                      `xml:"CtrlSum,omitempty"`
+	PaymentTypeInformation *PaymentTypeInformation     `xml:"PmtTpInf,omitempty"`
+	RequestedExecutionDate string                      `xml:"ReqdExctnDt"`
+	Debtor                 Party                       `xml:"Dbtr"`
+	DebtorAccount          Account                     `xml:"DbtrAcct"`
+	DebtorAgent            Agent                       `xml:"DbtrAgt"`
+	Transactions           []CreditTransferTransaction `xml:"CdtTrfTxInf"`
+}
+
+// CreditTransferTransaction is a single credit transfer
+type CreditTransferTransaction struct {
+	PaymentID             PaymentID              `xml:"PmtId"`
+	Amount                InstructedAmount       `xml:"Amt"`
+	CreditorAgent         *Agent                 `xml:"CdtrAgt,omitempty"`
+	Creditor              *Party                 `xml:"Cdtr,omitempty"`
+	CreditorAccount       *Account               `xml:"CdtrAcct,omitempty"`
+	RemittanceInformation *RemittanceInformation `xml:"RmtInf,omitempty"`
+}
+
+// InstructedAmount wraps the amount of a credit transfer
+type InstructedAmount struct {
+	InstructedAmount Amount `xml:"InstdAmt"`
+}
+
+// Pain008Document is a pain.008.001.02 message
+type Pain008Document struct {
+	XMLName    xml.Name                      `xml:"urn:iso:std:iso:20022:tech:xsd:pain.008.001.02 Document"`
+	Initiation CustomerDirectDebitInitiation `xml:"CstmrDrctDbtInitn"`
+}
+
+// CustomerDirectDebitInitiation is the body of a pain.008 message
+type CustomerDirectDebitInitiation struct {
+	GroupHeader        GroupHeader                     `xml:"GrpHdr"`
+	PaymentInformation []DirectDebitPaymentInformation `xml:"PmtInf"`
+}
+
+// DirectDebitPaymentInformation groups the direct debits of one creditor
+type DirectDebitPaymentInformation struct {
+	PaymentInformationID    string                   `xml:"PmtInfId"`
+	PaymentMethod           string                   `xml:"PmtMtd"`
+	NumberOfTransactions    string                   `xml:"NbOfTxs,omitempty"`
+	ControlSum              string                   `xml:"CtrlSum,omitempty"`
+	PaymentTypeInformation  *PaymentTypeInformation  `xml:"PmtTpInf,omitempty"`
+	RequestedCollectionDate string                   `xml:"ReqdColltnDt"`
+	Creditor                Party                    `xml:"Cdtr"`
+	CreditorAccount         Account                  `xml:"CdtrAcct"`
+	CreditorAgent           Agent                    `xml:"CdtrAgt"`
+	Transactions            []DirectDebitTransaction `xml:"DrctDbtTxInf"`
+}
+
+// DirectDebitTransaction is a single direct debit
+type DirectDebitTransaction struct {
+	PaymentID             PaymentID              `xml:"PmtId"`
+	InstructedAmount      Amount                 `xml:"InstdAmt"`
+	DebtorAgent           Agent                  `xml:"DbtrAgt"`
+	Debtor                Party                  `xml:"Dbtr"`
+	DebtorAccount         Account                `xml:"DbtrAcct"`
+	RemittanceInformation *RemittanceInformation `xml:"RmtInf,omitempty"`
+}
+
+// GroupHeader identifies a message and totals its transactions
+type GroupHeader struct {
+	MessageID            string `xml:"MsgId"`
+	CreationDateTime     string `xml:"CreDtTm"`
+	NumberOfTransactions string `xml:"NbOfTxs"`
+	ControlSum           string `xml:"CtrlSum,omitempty"`
+	InitiatingParty      Party  `xml:"InitgPty"`
+}
+
+// PaymentTypeInformation holds the service level and local instrument. The
+// NACHA mapping carries the SEC code as a proprietary local instrument.
+type PaymentTypeInformation struct {
+	ServiceLevel    *Code `xml:"SvcLvl,omitempty"`
+	LocalInstrument *Code `xml:"LclInstrm,omitempty"`
+}
+
+// Code is a choice between an external code and a proprietary value
+type Code struct {
+	Code        string `xml:"Cd,omitempty"`
+	Proprietary string `xml:"Prtry,omitempty"`
+}
+
+// Party identifies a debtor, creditor or initiating party
+type Party struct {
+	Name string               `xml:"Nm,omitempty"`
+	ID   *PartyIdentification `xml:"Id,omitempty"`
+}
+
+// PartyIdentification identifies an organisation or a private person
+type PartyIdentification struct {
+	Organisation *GenericIdentifications `xml:"OrgId,omitempty"`
+	Private      *GenericIdentifications `xml:"PrvtId,omitempty"`
+}
+
+// GenericIdentifications holds other identifications of a party
+type GenericIdentifications struct {
+	Other []GenericIdentification `xml:"Othr"`
+}
+
+// GenericIdentification is an identification with an optional scheme
+type GenericIdentification struct {
+	ID         string `xml:"Id"`
+	SchemeName *Code  `xml:"SchmeNm,omitempty"`
+}
+
+// Account identifies a cash account
+type Account struct {
+	ID       AccountIdentification `xml:"Id"`
+	Type     *Code                 `xml:"Tp,omitempty"`
+	Currency string                `xml:"Ccy,omitempty"`
+}
+
+// AccountIdentification is a choice between an IBAN and another identification
+type AccountIdentification struct {
+	IBAN  string                 `xml:"IBAN,omitempty"`
+	Other *GenericIdentification `xml:"Othr,omitempty"`
+}
+
+// Agent identifies a financial institution
+type Agent struct {
+	FinancialInstitution FinancialInstitution `xml:"FinInstnId"`
+}
+
+// FinancialInstitution identifies a financial institution by BIC or clearing
+// system membership
+type FinancialInstitution struct {
+	BIC                    string                `xml:"BIC,omitempty"`
+	ClearingSystemMemberID *ClearingSystemMember `xml:"ClrSysMmbId,omitempty"`
+	Name                   string                `xml:"Nm,omitempty"`
+	PostalAddress          *PostalAddress        `xml:"PstlAdr,omitempty"`
+}
+
+// ClearingSystemMember identifies a member of a clearing system, such as a
+// routing number in USABA
+type ClearingSystemMember struct {
+	ClearingSystemID *Code  `xml:"ClrSysId,omitempty"`
+	MemberID         string `xml:"MmbId"`
+}
+
+// PostalAddress holds the country of an address
+type PostalAddress struct {
+	Country string `xml:"Ctry,omitempty"`
+}
+
+// PaymentID identifies a transaction end to end
+type PaymentID struct {
+	InstructionID string `xml:"InstrId,omitempty"`
+	EndToEndID    string `xml:"EndToEndId"`
+}
+
+// Amount is an amount in currency units with its currency
+type Amount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// RemittanceInformation holds unstructured remittance lines
+type RemittanceInformation struct {
+	Unstructured []string `xml:"Ustrd"`
+}
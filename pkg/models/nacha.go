@@ -59,6 +59,7 @@ type BatchHeader struct {
 	StandardEntryClass       string
 	CompanyEntryDescription  string
 	CompanyDescriptiveDate   string
+	EffectiveEntryDate       string
 	SettlementDate           string
 	OriginatorStatusCode     string
 	OriginatingDFI           string
@@ -239,9 +240,9 @@ func (f *NachaFile) validateBatch(batch *Batch, batchNum int) error {
 		entryAddendaCount += len(entry.AddendaRecords)
 
 		// Calculate totals
-		if strings.HasPrefix(entry.TransactionCode, "2") {
+		if IsDebit(entry.TransactionCode) {
 			totalDebit += entry.Amount
-		} else if strings.HasPrefix(entry.TransactionCode, "3") {
+		} else if IsCredit(entry.TransactionCode) {
 			totalCredit += entry.Amount
 		}
 
@@ -326,6 +327,34 @@ func (f *NachaFile) validateEntry(entry *EntryDetail, entryNum int) error {
 	return nil
 }
 
+// IsCredit reports whether a transaction code credits the receiver's account.
+// The second digit of a transaction code gives its direction, 1 to 4 for
+// credits and 5 to 9 for debits, and the first digit the type of account.
+func IsCredit(transactionCode string) bool {
+	return transactionCodeDigit(transactionCode) >= '1' && transactionCodeDigit(transactionCode) <= '4'
+}
+
+// IsDebit reports whether a transaction code debits the receiver's account
+func IsDebit(transactionCode string) bool {
+	return transactionCodeDigit(transactionCode) >= '5' && transactionCodeDigit(transactionCode) <= '9'
+}
+
+// IsPrenote reports whether a transaction code is a prenotification, which
+// carries no amount
+func IsPrenote(transactionCode string) bool {
+	digit := transactionCodeDigit(transactionCode)
+	return digit == '3' || digit == '8'
+}
+
+// transactionCodeDigit returns the direction digit of a transaction code, or
+// zero when the code is not two digits
+func transactionCodeDigit(transactionCode string) byte {
+	if len(transactionCode) != 2 || transactionCode[0] < '0' || transactionCode[0] > '9' {
+		return 0
+	}
+	return transactionCode[1]
+}
+
 // ToBytes converts a NACHA file to its byte representation
 func (f *NachaFile) ToBytes() []byte {
 	var buf bytes.Buffer
@@ -366,79 +395,8 @@ func (f *NachaFile) ToBytes() []byte {
 
 // FromBytes converts bytes to a NACHA file
 func FromBytes(data []byte) *NachaFile {
-	if len(data) == 0 {
-		return &NachaFile{}
-	}
-
-	lines := strings.Split(string(data), "\n")
-	file := &NachaFile{}
-
-	for i := 0; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
-		if len(line) == 0 {
-			continue
-		}
-
-		if len(line) < RecordLength {
-			line = padRight(line, RecordLength)
-		} else if len(line) > RecordLength {
-			line = line[:RecordLength]
-		}
-
-		switch line[0] {
-		case '1': // File Header
-			file.Header = parseFileHeader(line)
-		case '5': // Batch Header
-			batch := Batch{
-				Header: parseBatchHeader(line),
-			}
-			i++
-			for i < len(lines) && len(lines[i]) > 0 {
-				line := strings.TrimSpace(lines[i])
-				if len(line) < RecordLength {
-					line = padRight(line, RecordLength)
-				} else if len(line) > RecordLength {
-					line = line[:RecordLength]
-				}
-
-				switch line[0] {
-				case '6': // Entry Detail
-					entry := parseEntryDetail(line)
-					i++
-					for i < len(lines) && len(lines[i]) > 0 {
-						line := strings.TrimSpace(lines[i])
-						if len(line) < RecordLength {
-							line = padRight(line, RecordLength)
-						} else if len(line) > RecordLength {
-							line = line[:RecordLength]
-						}
-
-						if line[0] == '7' {
-							entry.AddendaRecords = append(entry.AddendaRecords, parseAddendaRecord(line))
-							i++
-						} else {
-							break
-						}
-					}
-					i--
-					batch.Entries = append(batch.Entries, *entry)
-				case '8': // Batch Control
-					batch.Control = parseBatchControl(line)
-					file.Batches = append(file.Batches, batch)
-					goto NextLine
-				default:
-					i--
-					goto NextLine
-				}
-				i++
-			}
-			i--
-		case '9': // File Control
-			file.Control = parseFileControl(line)
-		}
-	NextLine:
-	}
-
+	// Reading from memory cannot fail
+	file, _ := NewReader(bytes.NewReader(data)).Read()
 	return file
 }
 
@@ -446,52 +404,53 @@ func FromBytes(data []byte) *NachaFile {
 func formatFileHeader(h *FileHeader) string {
 	var buf strings.Builder
 	buf.WriteString("1")
-	buf.WriteString(padRight(h.PriorityCode, 2))
-	buf.WriteString(padRight(h.ImmediateDestination, 10))
-	buf.WriteString(padRight(h.ImmediateOrigin, 10))
+	buf.WriteString(PadRight(h.PriorityCode, 2))
+	buf.WriteString(PadRight(h.ImmediateDestination, 10))
+	buf.WriteString(PadRight(h.ImmediateOrigin, 10))
 	buf.WriteString(h.FileCreationDate.Format("060102"))
-	buf.WriteString(padRight(h.FileCreationTime, 4))
-	buf.WriteString(padRight(h.FileIDModifier, 1))
-	buf.WriteString(padRight(h.RecordSize, 3))
-	buf.WriteString(padRight(h.BlockingFactor, 2))
-	buf.WriteString(padRight(h.FormatCode, 1))
-	buf.WriteString(padRight(h.DestinationName, 23))
-	buf.WriteString(padRight(h.OriginName, 23))
-	buf.WriteString(padRight(h.ReferenceCode, 8))
+	buf.WriteString(PadRight(h.FileCreationTime, 4))
+	buf.WriteString(PadRight(h.FileIDModifier, 1))
+	buf.WriteString(PadRight(h.RecordSize, 3))
+	buf.WriteString(PadRight(h.BlockingFactor, 2))
+	buf.WriteString(PadRight(h.FormatCode, 1))
+	buf.WriteString(PadRight(h.DestinationName, 23))
+	buf.WriteString(PadRight(h.OriginName, 23))
+	buf.WriteString(PadRight(h.ReferenceCode, 8))
 	return buf.String()
 }
 
 func formatBatchHeader(h *BatchHeader) string {
 	var buf strings.Builder
 	buf.WriteString("5")
-	buf.WriteString(padRight(h.ServiceClassCode, 3))
-	buf.WriteString(padRight(h.CompanyName, 16))
-	buf.WriteString(padRight(h.CompanyDiscretionaryData, 20))
-	buf.WriteString(padRight(h.CompanyIdentification, 10))
-	buf.WriteString(padRight(h.StandardEntryClass, 3))
-	buf.WriteString(padRight(h.CompanyEntryDescription, 10))
-	buf.WriteString(padRight(h.CompanyDescriptiveDate, 6))
-	buf.WriteString(padRight(h.SettlementDate, 3))
-	buf.WriteString(padRight(h.OriginatorStatusCode, 1))
-	buf.WriteString(padRight(h.OriginatingDFI, 8))
+	buf.WriteString(PadRight(h.ServiceClassCode, 3))
+	buf.WriteString(PadRight(h.CompanyName, 16))
+	buf.WriteString(PadRight(h.CompanyDiscretionaryData, 20))
+	buf.WriteString(PadRight(h.CompanyIdentification, 10))
+	buf.WriteString(PadRight(h.StandardEntryClass, 3))
+	buf.WriteString(PadRight(h.CompanyEntryDescription, 10))
+	buf.WriteString(PadRight(h.CompanyDescriptiveDate, 6))
+	buf.WriteString(PadRight(h.EffectiveEntryDate, 6))
+	buf.WriteString(PadRight(h.SettlementDate, 3))
+	buf.WriteString(PadRight(h.OriginatorStatusCode, 1))
+	buf.WriteString(PadRight(h.OriginatingDFI, 8))
 	// Convert batch number to int and format with leading zeros
 	batchNum, _ := strconv.Atoi(h.BatchNumber)
-	buf.WriteString(formatNumber(int64(batchNum), 7))
+	buf.WriteString(FormatNumber(int64(batchNum), 7))
 	return buf.String()
 }
 
 func formatEntryDetail(e *EntryDetail, batchNum, entryNum int) string {
 	var buf strings.Builder
 	buf.WriteString("6")
-	buf.WriteString(padRight(e.TransactionCode, 2))
-	buf.WriteString(padRight(e.ReceivingDFI, 8))
-	buf.WriteString(padRight(e.CheckDigit, 1))
-	buf.WriteString(padRight(e.DFIAccountNumber, 17))
+	buf.WriteString(PadRight(e.TransactionCode, 2))
+	buf.WriteString(PadRight(e.ReceivingDFI, 8))
+	buf.WriteString(PadRight(e.CheckDigit, 1))
+	buf.WriteString(PadRight(e.DFIAccountNumber, 17))
 	buf.WriteString(formatAmount(e.Amount))
-	buf.WriteString(padRight(e.IndividualIDNumber, 15))
-	buf.WriteString(padRight(e.IndividualName, 22))
-	buf.WriteString(padRight(e.DiscretionaryData, 2))
-	buf.WriteString(padRight(e.AddendaRecordIndicator, 1))
+	buf.WriteString(PadRight(e.IndividualIDNumber, 15))
+	buf.WriteString(PadRight(e.IndividualName, 22))
+	buf.WriteString(PadRight(e.DiscretionaryData, 2))
+	buf.WriteString(PadRight(e.AddendaRecordIndicator, 1))
 	buf.WriteString(formatTraceNumber(e.TraceNumber, batchNum, entryNum))
 	return buf.String()
 }
@@ -499,8 +458,8 @@ func formatEntryDetail(e *EntryDetail, batchNum, entryNum int) string {
 func formatAddendaRecord(a *AddendaRecord, entryNum, seqNum int) string {
 	var buf strings.Builder
 	buf.WriteString("7")
-	buf.WriteString(padRight(a.AddendaTypeCode, 2))
-	buf.WriteString(padRight(a.PaymentRelatedInformation, 80))
+	buf.WriteString(PadRight(a.AddendaTypeCode, 2))
+	buf.WriteString(PadRight(a.PaymentRelatedInformation, 80))
 	buf.WriteString(formatSequenceNumber(a.AddendaSequenceNumber, seqNum))
 	buf.WriteString(formatEntryDetailNumber(a.EntryDetailSequenceNumber, entryNum))
 	return buf.String()
@@ -509,26 +468,26 @@ func formatAddendaRecord(a *AddendaRecord, entryNum, seqNum int) string {
 func formatBatchControl(c *BatchControl) string {
 	var buf strings.Builder
 	buf.WriteString("8")
-	buf.WriteString(padRight(c.ServiceClassCode, 3))
-	buf.WriteString(formatNumber(int64(c.EntryAddendaCount), 6))
-	buf.WriteString(padRight(c.EntryHash, 10))
+	buf.WriteString(PadRight(c.ServiceClassCode, 3))
+	buf.WriteString(FormatNumber(int64(c.EntryAddendaCount), 6))
+	buf.WriteString(PadRight(c.EntryHash, 10))
 	buf.WriteString(formatAmount(c.TotalDebitAmount))
 	buf.WriteString(formatAmount(c.TotalCreditAmount))
-	buf.WriteString(padRight(c.CompanyIdentification, 10))
-	buf.WriteString(padRight(c.OriginatingDFI, 8))
+	buf.WriteString(PadRight(c.CompanyIdentification, 10))
+	buf.WriteString(PadRight(c.OriginatingDFI, 8))
 	// Convert batch number to int and format with leading zeros
 	batchNum, _ := strconv.Atoi(c.BatchNumber)
-	buf.WriteString(formatNumber(int64(batchNum), 7))
+	buf.WriteString(FormatNumber(int64(batchNum), 7))
 	return buf.String()
 }
 
 func formatFileControl(c *FileControl) string {
 	var buf strings.Builder
 	buf.WriteString("9")
-	buf.WriteString(formatNumber(int64(c.BatchCount), 6))
-	buf.WriteString(formatNumber(int64(c.BlockCount), 6))
-	buf.WriteString(formatNumber(int64(c.EntryAddendaCount), 8))
-	buf.WriteString(padRight(c.EntryHash, 10))
+	buf.WriteString(FormatNumber(int64(c.BatchCount), 6))
+	buf.WriteString(FormatNumber(int64(c.BlockCount), 6))
+	buf.WriteString(FormatNumber(int64(c.EntryAddendaCount), 8))
+	buf.WriteString(PadRight(c.EntryHash, 10))
 	buf.WriteString(formatAmount(c.TotalDebitAmount))
 	buf.WriteString(formatAmount(c.TotalCreditAmount))
 	buf.WriteString(strings.Repeat(" ", 39)) // Reserved
@@ -575,10 +534,11 @@ func parseBatchHeader(line string) BatchHeader {
 		StandardEntryClass:       strings.TrimSpace(line[50:53]),
 		CompanyEntryDescription:  strings.TrimSpace(line[53:63]),
 		CompanyDescriptiveDate:   strings.TrimSpace(line[63:69]),
-		SettlementDate:           strings.TrimSpace(line[69:72]),
-		OriginatorStatusCode:     strings.TrimSpace(line[72:73]),
-		OriginatingDFI:           strings.TrimSpace(line[73:81]),
-		BatchNumber:              strings.TrimSpace(line[81:88]), // Correct position for batch number
+		EffectiveEntryDate:       strings.TrimSpace(line[69:75]),
+		SettlementDate:           strings.TrimSpace(line[75:78]),
+		OriginatorStatusCode:     strings.TrimSpace(line[78:79]),
+		OriginatingDFI:           strings.TrimSpace(line[79:87]),
+		BatchNumber:              strings.TrimSpace(line[87:94]),
 	}
 }
 
@@ -666,22 +626,62 @@ func parseFileControl(line string) FileControl {
 	}
 }
 
-// Helper functions for formatting
-func padRight(s string, width int) string {
+// PadRight left justifies s in a field of width characters, padded with
+// spaces and truncated when longer
+func PadRight(s string, width int) string {
 	if len(s) > width {
 		return s[:width]
 	}
 	return fmt.Sprintf("%-*s", width, s)
 }
 
+// formatAmount formats an amount in cents as the ten digit NACHA amount field
 func formatAmount(amount int64) string {
 	return fmt.Sprintf("%010d", amount)
 }
 
-func formatNumber(n int64, width int) string {
+// FormatNumber formats n zero padded to width digits
+func FormatNumber(n int64, width int) string {
 	return fmt.Sprintf("%0*d", width, n)
 }
 
+// IsDigits reports whether s is a non-empty string of ASCII digits
+func IsDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// CheckDigit computes the ABA check digit of an eight digit routing number,
+// reporting false when routing is not eight digits. The digits are weighted
+// 3, 7, 1 in turn and the check digit brings the sum to a multiple of ten.
+func CheckDigit(routing string) (string, bool) {
+	if len(routing) != 8 || !IsDigits(routing) {
+		return "", false
+	}
+
+	weights := []int{3, 7, 1, 3, 7, 1, 3, 7}
+	sum := 0
+	for i, c := range routing {
+		sum += int(c-'0') * weights[i]
+	}
+	return strconv.Itoa((10 - sum%10) % 10), true
+}
+
+// ContainsFold reports whether s is one of values, ignoring case and the
+// spaces around each value
+func ContainsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), s) {
+			return true
+		}
+	}
+	return false
+}
+
 func formatTraceNumber(base string, batchNum, entryNum int) string {
 	// If the base is longer than 15 characters, truncate it
 	if len(base) >= 15 {
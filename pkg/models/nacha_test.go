@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -46,7 +47,7 @@ func TestNachaFile_Validate(t *testing.T) {
 				Entries: []EntryDetail{
 					{
 						RecordType:             "6",
-						TransactionCode:        "22",
+						TransactionCode:        "27",
 						ReceivingDFI:           "07640125",
 						CheckDigit:             "1",
 						DFIAccountNumber:       "123456789",
@@ -168,7 +169,7 @@ func TestNachaFile_ToBytes(t *testing.T) {
 				Entries: []EntryDetail{
 					{
 						RecordType:             "6",
-						TransactionCode:        "22",
+						TransactionCode:        "27",
 						ReceivingDFI:           "07640125",
 						CheckDigit:             "1",
 						DFIAccountNumber:       "123456789",
@@ -230,4 +231,60 @@ func TestNachaFile_ToBytes(t *testing.T) {
 	assert.Equal(t, file.Header.DestinationName, parsedFile.Header.DestinationName)
 	assert.Equal(t, file.Header.OriginName, parsedFile.Header.OriginName)
 	assert.Equal(t, file.Header.ReferenceCode, parsedFile.Header.ReferenceCode)
+
+	// Test case 3: Streaming reader handles CRLF line endings and block padding
+	padded := strings.ReplaceAll(string(content), "\n", "\r\n") + strings.Repeat("9", RecordLength) + "\r\n"
+	reader := NewReader(strings.NewReader(padded))
+	streamed, err := reader.Read()
+	assert.NoError(t, err)
+	assert.Len(t, streamed.Batches, 1)
+	assert.Len(t, streamed.Batches[0].Entries, 1)
+	assert.Len(t, streamed.Batches[0].Entries[0].AddendaRecords, 1)
+	assert.Equal(t, file.Control.BatchCount, streamed.Control.BatchCount)
+	assert.Equal(t, file.Control.TotalDebitAmount, streamed.Control.TotalDebitAmount)
+	assert.Equal(t, 7, reader.Lines())
+}
+
+func TestTransactionCodeDirection(t *testing.T) {
+	// Test case 1: The second digit gives the direction for every account type
+	for _, code := range []string{"21", "22", "23", "24", "31", "32", "33", "34", "42", "52"} {
+		assert.True(t, IsCredit(code), code)
+		assert.False(t, IsDebit(code), code)
+	}
+	for _, code := range []string{"26", "27", "28", "29", "36", "37", "38", "39", "47", "55"} {
+		assert.True(t, IsDebit(code), code)
+		assert.False(t, IsCredit(code), code)
+	}
+
+	// Test case 2: Prenotes of both directions
+	for _, code := range []string{"23", "28", "33", "38"} {
+		assert.True(t, IsPrenote(code), code)
+	}
+	assert.False(t, IsPrenote("22"))
+	assert.False(t, IsPrenote("29"))
+
+	// Test case 3: Malformed codes have no direction
+	for _, code := range []string{"", "2", "220", "20", "2x", "x2", " 2"} {
+		assert.False(t, IsCredit(code), code)
+		assert.False(t, IsDebit(code), code)
+		assert.False(t, IsPrenote(code), code)
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	// Test case 1: Routing numbers complete with their ABA check digit
+	for routing, want := range map[string]string{"07640125": "1", "02100002": "1", "11100061": "4", "00000000": "0"} {
+		check, ok := CheckDigit(routing)
+		assert.True(t, ok, routing)
+		assert.Equal(t, want, check, routing)
+	}
+
+	// Test case 2: Anything but eight digits has no check digit
+	for _, routing := range []string{"", "0764012", "076401251", "0764012x", " 7640125"} {
+		_, ok := CheckDigit(routing)
+		assert.False(t, ok, routing)
+	}
+	assert.True(t, IsDigits("0123456789"))
+	assert.False(t, IsDigits(""))
+	assert.False(t, IsDigits("12 3"))
 }
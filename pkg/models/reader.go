@@ -0,0 +1,115 @@
+package models
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxLineLength bounds the length of a single line accepted by the Reader
+const maxLineLength = 1 << 20
+
+// Reader parses a NACHA file record by record from an io.Reader, so the
+// raw content never has to be held in memory as a whole
+type Reader struct {
+	scanner *bufio.Scanner
+	file    *NachaFile
+	batch   *Batch
+	entry   *EntryDetail
+	lines   int
+}
+
+// NewReader creates a new NACHA file reader
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	return &Reader{
+		scanner: scanner,
+		file:    &NachaFile{},
+	}
+}
+
+// Read consumes the whole input and returns the parsed file. Batches that are
+// not closed by a batch control record are dropped.
+func (r *Reader) Read() (*NachaFile, error) {
+	for r.scanner.Scan() {
+		r.lines++
+		r.parseLine(r.scanner.Text())
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read line %d: %v", r.lines+1, err)
+	}
+	return r.file, nil
+}
+
+// Lines returns the number of lines read so far
+func (r *Reader) Lines() int {
+	return r.lines
+}
+
+// parseLine applies a single record to the file being built
+func (r *Reader) parseLine(line string) {
+	line = strings.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+
+	if len(line) < RecordLength {
+		line = PadRight(line, RecordLength)
+	} else if len(line) > RecordLength {
+		line = line[:RecordLength]
+	}
+
+	switch line[0] {
+	case '1': // File Header
+		r.dropBatch()
+		r.file.Header = parseFileHeader(line)
+	case '5': // Batch Header
+		r.dropBatch()
+		r.batch = &Batch{Header: parseBatchHeader(line)}
+	case '6': // Entry Detail
+		if r.batch == nil {
+			return
+		}
+		r.flushEntry()
+		r.entry = parseEntryDetail(line)
+	case '7': // Addenda
+		if r.entry == nil {
+			r.dropBatch()
+			return
+		}
+		r.entry.AddendaRecords = append(r.entry.AddendaRecords, parseAddendaRecord(line))
+	case '8': // Batch Control
+		if r.batch == nil {
+			return
+		}
+		r.flushEntry()
+		r.batch.Control = parseBatchControl(line)
+		r.file.Batches = append(r.file.Batches, *r.batch)
+		r.batch = nil
+	case '9': // File Control
+		r.dropBatch()
+		if strings.Trim(line, "9") == "" {
+			// Block padding record
+			return
+		}
+		r.file.Control = parseFileControl(line)
+	default:
+		r.dropBatch()
+	}
+}
+
+// flushEntry appends the pending entry to the open batch
+func (r *Reader) flushEntry() {
+	if r.entry != nil && r.batch != nil {
+		r.batch.Entries = append(r.batch.Entries, *r.entry)
+	}
+	r.entry = nil
+}
+
+// dropBatch discards a batch that was not closed by a batch control record
+func (r *Reader) dropBatch() {
+	r.batch = nil
+	r.entry = nil
+}
@@ -0,0 +1,396 @@
+// Package moovjson holds the JSON layout of NACHA files used by the
+// moov-io/ach library, with fileHeader, batches[].batchHeader,
+// batches[].entryDetails and the control records. Codes, counts and sequence
+// numbers are JSON numbers, amounts are integer cents and dates keep their
+// NACHA text. Only 05 addenda are carried; IAT and ADV records are not
+// supported.
+package moovjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// File is a NACHA file in the moov-io/ach layout
+type File struct {
+	ID      string       `json:"id"`
+	Header  FileHeader   `json:"fileHeader"`
+	Batches []Batch      `json:"batches"`
+	Control *FileControl `json:"fileControl,omitempty"`
+	// IATBatches are only read to reject files that have them
+	IATBatches []json.RawMessage `json:"IATBatches,omitempty"`
+}
+
+// FileHeader is the file header record
+type FileHeader struct {
+	ID                       string `json:"id"`
+	PriorityCode             string `json:"priorityCode"`
+	ImmediateDestination     string `json:"immediateDestination"`
+	ImmediateOrigin          string `json:"immediateOrigin"`
+	FileCreationDate         string `json:"fileCreationDate"` // YYMMDD, or RFC 3339 on import
+	FileCreationTime         string `json:"fileCreationTime"` // HHMM
+	FileIDModifier           string `json:"fileIDModifier"`
+	ImmediateDestinationName string `json:"immediateDestinationName"`
+	ImmediateOriginName      string `json:"immediateOriginName"`
+	ReferenceCode            string `json:"referenceCode"`
+}
+
+// Batch is a batch with its entries
+type Batch struct {
+	ID           string        `json:"id"`
+	Header       BatchHeader   `json:"batchHeader"`
+	EntryDetails []EntryDetail `json:"entryDetails"`
+	Control      *BatchControl `json:"batchControl,omitempty"`
+}
+
+// BatchHeader is the batch header record
+type BatchHeader struct {
+	ID                       string `json:"id"`
+	ServiceClassCode         int    `json:"serviceClassCode"`
+	CompanyName              string `json:"companyName"`
+	CompanyDiscretionaryData string `json:"companyDiscretionaryData,omitempty"`
+	CompanyIdentification    string `json:"companyIdentification"`
+	StandardEntryClassCode   string `json:"standardEntryClassCode"`
+	CompanyEntryDescription  string `json:"companyEntryDescription"`
+	CompanyDescriptiveDate   string `json:"companyDescriptiveDate,omitempty"`
+	EffectiveEntryDate       string `json:"effectiveEntryDate"` // YYMMDD
+	SettlementDate           string `json:"settlementDate,omitempty"`
+	OriginatorStatusCode     int    `json:"originatorStatusCode"`
+	ODFIIdentification       string `json:"ODFIIdentification"`
+	BatchNumber              int    `json:"batchNumber"`
+}
+
+// EntryDetail is an entry detail record with its 05 addenda
+type EntryDetail struct {
+	ID                     string      `json:"id"`
+	TransactionCode        int         `json:"transactionCode"`
+	RDFIIdentification     string      `json:"RDFIIdentification"`
+	CheckDigit             string      `json:"checkDigit"`
+	DFIAccountNumber       string      `json:"DFIAccountNumber"`
+	Amount                 int64       `json:"amount"`
+	IdentificationNumber   string      `json:"identificationNumber,omitempty"`
+	IndividualName         string      `json:"individualName"`
+	DiscretionaryData      string      `json:"discretionaryData,omitempty"`
+	AddendaRecordIndicator int         `json:"addendaRecordIndicator,omitempty"`
+	TraceNumber            string      `json:"traceNumber,omitempty"`
+	Addenda05              []Addenda05 `json:"addenda05,omitempty"`
+	Category               string      `json:"category,omitempty"`
+}
+
+// Addenda05 is a 05 addenda record
+type Addenda05 struct {
+	ID                        string `json:"id"`
+	TypeCode                  string `json:"typeCode"`
+	PaymentRelatedInformation string `json:"paymentRelatedInformation"`
+	SequenceNumber            int    `json:"sequenceNumber"`
+	EntryDetailSequenceNumber int    `json:"entryDetailSequenceNumber"`
+}
+
+// BatchControl is the batch control record
+type BatchControl struct {
+	ID                    string `json:"id"`
+	ServiceClassCode      int    `json:"serviceClassCode"`
+	EntryAddendaCount     int    `json:"entryAddendaCount"`
+	EntryHash             int64  `json:"entryHash"`
+	TotalDebit            int64  `json:"totalDebit"`
+	TotalCredit           int64  `json:"totalCredit"`
+	CompanyIdentification string `json:"companyIdentification"`
+	MessageAuthentication string `json:"messageAuthentication,omitempty"`
+	ODFIIdentification    string `json:"ODFIIdentification"`
+	BatchNumber           int    `json:"batchNumber"`
+}
+
+// FileControl is the file control record
+type FileControl struct {
+	ID                string `json:"id"`
+	BatchCount        int    `json:"batchCount"`
+	BlockCount        int    `json:"blockCount"`
+	EntryAddendaCount int    `json:"entryAddendaCount"`
+	EntryHash         int64  `json:"entryHash"`
+	TotalDebit        int64  `json:"totalDebit"`
+	TotalCredit       int64  `json:"totalCredit"`
+}
+
+// FromFile converts a NACHA file to the moov-io/ach layout. Numeric fields
+// that do not hold a number fail the conversion.
+func FromFile(file *models.NachaFile) (*File, error) {
+	h := file.Header
+	n := numbers{}
+
+	doc := &File{
+		Header: FileHeader{
+			PriorityCode:             h.PriorityCode,
+			ImmediateDestination:     strings.TrimSpace(h.ImmediateDestination),
+			ImmediateOrigin:          strings.TrimSpace(h.ImmediateOrigin),
+			FileCreationDate:         h.FileCreationDate.Format("060102"),
+			FileCreationTime:         h.FileCreationTime,
+			FileIDModifier:           h.FileIDModifier,
+			ImmediateDestinationName: h.DestinationName,
+			ImmediateOriginName:      h.OriginName,
+			ReferenceCode:            strings.TrimSpace(h.ReferenceCode),
+		},
+		Batches: make([]Batch, len(file.Batches)),
+		Control: &FileControl{
+			BatchCount:        file.Control.BatchCount,
+			BlockCount:        file.Control.BlockCount,
+			EntryAddendaCount: file.Control.EntryAddendaCount,
+			EntryHash:         n.int64("fileControl.entryHash", file.Control.EntryHash),
+			TotalDebit:        file.Control.TotalDebitAmount,
+			TotalCredit:       file.Control.TotalCreditAmount,
+		},
+	}
+
+	for i, batch := range file.Batches {
+		b := batch.Header
+		c := batch.Control
+		at := fmt.Sprintf("batches[%d]", i)
+		out := Batch{
+			Header: BatchHeader{
+				ServiceClassCode:         n.int(at+".batchHeader.serviceClassCode", b.ServiceClassCode),
+				CompanyName:              b.CompanyName,
+				CompanyDiscretionaryData: b.CompanyDiscretionaryData,
+				CompanyIdentification:    b.CompanyIdentification,
+				StandardEntryClassCode:   b.StandardEntryClass,
+				CompanyEntryDescription:  b.CompanyEntryDescription,
+				CompanyDescriptiveDate:   b.CompanyDescriptiveDate,
+				EffectiveEntryDate:       b.EffectiveEntryDate,
+				SettlementDate:           b.SettlementDate,
+				OriginatorStatusCode:     n.int(at+".batchHeader.originatorStatusCode", b.OriginatorStatusCode),
+				ODFIIdentification:       b.OriginatingDFI,
+				BatchNumber:              n.int(at+".batchHeader.batchNumber", b.BatchNumber),
+			},
+			EntryDetails: make([]EntryDetail, len(batch.Entries)),
+			Control: &BatchControl{
+				ServiceClassCode:      n.int(at+".batchControl.serviceClassCode", c.ServiceClassCode),
+				EntryAddendaCount:     c.EntryAddendaCount,
+				EntryHash:             n.int64(at+".batchControl.entryHash", c.EntryHash),
+				TotalDebit:            c.TotalDebitAmount,
+				TotalCredit:           c.TotalCreditAmount,
+				CompanyIdentification: c.CompanyIdentification,
+				MessageAuthentication: c.MessageAuthenticationCode,
+				ODFIIdentification:    c.OriginatingDFI,
+				BatchNumber:           n.int(at+".batchControl.batchNumber", c.BatchNumber),
+			},
+		}
+		// The control repeats these from the header, but may leave them empty
+		if out.Control.ODFIIdentification == "" {
+			out.Control.ODFIIdentification = out.Header.ODFIIdentification
+		}
+		if out.Control.BatchNumber == 0 {
+			out.Control.BatchNumber = out.Header.BatchNumber
+		}
+
+		for j, entry := range batch.Entries {
+			at := fmt.Sprintf("%s.entryDetails[%d]", at, j)
+			e := EntryDetail{
+				TransactionCode:        n.int(at+".transactionCode", entry.TransactionCode),
+				RDFIIdentification:     entry.ReceivingDFI,
+				CheckDigit:             entry.CheckDigit,
+				DFIAccountNumber:       entry.DFIAccountNumber,
+				Amount:                 entry.Amount,
+				IdentificationNumber:   entry.IndividualIDNumber,
+				IndividualName:         entry.IndividualName,
+				DiscretionaryData:      entry.DiscretionaryData,
+				AddendaRecordIndicator: n.int(at+".addendaRecordIndicator", entry.AddendaRecordIndicator),
+				TraceNumber:            entry.TraceNumber,
+				Category:               "Forward",
+			}
+			for k, a := range entry.AddendaRecords {
+				at := fmt.Sprintf("%s.addenda05[%d]", at, k)
+				e.Addenda05 = append(e.Addenda05, Addenda05{
+					TypeCode:                  a.AddendaTypeCode,
+					PaymentRelatedInformation: a.PaymentRelatedInformation,
+					SequenceNumber:            n.int(at+".sequenceNumber", a.AddendaSequenceNumber),
+					EntryDetailSequenceNumber: n.int(at+".entryDetailSequenceNumber", a.EntryDetailSequenceNumber),
+				})
+			}
+			out.EntryDetails[j] = e
+		}
+		doc.Batches[i] = out
+	}
+
+	if n.err != nil {
+		return nil, n.err
+	}
+	return doc, nil
+}
+
+// HasControls reports whether the file and every batch carry their controls
+func (f *File) HasControls() bool {
+	if f.Control == nil {
+		return false
+	}
+	for _, batch := range f.Batches {
+		if batch.Control == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ToFile converts the layout to a NACHA file. The record size, blocking
+// factor and format code, which the layout leaves out, take their standard
+// values. Missing controls are left empty for the creator to compute.
+func (f *File) ToFile() (*models.NachaFile, error) {
+	if len(f.IATBatches) > 0 {
+		return nil, fmt.Errorf("IATBatches are not supported")
+	}
+
+	h := f.Header
+	created, err := creationDate(h.FileCreationDate)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &models.NachaFile{
+		Header: models.FileHeader{
+			RecordType:           "1",
+			PriorityCode:         h.PriorityCode,
+			ImmediateDestination: h.ImmediateDestination,
+			ImmediateOrigin:      h.ImmediateOrigin,
+			FileCreationDate:     created,
+			FileCreationTime:     h.FileCreationTime,
+			FileIDModifier:       h.FileIDModifier,
+			RecordSize:           "094",
+			BlockingFactor:       "10",
+			FormatCode:           "1",
+			DestinationName:      h.ImmediateDestinationName,
+			OriginName:           h.ImmediateOriginName,
+			ReferenceCode:        h.ReferenceCode,
+		},
+		Batches: make([]models.Batch, len(f.Batches)),
+	}
+	if file.Header.PriorityCode == "" {
+		file.Header.PriorityCode = "01"
+	}
+
+	for i, batch := range f.Batches {
+		b := batch.Header
+		out := models.Batch{
+			Header: models.BatchHeader{
+				RecordType:               "5",
+				ServiceClassCode:         fmt.Sprintf("%03d", b.ServiceClassCode),
+				CompanyName:              b.CompanyName,
+				CompanyDiscretionaryData: b.CompanyDiscretionaryData,
+				CompanyIdentification:    b.CompanyIdentification,
+				StandardEntryClass:       b.StandardEntryClassCode,
+				CompanyEntryDescription:  b.CompanyEntryDescription,
+				CompanyDescriptiveDate:   b.CompanyDescriptiveDate,
+				EffectiveEntryDate:       b.EffectiveEntryDate,
+				SettlementDate:           b.SettlementDate,
+				OriginatorStatusCode:     strconv.Itoa(b.OriginatorStatusCode),
+				OriginatingDFI:           b.ODFIIdentification,
+			},
+			Entries: make([]models.EntryDetail, len(batch.EntryDetails)),
+		}
+		if b.BatchNumber > 0 {
+			out.Header.BatchNumber = fmt.Sprintf("%07d", b.BatchNumber)
+		}
+
+		for j, entry := range batch.EntryDetails {
+			e := models.EntryDetail{
+				RecordType:             "6",
+				TransactionCode:        fmt.Sprintf("%02d", entry.TransactionCode),
+				ReceivingDFI:           entry.RDFIIdentification,
+				CheckDigit:             entry.CheckDigit,
+				DFIAccountNumber:       entry.DFIAccountNumber,
+				Amount:                 entry.Amount,
+				IndividualIDNumber:     entry.IdentificationNumber,
+				IndividualName:         entry.IndividualName,
+				DiscretionaryData:      entry.DiscretionaryData,
+				AddendaRecordIndicator: strconv.Itoa(entry.AddendaRecordIndicator),
+				TraceNumber:            entry.TraceNumber,
+			}
+			for _, a := range entry.Addenda05 {
+				e.AddendaRecords = append(e.AddendaRecords, models.AddendaRecord{
+					AddendaTypeCode:           a.TypeCode,
+					PaymentRelatedInformation: a.PaymentRelatedInformation,
+					AddendaSequenceNumber:     fmt.Sprintf("%04d", a.SequenceNumber),
+					EntryDetailSequenceNumber: fmt.Sprintf("%07d", a.EntryDetailSequenceNumber),
+				})
+			}
+			if len(e.AddendaRecords) > 0 {
+				e.AddendaRecordIndicator = "1"
+			}
+			out.Entries[j] = e
+		}
+
+		if c := batch.Control; c != nil {
+			out.Control = models.BatchControl{
+				RecordType:                "8",
+				ServiceClassCode:          fmt.Sprintf("%03d", c.ServiceClassCode),
+				EntryAddendaCount:         c.EntryAddendaCount,
+				EntryHash:                 fmt.Sprintf("%010d", c.EntryHash),
+				TotalDebitAmount:          c.TotalDebit,
+				TotalCreditAmount:         c.TotalCredit,
+				CompanyIdentification:     c.CompanyIdentification,
+				MessageAuthenticationCode: c.MessageAuthentication,
+				OriginatingDFI:            c.ODFIIdentification,
+				BatchNumber:               fmt.Sprintf("%07d", c.BatchNumber),
+			}
+		}
+		file.Batches[i] = out
+	}
+
+	if c := f.Control; c != nil {
+		file.Control = models.FileControl{
+			RecordType:        "9",
+			BatchCount:        c.BatchCount,
+			BlockCount:        c.BlockCount,
+			EntryAddendaCount: c.EntryAddendaCount,
+			EntryHash:         fmt.Sprintf("%010d", c.EntryHash),
+			TotalDebitAmount:  c.TotalDebit,
+			TotalCreditAmount: c.TotalCredit,
+		}
+	}
+	return file, nil
+}
+
+// IsDocument reports whether data is a JSON object with a fileHeader, as
+// written by moov-io/ach
+func IsDocument(data []byte) bool {
+	var probe struct {
+		Header *json.RawMessage `json:"fileHeader"`
+	}
+	return json.Unmarshal(data, &probe) == nil && probe.Header != nil
+}
+
+// creationDate parses a YYMMDD file creation date, or an RFC 3339 timestamp
+// as accepted by moov-io/ach
+func creationDate(value string) (time.Time, error) {
+	if date, err := time.Parse("060102", value); err == nil {
+		return date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("fileHeader.fileCreationDate must be YYMMDD: %q", value)
+	}
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+// numbers converts numeric text fields, keeping the first failure
+type numbers struct {
+	err error
+}
+
+func (n *numbers) int(field, value string) int {
+	return int(n.int64(field, value))
+}
+
+func (n *numbers) int64(field, value string) int64 {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil && n.err == nil {
+		n.err = fmt.Errorf("%s must be a number: %q", field, value)
+	}
+	return v
+}
@@ -0,0 +1,464 @@
+// Package nachajson holds the versioned JSON schema of NACHA files, written
+// by the JSON exporter and read by ImportFromJson. Keys are snake_case, dates
+// are ISO 8601 and amounts are decimal strings of dollars or integer cents.
+// The schema is published as a JSON Schema document in Schema.
+package nachajson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nacha-service/pkg/models"
+)
+
+// Version is the schema version written in schema_version. Documents with
+// the same major version can be read.
+const Version = "1.0"
+
+// Date and time layouts of the schema
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04"
+)
+
+// File is a NACHA file. Controls are optional when they are recomputed on import.
+type File struct {
+	SchemaVersion string       `json:"schema_version"`
+	Header        FileHeader   `json:"file_header"`
+	Batches       []Batch      `json:"batches"`
+	Control       *FileControl `json:"file_control,omitempty"`
+}
+
+// FileHeader is the file header record
+type FileHeader struct {
+	PriorityCode             string `json:"priority_code"`
+	ImmediateDestination     string `json:"immediate_destination"`
+	ImmediateOrigin          string `json:"immediate_origin"`
+	FileCreationDate         string `json:"file_creation_date"` // YYYY-MM-DD
+	FileCreationTime         string `json:"file_creation_time"` // HH:MM
+	FileIDModifier           string `json:"file_id_modifier"`
+	RecordSize               string `json:"record_size"`
+	BlockingFactor           string `json:"blocking_factor"`
+	FormatCode               string `json:"format_code"`
+	ImmediateDestinationName string `json:"immediate_destination_name"`
+	ImmediateOriginName      string `json:"immediate_origin_name"`
+	ReferenceCode            string `json:"reference_code"`
+}
+
+// Batch is a batch with its entries
+type Batch struct {
+	Header  BatchHeader   `json:"header"`
+	Entries []Entry       `json:"entries"`
+	Control *BatchControl `json:"control,omitempty"`
+}
+
+// BatchHeader is the batch header record
+type BatchHeader struct {
+	ServiceClassCode         string `json:"service_class_code"`
+	CompanyName              string `json:"company_name"`
+	CompanyDiscretionaryData string `json:"company_discretionary_data"`
+	CompanyIdentification    string `json:"company_identification"`
+	StandardEntryClass       string `json:"standard_entry_class"`
+	CompanyEntryDescription  string `json:"company_entry_description"`
+	CompanyDescriptiveDate   string `json:"company_descriptive_date"`
+	EffectiveEntryDate       string `json:"effective_entry_date"` // YYYY-MM-DD
+	SettlementDate           string `json:"settlement_date"`      // Julian day, set by the ACH operator
+	OriginatorStatusCode     string `json:"originator_status_code"`
+	OriginatingDFI           string `json:"originating_dfi"`
+	BatchNumber              string `json:"batch_number"`
+}
+
+// Entry is an entry detail record with its addenda
+type Entry struct {
+	TransactionCode        string    `json:"transaction_code"`
+	ReceivingDFI           string    `json:"receiving_dfi"`
+	CheckDigit             string    `json:"check_digit"`
+	DFIAccountNumber       string    `json:"dfi_account_number"`
+	Amount                 Amount    `json:"amount"`
+	IndividualIDNumber     string    `json:"individual_id_number"`
+	IndividualName         string    `json:"individual_name"`
+	DiscretionaryData      string    `json:"discretionary_data"`
+	AddendaRecordIndicator string    `json:"addenda_record_indicator"`
+	TraceNumber            string    `json:"trace_number"`
+	Addenda                []Addenda `json:"addenda"`
+}
+
+// Addenda is an addenda record
+type Addenda struct {
+	AddendaTypeCode           string `json:"addenda_type_code"`
+	PaymentRelatedInformation string `json:"payment_related_information"`
+	AddendaSequenceNumber     string `json:"addenda_sequence_number"`
+	EntryDetailSequenceNumber string `json:"entry_detail_sequence_number"`
+}
+
+// BatchControl is the batch control record
+type BatchControl struct {
+	ServiceClassCode          string `json:"service_class_code"`
+	EntryAddendaCount         int    `json:"entry_addenda_count"`
+	EntryHash                 string `json:"entry_hash"`
+	TotalDebitAmount          Amount `json:"total_debit_amount"`
+	TotalCreditAmount         Amount `json:"total_credit_amount"`
+	CompanyIdentification     string `json:"company_identification"`
+	MessageAuthenticationCode string `json:"message_authentication_code"`
+	OriginatingDFI            string `json:"originating_dfi"`
+	BatchNumber               string `json:"batch_number"`
+}
+
+// FileControl is the file control record
+type FileControl struct {
+	BatchCount        int    `json:"batch_count"`
+	BlockCount        int    `json:"block_count"`
+	EntryAddendaCount int    `json:"entry_addenda_count"`
+	EntryHash         string `json:"entry_hash"`
+	TotalDebitAmount  Amount `json:"total_debit_amount"`
+	TotalCreditAmount Amount `json:"total_credit_amount"`
+}
+
+// Amount is an amount in cents. It is written as a decimal string of
+// dollars, such as "7500.00", and read from such a string or from an integer
+// number of cents.
+type Amount int64
+
+// MarshalJSON writes the amount as a decimal string
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FormatAmount(int64(a)))
+}
+
+// UnmarshalJSON reads a decimal string of dollars or an integer of cents
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		cents, err := ParseAmount(s)
+		if err != nil {
+			return err
+		}
+		*a = Amount(cents)
+		return nil
+	}
+
+	cents, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || cents < 0 {
+		return fmt.Errorf("amount must be a decimal string or a non-negative integer of cents: %s", data)
+	}
+	*a = Amount(cents)
+	return nil
+}
+
+// FormatAmount writes cents as a decimal string of dollars
+func FormatAmount(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// ParseAmount parses a decimal string of dollars with at most two decimal
+// places into cents
+func ParseAmount(s string) (int64, error) {
+	units, fraction, hasPoint := strings.Cut(strings.TrimSpace(s), ".")
+	if units == "" || len(fraction) > 2 || (hasPoint && fraction == "") {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	cents, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil || cents < 0 || strings.ContainsAny(units, "+-") {
+		return 0, fmt.Errorf("invalid amount: %q", s)
+	}
+	return cents, nil
+}
+
+// FromFile converts a NACHA file to the schema. The file creation date and
+// time are written in UTC.
+func FromFile(file *models.NachaFile) *File {
+	return FromFileIn(file, nil)
+}
+
+// FromFileIn converts a NACHA file to the schema with the file creation date
+// and time shown in location. A nil location keeps them in UTC.
+func FromFileIn(file *models.NachaFile, location *time.Location) *File {
+	h := file.Header
+	created := creationTimestamp(&h)
+	if location != nil {
+		created = created.In(location)
+	}
+
+	doc := &File{
+		SchemaVersion: Version,
+		Header: FileHeader{
+			PriorityCode:             h.PriorityCode,
+			ImmediateDestination:     h.ImmediateDestination,
+			ImmediateOrigin:          h.ImmediateOrigin,
+			FileCreationDate:         created.Format(DateLayout),
+			FileCreationTime:         created.Format(TimeLayout),
+			FileIDModifier:           h.FileIDModifier,
+			RecordSize:               h.RecordSize,
+			BlockingFactor:           h.BlockingFactor,
+			FormatCode:               h.FormatCode,
+			ImmediateDestinationName: h.DestinationName,
+			ImmediateOriginName:      h.OriginName,
+			ReferenceCode:            h.ReferenceCode,
+		},
+		Batches: make([]Batch, len(file.Batches)),
+		Control: &FileControl{
+			BatchCount:        file.Control.BatchCount,
+			BlockCount:        file.Control.BlockCount,
+			EntryAddendaCount: file.Control.EntryAddendaCount,
+			EntryHash:         file.Control.EntryHash,
+			TotalDebitAmount:  Amount(file.Control.TotalDebitAmount),
+			TotalCreditAmount: Amount(file.Control.TotalCreditAmount),
+		},
+	}
+
+	for i, batch := range file.Batches {
+		b := batch.Header
+		c := batch.Control
+		out := Batch{
+			Header: BatchHeader{
+				ServiceClassCode:         b.ServiceClassCode,
+				CompanyName:              b.CompanyName,
+				CompanyDiscretionaryData: b.CompanyDiscretionaryData,
+				CompanyIdentification:    b.CompanyIdentification,
+				StandardEntryClass:       b.StandardEntryClass,
+				CompanyEntryDescription:  b.CompanyEntryDescription,
+				CompanyDescriptiveDate:   b.CompanyDescriptiveDate,
+				EffectiveEntryDate:       isoDate(b.EffectiveEntryDate),
+				SettlementDate:           b.SettlementDate,
+				OriginatorStatusCode:     b.OriginatorStatusCode,
+				OriginatingDFI:           b.OriginatingDFI,
+				BatchNumber:              b.BatchNumber,
+			},
+			Entries: make([]Entry, len(batch.Entries)),
+			Control: &BatchControl{
+				ServiceClassCode:          c.ServiceClassCode,
+				EntryAddendaCount:         c.EntryAddendaCount,
+				EntryHash:                 c.EntryHash,
+				TotalDebitAmount:          Amount(c.TotalDebitAmount),
+				TotalCreditAmount:         Amount(c.TotalCreditAmount),
+				CompanyIdentification:     c.CompanyIdentification,
+				MessageAuthenticationCode: c.MessageAuthenticationCode,
+				OriginatingDFI:            c.OriginatingDFI,
+				BatchNumber:               c.BatchNumber,
+			},
+		}
+		for j, entry := range batch.Entries {
+			out.Entries[j] = FromEntry(&entry)
+		}
+		doc.Batches[i] = out
+	}
+	return doc
+}
+
+// FromEntry converts an entry and its addenda to the schema
+func FromEntry(entry *models.EntryDetail) Entry {
+	out := Entry{
+		TransactionCode:        entry.TransactionCode,
+		ReceivingDFI:           entry.ReceivingDFI,
+		CheckDigit:             entry.CheckDigit,
+		DFIAccountNumber:       entry.DFIAccountNumber,
+		Amount:                 Amount(entry.Amount),
+		IndividualIDNumber:     entry.IndividualIDNumber,
+		IndividualName:         entry.IndividualName,
+		DiscretionaryData:      entry.DiscretionaryData,
+		AddendaRecordIndicator: entry.AddendaRecordIndicator,
+		TraceNumber:            entry.TraceNumber,
+		Addenda:                make([]Addenda, len(entry.AddendaRecords)),
+	}
+	for k, a := range entry.AddendaRecords {
+		out.Addenda[k] = Addenda{
+			AddendaTypeCode:           a.AddendaTypeCode,
+			PaymentRelatedInformation: a.PaymentRelatedInformation,
+			AddendaSequenceNumber:     a.AddendaSequenceNumber,
+			EntryDetailSequenceNumber: a.EntryDetailSequenceNumber,
+		}
+	}
+	return out
+}
+
+// HasControls reports whether the file and every batch carry their controls
+func (f *File) HasControls() bool {
+	if f.Control == nil {
+		return false
+	}
+	for _, batch := range f.Batches {
+		if batch.Control == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ToFile converts the schema to a NACHA file. Missing controls are left
+// empty for the creator to compute.
+func (f *File) ToFile() (*models.NachaFile, error) {
+	if err := CheckVersion(f.SchemaVersion); err != nil {
+		return nil, err
+	}
+
+	h := f.Header
+	date, err := time.Parse(DateLayout, h.FileCreationDate)
+	if err != nil {
+		return nil, fmt.Errorf("file_header.file_creation_date must be YYYY-MM-DD: %q", h.FileCreationDate)
+	}
+	created, err := time.Parse(TimeLayout, h.FileCreationTime)
+	if err != nil {
+		return nil, fmt.Errorf("file_header.file_creation_time must be HH:MM: %q", h.FileCreationTime)
+	}
+
+	file := &models.NachaFile{
+		Header: models.FileHeader{
+			RecordType:           "1",
+			PriorityCode:         h.PriorityCode,
+			ImmediateDestination: h.ImmediateDestination,
+			ImmediateOrigin:      h.ImmediateOrigin,
+			FileCreationDate:     date,
+			FileCreationTime:     created.Format("1504"),
+			FileIDModifier:       h.FileIDModifier,
+			RecordSize:           h.RecordSize,
+			BlockingFactor:       h.BlockingFactor,
+			FormatCode:           h.FormatCode,
+			DestinationName:      h.ImmediateDestinationName,
+			OriginName:           h.ImmediateOriginName,
+			ReferenceCode:        h.ReferenceCode,
+		},
+		Batches: make([]models.Batch, len(f.Batches)),
+	}
+
+	for i, batch := range f.Batches {
+		b := batch.Header
+		effective := ""
+		if b.EffectiveEntryDate != "" {
+			date, err := time.Parse(DateLayout, b.EffectiveEntryDate)
+			if err != nil {
+				return nil, fmt.Errorf("batches[%d].header.effective_entry_date must be YYYY-MM-DD: %q", i, b.EffectiveEntryDate)
+			}
+			effective = date.Format("060102")
+		}
+
+		out := models.Batch{
+			Header: models.BatchHeader{
+				RecordType:               "5",
+				ServiceClassCode:         b.ServiceClassCode,
+				CompanyName:              b.CompanyName,
+				CompanyDiscretionaryData: b.CompanyDiscretionaryData,
+				CompanyIdentification:    b.CompanyIdentification,
+				StandardEntryClass:       b.StandardEntryClass,
+				CompanyEntryDescription:  b.CompanyEntryDescription,
+				CompanyDescriptiveDate:   b.CompanyDescriptiveDate,
+				EffectiveEntryDate:       effective,
+				SettlementDate:           b.SettlementDate,
+				OriginatorStatusCode:     b.OriginatorStatusCode,
+				OriginatingDFI:           b.OriginatingDFI,
+				BatchNumber:              b.BatchNumber,
+			},
+			Entries: make([]models.EntryDetail, len(batch.Entries)),
+		}
+
+		for j, entry := range batch.Entries {
+			e := models.EntryDetail{
+				RecordType:             "6",
+				TransactionCode:        entry.TransactionCode,
+				ReceivingDFI:           entry.ReceivingDFI,
+				CheckDigit:             entry.CheckDigit,
+				DFIAccountNumber:       entry.DFIAccountNumber,
+				Amount:                 int64(entry.Amount),
+				IndividualIDNumber:     entry.IndividualIDNumber,
+				IndividualName:         entry.IndividualName,
+				DiscretionaryData:      entry.DiscretionaryData,
+				AddendaRecordIndicator: entry.AddendaRecordIndicator,
+				TraceNumber:            entry.TraceNumber,
+			}
+			for _, a := range entry.Addenda {
+				e.AddendaRecords = append(e.AddendaRecords, models.AddendaRecord{
+					AddendaTypeCode:           a.AddendaTypeCode,
+					PaymentRelatedInformation: a.PaymentRelatedInformation,
+					AddendaSequenceNumber:     a.AddendaSequenceNumber,
+					EntryDetailSequenceNumber: a.EntryDetailSequenceNumber,
+				})
+			}
+			out.Entries[j] = e
+		}
+
+		if c := batch.Control; c != nil {
+			out.Control = models.BatchControl{
+				RecordType:                "8",
+				ServiceClassCode:          c.ServiceClassCode,
+				EntryAddendaCount:         c.EntryAddendaCount,
+				EntryHash:                 c.EntryHash,
+				TotalDebitAmount:          int64(c.TotalDebitAmount),
+				TotalCreditAmount:         int64(c.TotalCreditAmount),
+				CompanyIdentification:     c.CompanyIdentification,
+				MessageAuthenticationCode: c.MessageAuthenticationCode,
+				OriginatingDFI:            c.OriginatingDFI,
+				BatchNumber:               c.BatchNumber,
+			}
+			// The control repeats these from the header, but may leave them empty
+			if out.Control.OriginatingDFI == "" {
+				out.Control.OriginatingDFI = out.Header.OriginatingDFI
+			}
+			if out.Control.BatchNumber == "" {
+				out.Control.BatchNumber = out.Header.BatchNumber
+			}
+		}
+		file.Batches[i] = out
+	}
+
+	if c := f.Control; c != nil {
+		file.Control = models.FileControl{
+			RecordType:        "9",
+			BatchCount:        c.BatchCount,
+			BlockCount:        c.BlockCount,
+			EntryAddendaCount: c.EntryAddendaCount,
+			EntryHash:         c.EntryHash,
+			TotalDebitAmount:  int64(c.TotalDebitAmount),
+			TotalCreditAmount: int64(c.TotalCreditAmount),
+		}
+	}
+	return file, nil
+}
+
+// CheckVersion accepts schema versions with the major version of Version
+func CheckVersion(version string) error {
+	major, _, _ := strings.Cut(Version, ".")
+	got, _, _ := strings.Cut(version, ".")
+	if version == "" || got != major {
+		return fmt.Errorf("unsupported schema_version %q (supported: %s.x)", version, major)
+	}
+	return nil
+}
+
+// IsDocument reports whether data is a JSON object with a schema_version,
+// as opposed to the unversioned layout of earlier exports
+func IsDocument(data []byte) bool {
+	var probe struct {
+		SchemaVersion *string `json:"schema_version"`
+	}
+	return json.Unmarshal(data, &probe) == nil && probe.SchemaVersion != nil
+}
+
+// creationTimestamp combines the file creation date and time
+func creationTimestamp(h *models.FileHeader) time.Time {
+	created := h.FileCreationDate
+	if t, err := time.Parse("1504", h.FileCreationTime); err == nil {
+		created = created.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+	}
+	return created
+}
+
+// isoDate converts a YYMMDD date to YYYY-MM-DD, keeping other values as they are
+func isoDate(value string) string {
+	if date, err := time.Parse("060102", value); err == nil {
+		return date.Format(DateLayout)
+	}
+	return value
+}
@@ -0,0 +1,8 @@
+package nachajson
+
+import _ "embed"
+
+// Schema is the JSON Schema document of the current schema version
+//
+//go:embed schema.json
+var Schema []byte
@@ -0,0 +1,132 @@
+// Package remittance decodes the payment related information of 05 addenda
+// into the lines of a remittance advice. Addenda in ANSI X12 syntax, as sent
+// with CCD+ and CTX payments, are read segment by segment, with segments
+// ended by a backslash or a tilde and elements separated by asterisks:
+//
+//	RMR*IV*INV-1001**1500.00*1550.00*50.00\NTE*INV*MARCH SERVICES\
+//
+// is invoice INV-1001 of 1550.00 paid with 1500.00 after a 50.00 discount,
+// described as March services. Other text is free form: each addenda record
+// is a line, split further at semicolons.
+package remittance
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Line is a line of a remittance advice
+type Line struct {
+	// Qualifier is the X12 reference qualifier, such as IV for an invoice or
+	// PO for a purchase order, and is empty for free-form text
+	Qualifier   string
+	Reference   string
+	Description string
+	// Paid, Gross and Discount are the amount paid, the invoice amount and
+	// the discount taken, in cents
+	Paid     int64
+	Gross    int64
+	Discount int64
+}
+
+// Kind describes the qualifier of the line, such as Invoice for IV
+func (l Line) Kind() string {
+	if kind, ok := qualifiers[l.Qualifier]; ok {
+		return kind
+	}
+	return l.Qualifier
+}
+
+// qualifiers names the common X12 reference qualifiers
+var qualifiers = map[string]string{
+	"IV": "Invoice",
+	"PO": "Purchase Order",
+	"CR": "Credit Memo",
+	"CM": "Credit Memo",
+	"DM": "Debit Memo",
+	"VV": "Voucher",
+	"R7": "Accounts Receivable",
+	"OI": "Original Invoice",
+}
+
+// x12Segment matches the start of an X12 segment, such as RMR*
+var x12Segment = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,2}\*`)
+
+// Parse decodes the payment related information of the addenda of an entry,
+// in addenda sequence order
+func Parse(texts ...string) []Line {
+	var joined strings.Builder
+	for _, text := range texts {
+		joined.WriteString(strings.TrimRight(text, " "))
+	}
+	if x12Segment.MatchString(strings.TrimSpace(joined.String())) {
+		return parseX12(strings.TrimSpace(joined.String()))
+	}
+
+	var lines []Line
+	for _, text := range texts {
+		for _, part := range strings.Split(text, ";") {
+			if part = strings.TrimSpace(part); part != "" {
+				lines = append(lines, Line{Description: part})
+			}
+		}
+	}
+	return lines
+}
+
+// parseX12 reads the RMR, REF and NTE segments of X12 text; other segments,
+// such as DTM dates, are skipped
+func parseX12(text string) []Line {
+	var lines []Line
+	segments := strings.FieldsFunc(text, func(r rune) bool { return r == '\\' || r == '~' })
+	for _, segment := range segments {
+		elements := strings.Split(strings.TrimSpace(segment), "*")
+		element := func(i int) string {
+			if i < len(elements) {
+				return strings.TrimSpace(elements[i])
+			}
+			return ""
+		}
+
+		switch elements[0] {
+		case "RMR":
+			lines = append(lines, Line{
+				Qualifier: element(1),
+				Reference: element(2),
+				Paid:      amount(element(4)),
+				Gross:     amount(element(5)),
+				Discount:  amount(element(6)),
+			})
+		case "REF":
+			lines = append(lines, Line{
+				Qualifier:   element(1),
+				Reference:   element(2),
+				Description: element(3),
+			})
+		case "NTE":
+			note := element(2)
+			if len(lines) == 0 {
+				lines = append(lines, Line{Description: note})
+				continue
+			}
+			last := &lines[len(lines)-1]
+			if last.Description != "" {
+				note = last.Description + " " + note
+			}
+			last.Description = note
+		}
+	}
+	return lines
+}
+
+// amount reads an X12 monetary amount in dollars, such as 1500.00 or -25,
+// as cents; amounts that cannot be read are zero
+func amount(value string) int64 {
+	dollars, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(math.Round(dollars * 100))
+}
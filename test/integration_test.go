@@ -52,7 +52,7 @@ func TestCompleteNachaWorkflow(t *testing.T) {
 				Entries: []*pb.EntryDetailRequest{
 					{
 						RecordType:                     "6",
-						TransactionCode:                "22",
+						TransactionCode:                "27",
 						ReceivingDfiIdentification:     "07640125",
 						CheckDigit:                     "1",
 						DfiAccountNumber:               "123456789",
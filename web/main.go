@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -18,6 +19,13 @@ import (
 	"sync"
 	"time"
 	"unicode/utf8"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "nacha-web/proto"
 )
 
 // Gerenciador de arquivos em sessão
@@ -32,6 +40,7 @@ type FileSession struct {
 	FilePath   string     `json:"filepath"`
 	Content    string     `json:"content"`
 	ParsedData *NachaData `json:"parsed_data"`
+	FileID     string     `json:"file_id"` // file_id do UploadFile no servidor gRPC
 	UploadTime time.Time  `json:"upload_time"`
 	ExpiryTime time.Time  `json:"expiry_time"`
 }
@@ -117,6 +126,16 @@ func (fm *FileManager) GetFile(sessionID string) (*FileSession, bool) {
 	return session, true
 }
 
+// Guardar o file_id do servidor gRPC para não reenviar o conteúdo
+func (fm *FileManager) SetFileID(sessionID, fileID string) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+
+	if session, exists := fm.files[sessionID]; exists {
+		session.FileID = fileID
+	}
+}
+
 // Listar arquivos ativos
 func (fm *FileManager) GetActiveFiles() []*FileSession {
 	fm.mutex.RLock()
@@ -659,43 +678,60 @@ func viewHandler(w http.ResponseWriter, r *http.Request) {
 	currentSession := getCurrentSession(r)
 
 	if r.Method == "POST" {
-		var response string
+		// Paginação dos lotes e das entradas pelos tokens do ViewFile
+		request := &pb.FileRequest{
+			BatchPageSize:  defaultViewPageSize,
+			BatchPageToken: r.FormValue("batch_page_token"),
+			EntryPageSize:  defaultViewEntryPageSize,
+			EntryPageToken: r.FormValue("entry_page_token"),
+		}
+		if v, err := strconv.Atoi(r.FormValue("batch_page_size")); err == nil && v >= 0 {
+			request.BatchPageSize = int32(v)
+		}
+		if v, err := strconv.Atoi(r.FormValue("entry_page_size")); err == nil && v >= 0 {
+			request.EntryPageSize = int32(v)
+		}
 
+		// Os links de página enviam apenas o file_id, nunca o conteúdo
+		fileID := r.FormValue("file_id")
+		content := r.FormValue("content")
 		if currentSession != nil {
 			// Visualizar arquivo da sessão atual
-			response = callClientView(currentSession.Content)
-		} else {
-			// Visualizar arquivo enviado via formulário
-			content := r.FormValue("content")
-			if content == "" {
-				data := PageData{
-					Title:          "Visualizar Arquivo",
-					Error:          "Conteúdo do arquivo não pode estar vazio",
-					CurrentSession: currentSession,
-				}
-				renderTemplate(w, "base.html", data)
-				return
+			content = currentSession.Content
+			if fileID == "" {
+				fileID = currentSession.FileID
 			}
-			response = callClientView(content)
-		}
-
-		// Tentar parsear como JSON
-		var viewData map[string]interface{}
-		if err := json.Unmarshal([]byte(response), &viewData); err == nil {
+		} else if content == "" && fileID == "" {
+			// Visualizar arquivo enviado via formulário
 			data := PageData{
-				Title:          "Conteúdo do Arquivo",
-				Data:           viewData,
+				Title:          "Visualizar Arquivo",
+				Error:          "Conteúdo do arquivo não pode estar vazio",
 				CurrentSession: currentSession,
 			}
 			renderTemplate(w, "base.html", data)
-		} else {
+			return
+		}
+
+		response, fileID, err := callClientView(content, fileID, request)
+		if err != nil {
 			data := PageData{
 				Title:          "Visualizar Arquivo",
-				Message:        response,
+				Message:        fmt.Sprintf("❌ Erro ao visualizar arquivo: %v", status.Convert(err).Message()),
 				CurrentSession: currentSession,
 			}
 			renderTemplate(w, "base.html", data)
+			return
+		}
+		if currentSession != nil {
+			fileManager.SetFileID(currentSession.ID, fileID)
+		}
+
+		data := PageData{
+			Title:          "Conteúdo do Arquivo",
+			Data:           viewData(response, request, fileID, r.Form["batch_history"], r.FormValue("entry_batch"), r.Form["entry_history"]),
+			CurrentSession: currentSession,
 		}
+		renderTemplate(w, "base.html", data)
 		return
 	}
 
@@ -912,18 +948,179 @@ func callClientValidate(content string) string {
 	return strings.Join(validationResults, "\n")
 }
 
-func callClientView(content string) string {
-	data, err := parseNachaContent(content)
+// Quantidade padrão de lotes e de entradas por lote em cada página da visualização
+const (
+	defaultViewPageSize      = 50
+	defaultViewEntryPageSize = 20
+)
+
+// Endereço padrão do servidor gRPC NACHA, alterado por GRPC_SERVER
+const defaultServerAddress = "localhost:50051"
+
+var (
+	nachaConn     *grpc.ClientConn
+	nachaConnErr  error
+	nachaConnOnce sync.Once
+)
+
+// Cliente do servidor gRPC NACHA, com a conexão criada na primeira chamada
+func nachaClient() (pb.NachaServiceClient, error) {
+	nachaConnOnce.Do(func() {
+		address := os.Getenv("GRPC_SERVER")
+		if address == "" {
+			address = defaultServerAddress
+		}
+		nachaConn, nachaConnErr = grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	})
+	if nachaConnErr != nil {
+		return nil, nachaConnErr
+	}
+	return pb.NewNachaServiceClient(nachaConn), nil
+}
+
+// Visualizar uma página do arquivo pelo ViewFile. O conteúdo é enviado uma
+// única vez pelo UploadFile e as páginas seguintes usam o file_id devolvido,
+// que é enviado de novo quando expira no servidor.
+func callClientView(content, fileID string, request *pb.FileRequest) (*pb.FileDetailsResponse, string, error) {
+	client, err := nachaClient()
 	if err != nil {
-		return fmt.Sprintf("❌ Erro ao analisar arquivo: %v", err)
+		return nil, "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	upload := func() error {
+		if content == "" {
+			return fmt.Errorf("arquivo expirado no servidor, envie o conteúdo novamente")
+		}
+		resp, err := client.UploadFile(ctx, &pb.UploadRequest{FileContent: []byte(content)})
+		if err != nil {
+			return err
+		}
+		fileID = resp.FileId
+		return nil
 	}
 
-	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if fileID == "" {
+		if err := upload(); err != nil {
+			return nil, "", err
+		}
+	}
+	request.FileId = fileID
+	response, err := client.ViewFile(ctx, request)
+	if status.Code(err) == codes.NotFound {
+		if err := upload(); err != nil {
+			return nil, "", err
+		}
+		request.FileId = fileID
+		response, err = client.ViewFile(ctx, request)
+	}
 	if err != nil {
-		return fmt.Sprintf("❌ Erro ao formatar dados: %v", err)
+		return nil, "", err
 	}
+	return response, fileID, nil
+}
+
+// Montar os dados do template a partir da resposta do ViewFile. Os tokens são
+// opacos: o histórico de tokens das páginas anteriores volta nos formulários
+// e dá o token da página anterior e a posição dos lotes e entradas mostrados.
+func viewData(response *pb.FileDetailsResponse, request *pb.FileRequest, fileID string, batchHistory []string, entryBatch string, entryHistory []string) map[string]interface{} {
+	header := make(map[string]interface{})
+	if h := response.GetFileHeader(); h != nil {
+		header["immediate_destination"] = h.ImmediateDestination
+		header["immediate_origin"] = h.ImmediateOrigin
+		header["file_creation_date"] = h.FileCreationDate
+		header["file_creation_time"] = h.FileCreationTime
+	}
+
+	control := response.GetFileControl()
+	statistics := map[string]interface{}{
+		"total_batches":          int(response.TotalBatches),
+		"total_entries":          int(control.GetEntryAddendaCount()),
+		"total_amount_formatted": float64(control.GetTotalDebitAmount()+control.GetTotalCreditAmount()) / 100.0,
+	}
+
+	// Numerar os lotes pela posição no arquivo, não na página: as páginas
+	// anteriores estão sempre completas
+	firstBatch := len(batchHistory)*int(request.BatchPageSize) + 1
+	batches := make([]map[string]interface{}, 0, len(response.Batches))
+	for i, batch := range response.Batches {
+		number := strconv.Itoa(firstBatch + i)
+
+		// O token de entradas só pagina o lote para o qual foi devolvido
+		var history []string
+		if number == entryBatch && request.EntryPageToken != "" {
+			history = entryHistory
+		}
+		firstEntry := len(history)*int(request.EntryPageSize) + 1
+
+		entries := make([]map[string]interface{}, 0, len(batch.Entries))
+		for _, entry := range batch.Entries {
+			entries = append(entries, map[string]interface{}{
+				"transaction_code": entry.TransactionCode,
+				"individual_name":  entry.IndividualName,
+				"amount_formatted": float64(entry.Amount) / 100.0,
+				"trace_number":     entry.TraceNumber,
+			})
+		}
 
-	return string(jsonData)
+		view := map[string]interface{}{
+			"number":                 number,
+			"service_class_code":     batch.GetHeader().GetServiceClassCode(),
+			"company_name":           batch.GetHeader().GetCompanyName(),
+			"entry_count":            int(batch.TotalEntries),
+			"entry_hash":             batch.GetControl().GetEntryHash(),
+			"entries":                entries,
+			"first_entry":            min(firstEntry, firstEntry+len(entries)-1),
+			"last_entry":             firstEntry + len(entries) - 1,
+			"entries_truncated":      len(history) > 0 || batch.NextEntryPageToken != "",
+			"next_entry_page_token":  batch.NextEntryPageToken,
+			"next_entry_history":     append(append([]string{}, history...), request.EntryPageToken),
+			"has_previous_entries":   len(history) > 0,
+			"previous_entry_history": []string{},
+		}
+		if len(history) > 0 {
+			view["previous_entry_page_token"] = history[len(history)-1]
+			view["previous_entry_history"] = history[:len(history)-1]
+		}
+		if len(history) == 0 {
+			// Primeira página de entradas deste lote, sem token anterior
+			view["next_entry_history"] = []string{""}
+		}
+		batches = append(batches, view)
+	}
+
+	pagination := map[string]interface{}{
+		"file_id":               fileID,
+		"total_batches":         int(response.TotalBatches),
+		"first_batch":           min(firstBatch, firstBatch+len(batches)-1),
+		"last_batch":            firstBatch + len(batches) - 1,
+		"batch_page_size":       int(request.BatchPageSize),
+		"batch_page_token":      request.BatchPageToken,
+		"batch_history":         batchHistory,
+		"entry_page_size":       int(request.EntryPageSize),
+		"next_batch_page_token": response.NextBatchPageToken,
+		"next_batch_history":    append(append([]string{}, batchHistory...), request.BatchPageToken),
+		"has_previous":          len(batchHistory) > 0,
+		"truncated":             len(batchHistory) > 0 || response.NextBatchPageToken != "",
+	}
+	if len(batchHistory) > 0 {
+		pagination["previous_batch_page_token"] = batchHistory[len(batchHistory)-1]
+		pagination["previous_batch_history"] = batchHistory[:len(batchHistory)-1]
+	}
+
+	// Os formulários de página das entradas repetem a página de lotes
+	for _, batch := range batches {
+		batch["pagination"] = pagination
+	}
+
+	return map[string]interface{}{
+		"header":     header,
+		"batches":    batches,
+		"statistics": statistics,
+		"pagination": pagination,
+	}
 }
 
 func callClientExport(content, format string) string {